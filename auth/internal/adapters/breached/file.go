@@ -0,0 +1,130 @@
+package breached
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	sha1HexLength = 40
+	prefixLength  = 5
+)
+
+// FileList - хеши паролей из утечек в локальном файле формата загрузки Have I
+// Been Pwned, упорядоченного по хешу: SHA-1 в hex и, через двоеточие, число
+// утечек. Файл не загружается в память: диапазон хешей с общим началом
+// находится двоичным поиском по смещениям в файле.
+type FileList struct {
+	path string
+	file *os.File
+	size int64
+}
+
+// NewFileList открывает passwords.breached_file на все время работы сервиса.
+// Без файла список пуст и проверка по утечкам ничего не находит.
+func NewFileList(cfg *config.Config) (domain.BreachedPasswords, error) {
+	path := cfg.Passwords.BreachedFile
+	if path == "" {
+		return &FileList{}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при открытии списка утекших паролей: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("ошибка при открытии списка утекших паролей: %w", err)
+	}
+
+	list := &FileList{path: path, file: file, size: info.Size()}
+	if hash, _, err := list.hashAt(0); err != nil || (hash != "" && len(hash) != sha1HexLength) {
+		file.Close()
+		return nil, fmt.Errorf("список утекших паролей %s: ожидались SHA-1 хеши, упорядоченные по значению", path)
+	}
+	return list, nil
+}
+
+func (l *FileList) Range(_ context.Context, prefix string) ([]string, error) {
+	if l.file == nil {
+		return nil, nil
+	}
+	prefix = strings.ToUpper(prefix)
+
+	// Наименьшее смещение, с которого первая строка уже не меньше prefix
+	lo, hi := int64(0), l.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		hash, _, err := l.hashAt(mid)
+		if err != nil {
+			return nil, err
+		}
+		if hash != "" && hash[:min(len(hash), prefixLength)] < prefix {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	_, start, err := l.hashAt(lo)
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(io.NewSectionReader(l.file, start, l.size-start))
+	var suffixes []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("ошибка при чтении списка утекших паролей: %w", err)
+		}
+		hash := parseHash(line)
+		if hash == "" || !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		if len(hash) != sha1HexLength {
+			return nil, fmt.Errorf("список утекших паролей %s: ожидался SHA-1 хеш, получено %q", l.path, hash)
+		}
+		suffixes = append(suffixes, hash[prefixLength:])
+		if err != nil {
+			break
+		}
+	}
+	return suffixes, nil
+}
+
+// hashAt возвращает хеш первой строки, начинающейся не раньше offset, и ее
+// смещение. Если такой строки нет, хеш пуст.
+func (l *FileList) hashAt(offset int64) (string, int64, error) {
+	start := max(offset-1, 0)
+	reader := bufio.NewReader(io.NewSectionReader(l.file, start, l.size-start))
+	if offset > 0 {
+		skipped, err := reader.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			return "", l.size, nil
+		}
+		if err != nil {
+			return "", 0, fmt.Errorf("ошибка при чтении списка утекших паролей: %w", err)
+		}
+		start += int64(len(skipped))
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", 0, fmt.Errorf("ошибка при чтении списка утекших паролей: %w", err)
+	}
+	return parseHash(line), start, nil
+}
+
+func parseHash(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash)
+}
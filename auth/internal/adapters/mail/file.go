@@ -0,0 +1,74 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+// FileMailer - отправка писем для локальной разработки: письмо сохраняется
+// .eml файлом в каталог, а если каталог не задан - пишется в лог
+type FileMailer struct {
+	dir    string
+	from   string
+	logger *zap.Logger
+}
+
+func NewFileMailer(cfg *config.Config, logger *zap.Logger) *FileMailer {
+	return &FileMailer{
+		dir:    cfg.Mail.Dir,
+		from:   cfg.Mail.From,
+		logger: logger,
+	}
+}
+
+func (m *FileMailer) Send(_ context.Context, mail *domain.Mail) error {
+	if m.dir == "" {
+		m.logger.Info("mail",
+			zap.String("to", mail.To),
+			zap.String("subject", mail.Subject),
+			zap.String("body", mail.Body),
+		)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("ошибка создания каталога писем: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000000"), fileSafe(mail.To))
+	if err := os.WriteFile(filepath.Join(m.dir, name), message(m.from, mail), 0o644); err != nil {
+		return fmt.Errorf("ошибка сохранения письма: %w", err)
+	}
+	return nil
+}
+
+// fileSafe оставляет в адресе только символы, безопасные для имени файла:
+// адрес приходит от пользователя и не должен выводить файл из каталога
+func fileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '@' || r == '.' || r == '_' || r == '+' || r == '-':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// NewMailer выбирает реализацию по mail.driver: smtp или file (по умолчанию)
+func NewMailer(cfg *config.Config, logger *zap.Logger) domain.Mailer {
+	if cfg.Mail.Driver == "smtp" {
+		return NewSMTPMailer(cfg)
+	}
+	return NewFileMailer(cfg, logger)
+}
@@ -0,0 +1,58 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+// SMTPMailer отправляет письма через SMTP сервер с авторизацией PLAIN
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(cfg *config.Config) *SMTPMailer {
+	smtpCfg := cfg.Mail.SMTP
+
+	var auth smtp.Auth
+	if smtpCfg.Username != "" {
+		auth = smtp.PlainAuth("", smtpCfg.Username, smtpCfg.Password, smtpCfg.Host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(smtpCfg.Host, smtpCfg.Port),
+		from: cfg.Mail.From,
+		auth: auth,
+	}
+}
+
+func (m *SMTPMailer) Send(_ context.Context, mail *domain.Mail) error {
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{mail.To}, message(m.from, mail)); err != nil {
+		return fmt.Errorf("ошибка отправки письма: %w", err)
+	}
+	return nil
+}
+
+// message собирает письмо в формате RFC 5322
+func message(from string, mail *domain.Mail) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + mail.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", mail.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
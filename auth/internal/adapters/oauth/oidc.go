@@ -0,0 +1,141 @@
+package oauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+	"marketai/pkgAuth/jwt"
+)
+
+// idTokenClockSkew - допуск расхождения часов с провайдером при проверке id_token
+const idTokenClockSkew = time.Minute
+
+// OIDCProvider - провайдер OpenID Connect (Google или любой совместимый, в том
+// числе локальный мок). Профиль берется из id_token, подпись которого
+// проверяется по JWKS провайдера, а nonce сверяется с выданным при старте входа.
+type OIDCProvider struct {
+	client
+	issuer string
+
+	mu         sync.Mutex
+	discovered bool
+	authURL    string
+	tokenURL   string
+	jwksURL    string
+	verifier   *jwt.JWKSVerifier
+}
+
+func newOIDCProvider(base client, cfg config.OAuthProviderConfig) *OIDCProvider {
+	return &OIDCProvider{
+		client:   base,
+		issuer:   strings.TrimSuffix(cfg.Issuer, "/"),
+		authURL:  cfg.AuthURL,
+		tokenURL: cfg.TokenURL,
+		jwksURL:  cfg.JWKSURL,
+	}
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+	return p.authCodeURL(p.authURL, state, codeChallenge, url.Values{"nonce": {nonce}}), nil
+}
+
+type idTokenClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, exchange domain.OAuthExchange) (*domain.ExternalProfile, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	token, err := p.exchangeCode(ctx, p.tokenURL, exchange, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: %s: нет id_token", domain.ErrOAuthExchange, p.name)
+	}
+
+	var extra idTokenClaims
+	claims, err := p.verifier.VerifyInto(ctx, token.IDToken, &extra)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: id_token: %w", domain.ErrOAuthExchange, p.name, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(extra.Nonce), []byte(exchange.Nonce)) != 1 {
+		return nil, fmt.Errorf("%w: %s: nonce не совпадает", domain.ErrOAuthExchange, p.name)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: %s: нет sub", domain.ErrOAuthExchange, p.name)
+	}
+
+	return &domain.ExternalProfile{
+		Subject:       claims.Subject,
+		Email:         extra.Email,
+		EmailVerified: extra.EmailVerified,
+		FullName:      extra.Name,
+	}, nil
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// discover дополняет незаданные в конфигурации адреса из discovery документа.
+// Документ загружается при первом входе, чтобы недоступный провайдер не мешал старту сервиса.
+func (p *OIDCProvider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovered {
+		return nil
+	}
+
+	if p.authURL == "" || p.tokenURL == "" || p.jwksURL == "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.issuer+"/.well-known/openid-configuration", nil)
+		if err != nil {
+			return fmt.Errorf("ошибка создания запроса к %s: %w", p.name, err)
+		}
+		var doc discoveryDocument
+		if err := p.do(req, &doc); err != nil {
+			return err
+		}
+		if strings.TrimSuffix(doc.Issuer, "/") != p.issuer {
+			return fmt.Errorf("провайдер %s: issuer %q не совпадает с настройкой", p.name, doc.Issuer)
+		}
+
+		if p.authURL == "" {
+			p.authURL = doc.AuthorizationEndpoint
+		}
+		if p.tokenURL == "" {
+			p.tokenURL = doc.TokenEndpoint
+		}
+		if p.jwksURL == "" {
+			p.jwksURL = doc.JWKSURI
+		}
+	}
+
+	p.verifier = jwt.NewJWKSVerifier(p.jwksURL, 0, jwt.ValidationOptions{
+		Issuer:    p.issuer,
+		Audience:  p.clientID,
+		ClockSkew: idTokenClockSkew,
+	})
+	p.discovered = true
+	return nil
+}
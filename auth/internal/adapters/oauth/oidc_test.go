@@ -0,0 +1,301 @@
+package oauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	mockClientID = "marketai"
+	mockKID      = "mock-key"
+	mockCode     = "auth-code"
+	mockVerifier = "code-verifier"
+	mockNonce    = "nonce-1"
+)
+
+// mockOIDC - OIDC провайдер с discovery, token и JWKS эндпоинтами.
+// idToken возвращает id_token, который выдаст token эндпоинт.
+type mockOIDC struct {
+	*httptest.Server
+	key     *rsa.PrivateKey
+	idToken func(m *mockOIDC) string
+	// form - параметры последнего запроса обмена кода
+	form url.Values
+}
+
+func newMockOIDC(t *testing.T) *mockOIDC {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	m := &mockOIDC{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 m.URL,
+			"authorization_endpoint": m.URL + "/authorize",
+			"token_endpoint":         m.URL + "/token",
+			"jwks_uri":               m.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": mockKID,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.form = r.PostForm
+		if r.PostForm.Get("code") != mockCode || r.PostForm.Get("code_verifier") != mockVerifier {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, map[string]string{"access_token": "access", "id_token": m.idToken(m)})
+	})
+
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+// sign подписывает claims RS256 ключом key
+func (m *mockOIDC) sign(t *testing.T, key *rsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": mockKID})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	sum := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (m *mockOIDC) claims(overrides map[string]any) map[string]any {
+	now := time.Now()
+	claims := map[string]any{
+		"iss":            m.URL,
+		"aud":            mockClientID,
+		"sub":            "subject-1",
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          mockNonce,
+		"email":          "User@Example.com",
+		"email_verified": true,
+		"name":           "Test User",
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newMockProvider(m *mockOIDC) *OIDCProvider {
+	cfg := config.OAuthProviderConfig{
+		Type:        TypeOIDC,
+		ClientID:    mockClientID,
+		RedirectURL: "https://marketai.local/oauth/callback",
+		Issuer:      m.URL,
+		Scopes:      []string{"openid", "email"},
+	}
+	return newOIDCProvider(newClient("mock", cfg), cfg)
+}
+
+func TestOIDCProviderAuthCodeURL(t *testing.T) {
+	m := newMockOIDC(t)
+	p := newMockProvider(m)
+
+	raw, err := p.AuthCodeURL(context.Background(), "state-1", mockNonce, "challenge-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+	if got := u.Scheme + "://" + u.Host + u.Path; got != m.URL+"/authorize" {
+		t.Errorf("endpoint = %s, want discovered %s/authorize", got, m.URL)
+	}
+
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             mockClientID,
+		"state":                 "state-1",
+		"nonce":                 mockNonce,
+		"code_challenge":        "challenge-1",
+		"code_challenge_method": "S256",
+		"scope":                 "openid email",
+	}
+	for k, v := range want {
+		if got := u.Query().Get(k); got != v {
+			t.Errorf("%s = %q, want %q", k, got, v)
+		}
+	}
+}
+
+func TestOIDCProviderExchange(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		nonce    string
+		idToken  func(t *testing.T, m *mockOIDC) string
+		want     *domain.ExternalProfile
+		wantFail bool
+	}{
+		{
+			name:  "valid id_token",
+			code:  mockCode,
+			nonce: mockNonce,
+			idToken: func(t *testing.T, m *mockOIDC) string {
+				return m.sign(t, m.key, m.claims(nil))
+			},
+			want: &domain.ExternalProfile{
+				Subject:       "subject-1",
+				Email:         "User@Example.com",
+				EmailVerified: true,
+				FullName:      "Test User",
+			},
+		},
+		{
+			name:  "nonce mismatch",
+			code:  mockCode,
+			nonce: "other-nonce",
+			idToken: func(t *testing.T, m *mockOIDC) string {
+				return m.sign(t, m.key, m.claims(nil))
+			},
+			wantFail: true,
+		},
+		{
+			name:  "foreign signing key",
+			code:  mockCode,
+			nonce: mockNonce,
+			idToken: func(t *testing.T, m *mockOIDC) string {
+				return m.sign(t, otherKey, m.claims(nil))
+			},
+			wantFail: true,
+		},
+		{
+			name:  "issued for another client",
+			code:  mockCode,
+			nonce: mockNonce,
+			idToken: func(t *testing.T, m *mockOIDC) string {
+				return m.sign(t, m.key, m.claims(map[string]any{"aud": "other-client"}))
+			},
+			wantFail: true,
+		},
+		{
+			name:  "another issuer",
+			code:  mockCode,
+			nonce: mockNonce,
+			idToken: func(t *testing.T, m *mockOIDC) string {
+				return m.sign(t, m.key, m.claims(map[string]any{"iss": "https://evil.example"}))
+			},
+			wantFail: true,
+		},
+		{
+			name:  "expired",
+			code:  mockCode,
+			nonce: mockNonce,
+			idToken: func(t *testing.T, m *mockOIDC) string {
+				return m.sign(t, m.key, m.claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()}))
+			},
+			wantFail: true,
+		},
+		{
+			name:  "missing subject",
+			code:  mockCode,
+			nonce: mockNonce,
+			idToken: func(t *testing.T, m *mockOIDC) string {
+				return m.sign(t, m.key, m.claims(map[string]any{"sub": nil}))
+			},
+			wantFail: true,
+		},
+		{
+			name:  "rejected code",
+			code:  "wrong-code",
+			nonce: mockNonce,
+			idToken: func(t *testing.T, m *mockOIDC) string {
+				return m.sign(t, m.key, m.claims(nil))
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockOIDC(t)
+			m.idToken = func(m *mockOIDC) string { return tt.idToken(t, m) }
+			p := newMockProvider(m)
+
+			profile, err := p.Exchange(context.Background(), domain.OAuthExchange{
+				Code:         tt.code,
+				State:        "state-1",
+				CodeVerifier: mockVerifier,
+				Nonce:        tt.nonce,
+			})
+			if tt.wantFail {
+				if !errors.Is(err, domain.ErrOAuthExchange) {
+					t.Fatalf("err = %v, want ErrOAuthExchange", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange: %v", err)
+			}
+			if *profile != *tt.want {
+				t.Errorf("profile = %+v, want %+v", *profile, *tt.want)
+			}
+			if got := m.form.Get("redirect_uri"); !strings.HasPrefix(got, "https://marketai.local/") {
+				t.Errorf("redirect_uri = %q", got)
+			}
+		})
+	}
+}
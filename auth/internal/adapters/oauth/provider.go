@@ -0,0 +1,159 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	TypeOIDC   = "oidc"
+	TypeYandex = "yandex"
+	TypeVK     = "vk"
+)
+
+// NewProviders создает провайдеры из конфигурации. Провайдер без client_id
+// считается выключенным и не попадает в список.
+func NewProviders(cfg *config.Config) (domain.IdentityProviders, error) {
+	providers := domain.IdentityProviders{}
+	for name, providerCfg := range cfg.OAuth.Providers {
+		if providerCfg.ClientID == "" {
+			continue
+		}
+
+		base := newClient(name, providerCfg)
+		switch providerCfg.Type {
+		case TypeOIDC:
+			if providerCfg.Issuer == "" {
+				return nil, fmt.Errorf("провайдер %s: не задан issuer", name)
+			}
+			providers[name] = newOIDCProvider(base, providerCfg)
+		case TypeYandex:
+			providers[name] = newYandexProvider(base, providerCfg)
+		case TypeVK:
+			providers[name] = newVKProvider(base, providerCfg)
+		default:
+			return nil, fmt.Errorf("провайдер %s: неизвестный тип %q", name, providerCfg.Type)
+		}
+	}
+	return providers, nil
+}
+
+// client - общая часть authorization code flow с PKCE
+type client struct {
+	name         string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	http         *http.Client
+}
+
+func newClient(name string, cfg config.OAuthProviderConfig) client {
+	return client{
+		name:         name,
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		redirectURL:  cfg.RedirectURL,
+		scopes:       cfg.Scopes,
+		http:         &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *client) Name() string {
+	return c.name
+}
+
+func (c *client) authCodeURL(authURL, state, codeChallenge string, extra url.Values) string {
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.clientID},
+		"redirect_uri":          {c.redirectURL},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	if len(c.scopes) > 0 {
+		params.Set("scope", strings.Join(c.scopes, " "))
+	}
+	for key, values := range extra {
+		params[key] = values
+	}
+
+	separator := "?"
+	if strings.Contains(authURL, "?") {
+		separator = "&"
+	}
+	return authURL + separator + params.Encode()
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// exchangeCode обменивает код на токены провайдера
+func (c *client) exchangeCode(ctx context.Context, tokenURL string, exchange domain.OAuthExchange, extra url.Values) (*tokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {exchange.Code},
+		"redirect_uri":  {c.redirectURL},
+		"client_id":     {c.clientID},
+		"code_verifier": {exchange.CodeVerifier},
+	}
+	if c.clientSecret != "" {
+		form.Set("client_secret", c.clientSecret)
+	}
+	for key, values := range extra {
+		form[key] = values
+	}
+
+	var token tokenResponse
+	if err := c.postForm(ctx, tokenURL, form, &token); err != nil {
+		return nil, err
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("%w: %s: %s %s", domain.ErrOAuthExchange, c.name, token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" && token.IDToken == "" {
+		return nil, fmt.Errorf("%w: %s: пустой ответ", domain.ErrOAuthExchange, c.name)
+	}
+	return &token, nil
+}
+
+func (c *client) postForm(ctx context.Context, endpoint string, form url.Values, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("ошибка создания запроса к %s: %w", c.name, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.do(req, dst)
+}
+
+func (c *client) do(req *http.Request, dst any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка запроса к %s: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	// Ошибки обмена кода приходят с кодом 400 и телом {"error": ...}, поэтому тело разбирается всегда
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("%w: %s: статус %d", domain.ErrOAuthExchange, c.name, resp.StatusCode)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("ошибка запроса к %s: статус %d", c.name, resp.StatusCode)
+	}
+	return nil
+}
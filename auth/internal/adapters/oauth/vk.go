@@ -0,0 +1,83 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	vkAuthURL     = "https://id.vk.com/authorize"
+	vkTokenURL    = "https://id.vk.com/oauth2/auth"
+	vkUserInfoURL = "https://id.vk.com/oauth2/user_info"
+)
+
+// VKProvider - VK ID. При обмене кода VK требует device_id, который фронтенд
+// получает вместе с кодом, и state исходного запроса.
+type VKProvider struct {
+	client
+	authURL     string
+	tokenURL    string
+	userInfoURL string
+}
+
+func newVKProvider(base client, cfg config.OAuthProviderConfig) *VKProvider {
+	return &VKProvider{
+		client:      base,
+		authURL:     valueOr(cfg.AuthURL, vkAuthURL),
+		tokenURL:    valueOr(cfg.TokenURL, vkTokenURL),
+		userInfoURL: valueOr(cfg.UserInfoURL, vkUserInfoURL),
+	}
+}
+
+func (p *VKProvider) AuthCodeURL(_ context.Context, state, _, codeChallenge string) (string, error) {
+	return p.authCodeURL(p.authURL, state, codeChallenge, nil), nil
+}
+
+type vkUserInfo struct {
+	User struct {
+		UserID    string `json:"user_id"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Email     string `json:"email"`
+	} `json:"user"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (p *VKProvider) Exchange(ctx context.Context, exchange domain.OAuthExchange) (*domain.ExternalProfile, error) {
+	if exchange.DeviceID == "" {
+		return nil, fmt.Errorf("%w: %s: не передан device_id", domain.ErrOAuthExchange, p.name)
+	}
+
+	token, err := p.exchangeCode(ctx, p.tokenURL, exchange, url.Values{
+		"device_id": {exchange.DeviceID},
+		"state":     {exchange.State},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var info vkUserInfo
+	if err := p.postForm(ctx, p.userInfoURL, url.Values{
+		"client_id":    {p.clientID},
+		"access_token": {token.AccessToken},
+	}, &info); err != nil {
+		return nil, err
+	}
+	if info.Error != "" || info.User.UserID == "" {
+		return nil, fmt.Errorf("%w: %s: профиль: %s %s", domain.ErrOAuthExchange, p.name, info.Error, info.ErrorDescription)
+	}
+
+	return &domain.ExternalProfile{
+		Subject: info.User.UserID,
+		Email:   info.User.Email,
+		// VK не гарантирует, что адрес подтвержден, поэтому по нему аккаунты не связываются
+		EmailVerified: false,
+		FullName:      strings.TrimSpace(info.User.FirstName + " " + info.User.LastName),
+	}, nil
+}
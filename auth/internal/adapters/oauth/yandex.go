@@ -0,0 +1,85 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	yandexAuthURL     = "https://oauth.yandex.ru/authorize"
+	yandexTokenURL    = "https://oauth.yandex.ru/token"
+	yandexUserInfoURL = "https://login.yandex.ru/info?format=json"
+)
+
+// YandexProvider - Яндекс ID. Яндекс не выдает id_token, профиль запрашивается
+// по токену доступа из API login.yandex.ru.
+type YandexProvider struct {
+	client
+	authURL     string
+	tokenURL    string
+	userInfoURL string
+}
+
+func newYandexProvider(base client, cfg config.OAuthProviderConfig) *YandexProvider {
+	return &YandexProvider{
+		client:      base,
+		authURL:     valueOr(cfg.AuthURL, yandexAuthURL),
+		tokenURL:    valueOr(cfg.TokenURL, yandexTokenURL),
+		userInfoURL: valueOr(cfg.UserInfoURL, yandexUserInfoURL),
+	}
+}
+
+func (p *YandexProvider) AuthCodeURL(_ context.Context, state, _, codeChallenge string) (string, error) {
+	return p.authCodeURL(p.authURL, state, codeChallenge, nil), nil
+}
+
+type yandexUserInfo struct {
+	ID           string `json:"id"`
+	DefaultEmail string `json:"default_email"`
+	RealName     string `json:"real_name"`
+	DisplayName  string `json:"display_name"`
+}
+
+func (p *YandexProvider) Exchange(ctx context.Context, exchange domain.OAuthExchange) (*domain.ExternalProfile, error) {
+	token, err := p.exchangeCode(ctx, p.tokenURL, exchange, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.userInfoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса к %s: %w", p.name, err)
+	}
+	req.Header.Set("Authorization", "OAuth "+token.AccessToken)
+
+	var info yandexUserInfo
+	if err := p.do(req, &info); err != nil {
+		return nil, err
+	}
+	if info.ID == "" {
+		return nil, fmt.Errorf("%w: %s: нет id пользователя", domain.ErrOAuthExchange, p.name)
+	}
+
+	name := info.RealName
+	if name == "" {
+		name = info.DisplayName
+	}
+	return &domain.ExternalProfile{
+		Subject: info.ID,
+		Email:   info.DefaultEmail,
+		// Основной адрес аккаунта Яндекса подтвержден самим Яндексом
+		EmailVerified: info.DefaultEmail != "",
+		FullName:      name,
+	}, nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
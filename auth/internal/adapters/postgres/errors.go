@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+// Уникальные ограничения таблицы users, по которым различаются занятые email и телефон
+const (
+	usersEmailKey         = "users_email_key"
+	usersEmailLowerUnique = "idx_users_email_lower_unique"
+	usersPhoneUnique      = "idx_users_phone_number_unique"
+)
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// userUniqueViolation переводит нарушение уникальности email или номера
+// телефона пользователя в ErrEmailTaken или ErrPhoneTaken, иначе возвращает nil
+func userUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolationCode {
+		return nil
+	}
+	switch pgErr.ConstraintName {
+	case usersEmailKey, usersEmailLowerUnique:
+		return domain.ErrEmailTaken
+	case usersPhoneUnique:
+		return domain.ErrPhoneTaken
+	}
+	return nil
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode
+}
@@ -1,11 +1,49 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
+// 10_login_attempts.down.sql (74B)
+// 10_login_attempts.up.sql (714B)
+// 11_user_profile.down.sql (107B)
+// 11_user_profile.up.sql (295B)
+// 12_rbac.down.sql (134B)
+// 12_rbac.up.sql (2.283kB)
+// 13_admin_users.down.sql (145B)
+// 13_admin_users.up.sql (949B)
+// 14_api_keys.down.sql (31B)
+// 14_api_keys.up.sql (732B)
+// 15_two_factor.down.sql (239B)
+// 15_two_factor.up.sql (2.143kB)
+// 16_security_events.down.sql (402B)
+// 16_security_events.up.sql (5.022kB)
+// 17_sessions.down.sql (112B)
+// 17_sessions.up.sql (1.328kB)
+// 18_rate_limits.down.sql (34B)
+// 18_rate_limits.up.sql (331B)
+// 19_keywords_permission.down.sql (119B)
+// 19_keywords_permission.up.sql (307B)
 // 1_user_migration.down.sql (27B)
 // 1_user_migration.up.sql (316B)
+// 20_users_phone_unique.down.sql (165B)
+// 20_users_phone_unique.up.sql (1.031kB)
+// 21_users_email_lower.down.sql (51B)
+// 21_users_email_lower.up.sql (832B)
+// 22_integrations_permission.down.sql (127B)
+// 22_integrations_permission.up.sql (329B)
 // 2_add_phoneNumber.down.sql (53B)
 // 2_add_phoneNumber.up.sql (88B)
 // 3_add_fullName.down.sql (50B)
 // 3_add_fullName.up.sql (84B)
+// 4_workspaces.down.sql (73B)
+// 4_workspaces.up.sql (1.392kB)
+// 5_refresh_tokens.down.sql (37B)
+// 5_refresh_tokens.up.sql (641B)
+// 6_revoked_tokens.down.sql (82B)
+// 6_revoked_tokens.up.sql (517B)
+// 7_user_tokens.down.sql (94B)
+// 7_user_tokens.up.sql (943B)
+// 8_otp_codes.down.sql (32B)
+// 8_otp_codes.up.sql (452B)
+// 9_user_identities.down.sql (73B)
+// 9_user_identities.up.sql (1.01kB)
 
 package migrations
 
@@ -73,6 +111,406 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var __10_login_attemptsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4a\x00\xb5\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x6f\x67\x69\x6e\x5f\x6c\x6f\x63\x6b\x6f\x75\x74\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x6f\x67\x69\x6e\x5f\x66\x61\x69\x6c\x75\x72\x65\x73\x3b\x0a\x03\x00\x13\xb1\xba\x62\x4a\x00\x00\x00")
+
+func _10_login_attemptsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__10_login_attemptsDownSql,
+		"10_login_attempts.down.sql",
+	)
+}
+
+func _10_login_attemptsDownSql() (*asset, error) {
+	bytes, err := _10_login_attemptsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "10_login_attempts.down.sql", size: 74, mode: os.FileMode(0644), modTime: time.Unix(1792388850, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x86, 0x59, 0x4d, 0xf2, 0xff, 0xe, 0xf2, 0xaf, 0x54, 0xdf, 0x1d, 0x68, 0xf2, 0xe6, 0x1a, 0x22, 0xab, 0xba, 0xf8, 0xfc, 0x39, 0xf3, 0x75, 0x45, 0x3c, 0xb9, 0xcd, 0x26, 0x60, 0x52, 0x9a, 0xfd}}
+	return a, nil
+}
+
+var __10_login_attemptsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xc1\x6e\xb3\x30\x10\x84\xef\x3c\xc5\xde\x02\x52\x0e\xd1\xff\x57\xbd\xe4\xe4\xc2\x46\xb1\x4a\x4c\x64\x4c\x93\xf4\x62\xd1\xd8\x8d\x2c\x28\x54\x80\xa5\xe6\xed\xab\x86\x80\x08\x4d\x53\xf5\x68\x79\x67\x76\xbf\x19\x9f\x23\x11\x08\x82\x3c\x84\x08\x74\x01\x2c\x12\x80\x5b\x1a\x8b\x18\xf2\xf2\x60\x0a\xf9\x9a\x9a\xdc\x56\xba\x06\xd7\x01\x00\xc8\xf4\x11\x9e\x08\xf7\x97\x84\xbb\xff\xff\xcd\x3c\x58\x73\xba\x22\x7c\x07\x8f\xb8\x9b\x9e\x26\x7a\x01\x65\xe2\x64\xc7\x92\x30\x84\x00\x17\x24\x09\x05\xcc\xda\xa1\x3c\xad\x9b\xce\x5a\xa6\x0d\x08\xba\xc2\x58\x90\xd5\x1a\x36\x54\x2c\x4f\x4f\x78\x8e\x18\xf6\x06\x67\x59\xb9\xcf\xb4\x92\xb6\x68\x4c\xfe\xa3\xc6\xf1\xe6\x8e\xf3\x2b\x57\x5e\xee\xb3\xd2\x36\x1d\x97\x51\x90\x24\x34\x18\xe2\xf4\x37\x1f\x74\x21\xab\xb4\x50\xe5\x9b\xb4\xd6\x28\xd7\x9b\x5e\x8f\xe2\xf2\xd6\xab\x39\xb4\x5f\xe6\xbd\x17\xde\xdf\x79\xdf\x43\x9a\x4c\xfe\x80\x3b\xf2\xde\x57\x3a\x6d\xb4\xba\x19\x6a\xb7\x87\x45\x9b\x0e\xc6\x16\xe7\x65\x37\x74\xa3\xc9\x97\x63\x1b\x19\xc7\x05\x72\x64\x3e\xc6\x60\x6b\x5d\xd5\xae\x51\x1e\x44\x0c\x02\x0c\x51\x20\xc4\xd8\xd2\x0d\x6b\xa1\x2c\xc0\xed\xa8\x16\xa3\x3e\xe4\x65\x35\xf2\x2b\xe1\x88\x8d\x0a\x73\x33\x7d\x9c\x0e\x31\x03\x8c\x7d\x6f\xee\x7c\x0e\x00\x6a\x84\x36\x86\xca\x02\x00\x00")
+
+func _10_login_attemptsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__10_login_attemptsUpSql,
+		"10_login_attempts.up.sql",
+	)
+}
+
+func _10_login_attemptsUpSql() (*asset, error) {
+	bytes, err := _10_login_attemptsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "10_login_attempts.up.sql", size: 714, mode: os.FileMode(0644), modTime: time.Unix(1792388850, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5d, 0x29, 0xd6, 0x15, 0x5a, 0x4b, 0x15, 0x1d, 0x8a, 0xad, 0x2b, 0x28, 0x3d, 0x75, 0x1e, 0x4a, 0x6f, 0x63, 0x1c, 0xe4, 0x75, 0x57, 0x21, 0x7, 0x3d, 0x8e, 0x85, 0x4f, 0x88, 0xe3, 0x7a, 0x5b}}
+	return a, nil
+}
+
+var __11_user_profileDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6b\x00\x94\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x5f\x74\x6f\x6b\x65\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x61\x79\x6c\x6f\x61\x64\x3b\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x65\x6c\x65\x74\x65\x64\x5f\x61\x74\x3b\x0a\x03\x00\x6a\x08\xb5\xdf\x6b\x00\x00\x00")
+
+func _11_user_profileDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__11_user_profileDownSql,
+		"11_user_profile.down.sql",
+	)
+}
+
+func _11_user_profileDownSql() (*asset, error) {
+	bytes, err := _11_user_profileDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "11_user_profile.down.sql", size: 107, mode: os.FileMode(0644), modTime: time.Unix(1792389008, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7, 0xce, 0xea, 0xc6, 0xf, 0xd1, 0xe1, 0xea, 0xd9, 0x84, 0x63, 0xf3, 0xec, 0x71, 0xd7, 0xff, 0xc8, 0x56, 0x85, 0x28, 0xc2, 0x11, 0xf9, 0x4, 0xf9, 0xf9, 0x88, 0x8f, 0xcc, 0xd4, 0x10, 0x8e}}
+	return a, nil
+}
+
+var __11_user_profileUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8f\x31\x4f\x83\x40\x00\x85\x77\x7e\xc5\xdb\xaa\x89\x2c\x26\x9d\x3a\x9d\xe5\x9a\x92\x1c\x60\xe0\x50\xe3\xd2\x90\x70\x83\x11\xad\xb1\x38\xb8\x81\x6b\xfd\x31\xc4\x94\x88\xa6\xb4\x7f\xe1\xdd\x3f\x32\xd4\xb8\x38\x74\x7c\xdf\x7b\xc3\xfb\x84\xd2\x32\x86\x16\x17\x4a\xe2\x65\x65\x9e\x57\x10\x9e\x87\x69\xa4\xd2\x20\x84\x3f\x43\x18\x69\xc8\x1b\x3f\xd1\x09\x72\x53\x98\xd2\xe4\x8b\xac\x84\xf6\x03\x99\x68\x11\x5c\xe2\xda\xd7\xf3\x43\xc4\x6d\x14\xca\x89\xe3\xb8\x2e\x9e\xb2\xd7\x62\x99\xe5\x70\xc1\x0d\x1b\xf6\xec\xed\x9a\x2d\xec\x1b\x77\xfc\x66\xcb\x9e\x0d\xd8\xf1\x13\xdc\xb3\xb3\xb5\x7d\xe7\x96\xcd\x19\x06\xce\xbd\xad\xd8\x71\xcb\xd6\x56\x03\xd8\xf1\xc3\xae\xf9\x05\xf3\x90\xdd\x15\xf8\x6d\x61\xeb\x61\xc0\x9e\x2d\xd8\x70\x63\x2b\xb6\xb6\x66\xe3\xfc\x77\x59\x94\xcb\x7b\xf3\x78\xc4\xe8\xef\xe8\x95\x88\xa7\x73\x11\x9f\x9c\x8f\xc7\xa7\x07\xe3\x30\x55\x0a\x9e\x9c\x89\x54\x69\x8c\x46\x13\xe7\x67\x00\xfe\x6a\x05\x88\x27\x01\x00\x00")
+
+func _11_user_profileUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__11_user_profileUpSql,
+		"11_user_profile.up.sql",
+	)
+}
+
+func _11_user_profileUpSql() (*asset, error) {
+	bytes, err := _11_user_profileUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "11_user_profile.up.sql", size: 295, mode: os.FileMode(0644), modTime: time.Unix(1792389008, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x99, 0xc0, 0x80, 0x9f, 0x43, 0x2d, 0x67, 0xdc, 0x48, 0x1c, 0xe5, 0xc, 0x84, 0xad, 0x59, 0xba, 0x95, 0x4, 0x47, 0xa0, 0x37, 0x41, 0xfa, 0x78, 0xf4, 0xa5, 0xa1, 0x8f, 0xbb, 0xaf, 0x64, 0x76}}
+	return a, nil
+}
+
+var __12_rbacDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x2d\x4e\x2d\x8a\x2f\xca\xcf\x49\x2d\xb6\xe6\xc2\xaa\x00\x24\x17\x5f\x90\x5a\x94\x9b\x59\x5c\x9c\x99\x9f\x87\x4b\x19\x61\x15\x45\xf9\x39\xa9\xc5\xd6\x5c\x80\x01\x00\x9e\xfc\xa8\x60\x86\x00\x00\x00")
+
+func _12_rbacDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__12_rbacDownSql,
+		"12_rbac.down.sql",
+	)
+}
+
+func _12_rbacDownSql() (*asset, error) {
+	bytes, err := _12_rbacDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "12_rbac.down.sql", size: 134, mode: os.FileMode(0644), modTime: time.Unix(1792389175, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x84, 0x52, 0x18, 0xad, 0xa4, 0xa1, 0x88, 0x36, 0x41, 0xc, 0xd7, 0x5c, 0x85, 0x28, 0x4a, 0x79, 0x8e, 0x4d, 0xe0, 0x67, 0xd, 0x27, 0x4, 0xe4, 0x52, 0x0, 0xa5, 0xda, 0x39, 0x10, 0xac, 0x30}}
+	return a, nil
+}
+
+var __12_rbacUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\xd1\x6e\xa3\x56\x10\x7d\xf7\x57\xcc\x1b\x20\xb1\x55\xb6\x52\x5e\xb6\xea\x03\x8b\xaf\x1b\x54\x02\x11\xe0\x4d\xb7\x2f\x11\x1b\xd0\x0a\x35\xb1\x57\xe0\xa8\xea\x9b\x6d\x69\xdb\x4a\x5d\x29\x95\xfa\x01\xad\xd4\x1f\x70\xad\x45\xa6\x4e\xcc\xfe\xc2\xdc\x3f\xaa\xe6\x42\x08\x06\x6c\x37\xda\x17\x84\x99\x99\xc3\x99\x33\xe7\x0e\xd6\x1d\xa6\x79\x0c\x3c\xed\xa5\xc9\xc0\x18\x80\x65\x7b\xc0\xbe\x33\x5c\xcf\x85\x78\x7c\x15\x26\x20\xf7\x00\x00\x46\xfe\x75\x08\xaf\x34\x47\x3f\xd1\x1c\xf9\xf8\x48\x81\x33\xc7\x38\xd5\x9c\xd7\xf0\x2d\x7b\xad\x8a\x8c\x20\x4c\x2e\xe3\xe8\xdd\x24\x1a\x8f\xaa\xc4\x2f\x8f\x8f\x15\x81\x68\x0d\x4d\x13\xfa\x6c\xa0\x0d\x4d\x0f\x24\xa9\xa8\x78\x73\x13\x5d\x4d\xa2\x11\xbc\xb4\x6d\x93\x69\x56\x3b\x71\xa0\x99\x2e\x2b\x72\x2f\xe3\xd0\x9f\x84\xc1\x85\x3f\x01\xcf\x38\x65\xae\xa7\x9d\x9e\xc1\xb9\xe1\x9d\x88\x9f\xf0\xbd\x6d\xb1\xaa\xcc\xb2\xcf\x65\xa5\xa7\x7c\xd5\xeb\xed\x69\xee\x5d\x18\x5f\x47\x49\x12\x8d\x47\x9d\x2d\x3e\x3f\xfa\xdc\x1e\x0f\x11\x20\x75\x2f\xda\x2c\xe8\x71\x85\x7d\x7c\x54\x83\x76\xd8\x80\x39\xcc\xd2\x59\x39\x19\x99\x08\x2b\x60\x5b\xd0\x67\x26\xf3\x18\xe8\x9a\xab\x6b\xfd\x52\xb0\x47\xe4\x0a\xed\xf9\xd1\x0e\xb8\x1a\x8b\xfd\xa0\x35\x41\x40\x26\x12\x6a\xad\xf6\xa0\xe4\x37\x49\x18\x5f\xd4\x4d\x25\x1e\x44\x01\x0c\x87\x46\xbf\x93\x18\x25\x24\x72\x14\xec\x24\xf4\x54\xb5\x8a\x36\xfc\x24\x89\xde\x8e\xc2\xe0\xe2\xcd\x4f\xc5\xbb\x0f\xbc\xd2\x65\xc5\x08\x1a\xd5\xff\xdb\x8b\x1d\xe2\x95\xad\xab\x82\x5c\xa1\x9c\x61\xb9\xcc\xf1\xc0\xb0\x3c\xbb\xa6\x6a\x02\x82\xb9\x5a\xf7\x9e\x02\xaf\x34\x73\xc8\x5c\x01\x2b\x4b\x97\x7e\x1c\x24\x2f\xe2\xd0\x0f\x24\x15\x24\xfc\x93\x4f\x31\xe7\x33\xbc\xc7\x9c\xcf\xf9\x14\x70\x8d\x0b\x3e\xe5\x73\xcc\xf9\x2f\x98\xe2\x5a\x52\xd4\xad\xc2\x1f\xe3\x68\x12\x8a\xca\x3f\x30\xc5\x0d\xa6\x7c\x8a\x0b\xfe\x33\x66\xfc\x16\x30\x03\x3e\xc5\x14\x3f\xe2\x02\xd7\x7c\x8e\x19\x61\xe3\x12\x17\xb8\xc1\x0c\xd3\x3d\xd8\xd4\xe0\x3e\x52\x9f\x30\xc7\x3b\xfe\x01\x57\x05\x1e\x9f\x63\x8a\x77\x98\xe2\xbf\x4d\x88\x6b\x7f\xe4\xbf\x2d\xf8\xfd\x8d\x9f\x88\x1b\x2e\x45\x66\xc9\xa0\x13\x88\xdf\xe2\x3d\x66\x8f\x50\xa4\xf2\x41\x28\xa2\xf8\x50\x4a\x9d\x63\xc6\xdf\x03\x6e\x70\x81\x2b\xba\x0a\xf5\xc4\x4b\xf1\xfe\x11\xf9\x6a\x7c\xf9\xc3\xf8\x66\xb2\x05\xde\x6a\x36\x03\x3e\xc3\x0d\xbf\x25\x05\x49\xb5\x7f\xf0\x0e\x73\x5c\x57\x72\xe6\xb8\x06\x5c\xf2\xf7\x98\x93\xd2\x92\xd2\xb3\x2d\xd0\x6d\x6b\x60\x1a\xba\x07\xe5\x91\xec\xdb\x64\xee\x13\xc3\xfa\xa6\xe1\x95\xf2\x44\xb5\x5c\xa2\x3e\x2c\xd8\x86\x5d\x48\xd6\x82\x66\xa7\x72\x1f\x88\x2b\x79\x60\x89\x19\x9f\xe1\x42\x52\xc1\x73\x86\xac\x6a\xd8\x0f\xae\xa3\x91\xa8\xff\x1d\x3f\x92\x54\xa4\x1e\x9f\xd1\x54\x05\x46\xce\xa7\x0f\x25\x4f\x6e\x63\x7b\x1f\xb6\x16\x4c\x77\x1f\x35\xfb\x2b\x6a\x67\xac\x70\x78\xbb\x83\xae\xca\x46\x70\x57\x69\xcd\xde\xbb\x82\xa5\x21\xda\xe1\x2d\x33\xb6\xc3\x4d\x47\x6d\xcb\xb8\xa5\xdf\xb3\x67\x80\x7f\x15\x53\x24\x53\x89\x1b\xfe\x2b\x39\x6c\x43\x97\x15\x2e\xc8\x4f\x98\xf2\x39\x9f\xd1\x69\x5e\xe3\x1d\x19\x10\x37\x34\x27\xbc\x7f\x21\xf2\x30\xc3\x15\x2e\x31\xa5\x19\xe2\x86\xff\x56\x9d\x04\x71\x04\x70\x55\xac\xc4\x2f\x88\x35\x59\x63\x49\x26\xe5\xb7\x15\xa4\x08\xf7\x86\x67\x7d\xfa\xd0\xd1\x7d\x22\xf6\x25\xa5\x7f\x5d\xcc\x01\xce\x4f\x98\xc3\xc4\x80\x89\x3b\x18\x16\xc8\x2e\x33\x99\xee\x15\x7f\x2b\x06\x8e\x7d\x2a\xa2\x49\x73\x0d\xd6\xbf\x18\x8d\x8d\x59\x02\x3c\x3c\x28\x40\x28\x27\xd9\x29\xd7\x7f\x03\x00\x94\xca\xf4\x93\xeb\x08\x00\x00")
+
+func _12_rbacUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__12_rbacUpSql,
+		"12_rbac.up.sql",
+	)
+}
+
+func _12_rbacUpSql() (*asset, error) {
+	bytes, err := _12_rbacUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "12_rbac.up.sql", size: 2283, mode: os.FileMode(0644), modTime: time.Unix(1792389175, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x31, 0xa1, 0x4, 0x5c, 0x90, 0xd9, 0x23, 0x48, 0x3, 0x75, 0x68, 0xef, 0x2f, 0xe2, 0x9f, 0xd6, 0x8b, 0xd6, 0x62, 0x64, 0xe9, 0xb1, 0xc4, 0xb4, 0x16, 0xcc, 0x2a, 0x6b, 0xa2, 0xb4, 0x64, 0xa3}}
+	return a, nil
+}
+
+var __13_admin_usersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x4c\xc9\xcd\xcc\x8b\x4f\x4c\x2e\xc9\xcc\xcf\x2b\xb6\xe6\xe2\x72\xf4\x09\x71\x0d\x82\xaa\x2a\x2d\x4e\x2d\x2a\x56\x00\x6b\x73\xf6\xf7\x09\xf5\xf5\x43\xd2\x97\x94\x93\x9f\x9c\x9d\x9a\x12\x5f\x94\x9a\x58\x9c\x9f\x67\x4d\xb2\xbe\xc4\x12\x6b\x2e\xc0\x00\xd0\x2b\xed\x3a\x91\x00\x00\x00")
+
+func _13_admin_usersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__13_admin_usersDownSql,
+		"13_admin_users.down.sql",
+	)
+}
+
+func _13_admin_usersDownSql() (*asset, error) {
+	bytes, err := _13_admin_usersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "13_admin_users.down.sql", size: 145, mode: os.FileMode(0644), modTime: time.Unix(1792389558, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x46, 0xf4, 0x7c, 0x9f, 0xbc, 0xf7, 0xf2, 0x5, 0xd9, 0xb, 0xe3, 0x24, 0x2f, 0x42, 0x8d, 0x70, 0x71, 0xb2, 0xdd, 0x55, 0x21, 0x42, 0x40, 0x80, 0x77, 0x3, 0x58, 0x27, 0xb6, 0xca, 0x9a, 0xdf}}
+	return a, nil
+}
+
+var __13_admin_usersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\xc1\x6a\xdb\x50\x10\x45\xf7\xfe\x8a\xd9\x59\x02\x1b\x4a\xc1\x2b\xaf\x14\x69\x4c\xd4\xca\x4f\x41\x7a\x6a\x92\x6e\x84\x6a\x89\x20\x9a\xc8\x20\xc9\x50\x28\x05\xd7\xeb\x42\x7f\x45\x4d\x5d\x62\xec\x46\xfd\x85\xfb\xfe\xa8\x58\x4a\x1c\xbb\x09\x85\xa6\xcb\x91\xe6\x9e\x3b\x73\xe7\xf5\xfb\xf4\xee\x72\x3a\x79\x9f\xc4\x61\x54\x52\x9f\xb0\x46\x8d\xef\x58\xa2\x22\x54\x58\xe2\x27\x56\xb8\xc5\x4a\x7d\x56\x0b\x35\x47\xa5\x16\xa8\xd5\x9c\x70\x83\x0a\xdf\xb0\x41\x8d\x35\x56\x6a\x8e\x1a\xd7\xa8\xb0\x21\xfc\x42\x8d\x8d\xfa\x82\x9b\xf6\x93\x5a\xe0\x07\x36\xea\x6b\x8f\x44\xe0\x38\x84\xe5\xb6\xd8\x92\xd7\x6a\x81\x15\xae\x71\xdb\xd8\xd5\x1d\xc3\x91\xec\x91\x34\x8e\x1c\xa6\x59\x91\xe4\x05\x19\x96\x45\xa6\xeb\x04\x63\x41\xf6\x88\x84\x2b\x89\xcf\x6c\x5f\xfa\xfb\xf3\x4a\x7b\xcc\xbe\x34\xc6\x27\x74\x6a\xcb\xe3\xa6\xa4\xb7\xae\xe0\xe1\x73\x78\x79\x12\x15\xd3\x8c\xde\x18\x9e\x79\x6c\x78\xda\xcb\xc1\x40\x6f\xda\x9a\xc9\x2d\x1e\x19\x81\x23\xa9\xdb\x1d\x76\x3a\xa6\xc7\x86\xe4\x3b\xfa\x21\x2c\x8a\xaf\xd2\x2c\x8c\x26\x65\x3a\xcd\x0a\xd2\x3a\x44\x44\x69\x4c\x41\x60\x5b\x74\xe2\xd9\x63\xc3\x3b\xa7\xd7\x7c\xbe\xe3\x5d\x24\x59\x98\x47\x59\x3c\xbd\x0a\x67\xb3\x34\xd6\xf4\x5e\x23\x69\x31\xf7\x42\x8f\x47\xec\xb1\x30\xd9\x6f\x77\xd1\xd2\x58\x27\x57\x90\xc5\x0e\x4b\x26\x9f\x65\x13\xef\x9d\xb4\xf1\xde\xad\x31\x78\xf1\xb0\x45\xdb\x50\x46\xf9\x45\x52\x86\x5b\xd2\xf3\x1c\xe2\xa4\x8c\xd2\xcb\x82\x5e\xf9\xae\x38\x7a\x22\xa2\x8f\x9f\xba\x6d\xe3\x24\x4f\xa2\xf2\xef\x97\xda\xa9\x84\x7b\xaa\xe9\x1d\xfd\x21\x5d\x5b\x58\x7c\xf6\xc7\xa9\xd2\xf8\x43\x78\x90\x70\xb8\x67\xe1\x8a\xc3\xf4\xb5\xbd\x7f\x16\xfb\xa6\x3e\xfc\x37\x74\x9b\xd3\x63\xec\x61\x7e\x3d\xfa\x4f\x9b\xa6\x7a\xec\x72\xff\x02\x9e\xe2\xff\x1e\x00\x46\x6a\x19\x0c\xb5\x03\x00\x00")
+
+func _13_admin_usersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__13_admin_usersUpSql,
+		"13_admin_users.up.sql",
+	)
+}
+
+func _13_admin_usersUpSql() (*asset, error) {
+	bytes, err := _13_admin_usersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "13_admin_users.up.sql", size: 949, mode: os.FileMode(0644), modTime: time.Unix(1792389558, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7c, 0xe8, 0x71, 0xca, 0x47, 0xeb, 0xd7, 0x3a, 0xff, 0xe4, 0x2d, 0xf6, 0xd1, 0xc2, 0xf5, 0x66, 0x9a, 0xaa, 0x81, 0x73, 0x2b, 0xd, 0x1f, 0x78, 0x4c, 0x70, 0x7b, 0x43, 0x18, 0x5a, 0x82, 0x54}}
+	return a, nil
+}
+
+var __14_api_keysDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1f\x00\xe0\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x70\x69\x5f\x6b\x65\x79\x73\x3b\x0a\x03\x00\xe7\x36\xb9\xd1\x1f\x00\x00\x00")
+
+func _14_api_keysDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__14_api_keysDownSql,
+		"14_api_keys.down.sql",
+	)
+}
+
+func _14_api_keysDownSql() (*asset, error) {
+	bytes, err := _14_api_keysDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "14_api_keys.down.sql", size: 31, mode: os.FileMode(0644), modTime: time.Unix(1792389770, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xae, 0x75, 0xfc, 0x8b, 0xff, 0x3, 0x67, 0xb6, 0xad, 0x51, 0xf2, 0x1b, 0xe8, 0xd9, 0x0, 0xab, 0xac, 0x98, 0xb0, 0x91, 0xee, 0xff, 0x7b, 0xe0, 0xb7, 0xff, 0x6, 0xf5, 0xac, 0xb3, 0xa5, 0x9a}}
+	return a, nil
+}
+
+var __14_api_keysUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x92\x5f\x6b\xd3\x50\x18\xc6\xef\xf3\x29\xde\xbb\x25\xb0\xc2\x14\xf1\x66\x57\xc7\xe4\x2d\x0b\xa6\x27\x33\x39\x71\x9d\x22\x87\xd0\x1c\x5d\xa8\x6b\x43\x4e\xa3\x1d\x22\xb8\x81\x78\x67\xbf\x4a\x05\x27\x62\x5d\x3f\xc3\x7b\xbe\x91\x34\x4d\x52\x51\xe8\x2e\x5f\xf2\x7b\xfe\x84\xf3\xb8\x11\x32\x81\x20\xd8\x93\x00\xc1\xef\x03\x0f\x05\xe0\xd0\x8f\x45\x0c\x69\x91\xcb\xb1\xba\xd2\x60\x5b\x00\x00\x79\x06\x49\xe2\x7b\x70\x1a\xf9\x03\x16\x9d\xc3\x53\x3c\x07\x0f\xfb\x2c\x09\x04\xbc\x51\x13\x59\xa6\x93\x6c\x7a\x29\xab\x2a\xcf\x6c\xe7\xb0\x96\x54\x5a\x95\xb2\xd5\x6d\x9c\x79\x12\x04\x10\x61\x1f\x23\xe4\x2e\xc6\x35\xa0\xed\x3c\x73\x20\xe4\xe0\x61\x80\x02\xc1\x65\xb1\xcb\x3c\xdc\x3a\xbc\x9f\x96\x63\x5d\xa4\x23\xd5\xd9\xfc\xa5\xee\x3e\xee\xb5\x98\xa4\x97\x0a\x9e\xb3\xc8\x3d\x61\x91\xfd\xe0\xe8\xc8\xe9\x9a\x6c\x23\x7a\x3d\x28\x4a\xf5\x3a\x9f\x43\x0f\xe8\x8e\x96\xe6\x0b\x2d\x69\x45\x6b\xa0\x5f\xb4\x32\x5f\x37\x27\xd0\x77\x5a\x99\x05\xd0\xda\xdc\xd0\x9a\xbe\x99\x4f\xb4\xa4\x1f\x74\x4b\x77\xf4\xd3\x2c\x0e\xc1\x5c\xd3\x92\x7e\x77\x82\x8d\xcd\x2d\x98\xcf\x35\xb6\x41\x6e\xcc\xb5\x59\xd4\x61\x4d\x52\x5b\xe7\xe1\x7f\x6d\xc6\xea\x4a\x5e\xa4\xfa\xa2\x6b\xfc\xf8\xd1\x0e\x81\x84\xfb\xcf\x92\xe6\xbf\xf4\x68\x5a\x28\x0d\x02\x87\xe2\xe5\xab\x1d\xd2\x3e\xc9\xc1\x87\x8f\x07\x5b\x50\xcd\x8b\xbc\x54\x5a\xa6\x33\x10\xfe\x00\x63\xc1\x06\xa7\x70\xe6\x8b\x93\xfa\x84\x17\x21\x6f\x1c\xdf\xa6\x7a\x26\x2b\xad\xb2\xfb\xd1\x51\xa9\xd2\xd9\x7e\xb0\x6b\xc2\xc3\xb3\x76\x10\xa5\x7a\x37\x1d\xef\x97\x59\xce\xb1\x65\x35\xab\xf4\xb9\x87\xc3\x7f\x56\x99\x67\x73\xd9\x2e\x53\xb6\x03\x0b\x79\xb7\x56\xbb\xd2\xaa\x94\x79\xe6\x1c\x5b\x7f\x06\x00\xf0\x08\x67\x7a\xdc\x02\x00\x00")
+
+func _14_api_keysUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__14_api_keysUpSql,
+		"14_api_keys.up.sql",
+	)
+}
+
+func _14_api_keysUpSql() (*asset, error) {
+	bytes, err := _14_api_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "14_api_keys.up.sql", size: 732, mode: os.FileMode(0644), modTime: time.Unix(1792389770, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x26, 0xe8, 0x1e, 0x99, 0x8f, 0x48, 0x52, 0x68, 0xc7, 0x29, 0xcc, 0xcc, 0x3b, 0xc3, 0x95, 0xeb, 0xcc, 0xae, 0x20, 0xfc, 0x5d, 0x92, 0x91, 0x7b, 0xd6, 0xf8, 0xe5, 0x25, 0xed, 0x6c, 0xfb, 0x9}}
+	return a, nil
+}
+
+var __15_two_factorDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x2e\x2e\x48\x4c\x4e\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x2d\x2c\xcd\x2c\x4a\x8d\x2f\x29\xcf\x8f\x4f\x4b\x4c\x2e\xc9\x2f\xb2\xe6\x42\x36\xa0\x28\x3f\x87\x24\xbd\x5c\x60\x6b\x20\x7a\x11\x2a\x11\x2a\xe2\x93\x33\x12\x73\x72\x52\xf3\xd2\x53\x8b\xad\xb1\xab\x2d\x4a\x4d\xce\x2f\x4b\x2d\xaa\x8c\x4f\xce\x4f\xc1\xa9\xa8\xb4\x38\xb5\x28\xbe\x24\xbf\xa4\xc0\x9a\x0b\x30\x00\x9a\x30\xb9\xda\xef\x00\x00\x00")
+
+func _15_two_factorDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__15_two_factorDownSql,
+		"15_two_factor.down.sql",
+	)
+}
+
+func _15_two_factorDownSql() (*asset, error) {
+	bytes, err := _15_two_factorDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "15_two_factor.down.sql", size: 239, mode: os.FileMode(0644), modTime: time.Unix(1792389941, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x64, 0x9c, 0xf7, 0x8d, 0xc9, 0x6, 0x4d, 0xd3, 0x17, 0xfb, 0xab, 0xfd, 0xbd, 0xb9, 0x8, 0x96, 0x72, 0xc1, 0x1d, 0x17, 0x47, 0x1c, 0xa0, 0xd1, 0x92, 0x8c, 0x6c, 0x9, 0x17, 0x9c, 0x92, 0x66}}
+	return a, nil
+}
+
+var __15_two_factorUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xc5\x54\xdb\x4e\xdb\x40\x10\x7d\xcf\x57\xcc\x1b\x89\x14\x2a\x04\xa5\x0f\xe5\xc9\x24\x9b\x62\xd5\x71\xa8\xe3\x14\xe8\x8b\xe5\x3a\x0b\x58\x24\x76\x6a\x3b\x05\xde\x80\x82\xa8\x44\x05\xbf\x12\xd2\x44\x40\x42\xc2\x2f\xac\xff\xa8\xb3\x6b\xe7\xce\x45\x55\xab\x16\x29\x12\x9e\x9d\x9d\x33\xe7\xec\xcc\xc9\x68\x44\xd2\x09\xe8\xd2\xaa\x42\x40\xce\x81\x5a\xd0\x81\x6c\xca\x45\xbd\x08\x75\x9f\x7a\x46\xe0\x06\x35\x48\x26\x00\xff\xc4\xb7\x5d\x86\x52\x49\xce\xc2\xba\x26\xe7\x25\x6d\x0b\xde\x93\x2d\xd0\x48\x8e\x68\x44\xcd\x90\xe8\x8e\x9f\xb4\xcb\x29\x28\xa8\x90\x25\x0a\xc1\xda\x19\xa9\x98\x91\xb2\x24\x2d\x8a\xcc\xcf\x83\x4f\x2d\x8f\x06\x30\x0f\xac\xc3\xba\xe1\x65\x78\x0e\xac\x09\x9f\x4d\x9f\x2e\x2d\xa6\x81\xdd\xb0\x46\xf8\x9d\xdd\x86\xa7\xe1\x11\xeb\xb3\x26\x6b\xb0\x1e\xeb\x85\x17\xec\x0e\x82\x7d\xd7\xd8\x36\xad\xc0\xf5\x5e\x51\xc7\xf2\x0e\x6b\x81\xed\x3a\xc6\x1e\x3d\x14\x85\xe3\xaa\x3a\xd9\xd4\x05\x09\xb5\xa4\x28\x43\x48\xcb\x75\xb6\x6d\xaf\x4a\xcb\x86\xc9\x81\xc5\x19\xb0\x07\x04\xe8\xb0\x46\xf4\x4f\x37\xfc\x81\xd8\x02\x31\x3c\x61\x6d\xfe\x0d\x08\xdd\x8e\x4e\x5b\x18\x6b\xb2\x36\xf6\xd4\x62\xb7\xac\x1b\x07\x07\x04\x30\xbd\x87\xe1\x36\x67\xc4\xc3\x7d\x76\x2f\x90\x27\x60\x75\x39\x4f\x8a\xba\x94\x5f\x87\x0d\x59\x5f\x13\x9f\xf0\xa9\xa0\x8e\x74\xa9\x98\x7e\x60\xa0\x80\x65\xc3\x0f\x68\x8d\xeb\x83\x20\xe1\x31\xeb\x62\xf9\x96\x00\xb8\xc3\x10\xb6\x70\x8b\x82\x5c\x85\x27\x42\x94\xa5\x85\x79\x4c\x69\xb3\x4e\xf8\x0d\x53\x5a\xb1\x54\x22\xe5\x44\xf4\xcb\x15\xec\xc6\x64\x9b\x18\xeb\x63\xac\xc7\xfa\x71\xab\x3c\xf3\x46\xf0\x64\x3f\x79\x30\x22\x1c\x61\xe0\xef\x1e\x2f\xb7\x11\xe9\x38\xbc\x12\x5d\x4e\xb5\xb8\x2a\xbf\x93\xd5\x91\xdc\xf8\xe0\x39\xa9\xa4\xe8\xb0\x10\x71\xc2\x07\x31\x83\xe7\xc9\x0f\xaf\xa8\x85\x8d\x64\x2a\x91\x5a\x49\x24\x32\x4f\x4f\xa4\x47\x2d\xf7\x2b\xf5\x0e\x0d\xcb\x2d\x53\x3f\x1e\xcb\xc7\x26\x72\x50\x75\x87\x3a\x86\x67\x3a\x65\xb7\x6a\xd4\xeb\x76\x39\x99\x4a\xcf\x4e\xf2\xb0\xfb\xdf\x19\x63\xde\x80\xb1\x6b\xfa\xbb\xf0\x51\xd2\x32\x6b\x92\x96\x7c\xf3\x3a\x35\x35\x77\x42\xa7\x17\x1f\xfe\x8f\x44\x92\xd5\x2c\xd9\x9c\x12\xc9\x2e\x1f\x18\x93\x42\x19\x03\xbe\x48\x66\xf2\x24\x19\x9f\xbc\xa0\xfb\x68\xf3\x0c\x6b\xd7\xac\x54\xa8\xb3\xf3\x7f\xe5\x0f\xdc\x3d\x2c\xfd\xa4\xfe\x50\x52\xe5\x0f\xa5\x38\x77\xdf\xf5\xf6\xfc\x9a\x69\xd1\x21\xe4\x18\xd2\xf0\x70\x1a\xae\x48\xa6\x2c\x84\x3a\x9e\x5b\xa9\x54\xa9\x23\x9c\x6b\xb0\x49\x7d\x5c\xb6\xf0\x14\xb7\xa4\x13\x05\x00\x23\xd7\xe1\x95\x70\x31\xe1\x22\xdc\x1a\xd2\x10\x6d\xdc\xc8\x4d\xc6\x8d\xe3\xed\x93\x66\xd2\x04\x44\x68\xe0\x12\xa2\x49\x85\x67\x1c\xf4\x4c\x24\x36\x44\x4f\x63\x0d\xad\x16\x0a\x0a\x91\xd4\xd9\x45\xcc\x49\x4a\x31\x96\xc1\x0c\x02\x5a\xad\x05\x3e\x3c\xb7\xb1\xdc\x85\xdc\x1d\xdb\x41\x5b\x00\xab\x62\x63\x6d\xc3\xae\x45\x7c\xcf\x22\xbb\x78\xe0\x44\x1e\xb0\x9f\x23\xe1\x99\x97\x69\x40\x73\x38\x17\x26\x71\x8e\x5d\x77\x04\x4b\xf4\x22\xec\x92\x1b\x3b\x9a\xcc\x50\x29\x6e\x30\xe8\x55\xd7\x9c\x14\x5e\xba\x10\xd6\x14\xbb\xcb\x98\xd7\x8d\x8b\x2b\xae\x8c\xc9\x1b\x53\x8f\x7a\x1c\xbc\xfc\xe2\xf2\x72\x6a\x96\xd1\xdc\x5c\xbc\x5f\x43\x1a\x8f\x4e\xca\x74\x3a\x3d\xa8\xd9\x1e\xae\xcc\x73\xeb\xf8\xef\xd6\x5c\x52\x74\xa2\xc5\x2b\x89\xaf\x8d\x4b\x27\x65\xb3\x90\x29\x28\xa5\xbc\x3a\xe3\x8d\x5f\xea\xd8\xb9\x31\xda\xd5\x17\xc6\x62\x65\xa2\xfa\x68\x11\xfe\x2a\xc4\x2f\x84\xa4\xf8\xf5\x5f\x08\x00\x00")
+
+func _15_two_factorUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__15_two_factorUpSql,
+		"15_two_factor.up.sql",
+	)
+}
+
+func _15_two_factorUpSql() (*asset, error) {
+	bytes, err := _15_two_factorUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "15_two_factor.up.sql", size: 2143, mode: os.FileMode(0644), modTime: time.Unix(1792395405, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1c, 0x57, 0xb2, 0x1b, 0x12, 0x7d, 0x2a, 0xdd, 0x1f, 0xe2, 0xdf, 0xce, 0xb0, 0x9c, 0x93, 0xb2, 0x2f, 0x83, 0xdc, 0xe9, 0x71, 0xb6, 0x4b, 0xee, 0x6e, 0x99, 0x23, 0x66, 0x9d, 0x7e, 0x5c, 0xba}}
+	return a, nil
+}
+
+var __16_security_eventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\xcf\xcf\x0a\x82\x40\x10\x06\xf0\xbb\x4f\x31\xb7\xea\x15\x92\x0e\x95\xab\x2d\x58\xc6\xaa\xd4\x6d\x59\x74\x0e\x0b\x3a\x2b\xbb\x6b\x60\x4f\x5f\x10\x94\xd8\x1f\xba\xce\xcc\xef\x1b\xbe\x88\xa5\xac\x60\x10\x8b\x6c\x0f\xd6\x34\x28\x3b\xb4\xad\x76\x4e\x1b\x72\x70\xda\x31\xc1\xe0\x35\x59\xcd\x54\x5f\x6b\xbf\xb4\xa8\xea\x59\x18\x44\x23\xfb\xce\x48\xb5\x38\x01\x41\x24\xb2\x23\xc4\xe5\x61\x5b\xf0\xec\x00\x3c\x06\x76\xe6\x79\x91\x83\x22\x43\x43\xab\xaf\x28\x1d\x56\xbd\xd5\x7e\x90\x78\x41\xf2\x6e\x5e\x96\x3c\x5a\x84\x0f\x58\x08\x9e\x24\x4c\x8c\xdc\xe4\x5a\x92\x91\xde\xf6\x54\x29\x8f\x70\x7f\x30\x59\xff\x1d\xa3\xba\x0e\xa9\x96\x86\x9a\xe1\x7b\xcc\x87\x1a\x3f\x72\xe6\xcf\x12\xeb\x4d\xca\xbe\x9b\x30\xb8\x01\xb4\x91\xdb\x2c\x92\x01\x00\x00")
+
+func _16_security_eventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__16_security_eventsDownSql,
+		"16_security_events.down.sql",
+	)
+}
+
+func _16_security_eventsDownSql() (*asset, error) {
+	bytes, err := _16_security_eventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "16_security_events.down.sql", size: 402, mode: os.FileMode(0644), modTime: time.Unix(1792395567, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x14, 0x5f, 0xfd, 0xc2, 0x7a, 0x8c, 0x7e, 0x7d, 0x69, 0x73, 0xc3, 0x5a, 0xc4, 0x14, 0x83, 0x6, 0x58, 0x98, 0xbf, 0x33, 0xd0, 0x99, 0xae, 0x20, 0x88, 0xfe, 0x2e, 0x43, 0xb4, 0xd6, 0xbe, 0x9c}}
+	return a, nil
+}
+
+var __16_security_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa5\x58\x5f\x6f\xdb\xd6\x15\x7f\xd7\xa7\x38\x0f\x19\x24\x01\xb4\xd0\x15\xcb\x1e\xe2\xa5\x98\x2c\x5d\xcb\x5c\x65\xd2\xa0\xa8\x3a\xed\x8b\xc0\x48\xb4\x4c\x40\x12\x35\x92\x6a\x97\x15\x05\xe2\x04\x49\x5b\xb4\x8b\x81\x3d\x0c\x7b\x5b\x81\x7e\x01\x59\xb5\x13\x25\xb6\x95\xaf\x70\xf9\x8d\x76\xce\xb9\x24\x45\xc9\xb2\x97\x76\x7a\x10\xa8\x7b\xcf\x9f\xdf\x3d\xf7\x9c\xdf\x39\x54\xcd\x12\x55\x5b\x80\x5d\xdd\x69\x0a\xd0\x77\xc1\x30\x6d\x10\x8f\xf4\x96\xdd\x82\xd0\xed\x4e\x02\x2f\x7a\xd2\x71\xbf\x74\x47\x51\x08\xa5\x02\xe0\xc7\xeb\x41\xbb\xad\xd7\xe1\xc0\xd2\xf7\xab\xd6\xe7\xf0\xa9\xf8\x1c\xea\x62\xb7\xda\x6e\xda\xd0\x77\x47\x9d\xc0\x19\xf5\xfc\x61\x67\x32\xf1\x7a\xa5\xb2\xc6\x2a\x5b\x5b\xc0\x26\x3a\xd1\x93\xb1\x0b\x5b\x30\xf0\xfb\xde\xa8\x32\x76\xc2\xf0\x2b\x3f\xe8\x69\x30\x09\xdd\xa0\x12\xb8\x7d\x2f\x8c\xdc\x40\x83\x74\xa3\xd2\x3d\x76\x46\x7d\x17\xe4\x1c\xe2\x67\x15\x79\x5e\x61\x63\x39\x4b\x9f\x55\xad\xda\x5e\xd5\x2a\xdd\xff\xa8\xcc\xb8\x8d\x76\xb3\x99\x79\xf4\x27\x51\xd7\x1f\x92\xbb\x70\xd2\xed\xba\x61\x88\x76\xe4\x25\xda\x3a\x72\xbc\xc1\x24\x70\x59\x2e\x15\x4a\x2d\x7d\xbc\xc9\x12\xc1\xeb\xe0\xb1\xe5\x99\xbc\x90\x6f\x40\xce\xe4\xb5\xbc\x88\xbf\xa3\x6f\xf9\x8b\x5c\x80\x7c\x27\x2f\xe3\x57\xf1\xb7\x72\xfa\x00\xe4\xeb\xf8\x79\xfc\x14\xb7\xa6\xf2\x12\x48\x02\xe4\xb9\x5c\xa0\xdf\xd7\x28\x7c\x0d\xf2\x0d\x6e\xcc\xe4\x3c\x3e\x41\x0b\xcf\xe2\x1f\x41\x2e\xe2\x67\x10\x3f\x47\x21\x54\x20\x11\xdc\x3b\x05\xf9\x9e\x74\xe2\x1f\x51\x7c\x81\xe2\xd3\xf8\x19\x6e\xd1\xf6\x5b\xc6\x94\x02\xa2\x7b\xc8\x50\x3a\xdd\xc8\xe7\xd5\x2d\x04\x84\x0a\x0b\x40\x27\xa4\x7d\x11\x3f\x45\xb0\x73\xc2\x73\x4e\x26\xe2\x13\xdc\x45\x0c\xf2\x42\x03\xdc\x3c\xe1\xa0\x30\x54\x7c\x9e\xca\xab\x5b\xbc\x23\xd8\x12\x6e\x9f\xcb\x2b\x54\x25\x98\x68\x06\x4f\x4a\xbb\x8b\xf8\x69\x99\x61\x64\x18\x56\x90\xf1\x75\x13\xac\x19\xa1\x61\x10\xd7\xf2\x3a\xfe\x41\xbe\x05\xf4\xbd\xc0\x18\xa2\x41\x8d\xd0\x5d\xd2\xd9\x29\xb8\x1c\x10\x8c\x28\x4a\xc5\x2f\x50\x31\x7e\x81\x72\x14\xc8\xd9\xed\xe8\x10\xd8\x82\xc2\x8c\x01\x95\x67\xa8\xc7\xe1\xa5\x3b\x9a\xa3\x28\x85\xe1\x84\x45\xaf\x19\x96\xc2\x94\x5d\xfb\xfd\xfb\xcb\x7b\xcf\xb2\xb9\x58\x54\x47\xf0\xc6\x99\xe0\x1f\xff\x70\x87\x1c\x5f\x8b\x83\x35\x10\x2d\x33\xf3\xf7\x1f\xdf\xa1\x10\xb8\x4e\xe8\x7f\x28\x8a\x9e\x1b\x61\xe2\x86\xf0\x97\x96\x69\xec\x6c\x10\xfb\xfa\x9b\x44\xb0\x8b\x66\x23\xb7\xd7\x71\x22\xb0\xf5\x7d\xd1\xb2\xab\xfb\x07\x70\xa8\xdb\x7b\xfc\x13\xbe\x30\x0d\x71\x53\xdd\x30\x0f\x4b\xe5\x42\x79\xbb\x50\xa8\x29\x42\xd0\x8d\xba\x78\xb4\x46\x08\x5e\xef\x6f\x9d\x35\x52\xe8\xe4\xbc\x99\xc6\x3a\x65\x94\x96\xbb\x68\xfa\x57\x5a\x4e\xb3\x7c\x83\xd9\x64\x4b\x83\xff\xc7\x3e\x53\xc8\x06\xe3\x4b\x82\x59\xb3\x5f\xc0\x5c\x96\xff\xca\xd5\x37\xa5\x3e\xa7\xe2\x3b\x62\x01\x4a\x4f\xce\x4d\xcc\xda\x53\x2e\xef\x93\xf8\xb4\x02\xf2\xdf\x98\x7e\x57\x9c\xf3\x5c\xde\x54\x67\x58\x36\xf2\x0d\x7e\x13\x8b\xd0\xe2\x82\x0a\x61\x1e\x7f\x47\x19\xab\xaa\x80\x93\x75\xce\x82\x19\x25\x50\x21\x53\x49\xb3\x73\xf4\xca\xc5\xc1\xa0\xce\x59\x4c\x15\xcb\x0a\x99\x5c\x73\x79\x2d\x6e\x2b\x9a\xd3\x07\xab\x15\x88\xe5\xcd\xa8\x4e\x88\x73\xdb\x18\xe4\xad\x2a\xa7\x33\x56\xf8\xb7\x88\xe2\x7b\x54\x7c\xa5\xce\xa5\xd1\x1a\x61\x4c\xa0\xa0\x91\x0b\x86\xc2\x94\xc7\xc7\xcd\x07\xc1\xb6\xda\x46\x8d\x2e\x87\x09\xf0\x3d\x3b\xf9\x5e\x31\xe2\x8c\xb8\x50\x5e\x55\xd2\xeb\x33\x2d\xb0\xc4\x41\xb3\x5a\x13\xb0\x8b\x4a\xb6\x7e\xf3\x8a\x3a\xce\x78\xec\x8e\x7a\x1d\x7f\x34\x78\x52\x2a\xa3\xb8\xdd\xb6\x8c\x16\x44\x81\xd7\xef\xbb\x01\x54\x5b\x70\xef\x5e\x61\x47\x34\x74\x23\xa5\x20\xf9\x73\x0e\xc2\x35\x73\x44\xc2\x5f\x0b\xf9\x4e\xe3\xf0\xc4\xff\xe0\xeb\xbc\x8a\x9f\x63\x08\x99\x15\x17\x8a\x25\x49\x61\x9a\x13\x27\xb6\x7c\xcf\x8f\x8a\x5b\xb3\x63\xde\xc8\x07\xba\xc8\xf6\x41\x1d\x9d\x32\x0e\x4c\x49\xbb\xd1\x31\x0f\xe0\x21\x14\xd5\x72\x11\xec\x3d\xa1\x40\x26\x02\x86\x38\xac\x60\xce\x3f\x04\xb3\x59\xc7\x87\x6c\x8b\x3e\x55\xa3\xce\xfb\xb9\x0e\xa8\xe4\x96\x0b\x1b\xe5\xd3\x26\xa7\x84\x93\x5f\x1b\x25\xd3\x92\xd3\x5b\x5c\x3c\x75\xac\x1d\x1d\xaf\x00\x76\x2d\x73\x9f\x95\x13\x81\x8d\xca\x19\xf9\xdf\xaa\x9d\x4a\x6c\x54\x4f\xc8\x50\x81\x54\x3f\x36\xca\xe5\x08\x47\xc9\x2e\x17\x36\xca\x2b\xa6\xc7\x90\x17\xb3\x25\x24\xf4\x95\xdf\x39\xe2\xa6\xf5\x1b\x66\xc8\x4b\x4a\xbf\x7f\xfe\x84\x55\x92\x9f\x99\xe8\xca\x3d\xd2\x47\x25\x25\xc9\x6e\x67\xeb\x02\x6d\xe9\xbb\xea\x77\xfe\xd9\xaa\xea\x2d\x81\x54\x55\x13\x07\x9c\xee\xc5\xf5\xc9\xcb\x0b\x41\xa5\xfc\x16\xa5\x7c\x71\xbb\x80\xda\xdb\x85\x7b\xf7\xa0\x59\x35\x1a\xed\x6a\x43\xc0\x78\x30\xee\x87\x7f\x1d\x20\x49\xd5\x2d\xcc\x30\xdb\xd2\x1b\x0d\x61\x51\x46\x6d\x1e\xe6\xf2\x25\xb4\x81\x04\x33\x32\x4d\x0d\xdd\xa1\xce\x47\xd8\x11\xbb\xa6\x25\x92\x6c\xdf\x60\x90\x85\x50\x04\x44\xb5\xb6\x07\x96\x79\x88\xb8\x44\xad\x6d\x7f\x68\x91\x7f\xf8\xc1\x46\x7e\x27\x0a\x26\xa3\x2e\xe6\xc4\x6f\x39\x58\x4e\x3d\x7f\xb0\x8c\x3d\xfe\xd7\xd1\xb0\xdd\xda\x62\x5f\x18\xf6\xaf\x3f\x20\xf1\x54\xd6\x2c\xe2\x53\x35\xc0\xcc\x33\x42\x4f\x56\xb8\x05\xf0\x7c\xa3\xfa\xc2\x72\xea\x59\xa5\x9f\x19\x32\xd8\x94\x87\x2d\x6c\x05\xf1\x4b\x60\xae\x3e\xe3\x3e\xf3\x32\xfe\x01\x4a\xcc\x60\xc9\xdc\x34\x97\xbf\xf0\x28\xf7\x12\x9f\xde\x96\x2b\x8c\xe4\xa7\x74\x9b\xb8\x6e\xce\x74\xf8\x3a\x1b\x50\x6f\x76\xbe\x33\x1e\x6c\x2f\x15\x1b\x32\x6c\xea\x18\xe4\x53\x91\x6d\x1e\xb4\x96\x36\x14\x6a\x20\xef\x90\x5c\x89\x7f\x89\x61\x99\x5f\x89\x80\x5f\x24\x9d\x2f\xf1\xc7\x80\x66\x38\x31\xde\xe8\xaf\x3c\x40\xab\x53\x26\x6d\xe8\x2d\xa4\x27\x8b\x5f\x01\x75\xb2\xf7\x6c\x6b\x46\xdc\x8e\xe3\x4e\x53\xe0\x95\x94\x9c\x49\xcf\x8b\x90\x65\x22\xbc\x08\xcf\x1f\x75\x7a\x8f\x79\xdc\xc0\xa3\x5b\xe2\x33\xf3\xd3\x34\x91\xb5\x44\x41\xbb\xeb\xfe\x15\xbf\x1d\xb4\x77\x9a\x7a\x6d\xbb\x50\x37\xa9\xf9\xd4\x45\xad\x59\xb5\x14\xed\xf7\xf1\x8d\x28\x72\x5d\xb0\xc5\x23\x7b\x3b\xd7\x96\x28\x65\xd2\x3d\x7d\x49\x1e\x2d\xf4\x58\xcb\xb1\x67\xbf\x92\x08\x65\x12\xec\xcf\x1b\x1d\xf9\xc1\xd0\x61\xf4\x61\xf7\xd8\x1d\x3a\x95\xc0\x1f\xb8\x9d\xc8\x79\x8c\xdf\xac\x12\x42\x3f\xd3\x39\xdc\x13\x98\xc5\xfd\x8a\xda\x1e\x39\xdc\x0e\xd6\x79\x26\xcf\x7c\xc4\x7b\xfd\xca\x38\xf0\xbe\xf4\x06\x6e\xdf\x55\xed\x46\x37\xa0\x94\xb6\x2e\x0d\x8a\x2a\x38\xf4\x94\x86\xa7\x58\xbe\x61\x22\x3d\x22\xb5\x04\xd6\x57\x91\x42\x2d\xf4\x1d\x50\xe3\xe2\xc8\xb3\x5e\xd3\x34\x0f\x96\x6c\x99\xd4\x8f\x3a\x67\xa9\xf8\x5b\x6f\xe6\x77\x3a\xfa\x4a\x50\x94\x97\xe4\x4b\xbe\x32\x22\x55\xd5\xe7\x8c\xfc\xd1\x93\xa1\xf7\x77\x77\x7d\x56\xcc\x8f\x63\x9c\xde\x1b\x87\x26\x6d\x65\x64\x9a\xe7\x76\x28\xc5\xf1\x2d\x84\xd3\x98\x52\xf8\x9c\x53\xf4\x8c\xf2\x35\x79\x2b\xc1\x57\xa1\xd9\x5a\x95\xd0\x8b\xcf\x2d\x63\x5b\x52\x94\x6b\x2f\x8e\xbc\x80\x75\x85\x08\x93\x4a\x50\xd3\x16\xbf\x6e\xce\x71\x12\xfd\xe7\xa6\x0a\x22\x50\xf9\x32\x99\x12\x1f\xac\xd0\x07\x56\xd2\x4b\x39\xbd\x39\x25\x71\x99\xa5\xd3\xd0\x3a\x43\xa8\x41\xf0\x1a\x11\xbe\x56\x03\x6e\x56\x86\xc9\x0d\xde\x39\xf0\xdd\x7a\x13\xa5\xc8\x09\xfa\x6e\xc4\x2f\x98\xcb\xc9\x6f\x47\xc7\xa2\xb2\x0b\xeb\xdd\x10\x6b\xa9\xd6\xb6\x74\x9b\xff\xa0\xd0\x0d\x64\xfb\x96\xb0\x31\x49\x9c\xa0\x7b\xdc\x19\x3b\xd1\x31\xd6\xc0\x78\xf2\x78\xe0\x75\x93\x99\x31\x5f\xb6\xce\xd1\x91\xdb\xc5\xb9\x22\x31\x9e\xaf\xdc\xa4\xcb\xad\x27\x89\xaa\x50\x72\xb1\x9c\x39\x34\x48\x86\x0d\x0d\xd6\xa6\x0c\x2d\x7b\xa3\x7b\x08\x6e\x36\x5e\x6c\x41\xf1\x28\xf0\x87\x45\x7a\x88\x7c\x55\x90\x9c\xc5\xa4\x1d\xc2\xa4\xb0\x2c\xe6\x89\x1a\x14\x55\x40\x0a\xcb\x92\x2b\xb9\xd9\x1c\xb7\xb6\xcb\x1f\x0c\x37\x4a\x28\x84\x7f\xfa\x24\x1d\x83\x4a\x03\xff\x2b\x37\x48\x37\xca\xa8\xa9\x16\x26\x15\x64\x16\x6f\x50\x26\x35\x37\x9b\xa5\x26\x95\xf1\xb1\x3f\x42\x1e\x99\x0c\x1f\x63\xed\x96\x93\xb2\x6a\x08\xe2\xad\x6a\xc3\x30\x91\xbb\x6a\xad\x65\x08\x1f\x52\xc7\xef\xd4\xcc\x36\xc5\x31\x37\x1d\xa5\x02\xf9\x3a\xd4\x8d\x96\xb0\x88\x2a\x6c\x13\xc6\x6e\x30\xf4\xc2\x10\x39\x2e\x84\x12\x91\x16\x05\x2d\xec\x06\xde\x98\x78\xaf\x8c\x6f\xce\xcd\xb6\x68\xb1\xc5\x52\x91\x39\xfd\x01\x0e\x83\x3d\x62\x24\xf9\x1f\xca\x4f\x4c\xfc\x2b\xea\x10\xf1\xd3\x95\x7f\x66\xa8\x1e\xf9\xcf\x1c\x7e\x65\x9b\xa2\x54\x5a\x89\x73\x64\x30\xcc\xbf\x9a\x69\xec\x22\x49\xd9\xca\x6b\x19\x90\xd5\x91\xbf\xf6\x74\xa3\xb1\x86\x90\x39\x77\x05\x26\xad\x68\x39\xe4\xeb\x28\x7b\x43\x6f\x44\x00\x73\x70\x57\x5d\xe6\x7d\xfd\x17\x10\xbc\xf0\xb6\x9e\x13\x00\x00")
+
+func _16_security_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__16_security_eventsUpSql,
+		"16_security_events.up.sql",
+	)
+}
+
+func _16_security_eventsUpSql() (*asset, error) {
+	bytes, err := _16_security_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "16_security_events.up.sql", size: 5022, mode: os.FileMode(0644), modTime: time.Unix(1792395576, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfb, 0x7b, 0x46, 0x56, 0x55, 0xb2, 0x94, 0x99, 0x32, 0xa9, 0xd3, 0x95, 0x77, 0x6c, 0xd7, 0x5f, 0x47, 0xee, 0xd9, 0xc8, 0x6f, 0x42, 0xa0, 0x73, 0xf6, 0x52, 0xd2, 0xb8, 0x19, 0xb4, 0xa3, 0x39}}
+	return a, nil
+}
+
+var __17_sessionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x70\x00\x8f\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x72\x65\x66\x72\x65\x73\x68\x5f\x74\x6f\x6b\x65\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4e\x53\x54\x52\x41\x49\x4e\x54\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x66\x72\x65\x73\x68\x5f\x74\x6f\x6b\x65\x6e\x73\x5f\x73\x65\x73\x73\x69\x6f\x6e\x5f\x66\x6b\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x65\x73\x73\x69\x6f\x6e\x73\x3b\x0a\x03\x00\x10\x2e\xe6\x67\x70\x00\x00\x00")
+
+func _17_sessionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__17_sessionsDownSql,
+		"17_sessions.down.sql",
+	)
+}
+
+func _17_sessionsDownSql() (*asset, error) {
+	bytes, err := _17_sessionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "17_sessions.down.sql", size: 112, mode: os.FileMode(0644), modTime: time.Unix(1792390664, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x75, 0x38, 0x51, 0xc2, 0xe6, 0x46, 0x6b, 0x95, 0x7c, 0x6c, 0x57, 0x8e, 0xb9, 0x64, 0x8c, 0xde, 0x3e, 0xf1, 0x8e, 0x4f, 0xa9, 0x1a, 0xc7, 0x29, 0x65, 0xb1, 0x35, 0x4e, 0x95, 0x6f, 0xb1, 0xea}}
+	return a, nil
+}
+
+var __17_sessionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x94\xc1\x4e\xdb\x40\x10\x86\xef\xfb\x14\x73\xc3\x96\x8c\x04\x55\xdb\x43\x72\x5a\xec\x0d\x59\xd5\xd9\x45\xeb\x4d\x81\x5e\xac\x88\x2c\xad\x05\x0d\x28\x36\x88\xde\x1a\xaa\x9e\x38\xe4\x19\xfa\x06\x10\x11\x15\x5a\x70\x5f\x61\xf6\x8d\x2a\x27\xb1\x63\x28\x42\xa8\xc7\x9d\xfd\xe7\x9f\xd9\xfd\x46\xe3\x2b\x46\x35\x03\x4d\x37\x42\x06\xbc\x05\x42\x6a\x60\x3b\x3c\xd2\x11\xa4\x26\x4d\x93\xa3\x41\x0a\x0e\x01\x00\x48\xfa\xd0\xed\xf2\x00\xb6\x14\xef\x50\xb5\x0b\xef\xd8\xae\x37\xbb\x38\x49\xcd\x30\x2e\x6f\x8b\x7c\xd1\x0d\x43\x50\xac\xc5\x14\x13\x3e\x8b\x66\x82\xd4\x49\xfa\x2e\x48\x01\x01\x0b\x99\x66\xe0\xd3\xc8\xa7\x01\x9b\x3b\xf4\xcd\x69\xb2\x67\xe0\x3d\x55\x7e\x9b\x2a\x67\x7d\x6d\xcd\x5d\x1a\x05\xac\x45\xbb\xa1\x86\x95\x95\xb9\x38\x39\xae\x84\x6f\x5f\x3f\xa3\x2b\xaa\xc6\xbd\x8f\x66\x90\x55\xfa\x37\xeb\xaf\x9e\x49\xd8\x1b\x9a\x5e\x66\xfa\x71\x2f\x03\xcd\x3b\x2c\xd2\xb4\xb3\x05\xdb\x5c\xb7\x67\x47\xf8\x20\x05\xfb\x37\x59\xc8\x6d\xc7\x9d\x37\x76\xd8\x4b\xb3\x38\x35\x66\xf0\xdf\x0e\xe6\xec\x38\x19\x9a\xf4\x45\xf9\xf3\x9a\x43\x73\x7a\x74\xf0\x7c\xcf\xc4\x6d\x12\xb2\xc0\xcc\x45\xc0\x76\x1e\x61\x4e\xfa\x67\x71\x89\x3a\x2e\x59\x4a\x51\xe1\x77\x16\x31\xb7\xf9\x62\x97\x5a\x57\x75\xa3\x65\xd8\x85\xed\x36\x53\xac\xde\x3e\x8f\xaa\xa7\x35\x09\x59\x5d\x05\xfc\x81\x53\xbc\xc3\x29\xde\xda\x91\x3d\xc7\x09\x5e\xc2\xd0\xec\x0f\x4d\xfa\x09\xec\x39\xe6\xf8\x0b\xa7\x78\x8f\x39\x4e\x3c\xc0\x89\xbd\xc0\x6b\xbc\xc4\x7b\xbc\xb7\x17\xf6\x3b\xe0\x35\xe6\x80\x7f\x30\xb7\x63\x9c\xe0\xef\x99\xf2\xc6\x8e\xc1\x8e\x70\x6a\x47\x76\x84\x37\x78\xeb\xc1\xcc\xb7\x48\xca\x71\x62\xc7\xf6\xdc\x8e\x1e\x48\xec\x18\xef\xf0\x06\xf0\x0a\xa7\xf8\x13\x1e\xfa\xe7\x78\x05\xf6\x5b\x61\x60\xbf\x62\x5e\xb5\x38\x25\x5c\x44\x4c\x69\xe0\x42\xcb\xea\xe1\xe0\x24\x7d\x0f\x16\xdf\xe8\xd5\xe6\xcc\x7b\x30\x33\x5e\x8d\xbf\x57\xfb\x1a\x97\x44\x2c\x64\xbe\x9e\x01\xdf\xef\x7d\x4e\x0e\xbf\xc4\x49\x7f\xce\xbf\xc3\x45\xc9\xa7\xd1\xc8\xcc\x59\xe6\x36\x1a\x27\x27\xf5\xdb\x65\xb5\xc5\x90\x75\xe8\xce\xd3\xc1\x65\xf5\x45\xd0\xa7\x11\x2b\x40\x09\xd8\x90\x32\x8c\xa9\x08\x9c\xa7\x81\xb9\xa0\x0b\x55\x61\x52\x6b\x1b\x98\x08\x48\x4b\xc9\x4e\xc9\x2d\xce\x8e\x0e\xcc\x20\x25\x9b\x4a\x76\xb7\x60\x63\x77\xf9\x18\x22\x05\xf8\x52\xb4\x42\xee\xeb\xe2\xb7\x5c\x08\x64\xe1\xde\xe6\x62\xb3\x49\x08\x0d\x35\x53\x8b\x1d\xf5\xc8\xab\x68\x9e\x06\x41\x91\x1d\x69\x45\xb9\xd0\x8f\xaa\x95\x53\x19\xef\x1f\x40\x4b\x2a\xc6\x37\x45\xb1\xba\xc0\xa9\x8a\xbb\xf5\x5d\x55\x32\x7b\x7a\x5d\x35\xc9\xdf\x01\x00\xe7\xee\x41\x90\x30\x05\x00\x00")
+
+func _17_sessionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__17_sessionsUpSql,
+		"17_sessions.up.sql",
+	)
+}
+
+func _17_sessionsUpSql() (*asset, error) {
+	bytes, err := _17_sessionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "17_sessions.up.sql", size: 1328, mode: os.FileMode(0644), modTime: time.Unix(1792390715, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd0, 0xbb, 0x3, 0x5e, 0x78, 0x63, 0x4f, 0x58, 0x7e, 0xb4, 0xe2, 0xc5, 0xd4, 0x1f, 0x88, 0x5c, 0xfc, 0x50, 0x4a, 0x8c, 0x43, 0x58, 0x30, 0xa7, 0xfc, 0x0, 0x90, 0x49, 0x6b, 0x7b, 0x87, 0xf}}
+	return a, nil
+}
+
+var __18_rate_limitsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x2c\x49\x8d\xcf\xc9\xcc\xcd\x2c\x29\xb6\xe6\x02\x00\xfa\x9b\xfd\x9d\x22\x00\x00\x00")
+
+func _18_rate_limitsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__18_rate_limitsDownSql,
+		"18_rate_limits.down.sql",
+	)
+}
+
+func _18_rate_limitsDownSql() (*asset, error) {
+	bytes, err := _18_rate_limitsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "18_rate_limits.down.sql", size: 34, mode: os.FileMode(0644), modTime: time.Unix(1792393240, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe, 0xf3, 0x7c, 0xcf, 0x89, 0x1c, 0xcf, 0x1e, 0xe, 0x1a, 0x8b, 0xbd, 0x1, 0x49, 0x7e, 0x50, 0x42, 0xd4, 0xf, 0x9d, 0xa2, 0x8b, 0x2b, 0xb8, 0x8f, 0x1a, 0xb8, 0xe, 0x85, 0xf5, 0x2f, 0xb0}}
+	return a, nil
+}
+
+var __18_rate_limitsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\x8f\x41\x0b\x82\x40\x10\x85\xef\xfe\x8a\x39\x16\x74\xe8\xde\x69\xd3\x91\x86\xd6\x5d\xd1\x91\xb4\x8b\x48\xed\x41\xca\x12\xdd\xc2\xfe\x7d\xa6\x50\x12\x04\xcd\xed\xf1\x3e\x3e\xe6\xb9\x11\x0a\x46\x60\xb1\x96\x08\xe4\x83\xd2\x0c\x98\x52\xcc\x31\x34\x85\x35\xf9\xb9\xac\x4a\xdb\xc2\xcc\x81\xfe\x4e\xe6\x01\x8c\x29\x43\x18\x51\x20\xa2\x0c\xb6\x98\x2d\x86\xe6\x70\xbd\x5d\x2c\x78\x3a\x79\x69\xc2\x08\x5d\x8a\x49\xab\xc1\xa6\x12\x29\xc1\x43\x5f\x24\x92\x61\x39\xe2\x75\x63\xee\xff\xd3\xad\x2d\xaa\x1a\x98\x02\x8c\x59\x04\x21\xec\x88\x37\x43\x84\xbd\x56\x38\x32\xa6\xab\xcb\xc6\xb4\x79\x61\x7f\x82\x6f\xbf\x33\x5f\x39\x8e\x3b\x0e\x27\xe5\x61\xfa\x35\xbc\x3c\x76\xf9\x64\x7c\x3e\x71\xf7\x5f\x4e\x9a\xd9\xa7\xe9\x95\x4f\x8f\xbe\x84\x41\x4b\x01\x00\x00")
+
+func _18_rate_limitsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__18_rate_limitsUpSql,
+		"18_rate_limits.up.sql",
+	)
+}
+
+func _18_rate_limitsUpSql() (*asset, error) {
+	bytes, err := _18_rate_limitsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "18_rate_limits.up.sql", size: 331, mode: os.FileMode(0644), modTime: time.Unix(1792393240, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8b, 0xa6, 0xde, 0x4e, 0x2b, 0x70, 0xa7, 0x1a, 0xc2, 0x2c, 0x42, 0x3a, 0x7b, 0xc2, 0x59, 0x5e, 0xbe, 0xd0, 0xf0, 0x77, 0xf4, 0x5b, 0x5b, 0x32, 0xc7, 0x29, 0x2d, 0x54, 0xca, 0xcf, 0xd5, 0x9c}}
+	return a, nil
+}
+
+var __19_keywords_permissionDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x71\xf5\x71\x0d\x71\x55\x70\x0b\xf2\xf7\x55\x28\xca\xcf\x49\x8d\x2f\x48\x2d\xca\xcd\x2c\x2e\xce\xcc\xcf\x2b\x56\x08\xf7\x70\x0d\x72\x55\x40\x88\xd8\xaa\x67\xa7\x56\x96\xe7\x17\xa5\x14\x5b\xe5\x26\xe6\x25\xa6\xa7\xaa\x5b\x73\xb9\x20\x19\x80\xa9\x37\x2f\x31\x37\x15\x9b\x2e\x00\x4c\xd8\x99\x56\x77\x00\x00\x00")
+
+func _19_keywords_permissionDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__19_keywords_permissionDownSql,
+		"19_keywords_permission.down.sql",
+	)
+}
+
+func _19_keywords_permissionDownSql() (*asset, error) {
+	bytes, err := _19_keywords_permissionDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "19_keywords_permission.down.sql", size: 119, mode: os.FileMode(0644), modTime: time.Unix(1792393937, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2c, 0x71, 0x18, 0xf6, 0x73, 0x2, 0xaa, 0x7e, 0x55, 0xe6, 0x27, 0x10, 0x11, 0x2a, 0x41, 0x2a, 0x3d, 0x3c, 0x99, 0x28, 0xea, 0x51, 0x9, 0x20, 0xb3, 0x4e, 0x18, 0xa2, 0xee, 0x6f, 0xff, 0xfe}}
+	return a, nil
+}
+
+var __19_keywords_permissionUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5d\x8f\xb1\x8a\xc2\x40\x14\x45\xfb\x7c\xc5\xeb\x62\x20\x5f\xb0\x56\x4b\x8c\xee\x80\xcc\xc0\x26\xda\x4a\x30\x83\x84\x35\x89\x64\x16\x16\xbb\x28\x58\x58\xd9\xfa\x19\x1a\xb5\x52\xe3\x2f\xdc\xf9\x23\x27\x6c\x13\x7d\xcd\xbb\x5c\xde\xe3\xde\xc3\x78\xe0\x7f\x87\xc4\x78\x28\x68\x21\x8b\x34\x51\x2a\xc9\x33\x45\x9d\x2c\x4a\xa5\x4b\xb1\x54\xd3\x22\x59\xfc\x1a\xcf\xa1\xf1\xe7\x70\xe4\x07\x16\x99\xe9\xd8\x3f\x72\xf9\x97\x17\xb1\xfa\x48\xa3\x2c\x9a\x49\xdb\x25\x1b\x7b\xdc\xf0\x40\xad\x4b\xbd\xa6\xc0\x17\xa4\x57\xb8\xa2\x46\x85\x83\xb1\x76\x2e\x19\x7d\xd4\x5b\x5c\x70\x42\x4d\x38\xe3\xaa\x77\x84\xca\x5c\x5d\xf4\x86\xf0\xd0\xa5\xf9\x5d\xe9\xb5\xd9\x07\xdc\x1b\x85\xca\x76\x2c\xc1\xc9\x13\xbc\x3f\x64\x5e\xf8\x5f\xcb\xa1\x9e\x20\x2e\xc2\x2f\xc6\x07\x5d\xcb\x62\x2d\x84\x22\x9f\xcb\xc9\x0b\x47\xe3\xb8\x2d\xb4\x37\x8c\x28\x4e\x93\xac\x29\xff\xce\xf3\x9a\xdb\x0e\x7c\x02\xf6\xc0\x27\xfe\x33\x01\x00\x00")
+
+func _19_keywords_permissionUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__19_keywords_permissionUpSql,
+		"19_keywords_permission.up.sql",
+	)
+}
+
+func _19_keywords_permissionUpSql() (*asset, error) {
+	bytes, err := _19_keywords_permissionUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "19_keywords_permission.up.sql", size: 307, mode: os.FileMode(0644), modTime: time.Unix(1792393937, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8, 0x6e, 0x22, 0xb1, 0x7b, 0xc, 0x8f, 0x78, 0xc3, 0x30, 0x31, 0x93, 0xf8, 0x77, 0xc1, 0xcd, 0x76, 0x15, 0x46, 0xf9, 0xfb, 0xee, 0xd3, 0x28, 0xa0, 0x96, 0x6c, 0x9, 0x3f, 0x8e, 0x3c, 0xcd}}
+	return a, nil
+}
+
 var __1_user_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x2d\x4e\x2d\x2a\xb6\x06\x04\x00\x00\xff\xff\xc8\x3d\x4e\x55\x1b\x00\x00\x00")
 
 func _1_user_migrationDownSqlBytes() ([]byte, error) {
@@ -113,6 +551,126 @@ func _1_user_migrationUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __20_users_phone_uniqueDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8d\xbd\x0a\x83\x30\x10\x80\x77\x9f\xe2\xde\xa3\x93\x36\x29\x04\xa2\x16\x4d\xc1\xed\x50\x73\xa0\x83\x49\x9a\x78\xd0\xc7\xaf\x05\x2d\x2e\xee\xdf\x8f\x68\xea\x27\xa8\x4a\xc8\x0e\xd4\x03\x64\xa7\x5a\xd3\xc2\x6c\x3f\xe8\xd7\x80\xa3\xb7\x94\x30\x4c\xde\x11\x06\x8e\xc1\x27\xc2\x31\x52\xbf\x92\xbd\x65\x59\xae\x8d\x6c\xc0\xe4\x85\x96\xf0\xa7\x41\xfc\x82\xf7\x5a\xbf\xca\xea\x54\xdc\xed\xcd\x12\x57\x43\x4e\x14\x8f\x99\xe3\x65\xa0\x88\xec\xe6\x37\x6f\xd2\x17\xec\x3d\x44\x12\xa5\x00\x00\x00")
+
+func _20_users_phone_uniqueDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20_users_phone_uniqueDownSql,
+		"20_users_phone_unique.down.sql",
+	)
+}
+
+func _20_users_phone_uniqueDownSql() (*asset, error) {
+	bytes, err := _20_users_phone_uniqueDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20_users_phone_unique.down.sql", size: 165, mode: os.FileMode(0644), modTime: time.Unix(1792394861, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xee, 0x61, 0xbc, 0x5f, 0x99, 0xfc, 0x4a, 0x2, 0x8a, 0x6c, 0xc5, 0xf2, 0x39, 0xaa, 0x1c, 0x2, 0x5d, 0x1e, 0x3d, 0xe6, 0xdc, 0x1c, 0x8, 0xe5, 0x8, 0x5d, 0x3c, 0xf4, 0x52, 0x90, 0xa0, 0xed}}
+	return a, nil
+}
+
+var __20_users_phone_uniqueUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x85\x52\x4d\x8f\xd2\x50\x14\xdd\xf7\x57\xdc\x1d\x90\x00\x89\xba\xd3\xd1\xa4\xd2\x92\x21\xe9\x14\xed\xc7\x38\xbb\x66\x1c\x1a\x25\x19\x29\x02\x4d\x5c\xce\xa0\xb8\xc1\xc8\xde\xc4\xff\xd0\x41\x2a\x64\x66\xc0\xbf\x70\xdf\x3f\xf2\xbc\xd7\xc2\x00\x86\xd8\x45\xdf\x7b\xf7\x9e\x7b\xee\xd7\xa9\x54\x88\x7f\xf2\x8a\xef\x39\x15\x57\x24\x86\x9c\xf2\x1d\xae\x5f\x60\x5a\x72\x42\xe2\x33\xdf\x72\xc2\x73\x31\xe6\x29\xce\x54\x0c\x49\xd9\xe1\x9e\xe1\xb2\xe2\x5f\xbc\x22\xfe\x83\xcb\x9d\xf8\xc6\x73\x9c\x80\x65\x2c\x62\xf2\x94\x78\x06\x82\x1b\x30\x2e\x24\x8d\x18\x8a\x71\x99\xc4\x35\xaf\xc4\x48\x5c\x81\x6e\x09\xdc\x92\x97\x20\x4f\x01\xe5\x95\x56\xa9\x64\x64\x33\x50\x4c\x65\x45\xfc\x1b\x76\x09\x5a\x88\x09\xa9\x84\xaa\x50\x4e\x14\x0f\xcc\x78\x27\xe2\x3b\x98\xaf\x01\x90\xd4\xc4\x53\x1c\xa9\x18\xe1\x82\xc4\x89\x8a\x47\x71\xe2\x2b\xce\x69\x99\xf8\x16\xe1\x8a\x46\xc2\x13\x5c\xb2\x26\xf2\x82\x64\x49\x78\x6a\xfe\x2b\x43\xf7\x4c\x8a\xfb\x61\xaf\x4f\xb1\xe6\x9a\x1e\x75\xdf\x47\x9d\x30\xe8\xc4\x1f\xde\x86\x3d\x7a\x4e\x85\x82\xf6\xe6\xd8\x74\x80\xa9\xee\x78\x8e\x5e\xc0\x45\xba\x6d\xc0\xd1\x0a\x2f\xc3\x41\xd8\x0a\xce\x07\xd4\x70\xc9\xf6\x2d\x4b\x23\x7c\xd2\x69\x9e\x35\x5c\xcf\xa5\xa2\x32\xc8\xcf\x35\x2d\xb3\xe6\xd1\x23\xaa\x3b\xcd\x93\x3c\x71\xb4\xf1\x66\xa9\xa2\xea\x5e\x11\x7b\xb9\x25\x71\x74\x28\xeb\xfa\x93\xa0\x62\x54\xbd\xe8\x85\xe7\x19\xaa\x8c\x98\x76\xab\x44\x47\x54\x8c\x77\xcc\xb1\x34\xab\xd8\xd2\x33\x4d\xab\x39\xa6\x1c\x89\x6f\x37\x5e\xfb\x26\x35\x6c\xc3\x3c\xa3\x46\x9d\xec\xa6\xb7\x6e\xa6\xdd\xfa\x14\xa8\xc2\x83\xed\xaa\x82\xb8\xd3\xfe\x18\x87\x8a\xa7\x69\x67\x9d\x15\xb7\x01\xa5\xbc\xbb\x03\x63\xfc\xb7\x1d\x14\x23\x95\xf2\x43\x29\x65\x2c\x37\x3e\x52\x82\x84\x2e\x17\x58\xf0\xda\xfc\x1f\x25\x4d\xd7\xf2\xdd\x92\x95\x7c\xa4\x52\x39\x73\xa8\x61\xa1\xc4\x31\x57\x22\x41\xa4\x98\xe0\x7f\x2f\xc6\x9a\x6e\x79\xa6\x43\x9e\xfe\xd2\xc2\x46\x06\xdd\xe0\x22\x6a\x85\x7d\xd2\x0d\x83\x6a\x4d\xcb\x3f\xb1\xf7\xa6\xd2\x8d\x7b\xdd\xa8\x1f\xd2\xa9\xee\xd4\x8e\x75\xa7\xf8\xe4\x71\x49\xb9\x65\x27\x64\x98\x75\xdd\xb7\x3c\x2a\x5c\x46\xef\xda\x9d\xc2\xc3\x98\x0f\xcd\x77\x93\x30\x9f\x71\xce\x1e\xe4\x7b\x93\x13\xde\x40\x76\xa6\x5c\x5e\x17\x52\xa6\x87\x1d\x23\xbf\x5b\xc3\x72\xff\x02\x0c\x74\xb6\x8a\x07\x04\x00\x00")
+
+func _20_users_phone_uniqueUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20_users_phone_uniqueUpSql,
+		"20_users_phone_unique.up.sql",
+	)
+}
+
+func _20_users_phone_uniqueUpSql() (*asset, error) {
+	bytes, err := _20_users_phone_uniqueUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20_users_phone_unique.up.sql", size: 1031, mode: os.FileMode(0644), modTime: time.Unix(1792394861, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe2, 0xe4, 0x4c, 0x34, 0x11, 0x3b, 0xbb, 0x68, 0x59, 0x83, 0xdb, 0xb7, 0x71, 0xf, 0xc0, 0x9, 0x3e, 0x1e, 0xdb, 0xf8, 0x86, 0x74, 0xb9, 0x68, 0x6d, 0xb0, 0x56, 0x9f, 0x8d, 0x16, 0xc5, 0x5f}}
+	return a, nil
+}
+
+var __21_users_email_lowerDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x4c\xa9\x88\x2f\x2d\x4e\x2d\x2a\x8e\x4f\xcd\x4d\xcc\xcc\x89\xcf\xc9\x2f\x4f\x2d\x8a\x2f\xcd\xcb\x2c\x2c\x4d\xb5\xe6\x02\x00\x8a\xe1\xf6\x0d\x33\x00\x00\x00")
+
+func _21_users_email_lowerDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__21_users_email_lowerDownSql,
+		"21_users_email_lower.down.sql",
+	)
+}
+
+func _21_users_email_lowerDownSql() (*asset, error) {
+	bytes, err := _21_users_email_lowerDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "21_users_email_lower.down.sql", size: 51, mode: os.FileMode(0644), modTime: time.Unix(1792396005, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xab, 0x39, 0x26, 0xad, 0xed, 0xff, 0x7b, 0xe, 0xeb, 0x14, 0x45, 0x1f, 0xf2, 0xc4, 0xd2, 0xad, 0x28, 0x0, 0x5b, 0x71, 0x87, 0xae, 0x7f, 0xed, 0xde, 0x54, 0xe8, 0xa3, 0x1a, 0xf2, 0xb8, 0xc}}
+	return a, nil
+}
+
+var __21_users_email_lowerUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5d\x91\xdb\x6e\xda\x40\x10\x86\xef\xfd\x14\x73\x41\x15\x88\x20\x6a\x6f\x43\x8b\x04\x66\x03\x96\x52\x9b\xfa\xd0\xa6\x57\xc8\x8d\x97\xd4\x12\x87\xd6\x86\x1e\xee\x02\x91\x1a\xa5\xad\x42\x1f\xc5\x24\xb1\x82\x20\xa6\xaf\x30\xfb\x46\x9d\xdd\x58\x2d\xd4\x17\xf6\x6a\xfe\x99\xef\xff\x67\x5d\xa9\x00\x1b\xf8\x61\x1f\xc4\x54\x9c\x63\x82\x37\x98\xe1\x92\xde\x09\xa6\x62\x46\xb5\x39\xe0\x02\x53\xbc\x07\x71\x21\x2e\x65\x0d\x13\xa0\xc6\x14\x6f\x71\x49\xf2\x4c\x0e\x1d\x00\xfe\xc2\x3b\x59\x15\x53\x4c\xca\x80\x1b\x6a\x5b\x93\x7e\x89\x89\xb8\x16\x57\x04\x4c\x15\x8a\xca\x1b\x5c\x8b\x9f\xb8\xc2\x8d\x56\xa9\xfc\x0f\xda\xe0\x03\x0d\x67\x98\x12\x01\x17\xe2\x3b\x89\x77\x34\x9b\x89\x39\x51\xf2\x30\x94\x50\x51\x1e\x08\x3d\x53\x16\xd2\x74\x85\xcb\x43\xa0\xda\x12\x6f\x65\x20\xf1\x8d\x94\xb9\xcc\x31\x95\x81\x09\x29\x17\x5b\xef\x2e\x46\x56\xbf\x09\xb4\xc2\x44\x46\xa1\xfc\x17\xb4\xa9\xec\x59\x49\xb4\xf8\x91\x27\x59\x50\x9d\x34\x31\x03\x75\x3f\xf7\x2a\x9a\x3c\x65\xb2\xe5\x46\x9c\xab\x8b\xc9\xe8\x7d\x7d\xa0\x35\x2d\x28\x14\xb4\x26\xd3\x8f\xeb\x36\xd3\x80\x9e\x60\xf2\xa1\x1f\x9e\xfa\x63\x1e\x43\xc3\x68\x19\xa6\x5b\xd5\x1a\x8c\xbe\x4a\x74\xd8\x31\xd3\x5d\xd0\x2d\xcf\x74\x8b\xfb\x25\x20\xd9\xda\x9a\x50\x3d\x47\xb6\xf5\x12\x8a\xea\xb8\x35\xd2\x1f\x7d\xe6\x51\x91\xcb\x3f\x57\x7a\x6c\x99\xc4\x3c\x8a\xa1\x65\x5b\x5e\x07\x1a\x6f\x77\x1b\xda\xf5\xd7\x86\xd9\xfa\xe7\x53\x83\x67\x0a\x58\x82\xa0\xaa\x0e\xc6\xd1\x76\xd0\x1a\x3c\x05\xb7\xcd\xcc\xbf\xa6\x76\xdd\x70\x18\xb0\x13\x9d\x75\x5c\xc3\x32\x61\x4f\x99\x1d\xc2\x13\x50\x06\xe0\x07\x41\xc4\xe3\x98\x46\x83\xb0\xd7\xe3\x11\x8c\x86\xfd\xaf\x10\x0e\xe1\xd4\x8f\x79\x19\x48\x1b\xf5\x3f\x71\x18\xbf\xe7\x03\x78\xc7\x7b\xa3\x88\xc3\x20\x3c\x8b\xfc\x71\x38\x3c\xdb\x2b\x6f\x59\x3f\xa6\x61\x66\x93\x12\x55\x35\xfa\x6a\x85\x42\x55\xd3\xbc\x4e\xb3\xee\xb2\x7c\x45\x87\xb9\xb9\xed\x8b\xdd\x2d\xdf\xb4\x99\xcd\x72\xe9\x79\x6d\x47\x23\x86\x6e\x33\xc9\xf0\x4c\xe3\x95\xc7\xe8\xa6\x9b\xec\x44\xae\x6d\x5a\x2e\x2d\x66\x38\xae\x03\x61\xf0\xa5\xab\x2c\xba\x6a\xa8\xab\x00\xdd\xc9\x30\xfc\x38\xe1\x40\x5b\x2b\xad\xb8\x8d\x25\xee\x1f\xb4\x4f\x76\x6e\x40\x03\x00\x00")
+
+func _21_users_email_lowerUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__21_users_email_lowerUpSql,
+		"21_users_email_lower.up.sql",
+	)
+}
+
+func _21_users_email_lowerUpSql() (*asset, error) {
+	bytes, err := _21_users_email_lowerUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "21_users_email_lower.up.sql", size: 832, mode: os.FileMode(0644), modTime: time.Unix(1792396005, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x49, 0xe2, 0xe2, 0x80, 0xd, 0x9f, 0x41, 0x84, 0x3f, 0x87, 0x22, 0xb7, 0xbf, 0x48, 0xea, 0x7d, 0xb0, 0x9b, 0x12, 0xa7, 0x67, 0x20, 0x60, 0xf6, 0xf3, 0x13, 0xa7, 0x31, 0x94, 0xf9, 0xce, 0x3e}}
+	return a, nil
+}
+
+var __22_integrations_permissionDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x71\xf5\x71\x0d\x71\x55\x70\x0b\xf2\xf7\x55\x28\xca\xcf\x49\x8d\x2f\x48\x2d\xca\xcd\x2c\x2e\xce\xcc\xcf\x2b\x56\x08\xf7\x70\x0d\x72\x55\x40\x88\xd8\xaa\x67\xe6\x95\xa4\xa6\x17\x25\x96\x80\xa4\xad\x72\x13\xf3\x12\xd3\x53\xd5\xad\xb9\x5c\x90\x0c\xc1\xd4\x9f\x97\x98\x9b\x8a\x4b\x27\x00\x5a\xfe\x96\xe6\x7f\x00\x00\x00")
+
+func _22_integrations_permissionDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__22_integrations_permissionDownSql,
+		"22_integrations_permission.down.sql",
+	)
+}
+
+func _22_integrations_permissionDownSql() (*asset, error) {
+	bytes, err := _22_integrations_permissionDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "22_integrations_permission.down.sql", size: 127, mode: os.FileMode(0644), modTime: time.Unix(1792397180, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb9, 0x31, 0xa4, 0x4f, 0x6c, 0xd, 0xf3, 0x97, 0x4c, 0x70, 0x1, 0x17, 0xff, 0x14, 0x81, 0xce, 0xd, 0x34, 0x26, 0x35, 0x5d, 0x41, 0x52, 0x79, 0x92, 0xc4, 0xb6, 0xfe, 0x16, 0x3d, 0xe2, 0x1a}}
+	return a, nil
+}
+
+var __22_integrations_permissionUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\xcf\x41\x0a\xc2\x30\x10\x05\xd0\x7d\x4f\x31\xbb\xb6\xd0\x13\xe8\x4a\xb4\x6a\xa0\x24\xa0\xd5\xad\x04\x0d\x12\xb0\xa9\x24\xba\xb7\x8a\xe7\xf0\x0a\x55\x14\x44\x50\xaf\x30\xb9\x91\x2d\xba\x68\x45\x67\x93\xe1\xe7\x2f\xde\x10\x3a\x0c\x07\x31\x10\x1a\x33\x58\x0a\x9d\x48\x63\x64\xaa\x0c\x78\x8a\x27\x22\x80\x99\x30\x53\x2d\x97\xab\x22\xf3\x61\xdc\x8a\x46\xe1\xd0\x81\x62\x3c\x57\xaa\x95\x98\x6b\x5e\xfe\x98\x46\xc2\x15\x9f\x0b\x37\x00\x17\x0f\xf8\xc0\x33\x3e\xf1\x6a\x33\xbc\xe1\x15\xf0\x8e\x39\xe0\x09\x2f\x78\xb4\x7b\xbb\x7b\x67\x4f\xbb\xc1\x87\xcd\xec\xb6\x78\x73\xbc\x97\x5b\x51\xc9\x5d\xdf\x61\x14\xda\x8c\x76\x23\xd2\x8e\xdf\x06\x1f\x3a\x0c\x28\x8b\xfb\x84\xf6\x9a\x8e\x43\x2a\x5e\x9d\x2e\xc4\xa4\x86\x2e\x93\xa0\x72\xc7\x97\x79\x6d\x84\x2e\x91\xbf\xec\x7e\xf0\x29\xf1\x59\x22\xd5\xdf\x56\x0d\x58\x95\xbd\x00\x8f\x8e\x92\x5d\x49\x01\x00\x00")
+
+func _22_integrations_permissionUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__22_integrations_permissionUpSql,
+		"22_integrations_permission.up.sql",
+	)
+}
+
+func _22_integrations_permissionUpSql() (*asset, error) {
+	bytes, err := _22_integrations_permissionUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "22_integrations_permission.up.sql", size: 329, mode: os.FileMode(0644), modTime: time.Unix(1792397180, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x94, 0xdb, 0x0, 0x78, 0x4a, 0x13, 0x42, 0x31, 0xf, 0x28, 0xea, 0xf4, 0x2e, 0xf1, 0x79, 0x13, 0x94, 0x61, 0xb6, 0x9d, 0x49, 0x29, 0x76, 0x28, 0xc2, 0xfe, 0xe1, 0x97, 0xff, 0x49, 0x8f, 0xf3}}
+	return a, nil
+}
+
 var __2_add_phonenumberDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\xc8\xcf\x4b\x8d\xcf\x2b\xcd\x4d\x4a\x2d\xb2\x06\x04\x00\x00\xff\xff\xd9\x99\x83\xec\x35\x00\x00\x00")
 
 func _2_add_phonenumberDownSqlBytes() ([]byte, error) {
@@ -193,6 +751,246 @@ func _3_add_fullnameUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __4_workspacesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x49\x00\xb6\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x77\x6f\x72\x6b\x73\x70\x61\x63\x65\x5f\x6d\x65\x6d\x62\x65\x72\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x77\x6f\x72\x6b\x73\x70\x61\x63\x65\x73\x3b\x0a\x03\x00\x59\xdc\x0b\x66\x49\x00\x00\x00")
+
+func _4_workspacesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__4_workspacesDownSql,
+		"4_workspaces.down.sql",
+	)
+}
+
+func _4_workspacesDownSql() (*asset, error) {
+	bytes, err := _4_workspacesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "4_workspaces.down.sql", size: 73, mode: os.FileMode(0644), modTime: time.Unix(1792386744, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x0, 0x20, 0xfe, 0x7b, 0xb3, 0x5e, 0x58, 0x1e, 0xdf, 0x55, 0x6d, 0x4a, 0x56, 0xc7, 0x25, 0xe3, 0xb0, 0xf4, 0xdd, 0x89, 0xa4, 0x4c, 0x71, 0xd1, 0x2f, 0x31, 0x1b, 0xe2, 0x67, 0x20, 0x28, 0x31}}
+	return a, nil
+}
+
+var __4_workspacesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa5\x54\x4d\x6f\xda\x40\x10\xbd\xfb\x57\xcc\x0d\x5b\x72\xaa\xaa\x52\x2e\xcd\xc9\x35\x4b\x59\x05\xec\xc8\x5e\x9a\xa4\x17\xcb\xc5\x6e\x65\x95\x8f\xc8\x40\xe9\xb1\x49\x94\xf6\xd0\x4a\x9c\xfa\x3f\xa2\x04\x37\x34\x04\xf2\x17\x76\xff\x51\x67\xd7\x38\x40\x43\xa2\x56\x41\x42\xb6\x77\xde\xbc\x79\xf3\x76\x76\x6d\x8f\x58\x8c\x00\xb3\x5e\xd5\x08\xd0\x0a\x38\x2e\x03\x72\x40\x7d\xe6\xc3\xb0\x9b\x7e\xec\x1d\x85\xcd\xb8\x07\xba\x06\xf8\x4b\x22\x68\x34\x68\x19\xf6\x3c\x5a\xb7\xbc\x43\xd8\x25\x87\x50\x26\x15\xab\x51\x63\xf0\x21\xee\x04\x69\xd8\x89\xba\xed\x60\x30\x48\x22\xdd\x30\x55\x4a\x27\x6c\xc7\xf0\xc6\xf2\xec\xaa\xe5\xe9\x2f\xb6\xb7\x0d\x55\xc0\x69\xd4\x6a\x79\xbc\x3b\xec\xc4\x69\x50\x10\x17\x31\xf0\x48\x85\x78\xc4\xb1\x89\x0f\x83\x5e\x9c\xf6\xf4\x24\x32\xc0\x75\xb0\x5a\x8d\xa0\x5a\xdb\xf2\x6d\xab\x4c\x72\x8a\x66\x1a\x87\xfd\x38\x0a\xc2\x3e\x30\x5a\x27\x3e\xb3\xea\x7b\xb0\x4f\x59\x55\x7d\xc2\x5b\xd7\x21\x77\x2a\x1d\x77\xbf\x50\x36\x38\x8a\xfe\x2f\x4d\x33\x76\x34\xcd\xfe\x07\xbb\x82\x76\xdc\x7e\x87\xa2\x17\xae\x2d\xd7\x1f\x6b\x73\x69\xf6\x63\xbd\x4a\x33\x9e\xe6\x56\xda\x6d\xad\x6c\xc8\xf3\xe5\x7e\x80\x5d\x25\xf6\x2e\xe8\x0a\x40\x1d\xd0\x4b\x6a\x6f\x4a\x26\x94\xe2\x28\xe9\x77\xd5\xdb\xa7\x24\x1e\xe2\x9a\x61\x3c\xc9\xfa\xd5\xf9\xd1\x57\xed\x31\x8b\x06\xd7\xcc\xa6\x4e\x99\x1c\xfc\x65\x76\x12\x7d\x0e\xee\x19\x1e\x14\xf6\x60\xef\xf7\x82\x7a\x41\x8d\xc4\x5b\x5b\xc0\xa7\x7c\x22\xbe\xf1\x19\x9f\xf3\x0c\xf8\xad\xf8\xc2\xe7\xe2\x58\x9c\xe0\xf3\x9c\xcf\xe4\x1b\xbf\xe0\x73\xe0\x63\x3e\x15\x23\x10\xa7\xfc\x97\x84\x5d\xf1\x73\x04\x64\xfc\x12\x73\x73\xf0\x44\x26\x22\x14\x93\x30\xed\xbb\x38\x43\x2e\x5c\x98\x8a\x1f\x08\x56\x01\x64\xca\xb0\x58\xc6\x7f\x3f\x03\xfe\x13\x53\xe7\x40\xcb\x52\x81\x38\x56\x80\x5b\xcc\x1d\xe3\x3f\x13\x27\xb8\x84\xb1\x07\x18\xc4\xe8\xa5\x8a\x00\x56\xca\xf8\x8d\x38\x05\x7e\xc3\x27\xfc\x52\x2a\x16\x5f\x51\xc8\x08\x9a\x61\x1a\xf5\x24\x28\x53\x2a\x67\xb2\x25\x0c\x9c\x00\xbf\x58\xeb\x57\xf5\xff\x60\xcb\xd7\xb2\x49\xfc\x98\x23\xfc\x9a\x4f\xcc\x5c\xe8\x95\x12\xa9\x9a\x94\x4e\x8c\x25\x12\xd5\x88\x11\x26\x4d\x55\x31\xa9\x60\x33\xab\xa6\x46\x62\x31\x2c\x60\xf9\x8b\x83\x41\x1d\x9f\x78\x0c\x1f\xcc\x5d\xbb\x6b\xe4\x1c\xc8\x7b\xc3\xbc\xbb\x1d\x0c\x85\xf7\x71\x9c\x6d\x06\x32\x6c\xbb\x56\x8d\xf8\x36\xd1\xe5\xe4\xd2\x8a\xfe\x7e\xd0\x6a\x05\x79\x4e\xa9\x64\x98\x10\xb7\xc3\xa4\x85\x4f\x9c\x85\x8a\xe7\xd6\xf3\x63\xa1\x48\x3c\xc2\x1a\x9e\x43\x9d\xd7\x8a\xa7\x28\xa0\x19\xda\x46\x35\xcb\xa3\xbc\x71\x4c\x4d\x75\x9c\x0c\x6d\x45\x59\xc1\x88\x42\xf2\xf3\x93\x0b\x58\x34\xbf\xa3\xfd\x01\x35\x05\x41\x53\x70\x05\x00\x00")
+
+func _4_workspacesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__4_workspacesUpSql,
+		"4_workspaces.up.sql",
+	)
+}
+
+func _4_workspacesUpSql() (*asset, error) {
+	bytes, err := _4_workspacesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "4_workspaces.up.sql", size: 1392, mode: os.FileMode(0644), modTime: time.Unix(1792394032, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x64, 0x36, 0x4a, 0x6f, 0x61, 0x70, 0x53, 0xcf, 0xda, 0x95, 0x47, 0x99, 0xea, 0x59, 0xe, 0xa7, 0x80, 0x7e, 0xfd, 0x89, 0xf7, 0xea, 0xaa, 0xc5, 0xfc, 0x41, 0xb5, 0xe1, 0x2b, 0xf4, 0x81, 0xe}}
+	return a, nil
+}
+
+var __5_refresh_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x25\x00\xda\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x66\x72\x65\x73\x68\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x0a\x03\x00\x32\x0f\x3f\x2a\x25\x00\x00\x00")
+
+func _5_refresh_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__5_refresh_tokensDownSql,
+		"5_refresh_tokens.down.sql",
+	)
+}
+
+func _5_refresh_tokensDownSql() (*asset, error) {
+	bytes, err := _5_refresh_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "5_refresh_tokens.down.sql", size: 37, mode: os.FileMode(0644), modTime: time.Unix(1792387613, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x16, 0x1d, 0x69, 0xea, 0xbd, 0xd7, 0x47, 0x2, 0xc1, 0x8c, 0x58, 0xf5, 0x3f, 0x41, 0xa4, 0x17, 0xc0, 0xb2, 0x99, 0x71, 0xdd, 0xa7, 0x5f, 0xa9, 0xd9, 0x97, 0x7e, 0x9c, 0xcc, 0x17, 0x87, 0x6a}}
+	return a, nil
+}
+
+var __5_refresh_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xcd\x6e\xea\x30\x10\x85\xf7\x79\x8a\x59\x26\xd2\x5d\x5e\x75\xc3\xca\x4d\x06\x61\x35\x38\xd4\x71\x0a\x74\x63\xb9\x78\x28\x16\x3f\x41\x36\xb4\xf0\xf6\x55\x03\xa1\x34\x48\x48\x5d\x46\x67\xce\x17\xf9\x7c\xa9\x44\xa6\x10\x14\x7b\xcc\x11\x78\x1f\x44\xa1\x00\x27\xbc\x54\x25\x78\x9a\x7b\x0a\x0b\xbd\xab\x97\xb4\x09\x10\x47\x00\x00\xce\x42\x55\xf1\x0c\x46\x92\x0f\x99\x9c\xc2\x13\x4e\x21\xc3\x3e\xab\x72\x05\xef\xb4\xd1\xde\x6c\x6c\xbd\xd6\xfb\xbd\xb3\x71\xf2\xaf\xa9\xec\x03\x79\xdd\xf6\xbe\xf9\xa2\xca\x73\x90\xd8\x47\x89\x22\xc5\xb2\x39\x08\xb1\xb3\x09\x14\x02\x32\xcc\x51\x21\xa4\xac\x4c\x59\x86\x27\xc2\xdc\xac\xdd\xea\x78\xc3\x38\x85\x9f\xb5\x5f\x86\xad\x99\xd1\x25\xbf\x42\x5f\xc2\x2e\xbf\xc4\x6b\x46\xf3\x44\xbd\x30\x61\x01\x2f\x4c\xa6\x03\x26\xe3\x87\xff\x09\x54\x82\x3f\x57\xd8\xf9\x1f\x1d\xb6\xce\x53\xd0\x66\x07\x8a\x0f\xb1\x54\x6c\x38\x82\x31\x57\x83\xe6\x13\x5e\x0b\xd1\x6d\xcc\x3c\x99\x1d\xd9\xbb\x8d\x76\x43\x51\x8c\xdb\xdd\x3c\x7d\xd4\xcb\xfb\xb5\xf6\x70\xbb\x32\x33\xb2\xfa\xed\xd8\x8c\x1c\x25\xbd\x28\x3a\x8b\xe5\x22\xc3\x49\x47\xac\xb3\x07\xfd\x5b\xae\x6e\x1d\x15\xa2\xa3\x3d\x3e\x27\x49\xef\x8f\xc4\x1f\x67\xb7\xcc\x4b\x96\xf4\xa2\xaf\x01\x00\x3f\xbc\x48\x1e\x81\x02\x00\x00")
+
+func _5_refresh_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__5_refresh_tokensUpSql,
+		"5_refresh_tokens.up.sql",
+	)
+}
+
+func _5_refresh_tokensUpSql() (*asset, error) {
+	bytes, err := _5_refresh_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "5_refresh_tokens.up.sql", size: 641, mode: os.FileMode(0644), modTime: time.Unix(1792387613, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb5, 0x75, 0x93, 0xd0, 0xc2, 0xcf, 0x7b, 0x3a, 0x5b, 0x61, 0x26, 0x2d, 0xc2, 0xda, 0x6c, 0x90, 0x13, 0xc6, 0xce, 0x92, 0x1a, 0x9e, 0xca, 0xe0, 0xd4, 0xf9, 0x35, 0x9, 0xbb, 0xdb, 0x80, 0x7d}}
+	return a, nil
+}
+
+var __6_revoked_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x52\x00\xad\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x75\x73\x65\x72\x5f\x74\x6f\x6b\x65\x6e\x5f\x72\x65\x76\x6f\x63\x61\x74\x69\x6f\x6e\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x76\x6f\x6b\x65\x64\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x0a\x03\x00\x76\x00\x8a\xed\x52\x00\x00\x00")
+
+func _6_revoked_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__6_revoked_tokensDownSql,
+		"6_revoked_tokens.down.sql",
+	)
+}
+
+func _6_revoked_tokensDownSql() (*asset, error) {
+	bytes, err := _6_revoked_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "6_revoked_tokens.down.sql", size: 82, mode: os.FileMode(0644), modTime: time.Unix(1792387869, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc7, 0x76, 0xec, 0x83, 0x21, 0xe4, 0x4c, 0x1e, 0xca, 0xb6, 0x7f, 0x63, 0xc6, 0x2c, 0x69, 0xc7, 0x55, 0x1c, 0xe, 0x6c, 0x34, 0xef, 0x60, 0x92, 0xaa, 0x31, 0x68, 0xc5, 0x1f, 0x11, 0x1d, 0x36}}
+	return a, nil
+}
+
+var __6_revoked_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\x51\x4b\xf3\x30\x18\x85\xef\xf3\x2b\xce\x65\x0b\xdf\xe5\x87\x37\xbb\x8a\xed\x5b\x16\x4c\x93\x91\xa4\x6e\xf3\x26\x4c\x1b\x21\x0e\xac\xb4\x55\xf6\xf3\xc5\xd4\xcd\x6e\x88\xe2\x65\xc8\x7b\x1e\xce\x79\x0a\x43\xdc\x11\x1c\xbf\x96\x04\x51\x41\x69\x07\xda\x08\xeb\x2c\xfa\xf0\xd6\xed\x43\xeb\xc7\x6e\x1f\x9e\x07\x64\x0c\x00\x9e\xc6\x88\x5b\x6e\x8a\x25\x37\xd9\xd5\xff\x1c\x2b\x23\x6a\x6e\xb6\xb8\xa1\xed\xbf\x74\xf0\x3a\x84\xde\xc7\x16\x4d\x23\xca\x44\x53\x8d\x94\x30\x54\x91\x21\x55\x90\x4d\x07\x43\x16\xdb\x1c\x5a\xa1\x24\x49\x8e\x50\x70\x5b\xf0\x92\x26\x42\x38\xbc\xc4\x3e\x0c\x7e\x37\xc2\x89\x9a\xac\xe3\xf5\x0a\x6b\xe1\x96\xe9\x89\x3b\xad\xe8\x04\x9e\x12\xc7\xa6\x3f\x25\x4a\xaa\x78\x23\x1d\x94\x5e\x67\x39\xcb\x17\x8c\x7d\x4e\x17\xaa\xa4\xcd\xc5\xf4\xd8\x1e\xfc\xf9\x7c\x3f\x6b\xa5\xd5\x85\x9b\xec\xeb\x73\x06\xfe\xce\xe9\xc7\xf8\x29\x94\xf8\x0f\xbb\x31\x76\x27\xb7\x67\xea\x66\x62\xff\x64\xef\xd8\xec\x3e\x3c\x76\x7d\xf8\xdd\x20\xcb\x17\xec\x7d\x00\xe8\x10\x7b\xa4\x05\x02\x00\x00")
+
+func _6_revoked_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__6_revoked_tokensUpSql,
+		"6_revoked_tokens.up.sql",
+	)
+}
+
+func _6_revoked_tokensUpSql() (*asset, error) {
+	bytes, err := _6_revoked_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "6_revoked_tokens.up.sql", size: 517, mode: os.FileMode(0644), modTime: time.Unix(1792387869, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8c, 0x23, 0x48, 0x9c, 0x80, 0xa1, 0x6a, 0x6c, 0x42, 0x1c, 0x19, 0x7e, 0xe5, 0xb3, 0x11, 0x7c, 0x4a, 0x6d, 0x4, 0x7c, 0x5b, 0x50, 0xb0, 0xc7, 0x6c, 0x56, 0x6a, 0x86, 0xae, 0xb4, 0x80, 0x72}}
+	return a, nil
+}
+
+var __7_user_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5e\x00\xa1\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x75\x73\x65\x72\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x65\x6d\x61\x69\x6c\x5f\x76\x65\x72\x69\x66\x69\x65\x64\x5f\x61\x74\x3b\x0a\x03\x00\xd0\x96\x2b\x58\x5e\x00\x00\x00")
+
+func _7_user_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__7_user_tokensDownSql,
+		"7_user_tokens.down.sql",
+	)
+}
+
+func _7_user_tokensDownSql() (*asset, error) {
+	bytes, err := _7_user_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "7_user_tokens.down.sql", size: 94, mode: os.FileMode(0644), modTime: time.Unix(1792388231, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd7, 0x9f, 0x28, 0x3d, 0x66, 0x1, 0x56, 0x16, 0x1f, 0x5a, 0x46, 0xb1, 0xff, 0x4c, 0x87, 0x36, 0xcf, 0x34, 0x99, 0xb6, 0xd9, 0xc7, 0xae, 0x92, 0xd9, 0x2d, 0x83, 0x58, 0x20, 0x6c, 0x6d, 0x27}}
+	return a, nil
+}
+
+var __7_user_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x52\xcb\x6e\x9b\x50\x10\xdd\xf3\x15\xb3\x04\x89\x6c\xda\xaa\x9b\xa8\x8b\x5b\xb8\x96\x51\x31\x76\x79\xd4\x49\x36\x08\x15\xda\xa0\x36\xb6\x05\x76\x95\x65\xec\x2c\x1a\x29\x55\xf3\x09\xfd\x05\xd7\x31\x2d\x8d\x13\xe7\x17\xe6\xfe\x51\x87\x4b\x70\x52\x2b\x0d\x12\x30\x33\xcc\x39\x73\xee\x19\x98\xed\x73\x17\x7c\xf6\xda\xe6\x30\xc9\x93\x2c\x07\x66\x9a\x60\x74\xed\xa0\xe3\x80\xd5\x02\xa7\xeb\x03\xdf\xb3\x3c\xdf\x83\xe4\x28\x4a\x3f\x87\x5f\x92\x2c\xfd\x90\x26\x71\x18\x8d\xc1\xb7\x3a\xdc\xf3\x59\xa7\x07\x7d\xcb\x6f\xcb\x14\x0e\xba\x0e\xdf\x55\x76\x76\x00\x7f\xe0\x1a\x57\xe2\x1b\xfe\xa6\xf7\x02\xe7\x62\x86\x05\xae\xb0\xd4\x81\x2a\x73\x71\x42\xd9\x25\x96\x62\x2a\x66\x14\x97\x74\xcb\x2e\xbc\xc1\x1b\x71\x8e\x05\xe0\x12\xd7\x80\xb7\x54\x5d\x12\x72\x81\x05\x75\xfc\xa2\x62\x41\x1d\xa5\xb8\xa8\xd5\xe8\x40\xf8\xaf\x94\xcf\x88\xf1\x3b\x31\x4d\xe9\xcb\x16\x68\x89\x25\x2e\xc4\x19\x3d\xaf\xb1\x04\x39\x75\xad\x4b\x81\x25\x51\xcd\x09\x5e\x48\x88\x98\x92\x3a\x0a\xd7\xf8\x93\xea\x95\x98\xd5\x66\x18\xa5\x14\x40\x75\x04\x71\x22\x2e\xaa\x51\x52\xa0\x54\x7f\x8a\xb7\x80\x57\x40\xf4\x74\x2a\x71\x46\xf7\x69\xa5\x07\xaf\x49\x1c\x31\xdf\x9d\xac\x92\x72\x45\xd1\x9f\x5a\xb9\x12\xf4\x4c\xe6\x37\x9e\x7b\xdc\x7f\xc4\xde\x57\xb4\x07\x66\x73\xcf\xe0\xea\xfb\x2c\x89\xc6\xb2\xaa\xd3\x4a\xfa\xaa\xa6\x41\xbf\xcd\x5d\xfe\x08\xca\xf2\xc0\x09\x6c\x7b\x57\x51\x0c\x97\x57\x23\xea\xe5\xfe\xbb\xcb\x6a\x6c\x38\x1e\x7e\x4a\x06\x39\xa8\x0a\xd0\x95\xc6\x10\x04\x96\x09\x3d\xd7\xea\x30\x77\x1f\xde\xf0\x7d\x30\x79\x8b\x05\xb6\x0f\x1f\x93\x41\x98\x45\x83\x78\x78\x14\x4e\x26\x69\xac\x6a\xba\x84\x48\x92\x06\x57\x91\x57\x73\xc1\xe5\x2d\x12\xe6\x18\xbc\x9e\x92\xab\x69\xac\x41\xd7\x21\x32\x9b\x93\x1a\x83\x79\x06\x33\x79\xcd\x30\x9a\x64\xa3\x61\x9e\xc0\x3b\xe6\x1a\x6d\xe6\xaa\xcf\x9f\x69\x1b\xa2\xba\x43\x6a\x0c\x0f\xa3\xfc\x70\xd3\xf4\xf2\x85\x06\x81\x63\xbd\x0d\xf8\x56\x6f\x72\x3c\x4a\xb3\x24\x7f\xea\xcf\xdc\x42\xdc\xdb\xfa\x7f\x44\x63\x82\xb4\x7d\x73\xf0\x27\x31\x8a\x76\xef\xbe\xe5\x98\x7c\x6f\xcb\xfd\x34\x3e\x0e\x1f\x6c\x20\x6c\x8c\x24\x97\x1e\x94\xd5\xbb\xb2\xde\xd8\x44\xac\x7f\x01\x74\x18\xb0\xd8\xaf\x03\x00\x00")
+
+func _7_user_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__7_user_tokensUpSql,
+		"7_user_tokens.up.sql",
+	)
+}
+
+func _7_user_tokensUpSql() (*asset, error) {
+	bytes, err := _7_user_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "7_user_tokens.up.sql", size: 943, mode: os.FileMode(0644), modTime: time.Unix(1792394487, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6, 0x3d, 0x4c, 0x41, 0xba, 0xa0, 0xb8, 0x82, 0xe4, 0x77, 0xf3, 0xfb, 0x3, 0x78, 0x7f, 0xe5, 0xe5, 0x8b, 0x7b, 0x55, 0x5, 0xf2, 0x2a, 0xd6, 0xcb, 0x21, 0x8a, 0x39, 0x38, 0xc1, 0x29, 0xc7}}
+	return a, nil
+}
+
+var __8_otp_codesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x20\x00\xdf\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6f\x74\x70\x5f\x63\x6f\x64\x65\x73\x3b\x0a\x03\x00\x93\x5a\x34\x90\x20\x00\x00\x00")
+
+func _8_otp_codesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__8_otp_codesDownSql,
+		"8_otp_codes.down.sql",
+	)
+}
+
+func _8_otp_codesDownSql() (*asset, error) {
+	bytes, err := _8_otp_codesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "8_otp_codes.down.sql", size: 32, mode: os.FileMode(0644), modTime: time.Unix(1792388395, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xad, 0xe2, 0x48, 0x91, 0x3c, 0xbc, 0xea, 0x21, 0x99, 0xa, 0xac, 0xd8, 0x8f, 0xe7, 0x58, 0x2, 0x1c, 0x4f, 0x5e, 0xe3, 0x90, 0x4b, 0x82, 0xc3, 0xd2, 0x57, 0xef, 0x37, 0x5d, 0xd1, 0x9d, 0xac}}
+	return a, nil
+}
+
+var __8_otp_codesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\xbf\x6e\x83\x30\x1c\x84\x77\x9e\xe2\x46\x90\x32\x54\x6d\xd5\x25\x93\x0b\xbf\x28\x56\xc1\x44\x60\x9a\xa4\x8b\x45\xb1\x55\x18\xf8\x23\x30\x52\x1e\xbf\x2a\x24\x34\x62\xc8\x68\xf9\xce\xdf\xf9\xf3\x13\x62\x92\x20\xd9\x7b\x48\xe0\x3b\x88\x58\x82\x4e\x3c\x95\x29\x5a\xdb\xa9\xa2\xd5\x66\x80\xeb\x00\x40\xa5\x91\x65\x3c\xc0\x21\xe1\x11\x4b\xce\xf8\xa0\x33\x02\xda\xb1\x2c\x94\xf8\x31\x8d\xea\xf3\x46\xb7\xb5\x1a\xc7\x4a\xbb\xde\x66\xaa\x74\x65\xdb\x18\xd5\x8c\xf5\xb7\xe9\xf1\xc9\x12\x7f\xcf\x12\xf7\xe5\xd9\x9b\x30\x22\x0b\xc3\x39\xf6\x47\x51\x65\x3e\x94\x4b\xe6\xed\x75\x9d\xc9\xad\x35\x75\x67\x07\x70\x21\x97\xab\x85\xff\x34\x87\xcc\xa5\xab\x7a\x33\xa8\xdc\x42\xf2\x88\x52\xc9\xa2\x03\x8e\x5c\xee\xa7\x23\xbe\x62\x41\xab\x67\x8b\xde\xe4\xd6\xe8\x87\x8d\x1b\x44\xc4\xc7\xdb\xc7\x8a\xb6\x19\xc6\xfa\x71\xcf\xf1\xb6\x8e\x73\xf5\xcb\x45\x40\xa7\x95\xdf\x4a\x5f\xd4\xe2\x58\xcd\xaa\xae\x73\x10\x8b\x7f\xfd\xee\xbd\xc5\xcd\xfd\xe2\x80\x52\xdf\xdb\x3a\xbf\x03\x00\x4b\x3a\x3e\x0a\xc4\x01\x00\x00")
+
+func _8_otp_codesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__8_otp_codesUpSql,
+		"8_otp_codes.up.sql",
+	)
+}
+
+func _8_otp_codesUpSql() (*asset, error) {
+	bytes, err := _8_otp_codesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "8_otp_codes.up.sql", size: 452, mode: os.FileMode(0644), modTime: time.Unix(1792388395, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x50, 0xd0, 0x43, 0x82, 0x39, 0x8d, 0x11, 0x1b, 0x82, 0x50, 0xaa, 0x5d, 0xcc, 0x55, 0x6b, 0x6a, 0x46, 0xf8, 0x9a, 0x75, 0x43, 0xfd, 0x6e, 0x1b, 0xfd, 0x70, 0x19, 0xc6, 0x64, 0x14, 0xc6, 0x63}}
+	return a, nil
+}
+
+var __9_user_identitiesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x49\x00\xb6\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6f\x61\x75\x74\x68\x5f\x73\x74\x61\x74\x65\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x75\x73\x65\x72\x5f\x69\x64\x65\x6e\x74\x69\x74\x69\x65\x73\x3b\x0a\x03\x00\x0c\x2d\xc9\xc2\x49\x00\x00\x00")
+
+func _9_user_identitiesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__9_user_identitiesDownSql,
+		"9_user_identities.down.sql",
+	)
+}
+
+func _9_user_identitiesDownSql() (*asset, error) {
+	bytes, err := _9_user_identitiesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "9_user_identities.down.sql", size: 73, mode: os.FileMode(0644), modTime: time.Unix(1792388520, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8a, 0x66, 0x60, 0x36, 0xd9, 0xd2, 0x21, 0x4e, 0x27, 0x24, 0xd7, 0xe4, 0x6c, 0xb2, 0x6b, 0x50, 0x2e, 0xca, 0x3b, 0xfc, 0xbd, 0xe2, 0x8, 0xa8, 0x8d, 0x0, 0xf5, 0x69, 0x98, 0x28, 0x2f, 0xbb}}
+	return a, nil
+}
+
+var __9_user_identitiesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x52\x4d\x4f\xdb\x40\x10\xbd\xe7\x57\xcc\x0d\x5b\x0a\x07\x52\xa8\xaa\xf6\xb4\x75\x36\xc2\xaa\xe3\x50\xc7\xe6\xa3\x97\x95\x89\xb7\xcd\xb6\xc5\x46\xfe\xa0\x1c\x69\x2b\x04\x37\x7e\x05\x77\x68\x9b\x92\x03\xd0\xbf\x30\xfe\x47\x9d\xd8\xd8\x58\x2e\x42\x74\x4f\x33\xbb\x6f\xde\xbe\x79\x33\x86\xc3\x99\xcb\xc1\x65\xaf\x2d\x0e\xe6\x00\xec\x91\x0b\x7c\xdb\x1c\xbb\x63\xc8\x12\x19\x0b\x15\xc8\x30\x55\xa9\x92\x09\x68\x1d\xa0\xa3\x02\xf0\x3c\xb3\x0f\x1b\x8e\x39\x64\xce\x0e\xbc\xe1\x3b\xd0\xe7\x03\xe6\x59\x2e\x7c\x90\xa1\x88\xfd\x30\x88\xf6\x44\x96\xa9\x40\xd3\xbb\x45\xc9\x1d\x51\x59\xb7\xf8\xc0\xf6\x2c\x0b\x1c\x3e\xe0\x0e\xb7\x0d\x5e\xfe\x94\x68\x2a\xd0\x61\x64\x13\x99\xc5\x49\x91\xc1\xc6\x06\xeb\xf3\x92\x61\x3f\x8e\x0e\x48\x49\x0c\x9b\xcc\x31\xd6\x99\xa3\x3d\xeb\xe9\x35\x53\x09\x49\xb2\xdd\x8f\x72\x92\xd6\x88\xde\xda\x5a\x1b\x22\xf7\x7c\xf5\xf9\x61\x40\xdd\xc2\xd2\x52\x89\x9d\xc4\xd2\x4f\x65\x20\xfc\x14\x5c\x73\xc8\xc7\x2e\x1b\x6e\xc0\x96\xe9\xae\x17\x29\xbc\x1b\xd9\xbc\xae\xb1\x47\x5b\x55\xab\x9e\x6d\xbe\xf5\x38\x68\x95\xe0\x6e\xa5\x4b\xef\xe8\xaf\x3a\x1d\xa3\x74\xdb\xb4\xfb\x7c\xbb\xe5\xb6\x0a\x0e\x45\xcb\xf1\x2a\x5f\xb8\xd2\x7a\xd2\xee\xf2\x06\xe9\x43\x23\x8c\xfc\x2c\x9d\x8a\x24\xa5\x56\xaa\xf9\x15\x89\x98\xfa\xc9\xb4\x76\xe2\xf9\xaa\xde\x1c\xe7\x93\x2d\x9f\x44\x81\x14\x07\x32\x56\xef\x55\x03\xb7\xd2\x7b\xd1\x06\x86\x51\x38\x91\x8f\x01\x96\x97\x01\xcf\x71\x96\x9f\x12\x67\xf4\x49\x49\xc0\xcb\xfc\x08\x2f\xf2\xef\x78\x45\xb7\x14\x75\x01\x6f\x28\x3f\xc1\x0b\xfc\x91\x9f\xe2\x0c\x7f\xe2\x2d\x50\x78\x8c\xb7\xf8\xeb\x65\xd9\x14\xe0\x1c\xaf\x20\x3f\xa1\xaa\xdf\x74\x5d\x20\x5a\x34\x0b\x96\x19\xe0\x1f\x8a\xe7\x14\xce\xf1\x9a\x08\x67\xf9\xb7\xfc\x6b\x7e\x56\x08\xd9\x8d\xa3\x2f\x0b\x67\xff\xf1\xa7\xb5\x49\x87\xfb\x2a\xa6\x01\x3d\xb6\x1d\x2d\xaf\xfe\x77\x9f\x9e\xb2\x2f\xcd\xf1\x8a\x86\x26\xda\x97\xe6\x93\x76\xff\x44\xa4\x7f\x01\xe4\x18\x69\xdb\xf2\x03\x00\x00")
+
+func _9_user_identitiesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__9_user_identitiesUpSql,
+		"9_user_identities.up.sql",
+	)
+}
+
+func _9_user_identitiesUpSql() (*asset, error) {
+	bytes, err := _9_user_identitiesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "9_user_identities.up.sql", size: 1010, mode: os.FileMode(0644), modTime: time.Unix(1792394606, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd6, 0xf0, 0x7f, 0xfc, 0x1f, 0x3f, 0xa2, 0x72, 0xcd, 0x2e, 0xb5, 0x96, 0xe6, 0x68, 0x21, 0xc9, 0x8d, 0x31, 0xab, 0xe3, 0x6d, 0x40, 0x9f, 0x5d, 0x3f, 0xb7, 0x57, 0xdc, 0x76, 0x65, 0xa6, 0x5}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -284,12 +1082,50 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"1_user_migration.down.sql":  _1_user_migrationDownSql,
-	"1_user_migration.up.sql":    _1_user_migrationUpSql,
-	"2_add_phoneNumber.down.sql": _2_add_phonenumberDownSql,
-	"2_add_phoneNumber.up.sql":   _2_add_phonenumberUpSql,
-	"3_add_fullName.down.sql":    _3_add_fullnameDownSql,
-	"3_add_fullName.up.sql":      _3_add_fullnameUpSql,
+	"10_login_attempts.down.sql":          _10_login_attemptsDownSql,
+	"10_login_attempts.up.sql":            _10_login_attemptsUpSql,
+	"11_user_profile.down.sql":            _11_user_profileDownSql,
+	"11_user_profile.up.sql":              _11_user_profileUpSql,
+	"12_rbac.down.sql":                    _12_rbacDownSql,
+	"12_rbac.up.sql":                      _12_rbacUpSql,
+	"13_admin_users.down.sql":             _13_admin_usersDownSql,
+	"13_admin_users.up.sql":               _13_admin_usersUpSql,
+	"14_api_keys.down.sql":                _14_api_keysDownSql,
+	"14_api_keys.up.sql":                  _14_api_keysUpSql,
+	"15_two_factor.down.sql":              _15_two_factorDownSql,
+	"15_two_factor.up.sql":                _15_two_factorUpSql,
+	"16_security_events.down.sql":         _16_security_eventsDownSql,
+	"16_security_events.up.sql":           _16_security_eventsUpSql,
+	"17_sessions.down.sql":                _17_sessionsDownSql,
+	"17_sessions.up.sql":                  _17_sessionsUpSql,
+	"18_rate_limits.down.sql":             _18_rate_limitsDownSql,
+	"18_rate_limits.up.sql":               _18_rate_limitsUpSql,
+	"19_keywords_permission.down.sql":     _19_keywords_permissionDownSql,
+	"19_keywords_permission.up.sql":       _19_keywords_permissionUpSql,
+	"1_user_migration.down.sql":           _1_user_migrationDownSql,
+	"1_user_migration.up.sql":             _1_user_migrationUpSql,
+	"20_users_phone_unique.down.sql":      _20_users_phone_uniqueDownSql,
+	"20_users_phone_unique.up.sql":        _20_users_phone_uniqueUpSql,
+	"21_users_email_lower.down.sql":       _21_users_email_lowerDownSql,
+	"21_users_email_lower.up.sql":         _21_users_email_lowerUpSql,
+	"22_integrations_permission.down.sql": _22_integrations_permissionDownSql,
+	"22_integrations_permission.up.sql":   _22_integrations_permissionUpSql,
+	"2_add_phoneNumber.down.sql":          _2_add_phonenumberDownSql,
+	"2_add_phoneNumber.up.sql":            _2_add_phonenumberUpSql,
+	"3_add_fullName.down.sql":             _3_add_fullnameDownSql,
+	"3_add_fullName.up.sql":               _3_add_fullnameUpSql,
+	"4_workspaces.down.sql":               _4_workspacesDownSql,
+	"4_workspaces.up.sql":                 _4_workspacesUpSql,
+	"5_refresh_tokens.down.sql":           _5_refresh_tokensDownSql,
+	"5_refresh_tokens.up.sql":             _5_refresh_tokensUpSql,
+	"6_revoked_tokens.down.sql":           _6_revoked_tokensDownSql,
+	"6_revoked_tokens.up.sql":             _6_revoked_tokensUpSql,
+	"7_user_tokens.down.sql":              _7_user_tokensDownSql,
+	"7_user_tokens.up.sql":                _7_user_tokensUpSql,
+	"8_otp_codes.down.sql":                _8_otp_codesDownSql,
+	"8_otp_codes.up.sql":                  _8_otp_codesUpSql,
+	"9_user_identities.down.sql":          _9_user_identitiesDownSql,
+	"9_user_identities.up.sql":            _9_user_identitiesUpSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -338,12 +1174,50 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"1_user_migration.down.sql":  {_1_user_migrationDownSql, map[string]*bintree{}},
-	"1_user_migration.up.sql":    {_1_user_migrationUpSql, map[string]*bintree{}},
-	"2_add_phoneNumber.down.sql": {_2_add_phonenumberDownSql, map[string]*bintree{}},
-	"2_add_phoneNumber.up.sql":   {_2_add_phonenumberUpSql, map[string]*bintree{}},
-	"3_add_fullName.down.sql":    {_3_add_fullnameDownSql, map[string]*bintree{}},
-	"3_add_fullName.up.sql":      {_3_add_fullnameUpSql, map[string]*bintree{}},
+	"10_login_attempts.down.sql":          {_10_login_attemptsDownSql, map[string]*bintree{}},
+	"10_login_attempts.up.sql":            {_10_login_attemptsUpSql, map[string]*bintree{}},
+	"11_user_profile.down.sql":            {_11_user_profileDownSql, map[string]*bintree{}},
+	"11_user_profile.up.sql":              {_11_user_profileUpSql, map[string]*bintree{}},
+	"12_rbac.down.sql":                    {_12_rbacDownSql, map[string]*bintree{}},
+	"12_rbac.up.sql":                      {_12_rbacUpSql, map[string]*bintree{}},
+	"13_admin_users.down.sql":             {_13_admin_usersDownSql, map[string]*bintree{}},
+	"13_admin_users.up.sql":               {_13_admin_usersUpSql, map[string]*bintree{}},
+	"14_api_keys.down.sql":                {_14_api_keysDownSql, map[string]*bintree{}},
+	"14_api_keys.up.sql":                  {_14_api_keysUpSql, map[string]*bintree{}},
+	"15_two_factor.down.sql":              {_15_two_factorDownSql, map[string]*bintree{}},
+	"15_two_factor.up.sql":                {_15_two_factorUpSql, map[string]*bintree{}},
+	"16_security_events.down.sql":         {_16_security_eventsDownSql, map[string]*bintree{}},
+	"16_security_events.up.sql":           {_16_security_eventsUpSql, map[string]*bintree{}},
+	"17_sessions.down.sql":                {_17_sessionsDownSql, map[string]*bintree{}},
+	"17_sessions.up.sql":                  {_17_sessionsUpSql, map[string]*bintree{}},
+	"18_rate_limits.down.sql":             {_18_rate_limitsDownSql, map[string]*bintree{}},
+	"18_rate_limits.up.sql":               {_18_rate_limitsUpSql, map[string]*bintree{}},
+	"19_keywords_permission.down.sql":     {_19_keywords_permissionDownSql, map[string]*bintree{}},
+	"19_keywords_permission.up.sql":       {_19_keywords_permissionUpSql, map[string]*bintree{}},
+	"1_user_migration.down.sql":           {_1_user_migrationDownSql, map[string]*bintree{}},
+	"1_user_migration.up.sql":             {_1_user_migrationUpSql, map[string]*bintree{}},
+	"20_users_phone_unique.down.sql":      {_20_users_phone_uniqueDownSql, map[string]*bintree{}},
+	"20_users_phone_unique.up.sql":        {_20_users_phone_uniqueUpSql, map[string]*bintree{}},
+	"21_users_email_lower.down.sql":       {_21_users_email_lowerDownSql, map[string]*bintree{}},
+	"21_users_email_lower.up.sql":         {_21_users_email_lowerUpSql, map[string]*bintree{}},
+	"22_integrations_permission.down.sql": {_22_integrations_permissionDownSql, map[string]*bintree{}},
+	"22_integrations_permission.up.sql":   {_22_integrations_permissionUpSql, map[string]*bintree{}},
+	"2_add_phoneNumber.down.sql":          {_2_add_phonenumberDownSql, map[string]*bintree{}},
+	"2_add_phoneNumber.up.sql":            {_2_add_phonenumberUpSql, map[string]*bintree{}},
+	"3_add_fullName.down.sql":             {_3_add_fullnameDownSql, map[string]*bintree{}},
+	"3_add_fullName.up.sql":               {_3_add_fullnameUpSql, map[string]*bintree{}},
+	"4_workspaces.down.sql":               {_4_workspacesDownSql, map[string]*bintree{}},
+	"4_workspaces.up.sql":                 {_4_workspacesUpSql, map[string]*bintree{}},
+	"5_refresh_tokens.down.sql":           {_5_refresh_tokensDownSql, map[string]*bintree{}},
+	"5_refresh_tokens.up.sql":             {_5_refresh_tokensUpSql, map[string]*bintree{}},
+	"6_revoked_tokens.down.sql":           {_6_revoked_tokensDownSql, map[string]*bintree{}},
+	"6_revoked_tokens.up.sql":             {_6_revoked_tokensUpSql, map[string]*bintree{}},
+	"7_user_tokens.down.sql":              {_7_user_tokensDownSql, map[string]*bintree{}},
+	"7_user_tokens.up.sql":                {_7_user_tokensUpSql, map[string]*bintree{}},
+	"8_otp_codes.down.sql":                {_8_otp_codesDownSql, map[string]*bintree{}},
+	"8_otp_codes.up.sql":                  {_8_otp_codesUpSql, map[string]*bintree{}},
+	"9_user_identities.down.sql":          {_9_user_identitiesDownSql, map[string]*bintree{}},
+	"9_user_identities.up.sql":            {_9_user_identitiesUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
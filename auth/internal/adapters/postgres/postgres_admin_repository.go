@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type AdminRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewAdminRepository(conn *pgxpool.Pool) *AdminRepository {
+	return &AdminRepository{conn: conn}
+}
+
+func (r *AdminRepository) ListUsers(ctx context.Context, filter domain.UserFilter) (*domain.UserPage, error) {
+	pattern := ""
+	if filter.Query != "" {
+		pattern = "%" + likeEscaper.Replace(filter.Query) + "%"
+	}
+
+	page := &domain.UserPage{}
+	if err := r.conn.QueryRow(ctx, countUsers, pattern, filter.Role, filter.Blocked).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.conn.Query(ctx, listUsers, pattern, filter.Role, filter.Blocked, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &domain.User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.FullName,
+			&user.Email,
+			&user.PasswordHash,
+			&user.PhoneNumber,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.EmailVerifiedAt,
+			&user.BlockedAt,
+			&user.BlockedReason,
+		); err != nil {
+			return nil, err
+		}
+		page.Users = append(page.Users, user)
+	}
+	return page, rows.Err()
+}
+
+func (r *AdminRepository) SetUserBlocked(ctx context.Context, userID string, blockedAt *time.Time, reason string) error {
+	tag, err := r.conn.Exec(ctx, setUserBlocked, userID, blockedAt, reason)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *AdminRepository) RecordAction(ctx context.Context, record *domain.AdminActionRecord) error {
+	details := record.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	return r.conn.QueryRow(ctx, createAdminAction,
+		record.AdminID,
+		string(record.Action),
+		record.TargetUserID,
+		details,
+		record.CreatedAt,
+	).Scan(&record.ID)
+}
+
+func (r *AdminRepository) ListActions(ctx context.Context, filter domain.AdminActionFilter) ([]*domain.AdminActionRecord, int, error) {
+	var total int
+	err := r.conn.QueryRow(ctx, countAdminActions, filter.AdminID, filter.TargetUserID, string(filter.Action)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.conn.Query(ctx, listAdminActions,
+		filter.AdminID, filter.TargetUserID, string(filter.Action), filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var records []*domain.AdminActionRecord
+	for rows.Next() {
+		record := &domain.AdminActionRecord{}
+		if err := rows.Scan(
+			&record.ID,
+			&record.AdminID,
+			&record.Action,
+			&record.TargetUserID,
+			&record.Details,
+			&record.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		records = append(records, record)
+	}
+	return records, total, rows.Err()
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type APIKeyRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(conn *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{conn: conn}
+}
+
+// CreateAPIKey считает действующие ключи под блокировкой строки пользователя,
+// поэтому параллельные запросы не превысят лимит
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey, maxActive int) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var active int
+	err = tx.QueryRow(ctx, lockAndCountActiveAPIKeys, key.UserID, key.CreatedAt).Scan(&active)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if active >= maxActive {
+		return domain.ErrAPIKeyLimit
+	}
+
+	err = tx.QueryRow(ctx, createAPIKey,
+		key.UserID,
+		key.WorkspaceID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.ExpiresAt,
+		key.CreatedAt,
+	).Scan(&key.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	key, err := scanAPIKey(r.conn.QueryRow(ctx, getAPIKeyByHash, hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+	return key, err
+}
+
+func (r *APIKeyRepository) ListUserAPIKeys(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	rows, err := r.conn.Query(ctx, listUserAPIKeys, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepository) RevokeUserAPIKeys(ctx context.Context, userID string, at time.Time) error {
+	_, err := r.conn.Exec(ctx, revokeUserAPIKeys, userID, at)
+	return err
+}
+
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, userID, keyID string, at time.Time) error {
+	tag, err := r.conn.Exec(ctx, revokeAPIKey, userID, keyID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, keyID string, at time.Time) error {
+	_, err := r.conn.Exec(ctx, touchAPIKey, keyID, at)
+	return err
+}
+
+func scanAPIKey(row pgx.Row) (*domain.APIKey, error) {
+	key := &domain.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.WorkspaceID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.Scopes,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.CreatedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type IdentityRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewIdentityRepository(conn *pgxpool.Pool) *IdentityRepository {
+	return &IdentityRepository{conn: conn}
+}
+
+func (r *IdentityRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.Identity, error) {
+	identity := &domain.Identity{}
+	err := r.conn.QueryRow(ctx, getIdentity, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrIdentityNotFound
+		}
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (r *IdentityRepository) LinkIdentity(ctx context.Context, identity *domain.Identity) error {
+	return insertIdentity(ctx, r.conn, identity)
+}
+
+func (r *IdentityRepository) CreateUserWithIdentity(
+	ctx context.Context,
+	user *domain.User,
+	workspace *domain.Workspace,
+	identity *domain.Identity,
+) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertUserWithWorkspace(ctx, tx, user, workspace); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrOAuthAccountExists
+		}
+		return err
+	}
+
+	identity.UserID = user.ID
+	if err := insertIdentity(ctx, tx, identity); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func insertIdentity(ctx context.Context, q queryRower, identity *domain.Identity) error {
+	return q.QueryRow(ctx, createIdentity,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.CreatedAt,
+	).Scan(&identity.ID)
+}
+
+type OAuthStateRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewOAuthStateRepository(conn *pgxpool.Pool) *OAuthStateRepository {
+	return &OAuthStateRepository{conn: conn}
+}
+
+func (r *OAuthStateRepository) CreateOAuthState(ctx context.Context, state *domain.OAuthState) error {
+	_, err := r.conn.Exec(ctx, createOAuthState,
+		state.StateHash,
+		state.Provider,
+		state.CodeVerifier,
+		state.Nonce,
+		state.BrowserHash,
+		state.ExpiresAt,
+		state.CreatedAt,
+	)
+	return err
+}
+
+func (r *OAuthStateRepository) ConsumeOAuthState(ctx context.Context, stateHash string) (*domain.OAuthState, error) {
+	state := &domain.OAuthState{}
+	err := r.conn.QueryRow(ctx, consumeOAuthState, stateHash).Scan(
+		&state.StateHash,
+		&state.Provider,
+		&state.CodeVerifier,
+		&state.Nonce,
+		&state.BrowserHash,
+		&state.ExpiresAt,
+		&state.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOAuthStateInvalid
+		}
+		return nil, err
+	}
+	return state, nil
+}
+
+func (r *OAuthStateRepository) DeleteExpiredOAuthStates(ctx context.Context) (int64, error) {
+	tag, err := r.conn.Exec(ctx, deleteExpiredOAuthStates)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type LoginAttemptRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewLoginAttemptRepository(conn *pgxpool.Pool) *LoginAttemptRepository {
+	return &LoginAttemptRepository{conn: conn}
+}
+
+func (r *LoginAttemptRepository) GetLoginFailures(ctx context.Context, keys []string) ([]*domain.LoginFailure, error) {
+	return scanLoginFailures(r.conn.Query(ctx, getLoginFailures, keys))
+}
+
+func scanLoginFailures(rows pgx.Rows, err error) ([]*domain.LoginFailure, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var failures []*domain.LoginFailure
+	for rows.Next() {
+		failure := &domain.LoginFailure{}
+		if err := rows.Scan(
+			&failure.Key,
+			&failure.Failures,
+			&failure.LastFailureAt,
+			&failure.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		failures = append(failures, failure)
+	}
+	return failures, rows.Err()
+}
+
+func (r *LoginAttemptRepository) ReserveLoginAttempt(
+	ctx context.Context,
+	keys []string,
+	window time.Duration,
+	at time.Time,
+	check func(failures []*domain.LoginFailure) error,
+) ([]*domain.LoginFailure, error) {
+	// Ключи блокируются в одном порядке, чтобы параллельные входы не взаимоблокировались
+	keys = slices.Sorted(slices.Values(keys))
+
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, ensureLoginFailures, keys, at); err != nil {
+		return nil, err
+	}
+	locked, err := scanLoginFailures(tx.Query(ctx, lockLoginFailures, keys))
+	if err != nil {
+		return nil, err
+	}
+	if err := check(locked); err != nil {
+		return nil, err
+	}
+
+	failures := make([]*domain.LoginFailure, 0, len(keys))
+	for _, key := range keys {
+		failure := &domain.LoginFailure{}
+		if err := tx.QueryRow(ctx, registerLoginFailure, key, at, at.Add(-window)).Scan(
+			&failure.Key,
+			&failure.Failures,
+			&failure.LastFailureAt,
+			&failure.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		failures = append(failures, failure)
+	}
+
+	return failures, tx.Commit(ctx)
+}
+
+func (r *LoginAttemptRepository) RefundLoginAttempt(ctx context.Context, key string) error {
+	_, err := r.conn.Exec(ctx, refundLoginAttempt, key)
+	return err
+}
+
+func (r *LoginAttemptRepository) LockLogin(ctx context.Context, lockout *domain.LoginLockout) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, lockLogin, lockout.Key, lockout.LockedUntil); err != nil {
+		return err
+	}
+	if err := tx.QueryRow(ctx, createLoginLockout,
+		lockout.Key,
+		lockout.Failures,
+		lockout.IP,
+		lockout.LockedUntil,
+		lockout.CreatedAt,
+	).Scan(&lockout.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *LoginAttemptRepository) ResetLoginFailures(ctx context.Context, key string) error {
+	_, err := r.conn.Exec(ctx, resetLoginFailures, key)
+	return err
+}
+
+func (r *LoginAttemptRepository) UnlockLogin(ctx context.Context, key, unlockedBy string, at time.Time) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	reset, err := tx.Exec(ctx, resetLoginFailures, key)
+	if err != nil {
+		return err
+	}
+	unlocked, err := tx.Exec(ctx, unlockLoginLockouts, key, unlockedBy, at)
+	if err != nil {
+		return err
+	}
+	if reset.RowsAffected() == 0 && unlocked.RowsAffected() == 0 {
+		return domain.ErrLockoutNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *LoginAttemptRepository) GetActiveLockouts(ctx context.Context, at time.Time) ([]*domain.LoginLockout, error) {
+	rows, err := r.conn.Query(ctx, getActiveLockouts, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lockouts []*domain.LoginLockout
+	for rows.Next() {
+		lockout := &domain.LoginLockout{}
+		if err := rows.Scan(
+			&lockout.ID,
+			&lockout.Key,
+			&lockout.Failures,
+			&lockout.IP,
+			&lockout.LockedUntil,
+			&lockout.CreatedAt,
+			&lockout.UnlockedAt,
+			&lockout.UnlockedBy,
+		); err != nil {
+			return nil, err
+		}
+		lockouts = append(lockouts, lockout)
+	}
+	return lockouts, rows.Err()
+}
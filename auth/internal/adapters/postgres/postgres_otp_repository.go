@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type OTPRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewOTPRepository(conn *pgxpool.Pool) *OTPRepository {
+	return &OTPRepository{conn: conn}
+}
+
+// CreateOTP проверяет лимиты и сохраняет код в одной транзакции под
+// advisory блокировкой номера, поэтому параллельные запросы не обходят лимиты
+func (r *OTPRepository) CreateOTP(ctx context.Context, code *domain.OTPCode, resendInterval time.Duration, maxPerHour int) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, lockOTPPhone, code.PhoneNumber); err != nil {
+		return err
+	}
+
+	var latest *time.Time
+	if err := tx.QueryRow(ctx, latestOTPCreatedAt, code.PhoneNumber, code.Purpose).Scan(&latest); err != nil {
+		return err
+	}
+	if latest != nil && code.CreatedAt.Sub(*latest) < resendInterval {
+		return domain.ErrOTPRateLimited
+	}
+
+	// Лимит в час общий для всех назначений: это лимит SMS на номер
+	var issued int
+	if err := tx.QueryRow(ctx, countOTPsSince, code.PhoneNumber, code.CreatedAt.Add(-time.Hour)).Scan(&issued); err != nil {
+		return err
+	}
+	if issued >= maxPerHour {
+		return domain.ErrOTPRateLimited
+	}
+
+	err = tx.QueryRow(ctx, createOTP,
+		code.PhoneNumber,
+		code.Purpose,
+		code.CodeHash,
+		code.ExpiresAt,
+		code.CreatedAt,
+	).Scan(&code.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *OTPRepository) GetLatestOTP(ctx context.Context, purpose, phoneNumber string) (*domain.OTPCode, error) {
+	code := &domain.OTPCode{}
+	err := r.conn.QueryRow(ctx, getLatestOTP, phoneNumber, purpose).Scan(
+		&code.ID,
+		&code.PhoneNumber,
+		&code.Purpose,
+		&code.CodeHash,
+		&code.Attempts,
+		&code.ExpiresAt,
+		&code.CreatedAt,
+		&code.ConsumedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return code, nil
+}
+
+func (r *OTPRepository) ReserveOTPAttempt(ctx context.Context, id string, maxAttempts int) (int, error) {
+	var attempts int
+	err := r.conn.QueryRow(ctx, reserveOTPAttempt, id, maxAttempts).Scan(&attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrOTPAttemptsExceeded
+	}
+	return attempts, err
+}
+
+func (r *OTPRepository) ConsumeOTP(ctx context.Context, id string) error {
+	tag, err := r.conn.Exec(ctx, consumeOTP, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrOTPInvalid
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type RBACRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewRBACRepository(conn *pgxpool.Pool) *RBACRepository {
+	return &RBACRepository{conn: conn}
+}
+
+func (r *RBACRepository) ListPermissions(ctx context.Context) ([]*domain.Permission, error) {
+	rows, err := r.conn.Query(ctx, listPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []*domain.Permission
+	for rows.Next() {
+		permission := &domain.Permission{}
+		if err := rows.Scan(&permission.Name, &permission.Description); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, rows.Err()
+}
+
+func (r *RBACRepository) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	rows, err := r.conn.Query(ctx, listRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*domain.Role
+	for rows.Next() {
+		role := &domain.Role{}
+		if err := rows.Scan(
+			&role.Name,
+			&role.Description,
+			&role.Builtin,
+			&role.RequireTwoFactor,
+			&role.CreatedAt,
+			&role.Permissions,
+		); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (r *RBACRepository) SaveRole(ctx context.Context, role *domain.Role) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, upsertRole, role.Name, role.Description, role.RequireTwoFactor, time.Now()).Scan(
+		&role.Builtin,
+		&role.CreatedAt,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, deleteRolePermissions, role.Name); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, addRolePermissions, role.Name, role.Permissions); err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ErrUnknownPermission
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *RBACRepository) DeleteRole(ctx context.Context, name string) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var builtin bool
+	if err := tx.QueryRow(ctx, getRoleBuiltin, name).Scan(&builtin); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrRoleNotFound
+		}
+		return err
+	}
+	if builtin {
+		return domain.ErrBuiltinRole
+	}
+
+	var assigned int
+	if err := tx.QueryRow(ctx, countRoleAssignments, name).Scan(&assigned); err != nil {
+		return err
+	}
+	if assigned > 0 {
+		return domain.ErrRoleInUse
+	}
+
+	if _, err := tx.Exec(ctx, deleteRole, name); err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ErrRoleInUse
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *RBACRepository) GetUserAccess(ctx context.Context, userID string) (*domain.Access, error) {
+	roles, err := r.strings(ctx, getUserRoles, userID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := r.strings(ctx, getUserPermissions, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Access{Roles: roles, Permissions: permissions}, nil
+}
+
+func (r *RBACRepository) SetUserRoles(ctx context.Context, userID string, roles []string, assignedBy string) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	access := &domain.Access{Roles: roles}
+	if !access.HasRole(domain.RoleAdmin) {
+		if err := checkNotLastAdmin(ctx, tx, userID); err != nil {
+			return err
+		}
+	}
+
+	tag, err := tx.Exec(ctx, updateUserRole, userID, access.PrimaryRole(), now)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ErrRoleNotFound
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	if _, err := tx.Exec(ctx, removeUserRolesExcept, userID, roles); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, addUserRoles, userID, roles, assignedBy, now); err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ErrRoleNotFound
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// checkNotLastAdmin возвращает ErrLastAdmin, если userID - единственный администратор.
+// Назначения роли блокируются, поэтому два параллельных снятия не оставят систему без администраторов.
+func checkNotLastAdmin(ctx context.Context, tx pgx.Tx, userID string) error {
+	rows, err := tx.Query(ctx, lockRoleHolders, domain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var admins []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		admins = append(admins, id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(admins) == 1 && admins[0] == userID {
+		return domain.ErrLastAdmin
+	}
+	return nil
+}
+
+func (r *RBACRepository) strings(ctx context.Context, query, userID string) ([]string, error) {
+	rows, err := r.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type RefreshTokenRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewRefreshTokenRepository(conn *pgxpool.Pool) *RefreshTokenRepository {
+	return &RefreshTokenRepository{conn: conn}
+}
+
+func (r *RefreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	t := &domain.RefreshToken{}
+	err := r.conn.QueryRow(ctx, getRefreshTokenByHash, hash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.FamilyID,
+		&t.WorkspaceID,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.CreatedAt,
+		&t.RevokedAt,
+		&t.ReplacedBy,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRefreshTokenInvalid
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *RefreshTokenRepository) RotateRefreshToken(ctx context.Context, oldID string, next *domain.RefreshToken) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertRefreshToken(ctx, tx, next); err != nil {
+		return err
+	}
+
+	// Старый токен мог быть использован параллельным запросом
+	tag, err := tx.Exec(ctx, rotateRefreshToken, oldID, next.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+
+	if _, err := tx.Exec(ctx, touchSession, next.FamilyID, next.CreatedAt, next.ExpiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *RefreshTokenRepository) ReplaceSessionTokens(ctx context.Context, next *domain.RefreshToken) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, touchSession, next.FamilyID, next.CreatedAt, next.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := insertRefreshToken(ctx, tx, next); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, replaceSessionTokens, next.FamilyID, next.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *RefreshTokenRepository) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	_, err := r.conn.Exec(ctx, revokeTokenFamily, familyID)
+	return err
+}
+
+func (r *RefreshTokenRepository) RevokeUserTokens(ctx context.Context, userID string) error {
+	_, err := r.conn.Exec(ctx, revokeUserTokens, userID)
+	return err
+}
+
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func insertRefreshToken(ctx context.Context, q queryRower, token *domain.RefreshToken) error {
+	return q.QueryRow(ctx, createRefreshToken,
+		token.UserID,
+		token.FamilyID,
+		token.WorkspaceID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	).Scan(&token.ID)
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type RevocationRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewRevocationRepository(conn *pgxpool.Pool) *RevocationRepository {
+	return &RevocationRepository{conn: conn}
+}
+
+func (r *RevocationRepository) RevokeToken(ctx context.Context, token *domain.RevokedToken) error {
+	_, err := r.conn.Exec(ctx, revokeToken, token.JTI, token.UserID, token.ExpiresAt, token.RevokedAt)
+	return err
+}
+
+func (r *RevocationRepository) RevokeUserTokensBefore(ctx context.Context, userID string, before time.Time) error {
+	_, err := r.conn.Exec(ctx, revokeUserTokensBefore, userID, before)
+	return err
+}
+
+func (r *RevocationRepository) GetRevocations(ctx context.Context, since time.Time) (*domain.Revocations, error) {
+	revocations := &domain.Revocations{
+		Tokens:   make(map[string]time.Time),
+		Users:    make(map[string]time.Time),
+		Sessions: make(map[string]time.Time),
+	}
+
+	rows, err := r.conn.Query(ctx, getRevokedTokens)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var (
+			jti       string
+			expiresAt time.Time
+		)
+		if err := rows.Scan(&jti, &expiresAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		revocations.Tokens[jti] = expiresAt
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.conn.Query(ctx, getUserTokenRevocations, since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var (
+			userID string
+			before time.Time
+		)
+		if err := rows.Scan(&userID, &before); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		revocations.Users[userID] = before
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.conn.Query(ctx, getRevokedSessions, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			sessionID string
+			revokedAt time.Time
+		)
+		if err := rows.Scan(&sessionID, &revokedAt); err != nil {
+			return nil, err
+		}
+		revocations.Sessions[sessionID] = revokedAt
+	}
+
+	return revocations, rows.Err()
+}
+
+func (r *RevocationRepository) DeleteExpiredRevocations(ctx context.Context, now, since time.Time) error {
+	if _, err := r.conn.Exec(ctx, deleteExpiredRevokedTokens, now); err != nil {
+		return err
+	}
+	_, err := r.conn.Exec(ctx, deleteStaleUserTokenRevocations, since)
+	return err
+}
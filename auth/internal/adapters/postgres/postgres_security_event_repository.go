@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type SecurityEventRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewSecurityEventRepository(conn *pgxpool.Pool) *SecurityEventRepository {
+	return &SecurityEventRepository{conn: conn}
+}
+
+func (r *SecurityEventRepository) RecordSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error {
+	details := event.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	return r.conn.QueryRow(ctx, createSecurityEvent,
+		string(event.Type),
+		string(event.Outcome),
+		event.UserID,
+		event.ActorID,
+		event.Login,
+		event.IP,
+		event.UserAgent,
+		event.Reason,
+		details,
+		event.CreatedAt,
+	).Scan(&event.ID)
+}
+
+func (r *SecurityEventRepository) ListSecurityEvents(
+	ctx context.Context,
+	filter domain.SecurityEventFilter,
+) ([]*domain.SecurityEvent, int, error) {
+	args := []any{
+		filter.UserID,
+		string(filter.Type),
+		string(filter.Outcome),
+		filter.IP,
+		filter.From,
+		filter.To,
+	}
+
+	var total int
+	if err := r.conn.QueryRow(ctx, countSecurityEvents, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.conn.Query(ctx, listSecurityEvents, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var events []*domain.SecurityEvent
+	for rows.Next() {
+		event := &domain.SecurityEvent{}
+		if err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.Outcome,
+			&event.UserID,
+			&event.ActorID,
+			&event.Login,
+			&event.IP,
+			&event.UserAgent,
+			&event.Reason,
+			&event.Details,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+	return events, total, rows.Err()
+}
+
+// SecurityEventPurger работает через отдельное подключение под ролью с правом
+// DELETE на security_events (audit.retention_db_user)
+type SecurityEventPurger struct {
+	conn *pgxpool.Pool
+}
+
+func NewSecurityEventPurger(conn *pgxpool.Pool) *SecurityEventPurger {
+	return &SecurityEventPurger{conn: conn}
+}
+
+func (p *SecurityEventPurger) DeleteSecurityEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := p.conn.Exec(ctx, deleteSecurityEventsBefore, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type SessionRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewSessionRepository(conn *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{conn: conn}
+}
+
+func (r *SessionRepository) CreateSession(ctx context.Context, session *domain.Session, first *domain.RefreshToken) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, createSession,
+		session.ID,
+		session.UserID,
+		session.Device,
+		session.IP,
+		session.UserAgent,
+		session.CreatedAt,
+		session.ExpiresAt,
+	); err != nil {
+		return err
+	}
+	if err := insertRefreshToken(ctx, tx, first); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *SessionRepository) ListUserSessions(ctx context.Context, userID string, now time.Time) ([]*domain.Session, error) {
+	rows, err := r.conn.Query(ctx, listUserSessions, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		s := &domain.Session{}
+		if err := rows.Scan(
+			&s.ID,
+			&s.UserID,
+			&s.Device,
+			&s.IP,
+			&s.UserAgent,
+			&s.CreatedAt,
+			&s.LastSeenAt,
+			&s.ExpiresAt,
+			&s.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *SessionRepository) RevokeSession(ctx context.Context, userID, sessionID string, now time.Time) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, revokeSession, userID, sessionID, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+	if _, err := tx.Exec(ctx, revokeSessionTokens, sessionID, now); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *SessionRepository) DeleteEndedSessions(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.conn.Exec(ctx, deleteEndedSessions, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
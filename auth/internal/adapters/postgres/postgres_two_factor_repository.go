@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+	"marketai/pkg/encryption"
+)
+
+// TwoFactorRepository хранит секреты TOTP зашифрованными: с ними можно
+// получать коды второго фактора, поэтому утечка базы не должна их раскрывать
+type TwoFactorRepository struct {
+	conn *pgxpool.Pool
+	box  *encryption.Box
+}
+
+func NewTwoFactorRepository(conn *pgxpool.Pool, box *encryption.Box) *TwoFactorRepository {
+	return &TwoFactorRepository{conn: conn, box: box}
+}
+
+func (r *TwoFactorRepository) GetTOTP(ctx context.Context, userID string) (*domain.TOTPSecret, error) {
+	secret := &domain.TOTPSecret{}
+	err := r.conn.QueryRow(ctx, getTOTP, userID).Scan(
+		&secret.UserID,
+		&secret.Secret,
+		&secret.ConfirmedAt,
+		&secret.LastUsedStep,
+		&secret.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTwoFactorNotEnabled
+		}
+		return nil, err
+	}
+	if secret.Secret, err = r.box.Open(secret.Secret); err != nil {
+		return nil, fmt.Errorf("ошибка при расшифровке секрета TOTP: %w", err)
+	}
+	return secret, nil
+}
+
+func (r *TwoFactorRepository) SaveTOTP(ctx context.Context, secret *domain.TOTPSecret) error {
+	sealed, err := r.box.Seal(secret.Secret)
+	if err != nil {
+		return fmt.Errorf("ошибка при шифровании секрета TOTP: %w", err)
+	}
+
+	tag, err := r.conn.Exec(ctx, saveTOTP, secret.UserID, sealed, secret.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTwoFactorAlreadyEnabled
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) ConfirmTOTP(
+	ctx context.Context,
+	userID string,
+	step int64,
+	at time.Time,
+	recoveryCodeHashes []string,
+) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, confirmTOTP, userID, step, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTwoFactorAlreadyEnabled
+	}
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes, at); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TwoFactorRepository) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	tag, err := r.conn.Exec(ctx, useTOTPStep, userID, step)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTwoFactorCodeInvalid
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) DeleteTOTP(ctx context.Context, userID string) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, q := range []string{deleteTOTP, deleteRecoveryCodes} {
+		if _, err := tx.Exec(ctx, q, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string, at time.Time) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, hashes, at); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string, hashes []string, at time.Time) error {
+	if _, err := tx.Exec(ctx, deleteRecoveryCodes, userID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, addRecoveryCodes, userID, hashes, at)
+	return err
+}
+
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, hash string, at time.Time) error {
+	tag, err := r.conn.Exec(ctx, useRecoveryCode, userID, hash, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTwoFactorCodeInvalid
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.conn.QueryRow(ctx, countRecoveryCodes, userID).Scan(&count)
+	return count, err
+}
+
+func (r *TwoFactorRepository) TwoFactorRequired(ctx context.Context, userID string) (bool, error) {
+	var required bool
+	err := r.conn.QueryRow(ctx, twoFactorRequired, userID).Scan(&required)
+	return required, err
+}
+
+func (r *TwoFactorRepository) SetWorkspaceTwoFactor(ctx context.Context, workspaceID string, required bool) error {
+	tag, err := r.conn.Exec(ctx, setWorkspaceTwoFactor, workspaceID, required)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWorkspaceNotFound
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) CreateChallenge(ctx context.Context, challenge *domain.TwoFactorChallenge) error {
+	return r.conn.QueryRow(ctx, createTwoFactorChallenge,
+		challenge.UserID,
+		challenge.TokenHash,
+		challenge.WorkspaceID,
+		challenge.Enrollment,
+		challenge.Login,
+		challenge.ClientIP,
+		challenge.ExpiresAt,
+		challenge.CreatedAt,
+	).Scan(&challenge.ID)
+}
+
+func (r *TwoFactorRepository) GetChallengeByHash(ctx context.Context, hash string) (*domain.TwoFactorChallenge, error) {
+	c := &domain.TwoFactorChallenge{}
+	err := r.conn.QueryRow(ctx, getTwoFactorChallengeByHash, hash).Scan(
+		&c.ID,
+		&c.UserID,
+		&c.TokenHash,
+		&c.WorkspaceID,
+		&c.Enrollment,
+		&c.Attempts,
+		&c.Login,
+		&c.ClientIP,
+		&c.ExpiresAt,
+		&c.UsedAt,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTwoFactorChallengeInvalid
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *TwoFactorRepository) ReserveChallengeAttempt(ctx context.Context, id string, maxAttempts int) (int, error) {
+	var attempts int
+	err := r.conn.QueryRow(ctx, reserveChallengeAttempt, id, maxAttempts).Scan(&attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrTwoFactorAttemptsExceeded
+	}
+	return attempts, err
+}
+
+func (r *TwoFactorRepository) ConsumeChallenge(ctx context.Context, id string, at time.Time) error {
+	tag, err := r.conn.Exec(ctx, consumeTwoFactorChallenge, id, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTwoFactorChallengeInvalid
+	}
+	return nil
+}
+
+// SealSecrets шифрует секреты TOTP, сохраненные до включения шифрования
+func (r *TwoFactorRepository) SealSecrets(ctx context.Context) (int, error) {
+	rows, err := r.conn.Query(ctx, listPlainTOTPSecrets)
+	if err != nil {
+		return 0, err
+	}
+	plain := map[string]string{}
+	for rows.Next() {
+		var userID, secret string
+		if err := rows.Scan(&userID, &secret); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		plain[userID] = secret
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for userID, secret := range plain {
+		sealed, err := r.box.Seal(secret)
+		if err != nil {
+			return 0, fmt.Errorf("ошибка при шифровании секрета TOTP: %w", err)
+		}
+		if _, err := r.conn.Exec(ctx, sealTOTPSecret, userID, sealed, secret); err != nil {
+			return 0, err
+		}
+	}
+	return len(plain), nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -27,6 +28,9 @@ func (r *AuthRepository) GetUserByUsername(ctx context.Context, email string, ph
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.EmailVerifiedAt,
+		&user.BlockedAt,
+		&user.BlockedReason,
 	)
 
 	if err != nil {
@@ -39,7 +43,103 @@ func (r *AuthRepository) GetUserByUsername(ctx context.Context, email string, ph
 }
 
 func (r *AuthRepository) CreateUser(ctx context.Context, user *domain.User) error {
-	err := r.conn.QueryRow(ctx, createUser,
+	return insertUser(ctx, r.conn, user)
+}
+
+func (r *AuthRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user := &domain.User{}
+	err := r.conn.QueryRow(ctx, getByEmail, email).Scan(
+		&user.ID,
+		&user.FullName,
+		&user.Email,
+		&user.PasswordHash,
+		&user.PhoneNumber,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.EmailVerifiedAt,
+		&user.BlockedAt,
+		&user.BlockedReason,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *AuthRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	user := &domain.User{}
+	err := r.conn.QueryRow(ctx, getByID, id).Scan(
+		&user.ID,
+		&user.FullName,
+		&user.Email,
+		&user.PasswordHash,
+		&user.PhoneNumber,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.EmailVerifiedAt,
+		&user.BlockedAt,
+		&user.BlockedReason,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *AuthRepository) GetUserByPhone(ctx context.Context, phoneNumber string) (*domain.User, error) {
+	user := &domain.User{}
+	err := r.conn.QueryRow(ctx, getByPhone, phoneNumber).Scan(
+		&user.ID,
+		&user.FullName,
+		&user.Email,
+		&user.PasswordHash,
+		&user.PhoneNumber,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.EmailVerifiedAt,
+		&user.BlockedAt,
+		&user.BlockedReason,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *AuthRepository) CreateUserWithWorkspace(ctx context.Context, user *domain.User, workspace *domain.Workspace) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertUserWithWorkspace(ctx, tx, user, workspace); err != nil {
+		if taken := userUniqueViolation(err); taken != nil {
+			return taken
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func insertUser(ctx context.Context, q queryRower, user *domain.User) error {
+	return q.QueryRow(ctx, createUser,
 		user.FullName,
 		user.Email,
 		user.PasswordHash,
@@ -47,19 +147,96 @@ func (r *AuthRepository) CreateUser(ctx context.Context, user *domain.User) erro
 		user.Role,
 		user.CreatedAt,
 		user.UpdatedAt,
+		user.EmailVerifiedAt,
 	).Scan(&user.ID)
+}
+
+// insertUserWithWorkspace создает пользователя и его личное пространство в транзакции tx
+func insertUserWithWorkspace(ctx context.Context, tx pgx.Tx, user *domain.User, workspace *domain.Workspace) error {
+	if err := insertUser(ctx, tx, user); err != nil {
+		return err
+	}
+
+	workspace.OwnerID = user.ID
+	return insertWorkspace(ctx, tx, workspace)
+}
+
+func (r *AuthRepository) MarkEmailVerified(ctx context.Context, userID string, at time.Time) error {
+	_, err := r.conn.Exec(ctx, markEmailVerified, userID, at)
+	return err
+}
+
+func (r *AuthRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	tag, err := r.conn.Exec(ctx, updatePassword, userID, passwordHash, time.Now())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *AuthRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	tag, err := r.conn.Exec(ctx, updateProfile, user.ID, user.FullName, user.PhoneNumber, user.UpdatedAt)
+	if taken := userUniqueViolation(err); taken != nil {
+		return taken
+	}
 	if err != nil {
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
 	return nil
 }
 
-func (r *AuthRepository) GetDataByToken(ctx context.Context, token string) (*domain.GetData, error) {
-	// TODO: Реализовать валидацию токена и получение данных пользователя
-	// Пока возвращаем заглушку для тестирования
-	return &domain.GetData{
-		ID:    "test-user-id",
-		Email: "test@example.com",
-		Role:  "user",
-	}, nil
+func (r *AuthRepository) UpdateEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error {
+	tag, err := r.conn.Exec(ctx, updateEmail, userID, email, verifiedAt)
+	if err != nil {
+		if taken := userUniqueViolation(err); taken != nil {
+			return taken
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *AuthRepository) AnonymizeUser(ctx context.Context, userID string, at time.Time) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var soleOwned int
+	if err := tx.QueryRow(ctx, countSoleOwnedSharedWorkspaces, userID).Scan(&soleOwned); err != nil {
+		return err
+	}
+	if soleOwned > 0 {
+		return domain.ErrLastWorkspaceOwner
+	}
+
+	// События журнала очищаются до замены email, по которому находятся неудачные входы
+	for _, q := range []string{
+		anonymizeSecurityEvents, deleteOTPsOfUser, deleteUserMemberships, deleteUserIdentities, deleteUserTokens, deleteAllUserRoles, deleteUserAPIKeys,
+		deleteTOTP, deleteRecoveryCodes, deleteUserSessions,
+	} {
+		if _, err := tx.Exec(ctx, q, userID); err != nil {
+			return err
+		}
+	}
+
+	tag, err := tx.Exec(ctx, anonymizeUser, userID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return tx.Commit(ctx)
 }
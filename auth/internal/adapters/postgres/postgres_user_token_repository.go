@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type UserTokenRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewUserTokenRepository(conn *pgxpool.Pool) *UserTokenRepository {
+	return &UserTokenRepository{conn: conn}
+}
+
+func (r *UserTokenRepository) CreateUserToken(ctx context.Context, token *domain.UserToken) error {
+	return r.conn.QueryRow(ctx, createUserToken,
+		token.UserID,
+		token.Purpose,
+		token.TokenHash,
+		token.Payload,
+		token.ExpiresAt,
+		token.CreatedAt,
+	).Scan(&token.ID)
+}
+
+func (r *UserTokenRepository) GetUserToken(ctx context.Context, purpose domain.UserTokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	return scanUserToken(r.conn.QueryRow(ctx, getUserToken, purpose, tokenHash))
+}
+
+func (r *UserTokenRepository) ConsumeUserToken(ctx context.Context, purpose domain.UserTokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	return scanUserToken(r.conn.QueryRow(ctx, consumeUserToken, purpose, tokenHash))
+}
+
+func scanUserToken(row pgx.Row) (*domain.UserToken, error) {
+	t := &domain.UserToken{}
+	err := row.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Purpose,
+		&t.TokenHash,
+		&t.Payload,
+		&t.ExpiresAt,
+		&t.CreatedAt,
+		&t.UsedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserTokenInvalid
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *UserTokenRepository) InvalidateUserTokens(ctx context.Context, userID string, purpose domain.UserTokenPurpose) error {
+	_, err := r.conn.Exec(ctx, invalidateUserTokens, userID, purpose)
+	return err
+}
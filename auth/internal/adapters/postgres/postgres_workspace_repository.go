@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type WorkspaceRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewWorkspaceRepository(conn *pgxpool.Pool) *WorkspaceRepository {
+	return &WorkspaceRepository{conn: conn}
+}
+
+func (r *WorkspaceRepository) CreateWorkspace(ctx context.Context, workspace *domain.Workspace) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertWorkspace(ctx, tx, workspace); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertWorkspace создает пространство и добавляет владельца участником внутри переданной транзакции
+func insertWorkspace(ctx context.Context, tx pgx.Tx, workspace *domain.Workspace) error {
+	err := tx.QueryRow(ctx, createWorkspace,
+		workspace.Name,
+		workspace.OwnerID,
+		workspace.CreatedAt,
+		workspace.UpdatedAt,
+	).Scan(&workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, addWorkspaceMember,
+		workspace.ID,
+		workspace.OwnerID,
+		domain.WorkspaceRoleOwner,
+		workspace.CreatedAt,
+	)
+	return err
+}
+
+func (r *WorkspaceRepository) GetMembership(ctx context.Context, workspaceID, userID string) (*domain.Membership, error) {
+	m := &domain.Membership{Workspace: &domain.Workspace{}}
+	err := r.conn.QueryRow(ctx, getMembership, workspaceID, userID).Scan(
+		&m.Workspace.ID,
+		&m.Workspace.Name,
+		&m.Workspace.OwnerID,
+		&m.Workspace.CreatedAt,
+		&m.Workspace.UpdatedAt,
+		&m.Role,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotWorkspaceMember
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *WorkspaceRepository) GetUserWorkspaces(ctx context.Context, userID string) ([]*domain.Membership, error) {
+	rows, err := r.conn.Query(ctx, getUserWorkspaces, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var memberships []*domain.Membership
+	for rows.Next() {
+		m := &domain.Membership{Workspace: &domain.Workspace{}}
+		err := rows.Scan(
+			&m.Workspace.ID,
+			&m.Workspace.Name,
+			&m.Workspace.OwnerID,
+			&m.Workspace.CreatedAt,
+			&m.Workspace.UpdatedAt,
+			&m.Role,
+		)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, m)
+	}
+
+	return memberships, rows.Err()
+}
+
+func (r *WorkspaceRepository) GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]*domain.WorkspaceMember, error) {
+	rows, err := r.conn.Query(ctx, getWorkspaceMembers, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*domain.WorkspaceMember
+	for rows.Next() {
+		m := &domain.WorkspaceMember{}
+		err := rows.Scan(
+			&m.WorkspaceID,
+			&m.UserID,
+			&m.Email,
+			&m.FullName,
+			&m.Role,
+			&m.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *WorkspaceRepository) AddMember(ctx context.Context, member *domain.WorkspaceMember) error {
+	_, err := r.conn.Exec(ctx, addWorkspaceMember,
+		member.WorkspaceID,
+		member.UserID,
+		member.Role,
+		member.CreatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrMemberAlreadyExists
+	}
+	return err
+}
+
+func (r *WorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID, userID string, role domain.WorkspaceRole) error {
+	tag, err := r.conn.Exec(ctx, updateMemberRole, workspaceID, userID, role)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotWorkspaceMember
+	}
+	return nil
+}
+
+func (r *WorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	tag, err := r.conn.Exec(ctx, removeMember, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotWorkspaceMember
+	}
+	return nil
+}
+
+func (r *WorkspaceRepository) CountOwners(ctx context.Context, workspaceID string) (int, error) {
+	var count int
+	err := r.conn.QueryRow(ctx, countOwners, workspaceID).Scan(&count)
+	return count, err
+}
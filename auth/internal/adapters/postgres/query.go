@@ -3,14 +3,745 @@ package postgres
 const (
 	getByUserName = `
 		SELECT 
-			id, email, password_hash, role, created_at, updated_at
+			id, email, password_hash, role, created_at, updated_at, email_verified_at, blocked_at, blocked_reason
 		FROM users
-		WHERE email=$1 AND phone_number=$2
+		WHERE lower(email)=lower($1) AND phone_number=$2 AND deleted_at IS NULL
 	`
 
+	// Роль пользователя сразу записывается и в назначения ролей
 	createUser = `
-		INSERT INTO users
-			(id, full_name, email, password_hash, phone_number, role, created_at, updated_at)
-        VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7)
+		WITH created AS (
+			INSERT INTO users
+				(id, full_name, email, password_hash, phone_number, role, created_at, updated_at, email_verified_at)
+			VALUES (gen_random_uuid(), $1, lower($2), $3, $4, $5, $6, $7, $8)
+			RETURNING id, role, created_at
+		)
+		INSERT INTO user_roles (user_id, role, assigned_at)
+		SELECT id, role, created_at FROM created
+		RETURNING user_id`
+
+	getByEmail = `
+		SELECT
+			id, full_name, email, password_hash, phone_number, role, created_at, updated_at, email_verified_at,
+			blocked_at, blocked_reason
+		FROM users
+		WHERE lower(email)=lower($1) AND deleted_at IS NULL
+	`
+
+	createWorkspace = `
+		INSERT INTO workspaces
+			(id, name, owner_id, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4)
+		RETURNING id`
+
+	addWorkspaceMember = `
+		INSERT INTO workspace_members
+			(workspace_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	getMembership = `
+		SELECT
+			w.id, w.name, w.owner_id, w.created_at, w.updated_at, m.role
+		FROM workspace_members m
+		JOIN workspaces w ON w.id = m.workspace_id
+		WHERE m.workspace_id=$1 AND m.user_id=$2
+	`
+
+	getUserWorkspaces = `
+		SELECT
+			w.id, w.name, w.owner_id, w.created_at, w.updated_at, m.role
+		FROM workspace_members m
+		JOIN workspaces w ON w.id = m.workspace_id
+		WHERE m.user_id=$1
+		ORDER BY w.created_at
+	`
+
+	getWorkspaceMembers = `
+		SELECT
+			m.workspace_id, m.user_id, u.email, u.full_name, m.role, m.created_at
+		FROM workspace_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id=$1
+		ORDER BY m.created_at
+	`
+
+	updateMemberRole = `
+		UPDATE workspace_members
+		SET role=$3
+		WHERE workspace_id=$1 AND user_id=$2`
+
+	removeMember = `
+		DELETE FROM workspace_members
+		WHERE workspace_id=$1 AND user_id=$2`
+
+	countOwners = `
+		SELECT COUNT(*)
+		FROM workspace_members
+		WHERE workspace_id=$1 AND role='owner'`
+
+	getByID = `
+		SELECT
+			id, full_name, email, password_hash, phone_number, role, created_at, updated_at, email_verified_at,
+			blocked_at, blocked_reason
+		FROM users
+		WHERE id=$1 AND deleted_at IS NULL
+	`
+
+	createRefreshToken = `
+		INSERT INTO refresh_tokens
+			(id, user_id, family_id, workspace_id, token_hash, expires_at, created_at)
+		VALUES (gen_random_uuid(), $1, $2, NULLIF($3, '')::uuid, $4, $5, $6)
+		RETURNING id`
+
+	getRefreshTokenByHash = `
+		SELECT
+			id, user_id, family_id, COALESCE(workspace_id::text, ''), token_hash,
+			expires_at, created_at, revoked_at, replaced_by
+		FROM refresh_tokens
+		WHERE token_hash=$1
+	`
+
+	rotateRefreshToken = `
+		UPDATE refresh_tokens
+		SET revoked_at=NOW(), replaced_by=$2
+		WHERE id=$1 AND revoked_at IS NULL`
+
+	replaceSessionTokens = `
+		UPDATE refresh_tokens
+		SET revoked_at=NOW(), replaced_by=$2
+		WHERE family_id=$1 AND id<>$2 AND revoked_at IS NULL`
+
+	revokeTokenFamily = `
+		WITH session AS (
+			UPDATE sessions
+			SET revoked_at=NOW()
+			WHERE id=$1 AND revoked_at IS NULL
+		)
+		UPDATE refresh_tokens
+		SET revoked_at=NOW()
+		WHERE family_id=$1 AND revoked_at IS NULL`
+
+	revokeUserTokens = `
+		WITH session AS (
+			UPDATE sessions
+			SET revoked_at=NOW()
+			WHERE user_id=$1 AND revoked_at IS NULL
+		)
+		UPDATE refresh_tokens
+		SET revoked_at=NOW()
+		WHERE user_id=$1 AND revoked_at IS NULL`
+
+	createSession = `
+		INSERT INTO sessions
+			(id, user_id, device, ip, user_agent, created_at, last_seen_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, $7)`
+
+	// touchSession продлевает действующую сессию при выдаче нового refresh токена
+	touchSession = `
+		UPDATE sessions
+		SET last_seen_at=$2, expires_at=$3
+		WHERE id=$1 AND revoked_at IS NULL AND expires_at > $2`
+
+	listUserSessions = `
+		SELECT id, user_id, device, ip, user_agent, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE user_id=$1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_seen_at DESC`
+
+	revokeSession = `
+		UPDATE sessions
+		SET revoked_at=$3
+		WHERE id=$2 AND user_id=$1 AND revoked_at IS NULL AND expires_at > $3`
+
+	revokeSessionTokens = `
+		UPDATE refresh_tokens
+		SET revoked_at=$2
+		WHERE family_id=$1 AND revoked_at IS NULL`
+
+	getRevokedSessions = `
+		SELECT id, revoked_at
+		FROM sessions
+		WHERE revoked_at > $1`
+
+	deleteEndedSessions = `
+		DELETE FROM sessions
+		WHERE COALESCE(revoked_at, expires_at) < $1`
+
+	deleteUserSessions = `
+		DELETE FROM sessions
+		WHERE user_id=$1`
+
+	revokeToken = `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (jti) DO NOTHING`
+
+	revokeUserTokensBefore = `
+		INSERT INTO user_token_revocations (user_id, revoked_before)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET revoked_before=GREATEST(user_token_revocations.revoked_before, EXCLUDED.revoked_before)`
+
+	getRevokedTokens = `
+		SELECT jti, expires_at
+		FROM revoked_tokens
+		WHERE expires_at > NOW()`
+
+	getUserTokenRevocations = `
+		SELECT user_id, revoked_before
+		FROM user_token_revocations
+		WHERE revoked_before > $1`
+
+	deleteExpiredRevokedTokens = `
+		DELETE FROM revoked_tokens
+		WHERE expires_at < $1`
+
+	deleteStaleUserTokenRevocations = `
+		DELETE FROM user_token_revocations
+		WHERE revoked_before < $1`
+
+	markEmailVerified = `
+		UPDATE users
+		SET email_verified_at=$2, updated_at=$2
+		WHERE id=$1 AND email_verified_at IS NULL`
+
+	updatePassword = `
+		UPDATE users
+		SET password_hash=$2, updated_at=$3
+		WHERE id=$1`
+
+	createUserToken = `
+		INSERT INTO user_tokens
+			(id, user_id, purpose, token_hash, payload, expires_at, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	// Токен гасится атомарно, поэтому повторное или параллельное использование невозможно
+	getUserToken = `
+		SELECT id, user_id, purpose, token_hash, payload, expires_at, created_at, used_at
+		FROM user_tokens
+		WHERE purpose=$1 AND token_hash=$2 AND used_at IS NULL AND expires_at > NOW()`
+
+	consumeUserToken = `
+		UPDATE user_tokens
+		SET used_at=NOW()
+		WHERE purpose=$1 AND token_hash=$2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING id, user_id, purpose, token_hash, payload, expires_at, created_at, used_at`
+
+	invalidateUserTokens = `
+		UPDATE user_tokens
+		SET used_at=NOW()
+		WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL`
+
+	getByPhone = `
+		SELECT
+			id, full_name, email, password_hash, phone_number, role, created_at, updated_at, email_verified_at,
+			blocked_at, blocked_reason
+		FROM users
+		WHERE phone_number=$1 AND deleted_at IS NULL
+	`
+
+	createOTP = `
+		INSERT INTO otp_codes
+			(id, phone_number, purpose, code_hash, expires_at, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5)
+		RETURNING id`
+
+	// Блокировка номера до конца транзакции выпуска кода, ключ отделен от
+	// других advisory блокировок первым аргументом
+	lockOTPPhone = `
+		SELECT pg_advisory_xact_lock(hashtext('otp_codes'), hashtext($1))`
+
+	latestOTPCreatedAt = `
+		SELECT MAX(created_at)
+		FROM otp_codes
+		WHERE phone_number=$1 AND purpose=$2`
+
+	countOTPsSince = `
+		SELECT COUNT(*)
+		FROM otp_codes
+		WHERE phone_number=$1 AND created_at >= $2`
+
+	getLatestOTP = `
+		SELECT
+			id, phone_number, purpose, code_hash, attempts, expires_at, created_at, consumed_at
+		FROM otp_codes
+		WHERE phone_number=$1 AND purpose=$2
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	// Попытка резервируется до сравнения кода: параллельные запросы не могут
+	// проверить больше кодов, чем разрешено
+	reserveOTPAttempt = `
+		UPDATE otp_codes
+		SET attempts=attempts+1
+		WHERE id=$1 AND attempts < $2
+		RETURNING attempts`
+
+	consumeOTP = `
+		UPDATE otp_codes
+		SET consumed_at=NOW()
+		WHERE id=$1 AND consumed_at IS NULL`
+
+	getIdentity = `
+		SELECT
+			id, user_id, provider, subject, email, created_at
+		FROM user_identities
+		WHERE provider=$1 AND subject=$2
+	`
+
+	createIdentity = `
+		INSERT INTO user_identities
+			(id, user_id, provider, subject, email, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5)
+		RETURNING id`
+
+	createOAuthState = `
+		INSERT INTO oauth_states
+			(state_hash, provider, code_verifier, nonce, browser_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	// Состояние удаляется при чтении, поэтому один state нельзя использовать дважды
+	consumeOAuthState = `
+		DELETE FROM oauth_states
+		WHERE state_hash=$1 AND expires_at > NOW()
+		RETURNING state_hash, provider, code_verifier, nonce, browser_hash, expires_at, created_at`
+
+	deleteExpiredOAuthStates = `
+		DELETE FROM oauth_states
+		WHERE expires_at < NOW()`
+
+	getLoginFailures = `
+		SELECT key, failures, last_failure_at, locked_until
+		FROM login_failures
+		WHERE key = ANY($1)
+	`
+
+	// Пустые счетчики создаются заранее, чтобы первую попытку по ключу тоже
+	// можно было заблокировать FOR UPDATE
+	ensureLoginFailures = `
+		INSERT INTO login_failures (key, failures, last_failure_at)
+		SELECT unnest($1::text[]), 0, $2
+		ON CONFLICT (key) DO NOTHING`
+
+	lockLoginFailures = `
+		SELECT key, failures, last_failure_at, locked_until
+		FROM login_failures
+		WHERE key = ANY($1)
+		ORDER BY key
+		FOR UPDATE
+	`
+
+	registerLoginFailure = `
+		INSERT INTO login_failures (key, failures, last_failure_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE
+		SET failures=CASE WHEN login_failures.last_failure_at < $3 THEN 1 ELSE login_failures.failures + 1 END,
+			last_failure_at=EXCLUDED.last_failure_at
+		RETURNING key, failures, last_failure_at, locked_until`
+
+	lockLogin = `
+		UPDATE login_failures
+		SET locked_until=$2
+		WHERE key=$1`
+
+	createLoginLockout = `
+		INSERT INTO login_lockouts
+			(id, key, failures, ip, locked_until, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5)
+		RETURNING id`
+
+	resetLoginFailures = `
+		DELETE FROM login_failures
+		WHERE key=$1`
+
+	refundLoginAttempt = `
+		UPDATE login_failures
+		SET failures=GREATEST(failures - 1, 0)
+		WHERE key=$1`
+
+	unlockLoginLockouts = `
+		UPDATE login_lockouts
+		SET unlocked_at=$3, unlocked_by=NULLIF($2, '')::uuid
+		WHERE key=$1 AND unlocked_at IS NULL AND locked_until > $3`
+
+	getActiveLockouts = `
+		SELECT
+			id, key, failures, ip, locked_until, created_at, unlocked_at, unlocked_by::text
+		FROM login_lockouts
+		WHERE unlocked_at IS NULL AND locked_until > $1
+		ORDER BY created_at DESC
+	`
+
+	updateProfile = `
+		UPDATE users
+		SET full_name=$2, phone_number=$3, updated_at=$4
+		WHERE id=$1 AND deleted_at IS NULL`
+
+	updateEmail = `
+		UPDATE users
+		SET email=lower($2), email_verified_at=$3, updated_at=$3
+		WHERE id=$1 AND deleted_at IS NULL`
+
+	// Пространства, где пользователь единственный владелец, но есть и другие участники
+	countSoleOwnedSharedWorkspaces = `
+		SELECT COUNT(*)
+		FROM workspace_members m
+		WHERE m.user_id=$1 AND m.role='owner'
+			AND NOT EXISTS (
+				SELECT 1 FROM workspace_members o
+				WHERE o.workspace_id=m.workspace_id AND o.role='owner' AND o.user_id<>$1)
+			AND EXISTS (
+				SELECT 1 FROM workspace_members x
+				WHERE x.workspace_id=m.workspace_id AND x.user_id<>$1)`
+
+	deleteOTPsOfUser = `
+		DELETE FROM otp_codes
+		WHERE phone_number<>'' AND phone_number=(SELECT phone_number FROM users WHERE id=$1)`
+
+	deleteUserMemberships = `
+		DELETE FROM workspace_members
+		WHERE user_id=$1`
+
+	deleteUserIdentities = `
+		DELETE FROM user_identities
+		WHERE user_id=$1`
+
+	deleteUserTokens = `
+		DELETE FROM user_tokens
+		WHERE user_id=$1`
+
+	deleteUserAPIKeys = `
+		DELETE FROM api_keys
+		WHERE user_id=$1`
+
+	deleteAllUserRoles = `
+		DELETE FROM user_roles
+		WHERE user_id=$1`
+
+	// Пустой password_hash не совпадет ни с одним паролем, адрес заменяется
+	// уникальной заглушкой, чтобы освободить email для новой регистрации
+	anonymizeUser = `
+		UPDATE users
+		SET email='deleted-' || id || '@deleted.invalid', full_name='', phone_number='',
+			password_hash='', email_verified_at=NULL, deleted_at=$2, updated_at=$2
+		WHERE id=$1 AND deleted_at IS NULL`
+
+	listPermissions = `
+		SELECT name, description
+		FROM permissions
+		ORDER BY name
+	`
+
+	listRoles = `
+		SELECT
+			r.name, r.description, r.builtin, r.require_two_factor, r.created_at,
+			COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role = r.name
+		GROUP BY r.name
+		ORDER BY r.created_at, r.name
+	`
+
+	upsertRole = `
+		INSERT INTO roles (name, description, require_two_factor, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE
+		SET description=EXCLUDED.description, require_two_factor=EXCLUDED.require_two_factor
+		RETURNING builtin, created_at`
+
+	deleteRolePermissions = `
+		DELETE FROM role_permissions
+		WHERE role=$1`
+
+	addRolePermissions = `
+		INSERT INTO role_permissions (role, permission)
+		SELECT $1, unnest($2::text[])`
+
+	getRoleBuiltin = `
+		SELECT builtin
+		FROM roles
+		WHERE name=$1`
+
+	countRoleAssignments = `
+		SELECT COUNT(*)
+		FROM user_roles
+		WHERE role=$1`
+
+	deleteRole = `
+		DELETE FROM roles
+		WHERE name=$1`
+
+	getUserRoles = `
+		SELECT role
+		FROM user_roles
+		WHERE user_id=$1
+		ORDER BY role
+	`
+
+	getUserPermissions = `
+		SELECT DISTINCT rp.permission
+		FROM user_roles ur
+		JOIN role_permissions rp ON rp.role = ur.role
+		WHERE ur.user_id=$1
+		ORDER BY rp.permission
+	`
+
+	updateUserRole = `
+		UPDATE users
+		SET role=$2, updated_at=$3
+		WHERE id=$1 AND deleted_at IS NULL`
+
+	removeUserRolesExcept = `
+		DELETE FROM user_roles
+		WHERE user_id=$1 AND role <> ALL($2::text[])`
+
+	// Уже назначенные роли сохраняют исходные assigned_by и assigned_at
+	addUserRoles = `
+		INSERT INTO user_roles (user_id, role, assigned_by, assigned_at)
+		SELECT $1, unnest($2::text[]), NULLIF($3, '')::uuid, $4
+		ON CONFLICT (user_id, role) DO NOTHING`
+
+	// Блокирует назначения роли до конца транзакции: параллельное снятие роли
+	// дождется коммита и увидит уже уменьшенный список
+	lockRoleHolders = `
+		SELECT ur.user_id
+		FROM user_roles ur
+		JOIN users u ON u.id = ur.user_id
+		WHERE ur.role=$1 AND u.deleted_at IS NULL
+		ORDER BY ur.user_id
+		FOR UPDATE OF ur`
+
+	// Условия поиска пользователей: $1 - шаблон ILIKE (пустой - без поиска),
+	// $2 - роль, $3 - признак блокировки (NULL - все)
+	userFilterWhere = `
+		WHERE u.deleted_at IS NULL
+			AND ($1::text = '' OR u.email ILIKE $1 OR u.full_name ILIKE $1 OR u.phone_number ILIKE $1)
+			AND ($2::text = '' OR EXISTS (
+				SELECT 1 FROM user_roles ur WHERE ur.user_id = u.id AND ur.role = $2
+			))
+			AND ($3::boolean IS NULL OR (u.blocked_at IS NOT NULL) = $3)`
+
+	listUsers = `
+		SELECT
+			u.id, u.full_name, u.email, u.password_hash, u.phone_number, u.role, u.created_at, u.updated_at,
+			u.email_verified_at, u.blocked_at, u.blocked_reason
+		FROM users u` + userFilterWhere + `
+		ORDER BY u.created_at DESC, u.id
+		LIMIT $4 OFFSET $5`
+
+	countUsers = `
+		SELECT COUNT(*)
+		FROM users u` + userFilterWhere
+
+	setUserBlocked = `
+		UPDATE users
+		SET blocked_at=$2, blocked_reason=$3, updated_at=NOW()
+		WHERE id=$1 AND deleted_at IS NULL`
+
+	createAdminAction = `
+		INSERT INTO admin_actions
+			(id, admin_id, action, target_user_id, details, created_at)
+		VALUES (gen_random_uuid(), NULLIF($1, '')::uuid, $2, NULLIF($3, '')::uuid, $4, $5)
 		RETURNING id`
+
+	adminActionFilterWhere = `
+		WHERE ($1::text = '' OR admin_id::text = $1)
+			AND ($2::text = '' OR target_user_id::text = $2)
+			AND ($3::text = '' OR action = $3)`
+
+	listAdminActions = `
+		SELECT
+			id, COALESCE(admin_id::text, ''), action, COALESCE(target_user_id::text, ''), details, created_at
+		FROM admin_actions` + adminActionFilterWhere + `
+		ORDER BY created_at DESC, id
+		LIMIT $4 OFFSET $5`
+
+	countAdminActions = `
+		SELECT COUNT(*)
+		FROM admin_actions` + adminActionFilterWhere
+
+	createAPIKey = `
+		INSERT INTO api_keys
+			(id, user_id, workspace_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES (gen_random_uuid(), $1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	apiKeyColumns = `
+		id, user_id, COALESCE(workspace_id::text, ''), name, prefix, key_hash, scopes,
+		expires_at, last_used_at, created_at, revoked_at`
+
+	getAPIKeyByHash = `
+		SELECT` + apiKeyColumns + `
+		FROM api_keys
+		WHERE key_hash=$1`
+
+	listUserAPIKeys = `
+		SELECT` + apiKeyColumns + `
+		FROM api_keys
+		WHERE user_id=$1
+		ORDER BY created_at DESC`
+
+	// Строка пользователя блокируется до конца транзакции создания ключа
+	lockAndCountActiveAPIKeys = `
+		SELECT (
+			SELECT COUNT(*)
+			FROM api_keys
+			WHERE user_id=u.id AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > $2)
+		)
+		FROM users u
+		WHERE u.id=$1
+		FOR UPDATE OF u`
+
+	revokeAPIKey = `
+		UPDATE api_keys
+		SET revoked_at=$3
+		WHERE id=$2 AND user_id=$1 AND revoked_at IS NULL`
+
+	revokeUserAPIKeys = `
+		UPDATE api_keys
+		SET revoked_at=$2
+		WHERE user_id=$1 AND revoked_at IS NULL`
+
+	touchAPIKey = `
+		UPDATE api_keys
+		SET last_used_at=$2
+		WHERE id=$1 AND (last_used_at IS NULL OR last_used_at < $2 - INTERVAL '1 minute')`
+
+	getTOTP = `
+		SELECT user_id, secret, confirmed_at, last_used_step, created_at
+		FROM user_totp
+		WHERE user_id=$1`
+
+	// Подтвержденный секрет не заменяется: сначала второй фактор нужно отключить
+	saveTOTP = `
+		INSERT INTO user_totp (user_id, secret, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret=EXCLUDED.secret, created_at=EXCLUDED.created_at, last_used_step=0
+		WHERE user_totp.confirmed_at IS NULL`
+
+	confirmTOTP = `
+		UPDATE user_totp
+		SET confirmed_at=$3, last_used_step=$2
+		WHERE user_id=$1 AND confirmed_at IS NULL`
+
+	useTOTPStep = `
+		UPDATE user_totp
+		SET last_used_step=$2
+		WHERE user_id=$1 AND last_used_step < $2`
+
+	deleteTOTP = `
+		DELETE FROM user_totp
+		WHERE user_id=$1`
+
+	deleteRecoveryCodes = `
+		DELETE FROM recovery_codes
+		WHERE user_id=$1`
+
+	addRecoveryCodes = `
+		INSERT INTO recovery_codes (user_id, code_hash, created_at)
+		SELECT $1, unnest($2::text[]), $3`
+
+	useRecoveryCode = `
+		UPDATE recovery_codes
+		SET used_at=$3
+		WHERE id = (
+			SELECT id FROM recovery_codes
+			WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL
+			LIMIT 1
+		)`
+
+	countRecoveryCodes = `
+		SELECT COUNT(*)
+		FROM recovery_codes
+		WHERE user_id=$1 AND used_at IS NULL`
+
+	twoFactorRequired = `
+		SELECT EXISTS (
+			SELECT 1 FROM user_roles ur
+			JOIN roles r ON r.name = ur.role
+			WHERE ur.user_id=$1 AND r.require_two_factor
+		) OR EXISTS (
+			SELECT 1 FROM workspace_members m
+			JOIN workspaces w ON w.id = m.workspace_id
+			WHERE m.user_id=$1 AND w.require_two_factor
+		)`
+
+	setWorkspaceTwoFactor = `
+		UPDATE workspaces
+		SET require_two_factor=$2, updated_at=NOW()
+		WHERE id=$1`
+
+	createTwoFactorChallenge = `
+		INSERT INTO two_factor_challenges
+			(id, user_id, token_hash, workspace_id, enrollment, login, client_ip, expires_at, created_at)
+		VALUES (gen_random_uuid(), $1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	getTwoFactorChallengeByHash = `
+		SELECT
+			id, user_id, token_hash, COALESCE(workspace_id::text, ''), enrollment, attempts,
+			login, client_ip, expires_at, used_at, created_at
+		FROM two_factor_challenges
+		WHERE token_hash=$1`
+
+	// Попытка засчитывается, только пока лимит не исчерпан: параллельные
+	// запросы не проверят больше кодов, чем разрешено
+	reserveChallengeAttempt = `
+		UPDATE two_factor_challenges
+		SET attempts = attempts + 1
+		WHERE id=$1 AND attempts < $2 AND used_at IS NULL
+		RETURNING attempts`
+
+	listPlainTOTPSecrets = `
+		SELECT user_id, secret
+		FROM user_totp
+		WHERE secret NOT LIKE 'enc:%'`
+
+	// Условие на старое значение защищает от гонки с другим экземпляром
+	sealTOTPSecret = `
+		UPDATE user_totp
+		SET secret=$2
+		WHERE user_id=$1 AND secret=$3`
+
+	consumeTwoFactorChallenge = `
+		UPDATE two_factor_challenges
+		SET used_at=$2
+		WHERE id=$1 AND used_at IS NULL`
+
+	createSecurityEvent = `
+		INSERT INTO security_events
+			(id, event_type, outcome, user_id, actor_id, login, ip, user_agent, reason, details, created_at)
+		VALUES (gen_random_uuid(), $1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	securityEventFilterWhere = `
+		WHERE ($1::text = '' OR user_id::text = $1)
+			AND ($2::text = '' OR event_type = $2)
+			AND ($3::text = '' OR outcome = $3)
+			AND ($4::text = '' OR ip = $4)
+			AND ($5::timestamptz IS NULL OR created_at >= $5)
+			AND ($6::timestamptz IS NULL OR created_at < $6)`
+
+	listSecurityEvents = `
+		SELECT
+			id, event_type, outcome, COALESCE(user_id::text, ''), COALESCE(actor_id::text, ''),
+			login, ip, user_agent, reason, details, created_at
+		FROM security_events` + securityEventFilterWhere + `
+		ORDER BY created_at DESC, id
+		LIMIT $7 OFFSET $8`
+
+	countSecurityEvents = `
+		SELECT COUNT(*)
+		FROM security_events` + securityEventFilterWhere
+
+	// Функция выполняется с правами владельца журнала и только стирает персональные данные
+	anonymizeSecurityEvents = `
+		SELECT anonymize_security_events($1)`
+
+	deleteSecurityEventsBefore = `
+		DELETE FROM security_events
+		WHERE created_at < $1`
 )
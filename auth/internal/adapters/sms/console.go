@@ -0,0 +1,23 @@
+package sms
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	domain "marketai/auth/internal/domain"
+)
+
+// ConsoleSender - заглушка отправки SMS для локальной разработки, сообщение пишется в лог
+type ConsoleSender struct {
+	logger *zap.Logger
+}
+
+func NewConsoleSender(logger *zap.Logger) domain.SMSSender {
+	return &ConsoleSender{logger: logger}
+}
+
+func (s *ConsoleSender) Send(_ context.Context, phoneNumber, text string) error {
+	s.logger.Info("sms", zap.String("to", phoneNumber), zap.String("text", text))
+	return nil
+}
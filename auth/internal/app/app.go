@@ -1,19 +1,88 @@
 package app
 
 import (
+	"go.uber.org/zap"
+
 	"marketai/auth/internal/adapters/postgres"
+	"marketai/auth/internal/app/audit"
 	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/lockout"
+	"marketai/auth/internal/app/passwords"
 	"marketai/auth/internal/app/query"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/app/twofactor"
 	"marketai/auth/internal/config"
+	"marketai/auth/internal/domain"
+	"marketai/pkgAuth/jwt"
 )
 
 type Commands struct {
-	Register command.RegisterCommandHandler
+	Register           command.RegisterCommandHandler
+	CreateWorkspace    command.CreateWorkspaceHandler
+	AddMember          command.AddMemberHandler
+	UpdateMemberRole   command.UpdateMemberRoleHandler
+	RemoveMember       command.RemoveMemberHandler
+	RefreshToken       command.RefreshTokenHandler
+	Logout             command.LogoutHandler
+	LogoutAll          command.LogoutAllHandler
+	SendVerification   command.SendVerificationHandler
+	VerifyEmail        command.VerifyEmailHandler
+	ForgotPassword     command.ForgotPasswordHandler
+	ResetPassword      command.ResetPasswordHandler
+	RequestOTP         command.RequestOTPHandler
+	VerifyOTP          command.VerifyOTPHandler
+	StartOAuth         command.StartOAuthHandler
+	OAuthCallback      command.OAuthCallbackHandler
+	PurgeOAuthStates   command.PurgeOAuthStatesHandler
+	UnlockLogin        command.UnlockLoginHandler
+	UpdateProfile      command.UpdateProfileHandler
+	ConfirmPhone       command.ConfirmPhoneHandler
+	ChangePassword     command.ChangePasswordHandler
+	RequestEmailChange command.RequestEmailChangeHandler
+	ConfirmEmailChange command.ConfirmEmailChangeHandler
+	DeleteAccount      command.DeleteAccountHandler
+	SaveRole           command.SaveRoleHandler
+	DeleteRole         command.DeleteRoleHandler
+	SetUserRoles       command.SetUserRolesHandler
+	BlockUser          command.BlockUserHandler
+	UnblockUser        command.UnblockUserHandler
+	ForcePasswordReset command.ForcePasswordResetHandler
+	Impersonate        command.ImpersonateHandler
+	CreateAPIKey       command.CreateAPIKeyHandler
+	RevokeAPIKey       command.RevokeAPIKeyHandler
+	CompleteTwoFactor  command.CompleteTwoFactorLoginHandler
+	EnrollTwoFactor    command.EnrollTwoFactorHandler
+	StartTwoFactor     command.StartTwoFactorSetupHandler
+	ConfirmTwoFactor   command.ConfirmTwoFactorHandler
+	DisableTwoFactor   command.DisableTwoFactorHandler
+	RegenerateCodes    command.RegenerateRecoveryCodesHandler
+	WorkspaceTwoFactor command.SetWorkspaceTwoFactorHandler
+	ResetTwoFactor     command.ResetTwoFactorHandler
+	RevokeSession      command.RevokeSessionHandler
+	PurgeSessions      command.PurgeSessionsHandler
 }
 
 type Queries struct {
-	Login          query.LoginCommandHandler
-	GetUserByToken query.GetDataByTokenHandler
+	Login               query.LoginCommandHandler
+	OAuthProviders      query.OAuthProvidersHandler
+	ValidateToken       query.ValidateTokenHandler
+	GetUserByToken      query.GetDataByTokenHandler
+	GetUserWorkspaces   query.GetUserWorkspacesHandler
+	GetWorkspaceMembers query.GetWorkspaceMembersHandler
+	SwitchWorkspace     query.SwitchWorkspaceHandler
+	GetLockouts         query.GetLockoutsHandler
+	GetProfile          query.GetProfileHandler
+	ListRoles           query.ListRolesHandler
+	ListPermissions     query.ListPermissionsHandler
+	GetUserAccess       query.GetUserAccessHandler
+	ListUsers           query.ListUsersHandler
+	GetUser             query.GetUserHandler
+	ListAdminActions    query.ListAdminActionsHandler
+	ListAPIKeys         query.ListAPIKeysHandler
+	ValidateAPIKey      query.ValidateAPIKeyHandler
+	GetTwoFactorStatus  query.GetTwoFactorStatusHandler
+	ListSecurityEvents  query.ListSecurityEventsHandler
+	ListSessions        query.ListSessionsHandler
 }
 
 type AppCQRS struct {
@@ -23,15 +92,106 @@ type AppCQRS struct {
 
 func NewAppCQRS(
 	userRepo *postgres.AuthRepository,
+	workspaceRepo *postgres.WorkspaceRepository,
+	refreshRepo *postgres.RefreshTokenRepository,
+	revocationRepo *postgres.RevocationRepository,
+	userTokenRepo *postgres.UserTokenRepository,
+	mailer domain.Mailer,
+	otpRepo *postgres.OTPRepository,
+	smsSender domain.SMSSender,
+	identityRepo *postgres.IdentityRepository,
+	oauthStateRepo *postgres.OAuthStateRepository,
+	providers domain.IdentityProviders,
+	loginAttemptRepo *postgres.LoginAttemptRepository,
+	rbacRepo *postgres.RBACRepository,
+	adminRepo *postgres.AdminRepository,
+	apiKeyRepo *postgres.APIKeyRepository,
+	twoFactorRepo *postgres.TwoFactorRepository,
+	securityEventRepo *postgres.SecurityEventRepository,
+	sessionRepo *postgres.SessionRepository,
+	hasher *passwords.Hasher,
+	breached domain.BreachedPasswords,
+	logger *zap.Logger,
+	keys *jwt.KeySet,
 	cfg *config.Config,
 ) *AppCQRS {
+	issuer := token.NewIssuer(refreshRepo, sessionRepo, userRepo, workspaceRepo, rbacRepo, keys, cfg)
+	denylist := token.NewDenylist(revocationRepo, issuer.MaxAccessTTL(), cfg.Tokens.DenylistRefresh)
+	validateToken := query.NewValidateTokenHandler(userRepo, denylist, keys)
+	guard := lockout.NewGuard(loginAttemptRepo, cfg)
+	twoFactor := twofactor.NewService(twoFactorRepo, cfg)
+	recorder := audit.NewRecorder(securityEventRepo, cfg, logger)
+	policy := passwords.NewPolicy(cfg, hasher, breached)
+
 	return &AppCQRS{
 		Commands: Commands{
-			Register: command.NewRegisterUserCommandHandler(userRepo, cfg),
+			Register:         command.NewRegisterUserCommandHandler(userRepo, issuer, hasher, policy, recorder),
+			CreateWorkspace:  command.NewCreateWorkspaceHandler(workspaceRepo),
+			AddMember:        command.NewAddMemberHandler(userRepo, workspaceRepo),
+			UpdateMemberRole: command.NewUpdateMemberRoleHandler(workspaceRepo),
+			RemoveMember:     command.NewRemoveMemberHandler(workspaceRepo),
+			RefreshToken:     command.NewRefreshTokenHandler(issuer),
+			Logout:           command.NewLogoutHandler(issuer, denylist),
+			LogoutAll:        command.NewLogoutAllHandler(issuer, denylist),
+			SendVerification: command.NewSendVerificationHandler(userRepo, userTokenRepo, mailer, cfg),
+			VerifyEmail:      command.NewVerifyEmailHandler(userRepo, userTokenRepo),
+			ForgotPassword:   command.NewForgotPasswordHandler(userRepo, userTokenRepo, mailer, cfg),
+			ResetPassword:    command.NewResetPasswordHandler(userRepo, userTokenRepo, apiKeyRepo, issuer, denylist, hasher, policy, recorder),
+			RequestOTP:       command.NewRequestOTPHandler(userRepo, otpRepo, smsSender, cfg),
+			VerifyOTP:        command.NewVerifyOTPHandler(userRepo, workspaceRepo, otpRepo, issuer, twoFactor, recorder, cfg),
+			StartOAuth:       command.NewStartOAuthHandler(providers, oauthStateRepo, cfg),
+			OAuthCallback: command.NewOAuthCallbackHandler(
+				providers, oauthStateRepo, identityRepo, userRepo, workspaceRepo, issuer, twoFactor, hasher, recorder,
+			),
+			PurgeOAuthStates:   command.NewPurgeOAuthStatesHandler(oauthStateRepo),
+			UnlockLogin:        command.NewUnlockLoginHandler(loginAttemptRepo, adminRepo),
+			UpdateProfile:      command.NewUpdateProfileHandler(userRepo, otpRepo, smsSender, cfg),
+			ConfirmPhone:       command.NewConfirmPhoneHandler(userRepo, otpRepo, recorder, cfg),
+			ChangePassword:     command.NewChangePasswordHandler(userRepo, workspaceRepo, apiKeyRepo, issuer, denylist, hasher, policy, recorder),
+			RequestEmailChange: command.NewRequestEmailChangeHandler(userRepo, userTokenRepo, mailer, hasher, cfg),
+			ConfirmEmailChange: command.NewConfirmEmailChangeHandler(userRepo, userTokenRepo, mailer, recorder),
+			DeleteAccount:      command.NewDeleteAccountHandler(userRepo, issuer, denylist, hasher, recorder),
+			SaveRole:           command.NewSaveRoleHandler(rbacRepo, adminRepo),
+			DeleteRole:         command.NewDeleteRoleHandler(rbacRepo, adminRepo),
+			SetUserRoles:       command.NewSetUserRolesHandler(userRepo, rbacRepo, adminRepo, denylist, recorder),
+			BlockUser:          command.NewBlockUserHandler(userRepo, adminRepo, issuer, denylist),
+			UnblockUser:        command.NewUnblockUserHandler(userRepo, adminRepo),
+			ForcePasswordReset: command.NewForcePasswordResetHandler(userRepo, adminRepo, userTokenRepo, apiKeyRepo, mailer, issuer, denylist, hasher, cfg),
+			Impersonate:        command.NewImpersonateHandler(userRepo, rbacRepo, adminRepo, issuer),
+			CreateAPIKey:       command.NewCreateAPIKeyHandler(apiKeyRepo, rbacRepo, workspaceRepo, recorder, cfg),
+			RevokeAPIKey:       command.NewRevokeAPIKeyHandler(apiKeyRepo, recorder),
+			CompleteTwoFactor:  command.NewCompleteTwoFactorLoginHandler(userRepo, workspaceRepo, twoFactor, guard, issuer, recorder),
+			EnrollTwoFactor:    command.NewEnrollTwoFactorHandler(userRepo, twoFactor),
+			StartTwoFactor:     command.NewStartTwoFactorSetupHandler(userRepo, twoFactor),
+			ConfirmTwoFactor:   command.NewConfirmTwoFactorHandler(twoFactor, recorder),
+			DisableTwoFactor:   command.NewDisableTwoFactorHandler(userRepo, twoFactor, hasher, recorder),
+			RegenerateCodes:    command.NewRegenerateRecoveryCodesHandler(twoFactor),
+			WorkspaceTwoFactor: command.NewSetWorkspaceTwoFactorHandler(workspaceRepo, twoFactorRepo),
+			ResetTwoFactor:     command.NewResetTwoFactorHandler(userRepo, adminRepo, twoFactor, recorder),
+			RevokeSession:      command.NewRevokeSessionHandler(sessionRepo, denylist, recorder),
+			PurgeSessions:      command.NewPurgeSessionsHandler(sessionRepo, issuer),
 		},
 		Queries: Queries{
-			Login:          query.NewLoginCommandHandler(userRepo, cfg),
-			GetUserByToken: query.NewGetDataByTokenHandler(userRepo),
+			Login:               query.NewLoginCommandHandler(userRepo, workspaceRepo, issuer, guard, twoFactor, hasher, recorder),
+			OAuthProviders:      query.NewOAuthProvidersHandler(providers),
+			ValidateToken:       validateToken,
+			GetUserByToken:      query.NewGetDataByTokenHandler(validateToken),
+			GetUserWorkspaces:   query.NewGetUserWorkspacesHandler(workspaceRepo),
+			GetWorkspaceMembers: query.NewGetWorkspaceMembersHandler(workspaceRepo),
+			SwitchWorkspace:     query.NewSwitchWorkspaceHandler(userRepo, workspaceRepo, issuer),
+			GetLockouts:         query.NewGetLockoutsHandler(loginAttemptRepo),
+			GetProfile:          query.NewGetProfileHandler(userRepo),
+			ListRoles:           query.NewListRolesHandler(rbacRepo),
+			ListPermissions:     query.NewListPermissionsHandler(rbacRepo),
+			GetUserAccess:       query.NewGetUserAccessHandler(userRepo, rbacRepo),
+			ListUsers:           query.NewListUsersHandler(adminRepo),
+			GetUser:             query.NewGetUserHandler(userRepo, rbacRepo, workspaceRepo),
+			ListAdminActions:    query.NewListAdminActionsHandler(adminRepo),
+			ListAPIKeys:         query.NewListAPIKeysHandler(apiKeyRepo),
+			ValidateAPIKey:      query.NewValidateAPIKeyHandler(apiKeyRepo, userRepo, rbacRepo, workspaceRepo),
+			GetTwoFactorStatus:  query.NewGetTwoFactorStatusHandler(twoFactor),
+			ListSecurityEvents:  query.NewListSecurityEventsHandler(securityEventRepo),
+			ListSessions:        query.NewListSessionsHandler(sessionRepo),
 		},
 	}
 }
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.uber.org/zap"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	// maxReasonLength - длина security_events.reason
+	maxReasonLength = 255
+	// maxIPLength и maxUserAgentLength - длина колонок ip и user_agent
+	// в security_events и sessions
+	maxIPLength        = 64
+	maxUserAgentLength = 512
+)
+
+type clientKey struct{}
+
+// Client - откуда пришел запрос, кладется в контекст HTTP middleware
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// WithClient сохраняет клиента в контексте. Значения из заголовков
+// обрезаются, чтобы поместиться в колонки журнала и сессий.
+func WithClient(ctx context.Context, client Client) context.Context {
+	client.IP = truncate(client.IP, maxIPLength)
+	client.UserAgent = truncate(client.UserAgent, maxUserAgentLength)
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientKey{}).(Client)
+	return client
+}
+
+// Recorder пишет события журнала безопасности в Postgres и, если включен
+// audit.log_sink, дублирует их в лог сервиса: с настроенным logger.kafkaLogger
+// события уходят в Kafka вместе с остальными логами.
+type Recorder struct {
+	repo domain.SecurityEventRepository
+	sink *zap.Logger
+}
+
+func NewRecorder(repo domain.SecurityEventRepository, cfg *config.Config, logger *zap.Logger) *Recorder {
+	r := &Recorder{repo: repo}
+	if cfg.Audit.LogSink {
+		r.sink = logger.Named("security")
+	}
+	return r
+}
+
+// Record записывает событие с исходом по err: nil - успех, иначе неудача с
+// текстом ошибки в Reason. IP и User-Agent берутся из контекста. Ошибка
+// записи не прерывает операцию, а только попадает в лог.
+func (r *Recorder) Record(ctx context.Context, event *domain.SecurityEvent, err error) {
+	event.Outcome = domain.SecurityOutcomeSuccess
+	if err != nil {
+		event.Outcome = domain.SecurityOutcomeFailure
+		event.Reason = truncate(err.Error(), maxReasonLength)
+	}
+	client := ClientFromContext(ctx)
+	event.IP = client.IP
+	event.UserAgent = client.UserAgent
+	event.CreatedAt = time.Now()
+
+	// Событие записывается, даже если клиент уже отключился
+	if err := r.repo.RecordSecurityEvent(context.WithoutCancel(ctx), event); err != nil {
+		log.Printf("Ошибка записи в журнал безопасности %s: %v", event.Type, err)
+	}
+
+	if r.sink != nil {
+		r.sink.Info("security event",
+			zap.String("event_id", event.ID),
+			zap.String("event_type", string(event.Type)),
+			zap.String("outcome", string(event.Outcome)),
+			zap.String("user_id", event.UserID),
+			zap.String("actor_id", event.ActorID),
+			zap.String("login", event.Login),
+			zap.String("ip", event.IP),
+			zap.String("user_agent", event.UserAgent),
+			zap.String("reason", event.Reason),
+			zap.Any("details", event.Details),
+			zap.Time("created_at", event.CreatedAt),
+		)
+	}
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
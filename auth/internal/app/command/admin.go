@@ -0,0 +1,292 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"marketai/auth/internal/app/passwords"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+// adminAudit записывает действия администраторов в журнал admin_actions
+type adminAudit struct {
+	repo domain.AdminRepository
+}
+
+func (a adminAudit) record(
+	ctx context.Context,
+	adminID string,
+	action domain.AdminAction,
+	targetUserID string,
+	details map[string]string,
+) error {
+	err := a.repo.RecordAction(ctx, &domain.AdminActionRecord{
+		AdminID:      adminID,
+		Action:       action,
+		TargetUserID: targetUserID,
+		Details:      details,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка записи в журнал действий администраторов: %w", err)
+	}
+	return nil
+}
+
+// targetUser загружает пользователя, над которым выполняется действие
+// администратора. ID не в формате UUID означает такого же отсутствующего пользователя
+func targetUser(ctx context.Context, userRepo domain.UserRepository, userID string) (*domain.User, error) {
+	if uuid.Validate(userID) != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return userRepo.GetUserByID(ctx, userID)
+}
+
+type BlockUserCommand struct {
+	UserID  string
+	AdminID string
+	Reason  string
+}
+
+type BlockUserHandler interface {
+	Handle(ctx context.Context, cmd BlockUserCommand) error
+}
+
+type blockUserHandler struct {
+	userRepo  domain.UserRepository
+	adminRepo domain.AdminRepository
+	issuer    *token.Issuer
+	denylist  *token.Denylist
+}
+
+func NewBlockUserHandler(
+	userRepo domain.UserRepository,
+	adminRepo domain.AdminRepository,
+	issuer *token.Issuer,
+	denylist *token.Denylist,
+) *blockUserHandler {
+	return &blockUserHandler{
+		userRepo:  userRepo,
+		adminRepo: adminRepo,
+		issuer:    issuer,
+		denylist:  denylist,
+	}
+}
+
+// Handle блокирует пользователя и завершает все его сессии
+func (h *blockUserHandler) Handle(ctx context.Context, cmd BlockUserCommand) error {
+	if cmd.UserID == cmd.AdminID {
+		return domain.ErrSelfAdminAction
+	}
+	if _, err := targetUser(ctx, h.userRepo, cmd.UserID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reason := strings.TrimSpace(cmd.Reason)
+	if err := h.adminRepo.SetUserBlocked(ctx, cmd.UserID, &now, reason); err != nil {
+		return err
+	}
+	if err := h.issuer.RevokeAll(ctx, cmd.UserID); err != nil {
+		return err
+	}
+	if err := h.denylist.RevokeUser(ctx, cmd.UserID); err != nil {
+		return err
+	}
+
+	return adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionBlock, cmd.UserID,
+		map[string]string{"reason": reason})
+}
+
+type UnblockUserCommand struct {
+	UserID  string
+	AdminID string
+}
+
+type UnblockUserHandler interface {
+	Handle(ctx context.Context, cmd UnblockUserCommand) error
+}
+
+type unblockUserHandler struct {
+	userRepo  domain.UserRepository
+	adminRepo domain.AdminRepository
+}
+
+func NewUnblockUserHandler(userRepo domain.UserRepository, adminRepo domain.AdminRepository) *unblockUserHandler {
+	return &unblockUserHandler{userRepo: userRepo, adminRepo: adminRepo}
+}
+
+func (h *unblockUserHandler) Handle(ctx context.Context, cmd UnblockUserCommand) error {
+	if _, err := targetUser(ctx, h.userRepo, cmd.UserID); err != nil {
+		return err
+	}
+	if err := h.adminRepo.SetUserBlocked(ctx, cmd.UserID, nil, ""); err != nil {
+		return err
+	}
+	return adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionUnblock, cmd.UserID, nil)
+}
+
+type ForcePasswordResetCommand struct {
+	UserID  string
+	AdminID string
+}
+
+type ForcePasswordResetHandler interface {
+	Handle(ctx context.Context, cmd ForcePasswordResetCommand) error
+}
+
+type forcePasswordResetHandler struct {
+	userRepo   domain.UserRepository
+	adminRepo  domain.AdminRepository
+	apiKeyRepo domain.APIKeyRepository
+	issuer     *token.Issuer
+	denylist   *token.Denylist
+	hasher     *passwords.Hasher
+	tokens     *mailTokens
+}
+
+func NewForcePasswordResetHandler(
+	userRepo domain.UserRepository,
+	adminRepo domain.AdminRepository,
+	tokenRepo domain.UserTokenRepository,
+	apiKeyRepo domain.APIKeyRepository,
+	mailer domain.Mailer,
+	issuer *token.Issuer,
+	denylist *token.Denylist,
+	hasher *passwords.Hasher,
+	cfg *config.Config,
+) *forcePasswordResetHandler {
+	return &forcePasswordResetHandler{
+		userRepo:   userRepo,
+		adminRepo:  adminRepo,
+		apiKeyRepo: apiKeyRepo,
+		issuer:     issuer,
+		denylist:   denylist,
+		hasher:     hasher,
+		tokens:     &mailTokens{repo: tokenRepo, mailer: mailer, cfg: cfg},
+	}
+}
+
+// Handle заменяет пароль пользователя случайным, завершает все сессии, отзывает
+// API ключи и отправляет ссылку для смены пароля. Войти по паролю можно только после смены.
+func (h *forcePasswordResetHandler) Handle(ctx context.Context, cmd ForcePasswordResetCommand) error {
+	user, err := targetUser(ctx, h.userRepo, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	random, err := token.NewOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("ошибка при генерации пароля: %w", err)
+	}
+	hashedPassword, err := h.hasher.Hash(random)
+	if err != nil {
+		return err
+	}
+	if err := h.userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return err
+	}
+
+	if err := h.issuer.RevokeAll(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := h.apiKeyRepo.RevokeUserAPIKeys(ctx, user.ID, time.Now()); err != nil {
+		return err
+	}
+	if err := h.denylist.RevokeUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := h.tokens.send(ctx, user, domain.UserTokenPasswordReset); err != nil {
+		return err
+	}
+
+	return adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionPasswordReset, user.ID, nil)
+}
+
+type ImpersonateCommand struct {
+	UserID  string
+	AdminID string
+	// Reason - зачем администратору вход от имени пользователя, например номер обращения
+	Reason string
+}
+
+type ImpersonateResult struct {
+	Token     string
+	ExpiresIn time.Duration
+}
+
+type ImpersonateHandler interface {
+	Handle(ctx context.Context, cmd ImpersonateCommand) (*ImpersonateResult, error)
+}
+
+type impersonateHandler struct {
+	userRepo  domain.UserRepository
+	rbacRepo  domain.RBACRepository
+	adminRepo domain.AdminRepository
+	issuer    *token.Issuer
+}
+
+func NewImpersonateHandler(
+	userRepo domain.UserRepository,
+	rbacRepo domain.RBACRepository,
+	adminRepo domain.AdminRepository,
+	issuer *token.Issuer,
+) *impersonateHandler {
+	return &impersonateHandler{
+		userRepo:  userRepo,
+		rbacRepo:  rbacRepo,
+		adminRepo: adminRepo,
+		issuer:    issuer,
+	}
+}
+
+// Handle выпускает токен доступа от имени пользователя для поддержки. Вход от
+// имени администратора и пользователя с правами, которых нет у самого
+// администратора, запрещен, чтобы через него нельзя было расширить права.
+func (h *impersonateHandler) Handle(ctx context.Context, cmd ImpersonateCommand) (*ImpersonateResult, error) {
+	if cmd.UserID == cmd.AdminID {
+		return nil, domain.ErrSelfAdminAction
+	}
+
+	user, err := targetUser(ctx, h.userRepo, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	access, err := h.rbacRepo.GetUserAccess(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if access.HasRole(domain.RoleAdmin) {
+		return nil, domain.ErrImpersonateAdmin
+	}
+	// Права администратора читаются из базы, а не из токена: снятые роли действуют сразу
+	actor, err := h.rbacRepo.GetUserAccess(ctx, cmd.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	for _, permission := range access.Permissions {
+		if !slices.Contains(actor.Permissions, permission) {
+			return nil, domain.ErrImpersonateWider
+		}
+	}
+
+	accessToken, ttl, err := h.issuer.Impersonate(ctx, user, cmd.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionImpersonate, user.ID,
+		map[string]string{"reason": strings.TrimSpace(cmd.Reason)})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImpersonateResult{Token: accessToken, ExpiresIn: ttl}, nil
+}
@@ -0,0 +1,157 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	defaultMaxAPIKeys = 20
+	// apiKeyDisplayLength - сколько символов ключа после префикса видно в списке ключей
+	apiKeyDisplayLength = 8
+)
+
+type CreateAPIKeyCommand struct {
+	UserID      string
+	Name        string
+	WorkspaceID string
+	Scopes      []string
+	ExpiresAt   *time.Time
+}
+
+type CreateAPIKeyResult struct {
+	Key *domain.APIKey
+	// RawKey - сам ключ, показывается пользователю только в ответе на создание
+	RawKey string
+}
+
+type CreateAPIKeyHandler interface {
+	Handle(ctx context.Context, cmd CreateAPIKeyCommand) (*CreateAPIKeyResult, error)
+}
+
+type createAPIKeyHandler struct {
+	apiKeyRepo    domain.APIKeyRepository
+	rbacRepo      domain.RBACRepository
+	workspaceRepo domain.WorkspaceRepository
+	audit         *audit.Recorder
+	maxPerUser    int
+	maxTTL        time.Duration
+}
+
+func NewCreateAPIKeyHandler(
+	apiKeyRepo domain.APIKeyRepository,
+	rbacRepo domain.RBACRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	recorder *audit.Recorder,
+	cfg *config.Config,
+) *createAPIKeyHandler {
+	h := &createAPIKeyHandler{
+		apiKeyRepo:    apiKeyRepo,
+		rbacRepo:      rbacRepo,
+		workspaceRepo: workspaceRepo,
+		audit:         recorder,
+		maxPerUser:    cfg.APIKeys.MaxPerUser,
+		maxTTL:        cfg.APIKeys.MaxTTL,
+	}
+	if h.maxPerUser <= 0 {
+		h.maxPerUser = defaultMaxAPIKeys
+	}
+	return h
+}
+
+// Handle выпускает ключ с правами не шире прав пользователя. Если пространство не
+// указано, ключ действует в пространстве по умолчанию на момент запроса.
+func (h *createAPIKeyHandler) Handle(ctx context.Context, cmd CreateAPIKeyCommand) (_ *CreateAPIKeyResult, err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventAPIKeyCreate, UserID: cmd.UserID}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	now := time.Now()
+	scopes := uniqueSorted(cmd.Scopes)
+	if len(scopes) == 0 {
+		return nil, domain.ErrAPIKeyScopes
+	}
+	if cmd.ExpiresAt != nil && !cmd.ExpiresAt.After(now) {
+		return nil, domain.ErrAPIKeyExpiry
+	}
+	if h.maxTTL > 0 && (cmd.ExpiresAt == nil || cmd.ExpiresAt.After(now.Add(h.maxTTL))) {
+		return nil, fmt.Errorf("%w: не позднее %s", domain.ErrAPIKeyExpiry, now.Add(h.maxTTL).Format(time.RFC3339))
+	}
+
+	access, err := h.rbacRepo.GetUserAccess(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	granted := make(map[string]bool, len(access.Permissions))
+	for _, permission := range access.Permissions {
+		granted[permission] = true
+	}
+	for _, scope := range scopes {
+		if !granted[scope] {
+			return nil, fmt.Errorf("%w: %s", domain.ErrAPIKeyScope, scope)
+		}
+	}
+
+	if cmd.WorkspaceID != "" {
+		if _, err := h.workspaceRepo.GetMembership(ctx, cmd.WorkspaceID, cmd.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := token.NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации API ключа: %w", err)
+	}
+	raw := domain.APIKeyPrefix + secret
+
+	key := &domain.APIKey{
+		UserID:      cmd.UserID,
+		WorkspaceID: cmd.WorkspaceID,
+		Name:        strings.TrimSpace(cmd.Name),
+		Prefix:      raw[:len(domain.APIKeyPrefix)+apiKeyDisplayLength],
+		KeyHash:     token.HashOpaqueToken(raw),
+		Scopes:      scopes,
+		ExpiresAt:   cmd.ExpiresAt,
+		CreatedAt:   now,
+	}
+	if err := h.apiKeyRepo.CreateAPIKey(ctx, key, h.maxPerUser); err != nil {
+		return nil, err
+	}
+	event.Details = map[string]string{"key_id": key.ID, "scopes": strings.Join(scopes, " ")}
+
+	return &CreateAPIKeyResult{Key: key, RawKey: raw}, nil
+}
+
+type RevokeAPIKeyCommand struct {
+	UserID string
+	KeyID  string
+}
+
+type RevokeAPIKeyHandler interface {
+	Handle(ctx context.Context, cmd RevokeAPIKeyCommand) error
+}
+
+type revokeAPIKeyHandler struct {
+	apiKeyRepo domain.APIKeyRepository
+	audit      *audit.Recorder
+}
+
+func NewRevokeAPIKeyHandler(apiKeyRepo domain.APIKeyRepository, recorder *audit.Recorder) *revokeAPIKeyHandler {
+	return &revokeAPIKeyHandler{apiKeyRepo: apiKeyRepo, audit: recorder}
+}
+
+func (h *revokeAPIKeyHandler) Handle(ctx context.Context, cmd RevokeAPIKeyCommand) error {
+	err := h.apiKeyRepo.RevokeAPIKey(ctx, cmd.UserID, cmd.KeyID, time.Now())
+	h.audit.Record(ctx, &domain.SecurityEvent{
+		Type:    domain.SecurityEventAPIKeyRevoke,
+		UserID:  cmd.UserID,
+		Details: map[string]string{"key_id": cmd.KeyID},
+	}, err)
+	return err
+}
@@ -0,0 +1,282 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/passwords"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	defaultVerificationTTL = 48 * time.Hour
+	defaultResetTTL        = time.Hour
+)
+
+// mailTokens выпускает одноразовые токены и отправляет письма со ссылками на них
+type mailTokens struct {
+	repo   domain.UserTokenRepository
+	mailer domain.Mailer
+	cfg    *config.Config
+}
+
+func (m *mailTokens) send(ctx context.Context, user *domain.User, purpose domain.UserTokenPurpose) error {
+	return m.sendTo(ctx, user.ID, user.Email, purpose, "")
+}
+
+// sendTo отправляет ссылку на адрес to, который может отличаться от текущего
+// адреса пользователя, payload сохраняется вместе с токеном
+func (m *mailTokens) sendTo(ctx context.Context, userID, to string, purpose domain.UserTokenPurpose, payload string) error {
+	// Действует только последняя отправленная ссылка
+	if err := m.repo.InvalidateUserTokens(ctx, userID, purpose); err != nil {
+		return err
+	}
+
+	raw, err := token.NewOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("ошибка при генерации токена: %w", err)
+	}
+
+	ttl, link, mail := m.template(purpose, raw)
+	now := time.Now()
+	if err := m.repo.CreateUserToken(ctx, &domain.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: token.HashOpaqueToken(raw),
+		Payload:   payload,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	mail.To = to
+	mail.Body = fmt.Sprintf(mail.Body, link, now.Add(ttl).Format("02.01.2006 15:04 MST"))
+	return m.mailer.Send(ctx, mail)
+}
+
+func (m *mailTokens) template(purpose domain.UserTokenPurpose, raw string) (time.Duration, string, *domain.Mail) {
+	emailCfg := m.cfg.Email
+	switch purpose {
+	case domain.UserTokenPasswordReset:
+		return durationOr(emailCfg.ResetTTL, defaultResetTTL), withToken(emailCfg.ResetURL, raw), &domain.Mail{
+			Subject: "Восстановление пароля MarketAI",
+			Body: "Для смены пароля перейдите по ссылке:\n%s\n\nСсылка действует до %s. " +
+				"Если вы не запрашивали смену пароля, просто проигнорируйте это письмо.\n",
+		}
+	case domain.UserTokenEmailChange:
+		return durationOr(emailCfg.VerificationTTL, defaultVerificationTTL), withToken(emailCfg.ChangeURL, raw), &domain.Mail{
+			Subject: "Смена email в MarketAI",
+			Body: "Чтобы сделать этот адрес адресом вашей учетной записи MarketAI, перейдите по ссылке:\n%s\n\n" +
+				"Ссылка действует до %s. Если вы не меняли email, просто проигнорируйте это письмо.\n",
+		}
+	}
+
+	return durationOr(emailCfg.VerificationTTL, defaultVerificationTTL), withToken(emailCfg.VerifyURL, raw), &domain.Mail{
+		Subject: "Подтверждение email в MarketAI",
+		Body:    "Для подтверждения email перейдите по ссылке:\n%s\n\nСсылка действует до %s.\n",
+	}
+}
+
+func withToken(link, raw string) string {
+	return link + "?token=" + url.QueryEscape(raw)
+}
+
+func durationOr(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+type SendVerificationHandler interface {
+	Handle(ctx context.Context, userID string) error
+}
+
+type sendVerificationHandler struct {
+	userRepo domain.UserRepository
+	tokens   *mailTokens
+}
+
+func NewSendVerificationHandler(
+	userRepo domain.UserRepository,
+	tokenRepo domain.UserTokenRepository,
+	mailer domain.Mailer,
+	cfg *config.Config,
+) *sendVerificationHandler {
+	return &sendVerificationHandler{
+		userRepo: userRepo,
+		tokens:   &mailTokens{repo: tokenRepo, mailer: mailer, cfg: cfg},
+	}
+}
+
+// Handle отправляет письмо со ссылкой подтверждения, если email еще не подтвержден
+func (h *sendVerificationHandler) Handle(ctx context.Context, userID string) error {
+	user, err := h.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return nil
+	}
+
+	return h.tokens.send(ctx, user, domain.UserTokenEmailVerification)
+}
+
+type VerifyEmailHandler interface {
+	Handle(ctx context.Context, rawToken string) error
+}
+
+type verifyEmailHandler struct {
+	userRepo  domain.UserRepository
+	tokenRepo domain.UserTokenRepository
+}
+
+func NewVerifyEmailHandler(userRepo domain.UserRepository, tokenRepo domain.UserTokenRepository) *verifyEmailHandler {
+	return &verifyEmailHandler{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+func (h *verifyEmailHandler) Handle(ctx context.Context, rawToken string) error {
+	userToken, err := h.tokenRepo.ConsumeUserToken(ctx, domain.UserTokenEmailVerification, token.HashOpaqueToken(rawToken))
+	if err != nil {
+		return err
+	}
+
+	return h.userRepo.MarkEmailVerified(ctx, userToken.UserID, time.Now())
+}
+
+type ForgotPasswordHandler interface {
+	Handle(ctx context.Context, email string) error
+}
+
+type forgotPasswordHandler struct {
+	userRepo domain.UserRepository
+	tokens   *mailTokens
+}
+
+func NewForgotPasswordHandler(
+	userRepo domain.UserRepository,
+	tokenRepo domain.UserTokenRepository,
+	mailer domain.Mailer,
+	cfg *config.Config,
+) *forgotPasswordHandler {
+	return &forgotPasswordHandler{
+		userRepo: userRepo,
+		tokens:   &mailTokens{repo: tokenRepo, mailer: mailer, cfg: cfg},
+	}
+}
+
+// Handle отправляет ссылку для смены пароля. Для неизвестного email ничего не
+// происходит, чтобы по ответу нельзя было узнать, зарегистрирован ли адрес.
+func (h *forgotPasswordHandler) Handle(ctx context.Context, email string) error {
+	user, err := h.userRepo.GetUserByEmail(ctx, email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return h.tokens.send(ctx, user, domain.UserTokenPasswordReset)
+}
+
+type ResetPasswordCommand struct {
+	Token    string
+	Password string
+}
+
+type ResetPasswordHandler interface {
+	Handle(ctx context.Context, cmd ResetPasswordCommand) error
+}
+
+type resetPasswordHandler struct {
+	userRepo   domain.UserRepository
+	tokenRepo  domain.UserTokenRepository
+	apiKeyRepo domain.APIKeyRepository
+	issuer     *token.Issuer
+	denylist   *token.Denylist
+	hasher     *passwords.Hasher
+	policy     *passwords.Policy
+	audit      *audit.Recorder
+}
+
+func NewResetPasswordHandler(
+	userRepo domain.UserRepository,
+	tokenRepo domain.UserTokenRepository,
+	apiKeyRepo domain.APIKeyRepository,
+	issuer *token.Issuer,
+	denylist *token.Denylist,
+	hasher *passwords.Hasher,
+	policy *passwords.Policy,
+	recorder *audit.Recorder,
+) *resetPasswordHandler {
+	return &resetPasswordHandler{
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		apiKeyRepo: apiKeyRepo,
+		issuer:     issuer,
+		denylist:   denylist,
+		hasher:     hasher,
+		policy:     policy,
+		audit:      recorder,
+	}
+}
+
+// Handle меняет пароль по ссылке из письма, завершает все сессии пользователя и
+// отзывает его API ключи.
+// Ссылка гасится только после проверки пароля политикой, чтобы слабый
+// пароль можно было заменить, перейдя по той же ссылке.
+func (h *resetPasswordHandler) Handle(ctx context.Context, cmd ResetPasswordCommand) (err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventPasswordReset}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	tokenHash := token.HashOpaqueToken(cmd.Token)
+	pending, err := h.tokenRepo.GetUserToken(ctx, domain.UserTokenPasswordReset, tokenHash)
+	if err != nil {
+		return err
+	}
+	event.UserID = pending.UserID
+
+	user, err := h.userRepo.GetUserByID(ctx, pending.UserID)
+	if err != nil {
+		return err
+	}
+	if err := h.policy.Check(ctx, cmd.Password, user.Email); err != nil {
+		return err
+	}
+
+	userToken, err := h.tokenRepo.ConsumeUserToken(ctx, domain.UserTokenPasswordReset, tokenHash)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := h.hasher.Hash(cmd.Password)
+	if err != nil {
+		return err
+	}
+	if err := h.userRepo.UpdatePassword(ctx, userToken.UserID, hashedPassword); err != nil {
+		return err
+	}
+
+	// Письмо дошло до владельца адреса - это подтверждает email
+	if err := h.userRepo.MarkEmailVerified(ctx, userToken.UserID, time.Now()); err != nil {
+		return err
+	}
+
+	if err := h.issuer.RevokeAll(ctx, userToken.UserID); err != nil {
+		return err
+	}
+	if err := h.apiKeyRepo.RevokeUserAPIKeys(ctx, userToken.UserID, time.Now()); err != nil {
+		return err
+	}
+	return h.denylist.RevokeUser(ctx, userToken.UserID)
+}
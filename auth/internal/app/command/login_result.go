@@ -0,0 +1,81 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/app/twofactor"
+	domain "marketai/auth/internal/domain"
+)
+
+// LoginResult - результат входа без пароля (по коду из SMS или через внешнего провайдера)
+type LoginResult struct {
+	Token        string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	User         *domain.User
+	Workspace    *domain.Membership
+	// Created - пользователь зарегистрирован при этом входе
+	Created bool
+	// TwoFactor - вход ждет второго фактора, токены не выпущены
+	TwoFactor *twofactor.Challenge
+	// RecoveryCodes - резервные коды, выданные при подключении второго фактора во время входа
+	RecoveryCodes []string
+}
+
+func newLoginResult(pair *token.Pair, user *domain.User, membership *domain.Membership) *LoginResult {
+	return &LoginResult{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+		User:         user,
+		Workspace:    membership,
+	}
+}
+
+// loginMembership выбирает пространство для входа: запрошенное или личное пространство пользователя
+func loginMembership(ctx context.Context, workspaceRepo domain.WorkspaceRepository, userID, workspaceID string) (*domain.Membership, error) {
+	if workspaceID != "" {
+		return workspaceRepo.GetMembership(ctx, workspaceID, userID)
+	}
+
+	memberships, err := workspaceRepo.GetUserWorkspaces(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении рабочих пространств: %w", err)
+	}
+	return domain.DefaultMembership(memberships, userID), nil
+}
+
+// issueLogin выпускает токены или, если для входа нужен второй фактор,
+// возвращает незавершенный вход
+func issueLogin(
+	ctx context.Context,
+	issuer *token.Issuer,
+	twoFactor *twofactor.Service,
+	user *domain.User,
+	membership *domain.Membership,
+) (*LoginResult, error) {
+	challenge, err := twoFactor.Challenge(ctx, user, membership, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &LoginResult{User: user, Workspace: membership, TwoFactor: challenge}, nil
+	}
+
+	pair, err := issuer.Issue(ctx, user, membership)
+	if err != nil {
+		return nil, err
+	}
+	return newLoginResult(pair, user, membership), nil
+}
+
+// twoFactorDetails - подробности события входа, ожидающего второго фактора
+func twoFactorDetails(result *LoginResult) map[string]string {
+	if result.TwoFactor == nil {
+		return nil
+	}
+	return map[string]string{"two_factor": "required"}
+}
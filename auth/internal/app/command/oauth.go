@@ -0,0 +1,350 @@
+package command
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/passwords"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/app/twofactor"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	defaultOAuthStateTTL = 10 * time.Minute
+	// maxFullNameLength - длина users.full_name
+	maxFullNameLength = 50
+)
+
+type StartOAuthResult struct {
+	AuthorizationURL string
+	State            string
+	// Browser - значение cookie, которым вход привязывается к браузеру
+	Browser   string
+	ExpiresAt time.Time
+}
+
+type StartOAuthHandler interface {
+	Handle(ctx context.Context, provider string) (*StartOAuthResult, error)
+}
+
+type startOAuthHandler struct {
+	providers domain.IdentityProviders
+	stateRepo domain.OAuthStateRepository
+	stateTTL  time.Duration
+}
+
+func NewStartOAuthHandler(
+	providers domain.IdentityProviders,
+	stateRepo domain.OAuthStateRepository,
+	cfg *config.Config,
+) *startOAuthHandler {
+	return &startOAuthHandler{
+		providers: providers,
+		stateRepo: stateRepo,
+		stateTTL:  durationOr(cfg.OAuth.StateTTL, defaultOAuthStateTTL),
+	}
+}
+
+// Handle начинает authorization code flow: генерирует state, nonce и PKCE
+// verifier, сохраняет их и возвращает адрес страницы входа провайдера.
+// State возвращается клиенту, чтобы он сверил его с пришедшим в redirect,
+// а значение для cookie привязывает вход к браузеру: без него чужой state,
+// подброшенный в redirect, завершил бы вход в аккаунт злоумышленника.
+func (h *startOAuthHandler) Handle(ctx context.Context, providerName string) (*StartOAuthResult, error) {
+	provider, err := h.providers.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := token.NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации state: %w", err)
+	}
+	nonce, err := token.NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации nonce: %w", err)
+	}
+	verifier, err := token.NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации code_verifier: %w", err)
+	}
+	browser, err := token.NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации привязки к браузеру: %w", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, codeChallenge(verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := h.stateRepo.CreateOAuthState(ctx, &domain.OAuthState{
+		StateHash:    token.HashOpaqueToken(state),
+		Provider:     provider.Name(),
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		BrowserHash:  token.HashOpaqueToken(browser),
+		ExpiresAt:    now.Add(h.stateTTL),
+		CreatedAt:    now,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &StartOAuthResult{
+		AuthorizationURL: authURL,
+		State:            state,
+		Browser:          browser,
+		ExpiresAt:        now.Add(h.stateTTL),
+	}, nil
+}
+
+type PurgeOAuthStatesHandler interface {
+	// Handle удаляет брошенные входы и возвращает их количество
+	Handle(ctx context.Context) (int64, error)
+}
+
+type purgeOAuthStatesHandler struct {
+	stateRepo domain.OAuthStateRepository
+}
+
+func NewPurgeOAuthStatesHandler(stateRepo domain.OAuthStateRepository) *purgeOAuthStatesHandler {
+	return &purgeOAuthStatesHandler{stateRepo: stateRepo}
+}
+
+func (h *purgeOAuthStatesHandler) Handle(ctx context.Context) (int64, error) {
+	return h.stateRepo.DeleteExpiredOAuthStates(ctx)
+}
+
+type OAuthCallbackCommand struct {
+	Provider string
+	Code     string
+	State    string
+	// DeviceID - параметр redirect VK ID, для остальных провайдеров пустой
+	DeviceID string
+	// Browser - значение cookie, выданной при старте входа
+	Browser string
+}
+
+type OAuthCallbackHandler interface {
+	Handle(ctx context.Context, cmd OAuthCallbackCommand) (*LoginResult, error)
+}
+
+type oauthCallbackHandler struct {
+	providers     domain.IdentityProviders
+	stateRepo     domain.OAuthStateRepository
+	identityRepo  domain.IdentityRepository
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	issuer        *token.Issuer
+	twoFactor     *twofactor.Service
+	hasher        *passwords.Hasher
+	audit         *audit.Recorder
+}
+
+func NewOAuthCallbackHandler(
+	providers domain.IdentityProviders,
+	stateRepo domain.OAuthStateRepository,
+	identityRepo domain.IdentityRepository,
+	userRepo domain.UserRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	issuer *token.Issuer,
+	twoFactor *twofactor.Service,
+	hasher *passwords.Hasher,
+	recorder *audit.Recorder,
+) *oauthCallbackHandler {
+	return &oauthCallbackHandler{
+		providers:     providers,
+		stateRepo:     stateRepo,
+		identityRepo:  identityRepo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		issuer:        issuer,
+		twoFactor:     twoFactor,
+		hasher:        hasher,
+		audit:         recorder,
+	}
+}
+
+// Handle завершает вход: гасит state, обменивает код на профиль провайдера и
+// находит привязанного пользователя. Внешний аккаунт привязывается к
+// существующему пользователю только по подтвержденному провайдером email,
+// иначе при первом входе создается новый пользователь.
+func (h *oauthCallbackHandler) Handle(ctx context.Context, cmd OAuthCallbackCommand) (result *LoginResult, err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventLoginOAuth}
+	defer func() {
+		event.Details = map[string]string{"provider": cmd.Provider}
+		if result != nil {
+			event.UserID = result.User.ID
+			event.Login = result.User.Email
+			for k, v := range twoFactorDetails(result) {
+				event.Details[k] = v
+			}
+			if result.Created {
+				event.Details["created"] = "true"
+			}
+		}
+		h.audit.Record(ctx, event, err)
+	}()
+
+	state, err := h.stateRepo.ConsumeOAuthState(ctx, token.HashOpaqueToken(cmd.State))
+	if err != nil {
+		return nil, err
+	}
+	if state.Provider != cmd.Provider {
+		return nil, domain.ErrOAuthStateInvalid
+	}
+	browserHash := token.HashOpaqueToken(cmd.Browser)
+	if cmd.Browser == "" || subtle.ConstantTimeCompare([]byte(browserHash), []byte(state.BrowserHash)) != 1 {
+		return nil, domain.ErrOAuthStateInvalid
+	}
+
+	provider, err := h.providers.Get(cmd.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := provider.Exchange(ctx, domain.OAuthExchange{
+		Code:         cmd.Code,
+		State:        cmd.State,
+		CodeVerifier: state.CodeVerifier,
+		Nonce:        state.Nonce,
+		DeviceID:     cmd.DeviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user, membership, created, err := h.resolveUser(ctx, provider.Name(), profile)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		if membership, err = loginMembership(ctx, h.workspaceRepo, user.ID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err = issueLogin(ctx, h.issuer, h.twoFactor, user, membership)
+	if err != nil {
+		return nil, err
+	}
+	result.Created = created
+	return result, nil
+}
+
+// resolveUser возвращает пользователя внешнего аккаунта. Для нового пользователя
+// возвращается и его личное пространство, для существующего membership пустой.
+func (h *oauthCallbackHandler) resolveUser(
+	ctx context.Context,
+	provider string,
+	profile *domain.ExternalProfile,
+) (*domain.User, *domain.Membership, bool, error) {
+	identity, err := h.identityRepo.GetIdentity(ctx, provider, profile.Subject)
+	if err == nil {
+		user, err := h.userRepo.GetUserByID(ctx, identity.UserID)
+		return user, nil, false, err
+	}
+	if !errors.Is(err, domain.ErrIdentityNotFound) {
+		return nil, nil, false, err
+	}
+
+	// Провайдеры возвращают email в произвольном регистре
+	profile.Email = strings.ToLower(strings.TrimSpace(profile.Email))
+	if profile.Email == "" {
+		return nil, nil, false, domain.ErrOAuthEmailRequired
+	}
+
+	now := time.Now()
+	identity = &domain.Identity{
+		Provider:  provider,
+		Subject:   profile.Subject,
+		Email:     profile.Email,
+		CreatedAt: now,
+	}
+
+	existing, err := h.userRepo.GetUserByEmail(ctx, profile.Email)
+	switch {
+	case err == nil:
+		// Без подтверждения провайдера чужой аккаунт с тем же email мог бы
+		// войти в существующий профиль, поэтому привязку делает только сам владелец.
+		// Неподтвержденный локальный email мог зарегистрировать кто угодно: после
+		// привязки его пароль продолжал бы открывать аккаунт настоящего владельца
+		if !profile.EmailVerified || !existing.EmailVerified() {
+			return nil, nil, false, domain.ErrOAuthAccountExists
+		}
+		identity.UserID = existing.ID
+		if err := h.identityRepo.LinkIdentity(ctx, identity); err != nil {
+			return nil, nil, false, fmt.Errorf("ошибка при привязке аккаунта: %w", err)
+		}
+		return existing, nil, false, nil
+	case !errors.Is(err, domain.ErrUserNotFound):
+		return nil, nil, false, err
+	}
+
+	user, workspace, err := h.newUser(profile, now)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if err := h.identityRepo.CreateUserWithIdentity(ctx, user, workspace, identity); err != nil {
+		if errors.Is(err, domain.ErrOAuthAccountExists) {
+			return nil, nil, false, err
+		}
+		return nil, nil, false, fmt.Errorf("ошибка при сохранении пользователя: %w", err)
+	}
+	return user, &domain.Membership{Workspace: workspace, Role: domain.WorkspaceRoleOwner}, true, nil
+}
+
+func (h *oauthCallbackHandler) newUser(profile *domain.ExternalProfile, now time.Time) (*domain.User, *domain.Workspace, error) {
+	// Пароль пользователю неизвестен: войти по паролю можно будет после восстановления
+	password, err := token.NewOpaqueToken()
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при генерации пароля: %w", err)
+	}
+	hashedPassword, err := h.hasher.Hash(password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := &domain.User{
+		FullName:     truncateRunes(profile.FullName, maxFullNameLength),
+		Email:        profile.Email,
+		PasswordHash: hashedPassword,
+		Role:         domain.RoleUser,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if profile.EmailVerified {
+		user.EmailVerifiedAt = &now
+	}
+
+	workspace := &domain.Workspace{
+		Name:      personalWorkspaceName(user),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return user, workspace, nil
+}
+
+// codeChallenge - PKCE S256: base64url(SHA-256(verifier))
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
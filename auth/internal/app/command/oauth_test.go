@@ -0,0 +1,162 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+type fakeOAuthStateRepo struct {
+	states map[string]*domain.OAuthState
+}
+
+func (r *fakeOAuthStateRepo) CreateOAuthState(_ context.Context, state *domain.OAuthState) error {
+	r.states[state.StateHash] = state
+	return nil
+}
+
+func (r *fakeOAuthStateRepo) ConsumeOAuthState(_ context.Context, stateHash string) (*domain.OAuthState, error) {
+	state, ok := r.states[stateHash]
+	if !ok {
+		return nil, domain.ErrOAuthStateInvalid
+	}
+	delete(r.states, stateHash)
+	return state, nil
+}
+
+func (r *fakeOAuthStateRepo) DeleteExpiredOAuthStates(context.Context) (int64, error) {
+	return 0, nil
+}
+
+type fakeSecurityEventRepo struct{}
+
+func (fakeSecurityEventRepo) RecordSecurityEvent(context.Context, *domain.SecurityEvent) error {
+	return nil
+}
+
+func (fakeSecurityEventRepo) ListSecurityEvents(context.Context, domain.SecurityEventFilter) ([]*domain.SecurityEvent, int, error) {
+	return nil, 0, nil
+}
+
+func (fakeSecurityEventRepo) DeleteSecurityEventsBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+// errExchangeReached - провайдер дошел до обмена кода, то есть state принят
+var errExchangeReached = errors.New("exchange reached")
+
+// fakeProvider запоминает параметры начала входа и обмена кода
+type fakeProvider struct {
+	name      string
+	authQuery url.Values
+	exchange  *domain.OAuthExchange
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) AuthCodeURL(_ context.Context, state, nonce, codeChallenge string) (string, error) {
+	p.authQuery = url.Values{"state": {state}, "nonce": {nonce}, "code_challenge": {codeChallenge}}
+	return "https://provider.example/authorize?" + p.authQuery.Encode(), nil
+}
+
+func (p *fakeProvider) Exchange(_ context.Context, exchange domain.OAuthExchange) (*domain.ExternalProfile, error) {
+	p.exchange = &exchange
+	return nil, errExchangeReached
+}
+
+func TestOAuthCallbackState(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		state    func(started *StartOAuthResult) string
+		browser  func(started *StartOAuthResult) string
+		wantErr  error
+	}{
+		{
+			name:     "same browser",
+			provider: "mock",
+			state:    func(s *StartOAuthResult) string { return s.State },
+			browser:  func(s *StartOAuthResult) string { return s.Browser },
+			wantErr:  errExchangeReached,
+		},
+		{
+			name:     "no browser cookie",
+			provider: "mock",
+			state:    func(s *StartOAuthResult) string { return s.State },
+			browser:  func(*StartOAuthResult) string { return "" },
+			wantErr:  domain.ErrOAuthStateInvalid,
+		},
+		{
+			name:     "another browser",
+			provider: "mock",
+			state:    func(s *StartOAuthResult) string { return s.State },
+			browser:  func(*StartOAuthResult) string { return "attacker-browser" },
+			wantErr:  domain.ErrOAuthStateInvalid,
+		},
+		{
+			name:     "unknown state",
+			provider: "mock",
+			state:    func(*StartOAuthResult) string { return "forged-state" },
+			browser:  func(s *StartOAuthResult) string { return s.Browser },
+			wantErr:  domain.ErrOAuthStateInvalid,
+		},
+		{
+			name:     "state of another provider",
+			provider: "other",
+			state:    func(s *StartOAuthResult) string { return s.State },
+			browser:  func(s *StartOAuthResult) string { return s.Browser },
+			wantErr:  domain.ErrOAuthStateInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			provider := &fakeProvider{name: "mock"}
+			providers := domain.IdentityProviders{"mock": provider, "other": &fakeProvider{name: "other"}}
+			stateRepo := &fakeOAuthStateRepo{states: map[string]*domain.OAuthState{}}
+			recorder := audit.NewRecorder(fakeSecurityEventRepo{}, &config.Config{}, zap.NewNop())
+
+			started, err := NewStartOAuthHandler(providers, stateRepo, &config.Config{}).Handle(ctx, "mock")
+			if err != nil {
+				t.Fatalf("start: %v", err)
+			}
+			if started.Browser == "" || started.State == "" {
+				t.Fatalf("start returned empty state or browser binding")
+			}
+
+			callback := NewOAuthCallbackHandler(providers, stateRepo, nil, nil, nil, nil, nil, nil, recorder)
+			_, err = callback.Handle(ctx, OAuthCallbackCommand{
+				Provider: tt.provider,
+				Code:     "code",
+				State:    tt.state(started),
+				Browser:  tt.browser(started),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != errExchangeReached {
+				return
+			}
+			// В обмен уходят verifier и nonce, выданные при старте этого входа
+			if got := codeChallenge(provider.exchange.CodeVerifier); got != provider.authQuery.Get("code_challenge") {
+				t.Errorf("code_verifier does not match code_challenge")
+			}
+			if provider.exchange.Nonce != provider.authQuery.Get("nonce") {
+				t.Errorf("nonce = %q, want %q", provider.exchange.Nonce, provider.authQuery.Get("nonce"))
+			}
+			if len(stateRepo.states) != 0 {
+				t.Errorf("state was not consumed")
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/app/twofactor"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	defaultOTPLength         = 6
+	defaultOTPTTL            = 5 * time.Minute
+	defaultOTPMaxAttempts    = 5
+	defaultOTPResendInterval = time.Minute
+	defaultOTPMaxPerHour     = 5
+)
+
+// otpSettings - настройки кодов с подставленными значениями по умолчанию
+type otpSettings struct {
+	length         int
+	ttl            time.Duration
+	maxAttempts    int
+	resendInterval time.Duration
+	maxPerHour     int
+}
+
+func newOTPSettings(cfg *config.Config) otpSettings {
+	s := otpSettings{
+		length:         cfg.OTP.CodeLength,
+		ttl:            durationOr(cfg.OTP.TTL, defaultOTPTTL),
+		maxAttempts:    cfg.OTP.MaxAttempts,
+		resendInterval: durationOr(cfg.OTP.ResendInterval, defaultOTPResendInterval),
+		maxPerHour:     cfg.OTP.MaxPerHour,
+	}
+	if s.length <= 0 {
+		s.length = defaultOTPLength
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = defaultOTPMaxAttempts
+	}
+	if s.maxPerHour <= 0 {
+		s.maxPerHour = defaultOTPMaxPerHour
+	}
+	return s
+}
+
+type RequestOTPHandler interface {
+	Handle(ctx context.Context, phoneNumber string) error
+}
+
+type requestOTPHandler struct {
+	userRepo domain.UserRepository
+	otpRepo  domain.OTPRepository
+	sender   domain.SMSSender
+	settings otpSettings
+}
+
+func NewRequestOTPHandler(
+	userRepo domain.UserRepository,
+	otpRepo domain.OTPRepository,
+	sender domain.SMSSender,
+	cfg *config.Config,
+) *requestOTPHandler {
+	return &requestOTPHandler{
+		userRepo: userRepo,
+		otpRepo:  otpRepo,
+		sender:   sender,
+		settings: newOTPSettings(cfg),
+	}
+}
+
+// Handle выпускает код входа и отправляет его по SMS. Лимиты считаются по
+// номеру, в том числе незарегистрированному: так по ответу нельзя узнать,
+// есть ли пользователь с этим номером, а SMS на чужие номера не отправляются.
+func (h *requestOTPHandler) Handle(ctx context.Context, phoneNumber string) error {
+	code, err := h.settings.issue(ctx, h.otpRepo, domain.OTPPurposeLogin, phoneNumber, func(code string) string {
+		return hashOTP(phoneNumber, code)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = h.userRepo.GetUserByPhone(ctx, phoneNumber)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return h.sender.Send(ctx, phoneNumber, fmt.Sprintf("Код входа в MarketAI: %s. Никому не сообщайте его.", code))
+}
+
+type VerifyOTPCommand struct {
+	PhoneNumber string
+	Code        string
+	// WorkspaceID - пространство для входа, по умолчанию личное пространство пользователя
+	WorkspaceID string
+}
+
+type VerifyOTPHandler interface {
+	Handle(ctx context.Context, cmd VerifyOTPCommand) (*LoginResult, error)
+}
+
+type verifyOTPHandler struct {
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	otpRepo       domain.OTPRepository
+	issuer        *token.Issuer
+	twoFactor     *twofactor.Service
+	audit         *audit.Recorder
+	settings      otpSettings
+}
+
+func NewVerifyOTPHandler(
+	userRepo domain.UserRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	otpRepo domain.OTPRepository,
+	issuer *token.Issuer,
+	twoFactor *twofactor.Service,
+	recorder *audit.Recorder,
+	cfg *config.Config,
+) *verifyOTPHandler {
+	return &verifyOTPHandler{
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		otpRepo:       otpRepo,
+		issuer:        issuer,
+		twoFactor:     twoFactor,
+		audit:         recorder,
+		settings:      newOTPSettings(cfg),
+	}
+}
+
+// Handle проверяет код и выпускает обычную пару токенов, если второй фактор не нужен
+func (h *verifyOTPHandler) Handle(ctx context.Context, cmd VerifyOTPCommand) (result *LoginResult, err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventLoginOTP, Login: cmd.PhoneNumber}
+	defer func() {
+		if result != nil {
+			event.UserID = result.User.ID
+			event.Details = twoFactorDetails(result)
+		}
+		h.audit.Record(ctx, event, err)
+	}()
+
+	err = h.settings.verify(ctx, h.otpRepo, domain.OTPPurposeLogin, cmd.PhoneNumber, hashOTP(cmd.PhoneNumber, cmd.Code))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.userRepo.GetUserByPhone(ctx, cmd.PhoneNumber)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrOTPInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := loginMembership(ctx, h.workspaceRepo, user.ID, cmd.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return issueLogin(ctx, h.issuer, h.twoFactor, user, membership)
+}
+
+// issue выпускает код назначения purpose с учетом лимитов номера и возвращает его
+// для отправки. Новый код вытесняет предыдущий: проверяется только последний выпущенный.
+func (s otpSettings) issue(
+	ctx context.Context,
+	otpRepo domain.OTPRepository,
+	purpose, phoneNumber string,
+	hash func(code string) string,
+) (string, error) {
+	code, err := newOTPCode(s.length)
+	if err != nil {
+		return "", fmt.Errorf("ошибка при генерации кода: %w", err)
+	}
+
+	now := time.Now()
+	err = otpRepo.CreateOTP(ctx, &domain.OTPCode{
+		PhoneNumber: phoneNumber,
+		Purpose:     purpose,
+		CodeHash:    hash(code),
+		ExpiresAt:   now.Add(s.ttl),
+		CreatedAt:   now,
+	}, s.resendInterval, s.maxPerHour)
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// verify сверяет хеш введенного кода с последним кодом назначения purpose и
+// гасит код. Попытка засчитывается до сравнения, поэтому параллельные
+// запросы не проверят больше кодов, чем разрешено.
+func (s otpSettings) verify(
+	ctx context.Context,
+	otpRepo domain.OTPRepository,
+	purpose, phoneNumber, hash string,
+) error {
+	code, err := otpRepo.GetLatestOTP(ctx, purpose, phoneNumber)
+	if err != nil {
+		return err
+	}
+	if code == nil || code.ConsumedAt != nil || time.Now().After(code.ExpiresAt) {
+		return domain.ErrOTPInvalid
+	}
+
+	attempts, err := otpRepo.ReserveOTPAttempt(ctx, code.ID, s.maxAttempts)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(code.CodeHash), []byte(hash)) != 1 {
+		if attempts >= s.maxAttempts {
+			return domain.ErrOTPAttemptsExceeded
+		}
+		return domain.ErrOTPInvalid
+	}
+
+	return otpRepo.ConsumeOTP(ctx, code.ID)
+}
+
+func newOTPCode(length int) (string, error) {
+	digits := make([]byte, length)
+	for i := range digits {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits[i] = byte('0' + n.Int64())
+	}
+	return string(digits), nil
+}
+
+// hashOTP привязывает хеш кода к номеру, чтобы одинаковые коды разных номеров не совпадали
+func hashOTP(phoneNumber, code string) string {
+	return token.HashOpaqueToken(phoneNumber + ":" + code)
+}
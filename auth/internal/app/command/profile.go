@@ -0,0 +1,432 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/passwords"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+type UpdateProfileCommand struct {
+	UserID string
+	// Пустые поля не меняются
+	FullName    *string
+	PhoneNumber *string
+}
+
+type UpdateProfileResult struct {
+	User *domain.User
+	// PendingPhoneNumber - новый номер, на который отправлен код подтверждения.
+	// До подтверждения у пользователя остается прежний номер.
+	PendingPhoneNumber string
+}
+
+type UpdateProfileHandler interface {
+	Handle(ctx context.Context, cmd UpdateProfileCommand) (*UpdateProfileResult, error)
+}
+
+type updateProfileHandler struct {
+	userRepo domain.UserRepository
+	otpRepo  domain.OTPRepository
+	sender   domain.SMSSender
+	settings otpSettings
+}
+
+func NewUpdateProfileHandler(
+	userRepo domain.UserRepository,
+	otpRepo domain.OTPRepository,
+	sender domain.SMSSender,
+	cfg *config.Config,
+) *updateProfileHandler {
+	return &updateProfileHandler{
+		userRepo: userRepo,
+		otpRepo:  otpRepo,
+		sender:   sender,
+		settings: newOTPSettings(cfg),
+	}
+}
+
+// Handle обновляет профиль. Номер телефона дает вход по SMS, поэтому новый
+// номер сохраняется только после подтверждения кодом, отправленным на него.
+// Удаление номера применяется сразу.
+func (h *updateProfileHandler) Handle(ctx context.Context, cmd UpdateProfileCommand) (*UpdateProfileResult, error) {
+	user, err := h.userRepo.GetUserByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	result := &UpdateProfileResult{User: user}
+
+	if cmd.FullName != nil {
+		user.FullName = strings.TrimSpace(*cmd.FullName)
+	}
+	if cmd.PhoneNumber != nil && strings.TrimSpace(*cmd.PhoneNumber) != user.PhoneNumber {
+		phone := strings.TrimSpace(*cmd.PhoneNumber)
+		if phone == "" {
+			user.PhoneNumber = ""
+		} else {
+			if err := checkPhoneOwner(ctx, h.userRepo, user.ID, phone); err != nil {
+				return nil, err
+			}
+			code, err := h.settings.issue(ctx, h.otpRepo, domain.OTPPurposePhoneChange, phone, func(code string) string {
+				return hashPhoneChangeOTP(user.ID, phone, code)
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := h.sender.Send(ctx, phone, fmt.Sprintf("Код подтверждения номера в MarketAI: %s", code)); err != nil {
+				return nil, err
+			}
+			result.PendingPhoneNumber = phone
+		}
+	}
+
+	user.UpdatedAt = time.Now()
+	if err := h.userRepo.UpdateProfile(ctx, user); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type ConfirmPhoneCommand struct {
+	UserID      string
+	PhoneNumber string
+	Code        string
+}
+
+type ConfirmPhoneHandler interface {
+	Handle(ctx context.Context, cmd ConfirmPhoneCommand) (*domain.User, error)
+}
+
+type confirmPhoneHandler struct {
+	userRepo domain.UserRepository
+	otpRepo  domain.OTPRepository
+	settings otpSettings
+	audit    *audit.Recorder
+}
+
+func NewConfirmPhoneHandler(
+	userRepo domain.UserRepository,
+	otpRepo domain.OTPRepository,
+	recorder *audit.Recorder,
+	cfg *config.Config,
+) *confirmPhoneHandler {
+	return &confirmPhoneHandler{
+		userRepo: userRepo,
+		otpRepo:  otpRepo,
+		settings: newOTPSettings(cfg),
+		audit:    recorder,
+	}
+}
+
+// Handle проверяет код, отправленный на новый номер, и сохраняет номер в профиле
+func (h *confirmPhoneHandler) Handle(ctx context.Context, cmd ConfirmPhoneCommand) (_ *domain.User, err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventPhoneChange, UserID: cmd.UserID}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	user, err := h.userRepo.GetUserByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Код привязан к пользователю: код, выданный другому пользователю на тот же номер, не подойдет
+	hash := hashPhoneChangeOTP(user.ID, cmd.PhoneNumber, cmd.Code)
+	if err := h.settings.verify(ctx, h.otpRepo, domain.OTPPurposePhoneChange, cmd.PhoneNumber, hash); err != nil {
+		return nil, err
+	}
+
+	// Номер мог занять другой пользователь, пока код был в пути
+	if err := checkPhoneOwner(ctx, h.userRepo, user.ID, cmd.PhoneNumber); err != nil {
+		return nil, err
+	}
+	event.Details = map[string]string{"from": user.PhoneNumber, "to": cmd.PhoneNumber}
+
+	user.PhoneNumber = cmd.PhoneNumber
+	user.UpdatedAt = time.Now()
+	if err := h.userRepo.UpdateProfile(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkPhoneOwner проверяет, что номер не принадлежит другому пользователю.
+// По номеру выполняется вход по SMS, поэтому он должен однозначно указывать на пользователя.
+func checkPhoneOwner(ctx context.Context, userRepo domain.UserRepository, userID, phone string) error {
+	owner, err := userRepo.GetUserByPhone(ctx, phone)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+	if owner != nil && owner.ID != userID {
+		return domain.ErrPhoneTaken
+	}
+	return nil
+}
+
+func hashPhoneChangeOTP(userID, phoneNumber, code string) string {
+	return token.HashOpaqueToken(userID + ":" + phoneNumber + ":" + code)
+}
+
+type ChangePasswordCommand struct {
+	UserID          string
+	CurrentPassword string
+	NewPassword     string
+	// WorkspaceID - пространство текущей сессии, в нем выпускается новая пара токенов
+	WorkspaceID string
+}
+
+type ChangePasswordHandler interface {
+	Handle(ctx context.Context, cmd ChangePasswordCommand) (*LoginResult, error)
+}
+
+type changePasswordHandler struct {
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	apiKeyRepo    domain.APIKeyRepository
+	issuer        *token.Issuer
+	denylist      *token.Denylist
+	hasher        *passwords.Hasher
+	policy        *passwords.Policy
+	audit         *audit.Recorder
+}
+
+func NewChangePasswordHandler(
+	userRepo domain.UserRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	apiKeyRepo domain.APIKeyRepository,
+	issuer *token.Issuer,
+	denylist *token.Denylist,
+	hasher *passwords.Hasher,
+	policy *passwords.Policy,
+	recorder *audit.Recorder,
+) *changePasswordHandler {
+	return &changePasswordHandler{
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		apiKeyRepo:    apiKeyRepo,
+		issuer:        issuer,
+		denylist:      denylist,
+		hasher:        hasher,
+		policy:        policy,
+		audit:         recorder,
+	}
+}
+
+// Handle меняет пароль после проверки текущего. Все сессии, включая текущую,
+// завершаются, API ключи отзываются, а вызывающему выдается новая пара токенов.
+func (h *changePasswordHandler) Handle(ctx context.Context, cmd ChangePasswordCommand) (_ *LoginResult, err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventPasswordChange, UserID: cmd.UserID}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	user, err := h.userRepo.GetUserByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if ok, _ := h.hasher.Verify(user.PasswordHash, cmd.CurrentPassword); !ok {
+		return nil, domain.ErrWrongPassword
+	}
+	if err := h.policy.Check(ctx, cmd.NewPassword, user.Email); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := h.hasher.Hash(cmd.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return nil, err
+	}
+
+	if err := h.issuer.RevokeAll(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	if err := h.apiKeyRepo.RevokeUserAPIKeys(ctx, user.ID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.denylist.RevokeUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	membership, err := loginMembership(ctx, h.workspaceRepo, user.ID, cmd.WorkspaceID)
+	if errors.Is(err, domain.ErrNotWorkspaceMember) {
+		membership, err = loginMembership(ctx, h.workspaceRepo, user.ID, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := h.issuer.Issue(ctx, user, membership)
+	if err != nil {
+		return nil, err
+	}
+	return newLoginResult(pair, user, membership), nil
+}
+
+type RequestEmailChangeCommand struct {
+	UserID   string
+	NewEmail string
+	Password string
+}
+
+type RequestEmailChangeHandler interface {
+	Handle(ctx context.Context, cmd RequestEmailChangeCommand) error
+}
+
+type requestEmailChangeHandler struct {
+	userRepo domain.UserRepository
+	hasher   *passwords.Hasher
+	tokens   *mailTokens
+}
+
+func NewRequestEmailChangeHandler(
+	userRepo domain.UserRepository,
+	tokenRepo domain.UserTokenRepository,
+	mailer domain.Mailer,
+	hasher *passwords.Hasher,
+	cfg *config.Config,
+) *requestEmailChangeHandler {
+	return &requestEmailChangeHandler{
+		userRepo: userRepo,
+		hasher:   hasher,
+		tokens:   &mailTokens{repo: tokenRepo, mailer: mailer, cfg: cfg},
+	}
+}
+
+// Handle отправляет ссылку подтверждения на новый адрес. Email учетной записи
+// меняется только после перехода по ссылке, до этого действует прежний.
+func (h *requestEmailChangeHandler) Handle(ctx context.Context, cmd RequestEmailChangeCommand) error {
+	user, err := h.userRepo.GetUserByID(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if ok, _ := h.hasher.Verify(user.PasswordHash, cmd.Password); !ok {
+		return domain.ErrWrongPassword
+	}
+
+	newEmail := strings.ToLower(strings.TrimSpace(cmd.NewEmail))
+	_, err = h.userRepo.GetUserByEmail(ctx, newEmail)
+	if err == nil {
+		return domain.ErrEmailTaken
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+
+	return h.tokens.sendTo(ctx, user.ID, newEmail, domain.UserTokenEmailChange, newEmail)
+}
+
+type ConfirmEmailChangeHandler interface {
+	Handle(ctx context.Context, rawToken string) error
+}
+
+type confirmEmailChangeHandler struct {
+	userRepo  domain.UserRepository
+	tokenRepo domain.UserTokenRepository
+	mailer    domain.Mailer
+	audit     *audit.Recorder
+}
+
+func NewConfirmEmailChangeHandler(
+	userRepo domain.UserRepository,
+	tokenRepo domain.UserTokenRepository,
+	mailer domain.Mailer,
+	recorder *audit.Recorder,
+) *confirmEmailChangeHandler {
+	return &confirmEmailChangeHandler{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		mailer:    mailer,
+		audit:     recorder,
+	}
+}
+
+// Handle меняет email на адрес из письма и уведомляет прежний адрес
+func (h *confirmEmailChangeHandler) Handle(ctx context.Context, rawToken string) (err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventEmailChange}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	userToken, err := h.tokenRepo.ConsumeUserToken(ctx, domain.UserTokenEmailChange, token.HashOpaqueToken(rawToken))
+	if err != nil {
+		return err
+	}
+	event.UserID = userToken.UserID
+
+	user, err := h.userRepo.GetUserByID(ctx, userToken.UserID)
+	if err != nil {
+		return err
+	}
+	event.Details = map[string]string{"from": user.Email, "to": userToken.Payload}
+
+	// Переход по ссылке из письма подтверждает новый адрес
+	if err := h.userRepo.UpdateEmail(ctx, user.ID, userToken.Payload, time.Now()); err != nil {
+		return err
+	}
+
+	return h.mailer.Send(ctx, &domain.Mail{
+		To:      user.Email,
+		Subject: "Email учетной записи MarketAI изменен",
+		Body: fmt.Sprintf("Email вашей учетной записи изменен на %s. "+
+			"Если это были не вы, восстановите доступ и обратитесь в поддержку.\n", userToken.Payload),
+	})
+}
+
+type DeleteAccountCommand struct {
+	UserID   string
+	Password string
+}
+
+type DeleteAccountHandler interface {
+	Handle(ctx context.Context, cmd DeleteAccountCommand) error
+}
+
+type deleteAccountHandler struct {
+	userRepo domain.UserRepository
+	issuer   *token.Issuer
+	denylist *token.Denylist
+	hasher   *passwords.Hasher
+	audit    *audit.Recorder
+}
+
+func NewDeleteAccountHandler(
+	userRepo domain.UserRepository,
+	issuer *token.Issuer,
+	denylist *token.Denylist,
+	hasher *passwords.Hasher,
+	recorder *audit.Recorder,
+) *deleteAccountHandler {
+	return &deleteAccountHandler{
+		userRepo: userRepo,
+		issuer:   issuer,
+		denylist: denylist,
+		hasher:   hasher,
+		audit:    recorder,
+	}
+}
+
+// Handle удаляет учетную запись с обезличиванием персональных данных и завершает все сессии
+func (h *deleteAccountHandler) Handle(ctx context.Context, cmd DeleteAccountCommand) (err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventAccountDelete, UserID: cmd.UserID}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	user, err := h.userRepo.GetUserByID(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if ok, _ := h.hasher.Verify(user.PasswordHash, cmd.Password); !ok {
+		return domain.ErrWrongPassword
+	}
+
+	if err := h.userRepo.AnonymizeUser(ctx, user.ID, time.Now()); err != nil {
+		return err
+	}
+
+	if err := h.issuer.RevokeAll(ctx, user.ID); err != nil {
+		return err
+	}
+	return h.denylist.RevokeUser(ctx, user.ID)
+}
@@ -0,0 +1,189 @@
+package command
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/token"
+	domain "marketai/auth/internal/domain"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,50}$`)
+
+type SaveRoleCommand struct {
+	Name        string
+	Description string
+	Permissions []string
+	// RequireTwoFactor - обязательный второй фактор для пользователей роли
+	RequireTwoFactor bool
+	AdminID          string
+}
+
+type SaveRoleHandler interface {
+	Handle(ctx context.Context, cmd SaveRoleCommand) (*domain.Role, error)
+}
+
+type saveRoleHandler struct {
+	rbacRepo  domain.RBACRepository
+	adminRepo domain.AdminRepository
+}
+
+func NewSaveRoleHandler(rbacRepo domain.RBACRepository, adminRepo domain.AdminRepository) *saveRoleHandler {
+	return &saveRoleHandler{
+		rbacRepo:  rbacRepo,
+		adminRepo: adminRepo,
+	}
+}
+
+// Handle создает роль или заменяет набор ее прав. Новые права попадают в токены
+// пользователей при следующем обновлении токена.
+func (h *saveRoleHandler) Handle(ctx context.Context, cmd SaveRoleCommand) (*domain.Role, error) {
+	if !roleNamePattern.MatchString(cmd.Name) {
+		return nil, domain.ErrInvalidRoleName
+	}
+
+	role := &domain.Role{
+		Name:             cmd.Name,
+		Description:      strings.TrimSpace(cmd.Description),
+		Permissions:      uniqueSorted(cmd.Permissions),
+		RequireTwoFactor: cmd.RequireTwoFactor,
+	}
+	if err := h.rbacRepo.SaveRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	err := adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionSaveRole, "", map[string]string{
+		"role":               role.Name,
+		"permissions":        strings.Join(role.Permissions, " "),
+		"require_two_factor": strconv.FormatBool(role.RequireTwoFactor),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+type DeleteRoleCommand struct {
+	Name    string
+	AdminID string
+}
+
+type DeleteRoleHandler interface {
+	Handle(ctx context.Context, cmd DeleteRoleCommand) error
+}
+
+type deleteRoleHandler struct {
+	rbacRepo  domain.RBACRepository
+	adminRepo domain.AdminRepository
+}
+
+func NewDeleteRoleHandler(rbacRepo domain.RBACRepository, adminRepo domain.AdminRepository) *deleteRoleHandler {
+	return &deleteRoleHandler{
+		rbacRepo:  rbacRepo,
+		adminRepo: adminRepo,
+	}
+}
+
+func (h *deleteRoleHandler) Handle(ctx context.Context, cmd DeleteRoleCommand) error {
+	if err := h.rbacRepo.DeleteRole(ctx, cmd.Name); err != nil {
+		return err
+	}
+	return adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionDeleteRole, "",
+		map[string]string{"role": cmd.Name})
+}
+
+type SetUserRolesCommand struct {
+	UserID string
+	Roles  []string
+	// AdminID - кто назначил роли
+	AdminID string
+}
+
+type SetUserRolesHandler interface {
+	Handle(ctx context.Context, cmd SetUserRolesCommand) (*domain.Access, error)
+}
+
+type setUserRolesHandler struct {
+	userRepo  domain.UserRepository
+	rbacRepo  domain.RBACRepository
+	adminRepo domain.AdminRepository
+	denylist  *token.Denylist
+	audit     *audit.Recorder
+}
+
+func NewSetUserRolesHandler(
+	userRepo domain.UserRepository,
+	rbacRepo domain.RBACRepository,
+	adminRepo domain.AdminRepository,
+	denylist *token.Denylist,
+	recorder *audit.Recorder,
+) *setUserRolesHandler {
+	return &setUserRolesHandler{
+		userRepo:  userRepo,
+		rbacRepo:  rbacRepo,
+		adminRepo: adminRepo,
+		denylist:  denylist,
+		audit:     recorder,
+	}
+}
+
+// Handle заменяет роли пользователя. Выданные ему токены доступа отзываются,
+// чтобы новые права действовали сразу: клиент получит новый токен через /refresh.
+func (h *setUserRolesHandler) Handle(ctx context.Context, cmd SetUserRolesCommand) (_ *domain.Access, err error) {
+	roles := uniqueSorted(cmd.Roles)
+	event := &domain.SecurityEvent{
+		Type:    domain.SecurityEventRolesChange,
+		UserID:  cmd.UserID,
+		ActorID: cmd.AdminID,
+		Details: map[string]string{"roles": strings.Join(roles, " ")},
+	}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	if len(roles) == 0 {
+		return nil, domain.ErrRolesRequired
+	}
+
+	if _, err := h.userRepo.GetUserByID(ctx, cmd.UserID); err != nil {
+		return nil, err
+	}
+	current, err := h.rbacRepo.GetUserAccess(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.rbacRepo.SetUserRoles(ctx, cmd.UserID, roles, cmd.AdminID); err != nil {
+		return nil, err
+	}
+	if err := h.denylist.RevokeUser(ctx, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	err = adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionSetRoles, cmd.UserID, map[string]string{
+		"before": strings.Join(current.Roles, " "),
+		"after":  strings.Join(roles, " "),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h.rbacRepo.GetUserAccess(ctx, cmd.UserID)
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if _, ok := seen[value]; ok || value == "" {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
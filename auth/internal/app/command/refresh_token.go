@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"marketai/auth/internal/app/token"
+	domain "marketai/auth/internal/domain"
+	"marketai/pkgAuth/jwt"
+)
+
+type RefreshTokenResult struct {
+	Token        string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	Workspace    *domain.Membership
+}
+
+type RefreshTokenHandler interface {
+	Handle(ctx context.Context, refreshToken string) (*RefreshTokenResult, error)
+}
+
+type refreshTokenHandler struct {
+	issuer *token.Issuer
+}
+
+func NewRefreshTokenHandler(issuer *token.Issuer) *refreshTokenHandler {
+	return &refreshTokenHandler{issuer: issuer}
+}
+
+// Handle ротирует refresh токен: старый отзывается, выдается новая пара
+func (h *refreshTokenHandler) Handle(ctx context.Context, refreshToken string) (*RefreshTokenResult, error) {
+	pair, err := h.issuer.Refresh(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefreshTokenResult{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+		Workspace:    pair.Membership,
+	}, nil
+}
+
+type LogoutCommand struct {
+	RefreshToken string
+	// Access - claims токена доступа, если клиент его передал; токен отзывается вместе с сессией
+	Access *jwt.Claims
+}
+
+type LogoutHandler interface {
+	Handle(ctx context.Context, cmd LogoutCommand) error
+}
+
+type logoutHandler struct {
+	issuer   *token.Issuer
+	denylist *token.Denylist
+}
+
+func NewLogoutHandler(issuer *token.Issuer, denylist *token.Denylist) *logoutHandler {
+	return &logoutHandler{
+		issuer:   issuer,
+		denylist: denylist,
+	}
+}
+
+// Handle завершает сессию, к которой относится refresh токен
+func (h *logoutHandler) Handle(ctx context.Context, cmd LogoutCommand) error {
+	if err := h.issuer.Revoke(ctx, cmd.RefreshToken); err != nil {
+		return err
+	}
+
+	if cmd.Access == nil {
+		return nil
+	}
+	return h.denylist.Revoke(ctx, cmd.Access)
+}
+
+type LogoutAllHandler interface {
+	Handle(ctx context.Context, claims *jwt.Claims) error
+}
+
+type logoutAllHandler struct {
+	issuer   *token.Issuer
+	denylist *token.Denylist
+}
+
+func NewLogoutAllHandler(issuer *token.Issuer, denylist *token.Denylist) *logoutAllHandler {
+	return &logoutAllHandler{
+		issuer:   issuer,
+		denylist: denylist,
+	}
+}
+
+// Handle завершает все сессии пользователя: отзывает refresh токены и все
+// выпущенные ранее токены доступа, включая текущий
+func (h *logoutAllHandler) Handle(ctx context.Context, claims *jwt.Claims) error {
+	if err := h.issuer.RevokeAll(ctx, claims.UserID); err != nil {
+		return err
+	}
+	if err := h.denylist.RevokeUser(ctx, claims.UserID); err != nil {
+		return err
+	}
+	return h.denylist.Revoke(ctx, claims)
+}
@@ -4,80 +4,148 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"marketai/auth/internal/config"
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/passwords"
+	"marketai/auth/internal/app/token"
+	"strings"
 	"time"
 
-	"github.com/jackc/pgx/v5"
-
-	"golang.org/x/crypto/bcrypt"
-
 	domain "marketai/auth/internal/domain"
-	"marketai/pkgAuth/jwt"
 )
 
+type RegisterUserCommand struct {
+	FullName    string
+	Email       string
+	PhoneNumber string
+	Password    string
+}
+
 type RegisterUserCommandResult struct {
-	UserID string
-	Token  string
-	User   *domain.User
+	UserID       string
+	Token        string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	User         *domain.User
+	Workspace    *domain.Membership
 }
 
 type RegisterCommandHandler interface {
-	Handle(ctx context.Context, cmd domain.User) (*RegisterUserCommandResult, error)
+	Handle(ctx context.Context, cmd RegisterUserCommand) (*RegisterUserCommandResult, error)
 }
 
 type registerUserCommandHandler struct {
 	pgRepo domain.UserRepository
-	cfg    *config.Config
+	issuer *token.Issuer
+	hasher *passwords.Hasher
+	policy *passwords.Policy
+	audit  *audit.Recorder
 }
 
 func NewRegisterUserCommandHandler(
 	userRepo domain.UserRepository,
-	cfg *config.Config,
+	issuer *token.Issuer,
+	hasher *passwords.Hasher,
+	policy *passwords.Policy,
+	recorder *audit.Recorder,
 ) *registerUserCommandHandler {
 	return &registerUserCommandHandler{
 		pgRepo: userRepo,
-		cfg:    cfg,
+		issuer: issuer,
+		hasher: hasher,
+		policy: policy,
+		audit:  recorder,
 	}
 }
 
-func (h *registerUserCommandHandler) Handle(ctx context.Context, cmd domain.User) (*RegisterUserCommandResult, error) {
-	existingUser, err := h.pgRepo.GetUserByUsername(ctx, cmd.Email, cmd.PhoneNumber)
+// Handle регистрирует пользователя. Занятые email или номер телефона
+// возвращают ErrEmailTaken и ErrPhoneTaken, пароль проверяется политикой паролей.
+func (h *registerUserCommandHandler) Handle(ctx context.Context, cmd RegisterUserCommand) (_ *RegisterUserCommandResult, err error) {
+	email := strings.ToLower(strings.TrimSpace(cmd.Email))
+	phone := strings.TrimSpace(cmd.PhoneNumber)
+	event := &domain.SecurityEvent{Type: domain.SecurityEventRegister, Login: email}
+	defer func() { h.audit.Record(ctx, event, err) }()
 
-	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+	if err := h.checkAvailable(ctx, email, phone); err != nil {
 		return nil, err
 	}
-	if existingUser != nil {
-		return nil, errors.New("пользователь с таким email уже существует")
-	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(cmd.PasswordHash), bcrypt.DefaultCost)
+	if err := h.policy.Check(ctx, cmd.Password, email); err != nil {
+		return nil, err
+	}
+	hashedPassword, err := h.hasher.Hash(cmd.Password)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при хешировании пароля: %w", err)
+		return nil, err
 	}
 
 	newUser := &domain.User{
-		FullName:     cmd.FullName,
-		Email:        cmd.Email,
-		PasswordHash: string(hashedPassword),
-		PhoneNumber:  cmd.PhoneNumber,
-		Role:         cmd.Role,
+		FullName:     strings.TrimSpace(cmd.FullName),
+		Email:        email,
+		PasswordHash: hashedPassword,
+		PhoneNumber:  phone,
+		Role:         domain.RoleUser,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
-	if err := h.pgRepo.CreateUser(ctx, newUser); err != nil {
+	// Каждый пользователь получает личное рабочее пространство, в котором он владелец
+	workspace := &domain.Workspace{
+		Name:      personalWorkspaceName(newUser),
+		CreatedAt: newUser.CreatedAt,
+		UpdatedAt: newUser.UpdatedAt,
+	}
+	// Email или телефон мог занять параллельный запрос: репозиторий вернет ErrEmailTaken или ErrPhoneTaken
+	if err := h.pgRepo.CreateUserWithWorkspace(ctx, newUser, workspace); errors.Is(err, domain.ErrEmailTaken) ||
+		errors.Is(err, domain.ErrPhoneTaken) {
+		return nil, err
+	} else if err != nil {
 		return nil, fmt.Errorf("ошибка при сохранении пользователя: %w", err)
 	}
+	membership := &domain.Membership{Workspace: workspace, Role: domain.WorkspaceRoleOwner}
+	event.UserID = newUser.ID
 
-	// Генерируем JWT токен для нового пользователя
-	token, err := jwt.GenerateToken(newUser.ID, newUser.Role, h.cfg.JWTSecret, time.Hour*24)
+	// Выпускаем пару токенов для нового пользователя
+	pair, err := h.issuer.Issue(ctx, newUser, membership)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при генерации JWT токена: %w", err)
+		return nil, err
 	}
 
 	return &RegisterUserCommandResult{
-		UserID: newUser.ID,
-		Token:  token,
-		User:   newUser,
+		UserID:       newUser.ID,
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+		User:         newUser,
+		Workspace:    membership,
 	}, nil
 }
+
+// checkAvailable проверяет, что email и номер телефона не заняты: по ним
+// выполняется вход, поэтому они должны однозначно указывать на пользователя
+func (h *registerUserCommandHandler) checkAvailable(ctx context.Context, email, phone string) error {
+	owner, err := h.pgRepo.GetUserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+	if owner != nil {
+		return domain.ErrEmailTaken
+	}
+
+	if phone == "" {
+		return nil
+	}
+	owner, err = h.pgRepo.GetUserByPhone(ctx, phone)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+	if owner != nil {
+		return domain.ErrPhoneTaken
+	}
+	return nil
+}
+
+func personalWorkspaceName(user *domain.User) string {
+	if user.FullName != "" {
+		return user.FullName
+	}
+	return user.Email
+}
@@ -0,0 +1,30 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+type PurgeSecurityEventsHandler interface {
+	// Handle удаляет события старше срока хранения и возвращает их число
+	Handle(ctx context.Context) (int64, error)
+}
+
+type purgeSecurityEventsHandler struct {
+	purger    domain.SecurityEventPurger
+	retention time.Duration
+}
+
+func NewPurgeSecurityEventsHandler(purger domain.SecurityEventPurger, cfg *config.Config) *purgeSecurityEventsHandler {
+	return &purgeSecurityEventsHandler{purger: purger, retention: cfg.Audit.Retention}
+}
+
+func (h *purgeSecurityEventsHandler) Handle(ctx context.Context) (int64, error) {
+	if h.retention <= 0 {
+		return 0, nil
+	}
+	return h.purger.DeleteSecurityEventsBefore(ctx, time.Now().Add(-h.retention))
+}
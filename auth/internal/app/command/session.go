@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/token"
+	domain "marketai/auth/internal/domain"
+)
+
+type RevokeSessionCommand struct {
+	UserID    string
+	SessionID string
+}
+
+type RevokeSessionHandler interface {
+	Handle(ctx context.Context, cmd RevokeSessionCommand) error
+}
+
+type revokeSessionHandler struct {
+	sessionRepo domain.SessionRepository
+	denylist    *token.Denylist
+	audit       *audit.Recorder
+}
+
+func NewRevokeSessionHandler(
+	sessionRepo domain.SessionRepository,
+	denylist *token.Denylist,
+	recorder *audit.Recorder,
+) *revokeSessionHandler {
+	return &revokeSessionHandler{sessionRepo: sessionRepo, denylist: denylist, audit: recorder}
+}
+
+// Handle завершает сессию пользователя: ее refresh токены отзываются сразу,
+// токены доступа с claim sid перестают проходить проверку в /validate и
+// ValidateToken, как только экземпляр сервиса обновит список отзыва
+func (h *revokeSessionHandler) Handle(ctx context.Context, cmd RevokeSessionCommand) (err error) {
+	event := &domain.SecurityEvent{
+		Type:    domain.SecurityEventSessionRevoke,
+		UserID:  cmd.UserID,
+		Details: map[string]string{"session_id": cmd.SessionID},
+	}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	if uuid.Validate(cmd.SessionID) != nil {
+		return domain.ErrSessionNotFound
+	}
+	if err := h.sessionRepo.RevokeSession(ctx, cmd.UserID, cmd.SessionID, time.Now()); err != nil {
+		return err
+	}
+
+	h.denylist.RevokeSession(cmd.SessionID)
+	return nil
+}
+
+type PurgeSessionsHandler interface {
+	// Handle удаляет завершенные и истекшие сессии и возвращает их количество
+	Handle(ctx context.Context) (int64, error)
+}
+
+type purgeSessionsHandler struct {
+	sessionRepo domain.SessionRepository
+	issuer      *token.Issuer
+}
+
+func NewPurgeSessionsHandler(sessionRepo domain.SessionRepository, issuer *token.Issuer) *purgeSessionsHandler {
+	return &purgeSessionsHandler{sessionRepo: sessionRepo, issuer: issuer}
+}
+
+// Handle удаляет сессии, закончившиеся раньше, чем истекли бы все их токены
+// доступа: более свежие завершенные сессии еще нужны списку отзыва
+func (h *purgeSessionsHandler) Handle(ctx context.Context) (int64, error) {
+	return h.sessionRepo.DeleteEndedSessions(ctx, time.Now().Add(-h.issuer.MaxAccessTTL()))
+}
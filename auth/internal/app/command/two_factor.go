@@ -0,0 +1,365 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/app/lockout"
+	"marketai/auth/internal/app/passwords"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/app/twofactor"
+	domain "marketai/auth/internal/domain"
+)
+
+type CompleteTwoFactorLoginCommand struct {
+	ChallengeToken string
+	Code           string
+}
+
+type CompleteTwoFactorLoginHandler interface {
+	Handle(ctx context.Context, cmd CompleteTwoFactorLoginCommand) (*LoginResult, error)
+}
+
+type completeTwoFactorLoginHandler struct {
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	twoFactor     *twofactor.Service
+	guard         *lockout.Guard
+	issuer        *token.Issuer
+	audit         *audit.Recorder
+}
+
+func NewCompleteTwoFactorLoginHandler(
+	userRepo domain.UserRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	twoFactor *twofactor.Service,
+	guard *lockout.Guard,
+	issuer *token.Issuer,
+	recorder *audit.Recorder,
+) *completeTwoFactorLoginHandler {
+	return &completeTwoFactorLoginHandler{
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		twoFactor:     twoFactor,
+		guard:         guard,
+		issuer:        issuer,
+		audit:         recorder,
+	}
+}
+
+// Handle завершает вход кодом второго фактора. Если второй фактор обязателен,
+// но не был подключен, код подтверждает подключение, начатое через
+// EnrollTwoFactor, и в результат попадают резервные коды. Попытки ограничены
+// на вход и на пользователя по всем его входам.
+func (h *completeTwoFactorLoginHandler) Handle(ctx context.Context, cmd CompleteTwoFactorLoginCommand) (_ *LoginResult, err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventLoginTwoFactor}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	challenge, err := h.twoFactor.Pending(ctx, cmd.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	event.UserID = challenge.UserID
+	if challenge.Enrollment {
+		event.Details = map[string]string{"enrollment": "true"}
+	}
+
+	attempts, err := h.twoFactor.ReserveAttempt(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.guard.ReserveTwoFactor(ctx, challenge.UserID); err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	if challenge.Enrollment {
+		recoveryCodes, err = h.twoFactor.Confirm(ctx, challenge.UserID, cmd.Code)
+	} else {
+		err = h.twoFactor.Verify(ctx, challenge.UserID, cmd.Code)
+	}
+	if errors.Is(err, domain.ErrTwoFactorCodeInvalid) {
+		if err := h.guard.FailTwoFactor(ctx, challenge.UserID, challenge.ClientIP); err != nil {
+			return nil, fmt.Errorf("ошибка при учете неверного кода: %w", err)
+		}
+		return nil, h.twoFactor.Fail(attempts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.twoFactor.Complete(ctx, challenge); err != nil {
+		return nil, err
+	}
+	if err := h.guard.SucceedTwoFactor(ctx, challenge.UserID); err != nil {
+		return nil, fmt.Errorf("ошибка при сбросе счетчика второго фактора: %w", err)
+	}
+	// Вход по паролю считается успешным только после второго фактора
+	if challenge.Login != "" {
+		if err := h.guard.Succeed(ctx, challenge.Login, challenge.ClientIP); err != nil {
+			return nil, fmt.Errorf("ошибка при сбросе счетчика входа: %w", err)
+		}
+	}
+
+	user, err := h.userRepo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := loginMembership(ctx, h.workspaceRepo, user.ID, challenge.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := h.issuer.Issue(ctx, user, membership)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newLoginResult(pair, user, membership)
+	result.RecoveryCodes = recoveryCodes
+	return result, nil
+}
+
+type EnrollTwoFactorHandler interface {
+	Handle(ctx context.Context, challengeToken string) (*twofactor.Setup, error)
+}
+
+type enrollTwoFactorHandler struct {
+	userRepo  domain.UserRepository
+	twoFactor *twofactor.Service
+}
+
+func NewEnrollTwoFactorHandler(userRepo domain.UserRepository, twoFactor *twofactor.Service) *enrollTwoFactorHandler {
+	return &enrollTwoFactorHandler{userRepo: userRepo, twoFactor: twoFactor}
+}
+
+// Handle начинает подключение второго фактора во время входа, когда он
+// обязателен: без этого пользователь не смог бы войти, чтобы его подключить
+func (h *enrollTwoFactorHandler) Handle(ctx context.Context, challengeToken string) (*twofactor.Setup, error) {
+	challenge, err := h.twoFactor.Pending(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.Enrollment {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	user, err := h.userRepo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return h.twoFactor.StartSetup(ctx, user)
+}
+
+type StartTwoFactorSetupHandler interface {
+	Handle(ctx context.Context, userID string) (*twofactor.Setup, error)
+}
+
+type startTwoFactorSetupHandler struct {
+	userRepo  domain.UserRepository
+	twoFactor *twofactor.Service
+}
+
+func NewStartTwoFactorSetupHandler(userRepo domain.UserRepository, twoFactor *twofactor.Service) *startTwoFactorSetupHandler {
+	return &startTwoFactorSetupHandler{userRepo: userRepo, twoFactor: twoFactor}
+}
+
+func (h *startTwoFactorSetupHandler) Handle(ctx context.Context, userID string) (*twofactor.Setup, error) {
+	user, err := h.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return h.twoFactor.StartSetup(ctx, user)
+}
+
+type ConfirmTwoFactorCommand struct {
+	UserID string
+	Code   string
+}
+
+type ConfirmTwoFactorHandler interface {
+	Handle(ctx context.Context, cmd ConfirmTwoFactorCommand) ([]string, error)
+}
+
+type confirmTwoFactorHandler struct {
+	twoFactor *twofactor.Service
+	audit     *audit.Recorder
+}
+
+func NewConfirmTwoFactorHandler(twoFactor *twofactor.Service, recorder *audit.Recorder) *confirmTwoFactorHandler {
+	return &confirmTwoFactorHandler{twoFactor: twoFactor, audit: recorder}
+}
+
+// Handle включает второй фактор и возвращает резервные коды
+func (h *confirmTwoFactorHandler) Handle(ctx context.Context, cmd ConfirmTwoFactorCommand) ([]string, error) {
+	codes, err := h.twoFactor.Confirm(ctx, cmd.UserID, cmd.Code)
+	h.audit.Record(ctx, &domain.SecurityEvent{Type: domain.SecurityEventTwoFactorEnable, UserID: cmd.UserID}, err)
+	return codes, err
+}
+
+type DisableTwoFactorCommand struct {
+	UserID   string
+	Password string
+	Code     string
+}
+
+type DisableTwoFactorHandler interface {
+	Handle(ctx context.Context, cmd DisableTwoFactorCommand) error
+}
+
+type disableTwoFactorHandler struct {
+	userRepo  domain.UserRepository
+	twoFactor *twofactor.Service
+	hasher    *passwords.Hasher
+	audit     *audit.Recorder
+}
+
+func NewDisableTwoFactorHandler(
+	userRepo domain.UserRepository,
+	twoFactor *twofactor.Service,
+	hasher *passwords.Hasher,
+	recorder *audit.Recorder,
+) *disableTwoFactorHandler {
+	return &disableTwoFactorHandler{userRepo: userRepo, twoFactor: twoFactor, hasher: hasher, audit: recorder}
+}
+
+// Handle отключает второй фактор по паролю и коду. Если второй фактор
+// требует роль или рабочее пространство, отключить его нельзя.
+func (h *disableTwoFactorHandler) Handle(ctx context.Context, cmd DisableTwoFactorCommand) (err error) {
+	event := &domain.SecurityEvent{Type: domain.SecurityEventTwoFactorDisable, UserID: cmd.UserID}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	user, err := h.userRepo.GetUserByID(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if ok, _ := h.hasher.Verify(user.PasswordHash, cmd.Password); !ok {
+		return domain.ErrWrongPassword
+	}
+
+	required, err := h.twoFactor.Required(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return domain.ErrTwoFactorRequired
+	}
+
+	if err := h.twoFactor.Verify(ctx, user.ID, cmd.Code); err != nil {
+		return err
+	}
+	return h.twoFactor.Disable(ctx, user.ID)
+}
+
+type RegenerateRecoveryCodesCommand struct {
+	UserID string
+	Code   string
+}
+
+type RegenerateRecoveryCodesHandler interface {
+	Handle(ctx context.Context, cmd RegenerateRecoveryCodesCommand) ([]string, error)
+}
+
+type regenerateRecoveryCodesHandler struct {
+	twoFactor *twofactor.Service
+}
+
+func NewRegenerateRecoveryCodesHandler(twoFactor *twofactor.Service) *regenerateRecoveryCodesHandler {
+	return &regenerateRecoveryCodesHandler{twoFactor: twoFactor}
+}
+
+// Handle заменяет резервные коды новыми, старые перестают действовать
+func (h *regenerateRecoveryCodesHandler) Handle(ctx context.Context, cmd RegenerateRecoveryCodesCommand) ([]string, error) {
+	if err := h.twoFactor.Verify(ctx, cmd.UserID, cmd.Code); err != nil {
+		return nil, err
+	}
+	return h.twoFactor.RegenerateRecoveryCodes(ctx, cmd.UserID)
+}
+
+type SetWorkspaceTwoFactorCommand struct {
+	WorkspaceID string
+	ActorID     string
+	Required    bool
+}
+
+type SetWorkspaceTwoFactorHandler interface {
+	Handle(ctx context.Context, cmd SetWorkspaceTwoFactorCommand) error
+}
+
+type setWorkspaceTwoFactorHandler struct {
+	workspaceRepo domain.WorkspaceRepository
+	twoFactorRepo domain.TwoFactorRepository
+}
+
+func NewSetWorkspaceTwoFactorHandler(
+	workspaceRepo domain.WorkspaceRepository,
+	twoFactorRepo domain.TwoFactorRepository,
+) *setWorkspaceTwoFactorHandler {
+	return &setWorkspaceTwoFactorHandler{workspaceRepo: workspaceRepo, twoFactorRepo: twoFactorRepo}
+}
+
+// Handle включает или выключает обязательный второй фактор для участников
+// пространства. Участники без второго фактора подключат его при следующем входе.
+func (h *setWorkspaceTwoFactorHandler) Handle(ctx context.Context, cmd SetWorkspaceTwoFactorCommand) error {
+	if err := requireOwner(ctx, h.workspaceRepo, cmd.WorkspaceID, cmd.ActorID); err != nil {
+		return err
+	}
+	return h.twoFactorRepo.SetWorkspaceTwoFactor(ctx, cmd.WorkspaceID, cmd.Required)
+}
+
+type ResetTwoFactorCommand struct {
+	UserID  string
+	AdminID string
+}
+
+type ResetTwoFactorHandler interface {
+	Handle(ctx context.Context, cmd ResetTwoFactorCommand) error
+}
+
+type resetTwoFactorHandler struct {
+	userRepo  domain.UserRepository
+	adminRepo domain.AdminRepository
+	twoFactor *twofactor.Service
+	audit     *audit.Recorder
+}
+
+func NewResetTwoFactorHandler(
+	userRepo domain.UserRepository,
+	adminRepo domain.AdminRepository,
+	twoFactor *twofactor.Service,
+	recorder *audit.Recorder,
+) *resetTwoFactorHandler {
+	return &resetTwoFactorHandler{userRepo: userRepo, adminRepo: adminRepo, twoFactor: twoFactor, audit: recorder}
+}
+
+// Handle сбрасывает второй фактор пользователя, потерявшего устройство и
+// резервные коды. Если второй фактор обязателен, пользователь подключит
+// его заново при следующем входе.
+func (h *resetTwoFactorHandler) Handle(ctx context.Context, cmd ResetTwoFactorCommand) error {
+	if cmd.UserID == cmd.AdminID {
+		return domain.ErrSelfAdminAction
+	}
+	if _, err := h.userRepo.GetUserByID(ctx, cmd.UserID); err != nil {
+		return err
+	}
+
+	enabled, err := h.twoFactor.Enabled(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	err = h.twoFactor.Disable(ctx, cmd.UserID)
+	h.audit.Record(ctx, &domain.SecurityEvent{
+		Type:    domain.SecurityEventTwoFactorDisable,
+		UserID:  cmd.UserID,
+		ActorID: cmd.AdminID,
+	}, err)
+	if err != nil {
+		return err
+	}
+
+	return adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionResetTwoFactor, cmd.UserID,
+		map[string]string{"was_enabled": strconv.FormatBool(enabled)})
+}
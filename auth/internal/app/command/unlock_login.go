@@ -0,0 +1,53 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"marketai/auth/internal/app/lockout"
+	domain "marketai/auth/internal/domain"
+)
+
+type UnlockLoginCommand struct {
+	// Login - email или телефон, IP - адрес. Задается одно из двух
+	Login string
+	IP    string
+	// AdminID - кто снял блокировку, сохраняется в аудите
+	AdminID string
+}
+
+type UnlockLoginHandler interface {
+	Handle(ctx context.Context, cmd UnlockLoginCommand) error
+}
+
+type unlockLoginHandler struct {
+	repo      domain.LoginAttemptRepository
+	adminRepo domain.AdminRepository
+}
+
+func NewUnlockLoginHandler(repo domain.LoginAttemptRepository, adminRepo domain.AdminRepository) *unlockLoginHandler {
+	return &unlockLoginHandler{
+		repo:      repo,
+		adminRepo: adminRepo,
+	}
+}
+
+// Handle снимает блокировку входа и сбрасывает счетчик неудач
+func (h *unlockLoginHandler) Handle(ctx context.Context, cmd UnlockLoginCommand) error {
+	var key string
+	switch {
+	case cmd.Login != "" && cmd.IP == "":
+		key = lockout.AccountKey(cmd.Login)
+	case cmd.IP != "" && cmd.Login == "":
+		key = lockout.IPKey(cmd.IP)
+	default:
+		return errors.New("укажите логин или IP адрес")
+	}
+
+	if err := h.repo.UnlockLogin(ctx, key, cmd.AdminID, time.Now()); err != nil {
+		return err
+	}
+	return adminAudit{h.adminRepo}.record(ctx, cmd.AdminID, domain.AdminActionUnlockLogin, "",
+		map[string]string{"key": key})
+}
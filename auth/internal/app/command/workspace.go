@@ -0,0 +1,203 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type CreateWorkspaceCommand struct {
+	UserID string
+	Name   string
+}
+
+type CreateWorkspaceHandler interface {
+	Handle(ctx context.Context, cmd CreateWorkspaceCommand) (*domain.Membership, error)
+}
+
+type AddMemberCommand struct {
+	WorkspaceID string
+	// ActorID - пользователь, выполняющий действие
+	ActorID string
+	Email   string
+	Role    domain.WorkspaceRole
+}
+
+type AddMemberHandler interface {
+	Handle(ctx context.Context, cmd AddMemberCommand) (*domain.WorkspaceMember, error)
+}
+
+type UpdateMemberRoleCommand struct {
+	WorkspaceID string
+	ActorID     string
+	UserID      string
+	Role        domain.WorkspaceRole
+}
+
+type UpdateMemberRoleHandler interface {
+	Handle(ctx context.Context, cmd UpdateMemberRoleCommand) error
+}
+
+type RemoveMemberCommand struct {
+	WorkspaceID string
+	ActorID     string
+	UserID      string
+}
+
+type RemoveMemberHandler interface {
+	Handle(ctx context.Context, cmd RemoveMemberCommand) error
+}
+
+type createWorkspaceHandler struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewCreateWorkspaceHandler(workspaceRepo domain.WorkspaceRepository) *createWorkspaceHandler {
+	return &createWorkspaceHandler{workspaceRepo: workspaceRepo}
+}
+
+func (h *createWorkspaceHandler) Handle(ctx context.Context, cmd CreateWorkspaceCommand) (*domain.Membership, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return nil, errors.New("название рабочего пространства не может быть пустым")
+	}
+
+	now := time.Now()
+	workspace := &domain.Workspace{
+		Name:      name,
+		OwnerID:   cmd.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := h.workspaceRepo.CreateWorkspace(ctx, workspace); err != nil {
+		return nil, fmt.Errorf("ошибка при создании рабочего пространства: %w", err)
+	}
+
+	return &domain.Membership{Workspace: workspace, Role: domain.WorkspaceRoleOwner}, nil
+}
+
+type addMemberHandler struct {
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewAddMemberHandler(userRepo domain.UserRepository, workspaceRepo domain.WorkspaceRepository) *addMemberHandler {
+	return &addMemberHandler{
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+func (h *addMemberHandler) Handle(ctx context.Context, cmd AddMemberCommand) (*domain.WorkspaceMember, error) {
+	if !cmd.Role.Valid() {
+		return nil, domain.ErrInvalidWorkspaceRole
+	}
+	if err := requireOwner(ctx, h.workspaceRepo, cmd.WorkspaceID, cmd.ActorID); err != nil {
+		return nil, err
+	}
+
+	user, err := h.userRepo.GetUserByEmail(ctx, strings.TrimSpace(cmd.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	member := &domain.WorkspaceMember{
+		WorkspaceID: cmd.WorkspaceID,
+		UserID:      user.ID,
+		Email:       user.Email,
+		FullName:    user.FullName,
+		Role:        cmd.Role,
+		CreatedAt:   time.Now(),
+	}
+	if err := h.workspaceRepo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+type updateMemberRoleHandler struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewUpdateMemberRoleHandler(workspaceRepo domain.WorkspaceRepository) *updateMemberRoleHandler {
+	return &updateMemberRoleHandler{workspaceRepo: workspaceRepo}
+}
+
+func (h *updateMemberRoleHandler) Handle(ctx context.Context, cmd UpdateMemberRoleCommand) error {
+	if !cmd.Role.Valid() {
+		return domain.ErrInvalidWorkspaceRole
+	}
+	if err := requireOwner(ctx, h.workspaceRepo, cmd.WorkspaceID, cmd.ActorID); err != nil {
+		return err
+	}
+
+	member, err := h.workspaceRepo.GetMembership(ctx, cmd.WorkspaceID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if member.Role == domain.WorkspaceRoleOwner && cmd.Role != domain.WorkspaceRoleOwner {
+		if err := ensureAnotherOwner(ctx, h.workspaceRepo, cmd.WorkspaceID); err != nil {
+			return err
+		}
+	}
+
+	return h.workspaceRepo.UpdateMemberRole(ctx, cmd.WorkspaceID, cmd.UserID, cmd.Role)
+}
+
+type removeMemberHandler struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewRemoveMemberHandler(workspaceRepo domain.WorkspaceRepository) *removeMemberHandler {
+	return &removeMemberHandler{workspaceRepo: workspaceRepo}
+}
+
+func (h *removeMemberHandler) Handle(ctx context.Context, cmd RemoveMemberCommand) error {
+	// Покинуть пространство может любой участник, удалить другого - только владелец
+	if cmd.ActorID != cmd.UserID {
+		if err := requireOwner(ctx, h.workspaceRepo, cmd.WorkspaceID, cmd.ActorID); err != nil {
+			return err
+		}
+	}
+
+	member, err := h.workspaceRepo.GetMembership(ctx, cmd.WorkspaceID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if member.Role == domain.WorkspaceRoleOwner {
+		if err := ensureAnotherOwner(ctx, h.workspaceRepo, cmd.WorkspaceID); err != nil {
+			return err
+		}
+	}
+
+	return h.workspaceRepo.RemoveMember(ctx, cmd.WorkspaceID, cmd.UserID)
+}
+
+// requireOwner проверяет роль по базе, а не по токену:
+// роль могли изменить после выдачи токена
+func requireOwner(ctx context.Context, repo domain.WorkspaceRepository, workspaceID, userID string) error {
+	membership, err := repo.GetMembership(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !membership.Role.CanManageMembers() {
+		return domain.ErrWorkspaceForbidden
+	}
+	return nil
+}
+
+func ensureAnotherOwner(ctx context.Context, repo domain.WorkspaceRepository, workspaceID string) error {
+	owners, err := repo.CountOwners(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return domain.ErrLastWorkspaceOwner
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"marketai/auth/internal/domain"
+)
+
+type ListUsersRequest struct {
+	// Query - поиск по email, имени или номеру телефона
+	Query string `query:"q" validate:"max=100"`
+	Role  string `query:"role" validate:"max=50"`
+	// Blocked - true только заблокированные, false только активные
+	Blocked  string `query:"blocked" validate:"omitempty,oneof=true false"`
+	Page     int    `query:"page" validate:"gte=0"`
+	PageSize int    `query:"page_size" validate:"gte=0,lte=100"`
+}
+
+type AdminUserResponse struct {
+	ID            string              `json:"id"`
+	Email         string              `json:"email"`
+	EmailVerified bool                `json:"email_verified"`
+	FullName      string              `json:"fullname"`
+	PhoneNumber   string              `json:"phoneNumber"`
+	Role          string              `json:"role"`
+	Roles         []string            `json:"roles,omitempty"`
+	Permissions   []string            `json:"permissions,omitempty"`
+	Workspaces    []WorkspaceResponse `json:"workspaces,omitempty"`
+	Blocked       bool                `json:"blocked"`
+	BlockedAt     *time.Time          `json:"blocked_at,omitempty"`
+	BlockedReason string              `json:"blocked_reason,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+type ListUsersResponse struct {
+	Users    []AdminUserResponse `json:"users"`
+	Total    int                 `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"page_size"`
+}
+
+type BlockUserRequest struct {
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+type ImpersonateRequest struct {
+	// Reason - зачем нужен вход, например номер обращения в поддержку
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type ImpersonateResponse struct {
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+type ListAdminActionsRequest struct {
+	AdminID  string `query:"admin_id"`
+	UserID   string `query:"user_id"`
+	Action   string `query:"action"`
+	Page     int    `query:"page" validate:"gte=0"`
+	PageSize int    `query:"page_size" validate:"gte=0,lte=100"`
+}
+
+type AdminActionsResponse struct {
+	Actions  []*domain.AdminActionRecord `json:"actions"`
+	Total    int                         `json:"total"`
+	Page     int                         `json:"page"`
+	PageSize int                         `json:"page_size"`
+}
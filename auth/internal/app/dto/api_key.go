@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"marketai/auth/internal/domain"
+)
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	// WorkspaceID - пространство ключа, по умолчанию пространство по умолчанию пользователя
+	WorkspaceID string     `json:"workspace_id"`
+	Scopes      []string   `json:"scopes" validate:"required,min=1"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type CreateAPIKeyResponse struct {
+	// Key - ключ целиком, показывается один раз
+	Key    string         `json:"key"`
+	APIKey *domain.APIKey `json:"api_key"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type UnlockLoginRequest struct {
+	// Login - email или телефон заблокированной учетной записи
+	Login string `json:"login"`
+	// IP - заблокированный адрес
+	IP string `json:"ip"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+type OAuthAuthorizeResponse struct {
+	// AuthorizationURL - страница входа провайдера, на которую нужно перенаправить пользователя
+	AuthorizationURL string `json:"authorization_url"`
+	// State - нужно сверить с параметром state при возврате от провайдера
+	State string `json:"state"`
+}
+
+type OAuthCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+	// DeviceID - параметр device_id из redirect VK ID
+	DeviceID string `json:"device_id"`
+}
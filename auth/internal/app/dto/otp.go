@@ -0,0 +1,12 @@
+package dto
+
+type RequestOTPRequest struct {
+	PhoneNumber string `json:"phoneNumber" validate:"required,e164"`
+}
+
+type VerifyOTPRequest struct {
+	PhoneNumber string `json:"phoneNumber" validate:"required,e164"`
+	Code        string `json:"code" validate:"required,numeric"`
+	// WorkspaceID - пространство для входа, по умолчанию личное пространство пользователя
+	WorkspaceID string `json:"workspace_id"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+type ProfileResponse struct {
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	FullName      string `json:"fullname"`
+	PhoneNumber   string `json:"phoneNumber"`
+	// PendingPhoneNumber - новый номер, ожидающий подтверждения кодом из SMS
+	PendingPhoneNumber string    `json:"pendingPhoneNumber,omitempty"`
+	Role               string    `json:"role"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// UpdateProfileRequest - незаданные поля не меняются
+type UpdateProfileRequest struct {
+	FullName    *string `json:"fullname" validate:"omitempty,max=50"`
+	PhoneNumber *string `json:"phoneNumber" validate:"omitempty,e164"`
+}
+
+type ConfirmPhoneRequest struct {
+	PhoneNumber string `json:"phoneNumber" validate:"required,e164"`
+	Code        string `json:"code" validate:"required,numeric"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
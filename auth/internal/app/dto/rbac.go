@@ -0,0 +1,18 @@
+package dto
+
+type SaveRoleRequest struct {
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions"`
+	// RequireTwoFactor - пользователи роли входят только со вторым фактором
+	RequireTwoFactor bool `json:"require_two_factor"`
+}
+
+type SetUserRolesRequest struct {
+	Roles []string `json:"roles" validate:"required,min=1"`
+}
+
+type UserRolesResponse struct {
+	UserID      string   `json:"user_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RefreshTokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn - время жизни токена доступа в секундах
+	ExpiresIn int64 `json:"expires_in"`
+}
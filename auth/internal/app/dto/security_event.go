@@ -0,0 +1,22 @@
+package dto
+
+import "marketai/auth/internal/domain"
+
+type ListSecurityEventsRequest struct {
+	UserID  string `query:"user_id"`
+	Type    string `query:"type" validate:"max=50"`
+	Outcome string `query:"outcome" validate:"omitempty,oneof=success failure"`
+	IP      string `query:"ip" validate:"omitempty,ip"`
+	// From, To - границы периода в формате RFC 3339
+	From     string `query:"from"`
+	To       string `query:"to"`
+	Page     int    `query:"page" validate:"gte=0"`
+	PageSize int    `query:"page_size" validate:"gte=0,lte=100"`
+}
+
+type SecurityEventsResponse struct {
+	Events   []*domain.SecurityEvent `json:"events"`
+	Total    int                     `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
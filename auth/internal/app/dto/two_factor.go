@@ -0,0 +1,42 @@
+package dto
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	// Code - код из приложения-аутентификатора или резервный код
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+type TwoFactorEnrollRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required,max=20"`
+}
+
+type TwoFactorSetupResponse struct {
+	// Secret - ключ для ручного ввода, если QR код не сканируется
+	Secret string `json:"secret"`
+	// ProvisioningURI - otpauth:// адрес для QR кода
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	// RecoveryCodes - одноразовые резервные коды, показываются один раз
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled           bool `json:"enabled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+type WorkspaceTwoFactorRequest struct {
+	Required bool `json:"required"`
+}
@@ -1,13 +1,35 @@
 package dto
 
-type RegisterUserCommand struct {
-	Email    string
-	Password string
-	Role     string
+type RegisterRequest struct {
+	FullName    string `json:"fullName" validate:"max=255"`
+	Email       string `json:"email" validate:"required,email,max=255"`
+	PhoneNumber string `json:"phoneNumber" validate:"omitempty,e164"`
+	// Password проверяется политикой паролей сервиса
+	Password string `json:"password" validate:"required"`
+}
+
+type RegisterUserResponse struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullname"`
+	Email    string `json:"email"`
+}
+
+type RegisterResponse struct {
+	Token        string               `json:"token"`
+	RefreshToken string               `json:"refresh_token"`
+	ExpiresIn    int64                `json:"expires_in"`
+	User         RegisterUserResponse `json:"user"`
+	Workspace    WorkspaceResponse    `json:"workspace"`
 }
 
 type LoginCommand struct {
-	Email       string
-	Password    string
-	PhoneNumber string
+	// Email или PhoneNumber - логин; длина ограничена, чтобы логин помещался
+	// в счетчик неудачных попыток
+	Email       string `json:"email" validate:"required_without=PhoneNumber,omitempty,email,max=255"`
+	Password    string `json:"password" validate:"required,max=1024"`
+	PhoneNumber string `json:"phoneNumber" validate:"omitempty,e164"`
+	// WorkspaceID - пространство для входа, по умолчанию личное пространство пользователя
+	WorkspaceID string `json:"workspace_id"`
+	// ClientIP заполняется сервером для учета неудачных попыток по адресу
+	ClientIP string `json:"-"`
 }
@@ -5,7 +5,11 @@ type ValidateTokenRequest struct {
 }
 
 type ValidateTokenResponse struct {
-	Valid  bool   `json:"valid"`
-	UserID string `json:"user_id,omitempty"`
-	Role   string `json:"role,omitempty"`
+	Valid         bool   `json:"valid"`
+	UserID        string `json:"user_id,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role,omitempty"`
+	WorkspaceID   string `json:"workspace_id,omitempty"`
+	WorkspaceRole string `json:"workspace_role,omitempty"`
 }
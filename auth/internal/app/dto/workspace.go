@@ -0,0 +1,29 @@
+package dto
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" validate:"required"`
+	// Role - owner, editor или viewer
+	Role string `json:"role" validate:"required"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type WorkspaceResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	OwnerID string `json:"owner_id"`
+	Role    string `json:"role"`
+}
+
+type SwitchWorkspaceResponse struct {
+	Token        string            `json:"token"`
+	RefreshToken string            `json:"refresh_token"`
+	ExpiresIn    int64             `json:"expires_in"`
+	Workspace    WorkspaceResponse `json:"workspace"`
+}
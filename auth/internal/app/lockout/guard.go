@@ -0,0 +1,235 @@
+package lockout
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	defaultWindow             = 15 * time.Minute
+	defaultDelayAfter         = 3
+	defaultBaseDelay          = time.Second
+	defaultMaxDelay           = 30 * time.Second
+	defaultMaxAccountFailures = 10
+	defaultMaxIPFailures      = 50
+	defaultDuration           = 15 * time.Minute
+
+	accountKeyPrefix   = "account:"
+	ipKeyPrefix        = "ip:"
+	twoFactorKeyPrefix = "two_factor:"
+)
+
+// AccountKey - ключ счетчика учетной записи. Считается по введенному логину,
+// в том числе несуществующему, чтобы ответы не выдавали наличие пользователя.
+func AccountKey(login string) string {
+	return accountKeyPrefix + strings.ToLower(strings.TrimSpace(login))
+}
+
+func IPKey(ip string) string {
+	return ipKeyPrefix + ip
+}
+
+// TwoFactorKey - ключ счетчика неверных кодов второго фактора. Считается по
+// пользователю, а не по входу: новый вход по паролю не дает новых попыток.
+func TwoFactorKey(userID string) string {
+	return twoFactorKeyPrefix + userID
+}
+
+// Guard защищает вход по паролю от перебора. Неудачи считаются отдельно по
+// учетной записи и по IP: после DelayAfter неудач следующая попытка возможна
+// только через растущую задержку, после MaxFailures ключ блокируется на Duration.
+// Задержка не выдерживается сервером, а возвращается клиенту как RetryAfter.
+// Попытка засчитывается до проверки пароля под блокировкой счетчиков, поэтому
+// параллельные запросы перебора видят друг друга и тоже отклоняются.
+type Guard struct {
+	repo               domain.LoginAttemptRepository
+	window             time.Duration
+	delayAfter         int
+	baseDelay          time.Duration
+	maxDelay           time.Duration
+	maxAccountFailures int
+	maxIPFailures      int
+	duration           time.Duration
+}
+
+func NewGuard(repo domain.LoginAttemptRepository, cfg *config.Config) *Guard {
+	c := cfg.Lockout
+	return &Guard{
+		repo:               repo,
+		window:             durationOr(c.Window, defaultWindow),
+		delayAfter:         intOr(c.DelayAfter, defaultDelayAfter),
+		baseDelay:          durationOr(c.BaseDelay, defaultBaseDelay),
+		maxDelay:           durationOr(c.MaxDelay, defaultMaxDelay),
+		maxAccountFailures: intOr(c.MaxAccountFailures, defaultMaxAccountFailures),
+		maxIPFailures:      intOr(c.MaxIPFailures, defaultMaxIPFailures),
+		duration:           durationOr(c.Duration, defaultDuration),
+	}
+}
+
+// Reserve засчитывает попытку входа до проверки пароля. Возвращает
+// *domain.LoginBlockedError, если попытку нужно отклонить без проверки пароля.
+// Блокировка важнее задержки. После проверки пароля вызывается Fail или Succeed.
+func (g *Guard) Reserve(ctx context.Context, login, ip string) error {
+	return g.reserve(ctx, keys(login, ip))
+}
+
+// ReserveTwoFactor засчитывает попытку ввода кода второго фактора пользователя.
+// После проверки кода вызывается FailTwoFactor или SucceedTwoFactor.
+func (g *Guard) ReserveTwoFactor(ctx context.Context, userID string) error {
+	return g.reserve(ctx, []string{TwoFactorKey(userID)})
+}
+
+func (g *Guard) reserve(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	_, err := g.repo.ReserveLoginAttempt(ctx, keys, g.window, now, func(failures []*domain.LoginFailure) error {
+		return g.check(failures, now)
+	})
+	return err
+}
+
+func (g *Guard) check(failures []*domain.LoginFailure, now time.Time) error {
+	var locked, throttled time.Duration
+	for _, failure := range failures {
+		if failure.LockedUntil != nil && now.Before(*failure.LockedUntil) {
+			locked = max(locked, failure.LockedUntil.Sub(now))
+			continue
+		}
+		if now.Sub(failure.LastFailureAt) > g.window {
+			continue
+		}
+		// Лимит исчерпан параллельными попытками, а Fail еще не поставил блокировку
+		if failure.Failures >= g.maxFailures(failure.Key) {
+			locked = max(locked, g.duration)
+			continue
+		}
+		if wait := failure.LastFailureAt.Add(g.delay(failure.Failures)).Sub(now); wait > 0 {
+			throttled = max(throttled, wait)
+		}
+	}
+
+	switch {
+	case locked > 0:
+		return &domain.LoginBlockedError{Err: domain.ErrLoginLocked, RetryAfter: locked}
+	case throttled > 0:
+		return &domain.LoginBlockedError{Err: domain.ErrLoginThrottled, RetryAfter: throttled}
+	}
+	return nil
+}
+
+// Fail подтверждает неудачу попытки, засчитанной Reserve, и блокирует ключи,
+// превысившие лимит
+func (g *Guard) Fail(ctx context.Context, login, ip string) error {
+	return g.fail(ctx, keys(login, ip), ip)
+}
+
+// FailTwoFactor подтверждает неверный код второго фактора, ip - адрес попытки для журнала
+func (g *Guard) FailTwoFactor(ctx context.Context, userID, ip string) error {
+	return g.fail(ctx, []string{TwoFactorKey(userID)}, ip)
+}
+
+func (g *Guard) fail(ctx context.Context, keys []string, ip string) error {
+	failures, err := g.repo.GetLoginFailures(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, failure := range failures {
+		key := failure.Key
+		if failure.Failures < g.maxFailures(key) {
+			continue
+		}
+		if failure.LockedUntil != nil && now.Before(*failure.LockedUntil) {
+			continue
+		}
+
+		lockout := &domain.LoginLockout{
+			Key:         key,
+			Failures:    failure.Failures,
+			IP:          ip,
+			LockedUntil: now.Add(g.duration),
+			CreatedAt:   now,
+		}
+		if err := g.repo.LockLogin(ctx, lockout); err != nil {
+			return err
+		}
+		log.Printf("Вход заблокирован: %s до %s после %d неудачных попыток, последняя с %s",
+			key, lockout.LockedUntil.Format(time.RFC3339), failure.Failures, ip)
+	}
+	return nil
+}
+
+// Succeed сбрасывает счетчик учетной записи. Со счетчика IP снимается только
+// попытка, засчитанная Reserve: иначе перебор можно было бы продолжать,
+// периодически входя в свой аккаунт.
+func (g *Guard) Succeed(ctx context.Context, login, ip string) error {
+	if err := g.repo.ResetLoginFailures(ctx, AccountKey(login)); err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	return g.repo.RefundLoginAttempt(ctx, IPKey(ip))
+}
+
+// SucceedTwoFactor сбрасывает счетчик неверных кодов второго фактора пользователя
+func (g *Guard) SucceedTwoFactor(ctx context.Context, userID string) error {
+	return g.repo.ResetLoginFailures(ctx, TwoFactorKey(userID))
+}
+
+func (g *Guard) delay(failures int) time.Duration {
+	if failures < g.delayAfter {
+		return 0
+	}
+
+	delay := g.baseDelay
+	for i := g.delayAfter; i < failures && delay < g.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > g.maxDelay {
+		return g.maxDelay
+	}
+	return delay
+}
+
+func (g *Guard) maxFailures(key string) int {
+	if strings.HasPrefix(key, ipKeyPrefix) {
+		return g.maxIPFailures
+	}
+	// Счетчик второго фактора ограничен как счетчик учетной записи
+	return g.maxAccountFailures
+}
+
+func keys(login, ip string) []string {
+	keys := make([]string, 0, 2)
+	if strings.TrimSpace(login) != "" {
+		keys = append(keys, AccountKey(login))
+	}
+	if ip != "" {
+		keys = append(keys, IPKey(ip))
+	}
+	return keys
+}
+
+func durationOr(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+func intOr(n, def int) int {
+	if n <= 0 {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,179 @@
+package passwords
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"marketai/auth/internal/config"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+
+	defaultArgon2Memory      = 64 * 1024
+	defaultArgon2Iterations  = 3
+	defaultArgon2Parallelism = 2
+	argon2SaltLength         = 16
+	argon2KeyLength          = 32
+)
+
+var errUnknownHash = errors.New("неизвестный формат хеша пароля")
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// Hasher хеширует пароли выбранным алгоритмом и проверяет хеши любого из
+// поддерживаемых. Хеш другого алгоритма или с другими параметрами считается
+// устаревшим: после успешной проверки его нужно заменить новым.
+type Hasher struct {
+	algorithm  string
+	bcryptCost int
+	argon2     argon2Params
+	// dummy - хеш случайного пароля для проверки при входе несуществующего пользователя
+	dummy string
+}
+
+func NewHasher(cfg *config.Config) (*Hasher, error) {
+	c := cfg.Passwords.Hash
+	h := &Hasher{
+		algorithm:  c.Algorithm,
+		bcryptCost: c.BcryptCost,
+		argon2: argon2Params{
+			memory:      c.Argon2.Memory,
+			iterations:  c.Argon2.Iterations,
+			parallelism: c.Argon2.Parallelism,
+		},
+	}
+	if h.algorithm == "" {
+		h.algorithm = AlgorithmBcrypt
+	}
+	if h.algorithm != AlgorithmBcrypt && h.algorithm != AlgorithmArgon2id {
+		return nil, fmt.Errorf("неизвестный алгоритм хеширования паролей %q", h.algorithm)
+	}
+	if h.bcryptCost == 0 {
+		h.bcryptCost = bcrypt.DefaultCost
+	}
+	if h.bcryptCost < bcrypt.MinCost || h.bcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt_cost должен быть от %d до %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	if h.argon2.memory == 0 {
+		h.argon2.memory = defaultArgon2Memory
+	}
+	if h.argon2.iterations == 0 {
+		h.argon2.iterations = defaultArgon2Iterations
+	}
+	if h.argon2.parallelism == 0 {
+		h.argon2.parallelism = defaultArgon2Parallelism
+	}
+
+	dummy := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(dummy); err != nil {
+		return nil, fmt.Errorf("ошибка при генерации пароля: %w", err)
+	}
+	hash, err := h.Hash(base64.RawStdEncoding.EncodeToString(dummy))
+	if err != nil {
+		return nil, err
+	}
+	h.dummy = hash
+	return h, nil
+}
+
+// Algorithm - алгоритм новых хешей
+func (h *Hasher) Algorithm() string {
+	return h.algorithm
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.algorithm == AlgorithmArgon2id {
+		return h.hashArgon2id(password)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+	if err != nil {
+		return "", fmt.Errorf("ошибка при хешировании пароля: %w", err)
+	}
+	return string(hash), nil
+}
+
+// Verify сравнивает пароль с хешем. rehash - пароль верный, но хеш
+// получен не текущим алгоритмом или параметрами.
+func (h *Hasher) Verify(hash, password string) (ok, rehash bool) {
+	if strings.HasPrefix(hash, "$"+AlgorithmArgon2id+"$") {
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, false
+		}
+		actual := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(actual, key) != 1 {
+			return false, false
+		}
+		return true, h.algorithm != AlgorithmArgon2id || params != h.argon2
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return true, h.algorithm != AlgorithmBcrypt || err != nil || cost != h.bcryptCost
+}
+
+// VerifyDummy проверяет пароль по хешу случайного пароля текущим алгоритмом.
+// Вход несуществующего пользователя занимает столько же времени, сколько
+// неверный пароль, и время ответа не выдает, есть ли такой аккаунт.
+func (h *Hasher) VerifyDummy(password string) {
+	h.Verify(h.dummy, password)
+}
+
+// hashArgon2id кодирует хеш в формате PHC, как эталонная реализация:
+// $argon2id$v=19$m=65536,t=3,p=2$<соль>$<ключ>
+func (h *Hasher) hashArgon2id(password string) (string, error) {
+	params := h.argon2
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("ошибка при генерации соли: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, argon2KeyLength)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id, argon2.Version,
+		params.memory, params.iterations, params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func decodeArgon2id(hash string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, errUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errUnknownHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return params, nil, nil, errUnknownHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errUnknownHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errUnknownHash
+	}
+	return params, salt, key, nil
+}
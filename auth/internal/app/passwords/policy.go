@@ -0,0 +1,165 @@
+package passwords
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	defaultMinLength = 8
+	defaultMaxLength = 128
+	// bcryptMaxBytes - bcrypt не принимает пароли длиннее
+	bcryptMaxBytes = 72
+	// minEmailNameLength - более короткое имя из email слишком часто совпадает
+	// с частью пароля случайно
+	minEmailNameLength = 4
+	rangePrefixLength  = 5
+)
+
+// Policy проверяет новый пароль: длину, число классов символов, сходство
+// с email и наличие в списке паролей из утечек
+type Policy struct {
+	minLength          int
+	maxLength          int
+	maxBytes           int
+	minClasses         int
+	maxEmailSimilarity float64
+	breached           domain.BreachedPasswords
+}
+
+func NewPolicy(cfg *config.Config, hasher *Hasher, breached domain.BreachedPasswords) *Policy {
+	c := cfg.Passwords
+	p := &Policy{
+		minLength:          c.MinLength,
+		maxLength:          c.MaxLength,
+		minClasses:         c.MinCharacterClasses,
+		maxEmailSimilarity: c.MaxEmailSimilarity,
+		breached:           breached,
+	}
+	if p.minLength <= 0 {
+		p.minLength = defaultMinLength
+	}
+	if p.maxLength <= 0 {
+		p.maxLength = defaultMaxLength
+	}
+	if hasher.Algorithm() == AlgorithmBcrypt {
+		p.maxBytes = bcryptMaxBytes
+	}
+	return p
+}
+
+// Check возвращает ошибку domain.ErrPassword* с пояснением, если пароль
+// не подходит. email может быть пустым, тогда сходство не проверяется.
+func (p *Policy) Check(ctx context.Context, password, email string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.minLength {
+		return fmt.Errorf("%w: нужно не меньше %d символов", domain.ErrPasswordTooShort, p.minLength)
+	}
+	if length > p.maxLength {
+		return fmt.Errorf("%w: допускается не больше %d символов", domain.ErrPasswordTooLong, p.maxLength)
+	}
+	if p.maxBytes > 0 && len(password) > p.maxBytes {
+		return fmt.Errorf("%w: допускается не больше %d байт в UTF-8, кириллица занимает 2 байта на символ",
+			domain.ErrPasswordTooLong, p.maxBytes)
+	}
+	if p.minClasses > 1 && characterClasses(password) < p.minClasses {
+		return fmt.Errorf("%w: используйте хотя бы %d вида символов из строчных и заглавных букв, цифр и знаков",
+			domain.ErrPasswordTooSimple, p.minClasses)
+	}
+	if p.maxEmailSimilarity > 0 && similarToEmail(password, email, p.maxEmailSimilarity) {
+		return domain.ErrPasswordSimilarToEmail
+	}
+	return p.checkBreached(ctx, password)
+}
+
+// checkBreached сверяет пароль со списком утечек по k-анонимности: источнику
+// уходят только первые пять символов SHA-1, окончание сравнивается здесь
+func (p *Policy) checkBreached(ctx context.Context, password string) error {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := p.breached.Range(ctx, hash[:rangePrefixLength])
+	if err != nil {
+		return fmt.Errorf("ошибка при проверке пароля по утечкам: %w", err)
+	}
+	for _, suffix := range suffixes {
+		if strings.EqualFold(suffix, hash[rangePrefixLength:]) {
+			return domain.ErrPasswordBreached
+		}
+	}
+	return nil
+}
+
+func characterClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, other} {
+		if present {
+			classes++
+		}
+	}
+	return classes
+}
+
+// similarToEmail - пароль содержит имя из email, содержится в нем или
+// отличается от него на малое число правок
+func similarToEmail(password, email string, maxSimilarity float64) bool {
+	name, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if utf8.RuneCountInString(name) < minEmailNameLength {
+		return false
+	}
+
+	password = strings.ToLower(password)
+	if strings.Contains(password, name) || strings.Contains(name, password) {
+		return true
+	}
+	return similarity(password, name) >= maxSimilarity
+}
+
+// similarity - 1 минус расстояние Левенштейна, деленное на длину большей строки
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
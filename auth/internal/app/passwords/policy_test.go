@@ -0,0 +1,99 @@
+package passwords
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+// fakeBreached отдает диапазоны по заранее посчитанным хешам
+type fakeBreached map[string][]string
+
+func (f fakeBreached) Range(_ context.Context, prefix string) ([]string, error) {
+	return f[prefix], nil
+}
+
+func TestPolicyCheck(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Passwords.MinLength = 10
+	cfg.Passwords.MaxLength = 128
+	cfg.Passwords.MinCharacterClasses = 3
+	cfg.Passwords.MaxEmailSimilarity = 0.7
+	cfg.Passwords.Hash.Algorithm = AlgorithmBcrypt
+
+	hasher, err := NewHasher(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SHA-1 от "P@ssw0rd12345"
+	breached := fakeBreached{"4FFB9": {"0000000000000000000000000000000000A", "2F9843E5EFDC5A33C2565C3675DF185E520"}}
+	policy := NewPolicy(cfg, hasher, breached)
+
+	tests := []struct {
+		name     string
+		password string
+		email    string
+		want     error
+	}{
+		{
+			name:     "valid",
+			password: "Correct-Horse-42",
+			email:    "ivan.petrov@example.com",
+		},
+		{
+			name:     "too short",
+			password: "Ab1!",
+			want:     domain.ErrPasswordTooShort,
+		},
+		{
+			name:     "longer than max length",
+			password: "Aa1!" + strings.Repeat("x", 125),
+			want:     domain.ErrPasswordTooLong,
+		},
+		{
+			name:     "longer than bcrypt limit in bytes",
+			password: "Aa1!" + strings.Repeat("ж", 40),
+			want:     domain.ErrPasswordTooLong,
+		},
+		{
+			name:     "too few character classes",
+			password: "onlylowercaseletters",
+			want:     domain.ErrPasswordTooSimple,
+		},
+		{
+			name:     "contains email name",
+			password: "Ivan.Petrov-2024",
+			email:    "ivan.petrov@example.com",
+			want:     domain.ErrPasswordSimilarToEmail,
+		},
+		{
+			name:     "short email name is not compared",
+			password: "Ivan-Secure-2024",
+			email:    "iv@example.com",
+		},
+		{
+			name:     "breached",
+			password: "P@ssw0rd12345",
+			want:     domain.ErrPasswordBreached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(context.Background(), tt.password, tt.email)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Check() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Check() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package query
+
+import (
+	"context"
+	"strings"
+
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	defaultAdminPageSize = 20
+	maxAdminPageSize     = 100
+)
+
+type ListUsersQuery struct {
+	Search  string
+	Role    string
+	Blocked *bool
+	// Page - номер страницы с 1
+	Page     int
+	PageSize int
+}
+
+type ListUsersResult struct {
+	Users    []*domain.User
+	Total    int
+	Page     int
+	PageSize int
+}
+
+type ListUsersHandler interface {
+	Handle(ctx context.Context, q ListUsersQuery) (*ListUsersResult, error)
+}
+
+type listUsersHandler struct {
+	adminRepo domain.AdminRepository
+}
+
+func NewListUsersHandler(adminRepo domain.AdminRepository) *listUsersHandler {
+	return &listUsersHandler{adminRepo: adminRepo}
+}
+
+func (h *listUsersHandler) Handle(ctx context.Context, q ListUsersQuery) (*ListUsersResult, error) {
+	page, pageSize := pagination(q.Page, q.PageSize)
+
+	result, err := h.adminRepo.ListUsers(ctx, domain.UserFilter{
+		Query:   strings.TrimSpace(q.Search),
+		Role:    q.Role,
+		Blocked: q.Blocked,
+		Limit:   pageSize,
+		Offset:  (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUsersResult{
+		Users:    result.Users,
+		Total:    result.Total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+type AdminUserResult struct {
+	User        *domain.User
+	Access      *domain.Access
+	Memberships []*domain.Membership
+}
+
+type GetUserHandler interface {
+	Handle(ctx context.Context, userID string) (*AdminUserResult, error)
+}
+
+type getUserHandler struct {
+	userRepo      domain.UserRepository
+	rbacRepo      domain.RBACRepository
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewGetUserHandler(
+	userRepo domain.UserRepository,
+	rbacRepo domain.RBACRepository,
+	workspaceRepo domain.WorkspaceRepository,
+) *getUserHandler {
+	return &getUserHandler{
+		userRepo:      userRepo,
+		rbacRepo:      rbacRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// Handle возвращает пользователя с его ролями и пространствами
+func (h *getUserHandler) Handle(ctx context.Context, userID string) (*AdminUserResult, error) {
+	user, err := h.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	access, err := h.rbacRepo.GetUserAccess(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := h.workspaceRepo.GetUserWorkspaces(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminUserResult{
+		User:        user,
+		Access:      access,
+		Memberships: memberships,
+	}, nil
+}
+
+type ListAdminActionsQuery struct {
+	AdminID      string
+	TargetUserID string
+	Action       string
+	Page         int
+	PageSize     int
+}
+
+type ListAdminActionsResult struct {
+	Actions  []*domain.AdminActionRecord
+	Total    int
+	Page     int
+	PageSize int
+}
+
+type ListAdminActionsHandler interface {
+	Handle(ctx context.Context, q ListAdminActionsQuery) (*ListAdminActionsResult, error)
+}
+
+type listAdminActionsHandler struct {
+	adminRepo domain.AdminRepository
+}
+
+func NewListAdminActionsHandler(adminRepo domain.AdminRepository) *listAdminActionsHandler {
+	return &listAdminActionsHandler{adminRepo: adminRepo}
+}
+
+func (h *listAdminActionsHandler) Handle(ctx context.Context, q ListAdminActionsQuery) (*ListAdminActionsResult, error) {
+	page, pageSize := pagination(q.Page, q.PageSize)
+
+	actions, total, err := h.adminRepo.ListActions(ctx, domain.AdminActionFilter{
+		AdminID:      q.AdminID,
+		TargetUserID: q.TargetUserID,
+		Action:       domain.AdminAction(q.Action),
+		Limit:        pageSize,
+		Offset:       (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListAdminActionsResult{
+		Actions:  actions,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func pagination(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultAdminPageSize
+	}
+	if pageSize > maxAdminPageSize {
+		pageSize = maxAdminPageSize
+	}
+	return page, pageSize
+}
@@ -0,0 +1,143 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"marketai/auth/internal/app/token"
+	domain "marketai/auth/internal/domain"
+)
+
+type ListAPIKeysHandler interface {
+	Handle(ctx context.Context, userID string) ([]*domain.APIKey, error)
+}
+
+type listAPIKeysHandler struct {
+	apiKeyRepo domain.APIKeyRepository
+}
+
+func NewListAPIKeysHandler(apiKeyRepo domain.APIKeyRepository) *listAPIKeysHandler {
+	return &listAPIKeysHandler{apiKeyRepo: apiKeyRepo}
+}
+
+func (h *listAPIKeysHandler) Handle(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	return h.apiKeyRepo.ListUserAPIKeys(ctx, userID)
+}
+
+type ValidateAPIKeyResult struct {
+	Key  *domain.APIKey
+	User *domain.User
+	// Permissions - права ключа, которые у пользователя есть сейчас
+	Permissions []string
+	Membership  *domain.Membership
+}
+
+type ValidateAPIKeyHandler interface {
+	Handle(ctx context.Context, rawKey string) (*ValidateAPIKeyResult, error)
+}
+
+type validateAPIKeyHandler struct {
+	apiKeyRepo    domain.APIKeyRepository
+	userRepo      domain.UserRepository
+	rbacRepo      domain.RBACRepository
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewValidateAPIKeyHandler(
+	apiKeyRepo domain.APIKeyRepository,
+	userRepo domain.UserRepository,
+	rbacRepo domain.RBACRepository,
+	workspaceRepo domain.WorkspaceRepository,
+) *validateAPIKeyHandler {
+	return &validateAPIKeyHandler{
+		apiKeyRepo:    apiKeyRepo,
+		userRepo:      userRepo,
+		rbacRepo:      rbacRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// Handle проверяет ключ и отмечает его использование. Права ключа ограничены
+// текущими правами пользователя: после снятия роли ключ теряет ее права.
+func (h *validateAPIKeyHandler) Handle(ctx context.Context, rawKey string) (*ValidateAPIKeyResult, error) {
+	if !strings.HasPrefix(rawKey, domain.APIKeyPrefix) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	now := time.Now()
+	key, err := h.apiKeyRepo.GetAPIKeyByHash(ctx, token.HashOpaqueToken(rawKey))
+	if err != nil {
+		return nil, err
+	}
+	if !key.Active(now) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	user, err := h.userRepo.GetUserByID(ctx, key.UserID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Blocked() {
+		return nil, domain.ErrUserBlocked
+	}
+
+	access, err := h.rbacRepo.GetUserAccess(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении ролей: %w", err)
+	}
+
+	membership, err := h.membership(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Отметка использования не должна мешать запросу
+	if err := h.apiKeyRepo.TouchAPIKey(ctx, key.ID, now); err != nil {
+		log.Printf("Ошибка обновления времени использования API ключа %s: %v", key.ID, err)
+	}
+
+	return &ValidateAPIKeyResult{
+		Key:         key,
+		User:        user,
+		Permissions: intersect(key.Scopes, access.Permissions),
+		Membership:  membership,
+	}, nil
+}
+
+func (h *validateAPIKeyHandler) membership(ctx context.Context, key *domain.APIKey) (*domain.Membership, error) {
+	if key.WorkspaceID != "" {
+		membership, err := h.workspaceRepo.GetMembership(ctx, key.WorkspaceID, key.UserID)
+		if errors.Is(err, domain.ErrNotWorkspaceMember) {
+			return nil, domain.ErrAPIKeyInvalid
+		}
+		return membership, err
+	}
+
+	memberships, err := h.workspaceRepo.GetUserWorkspaces(ctx, key.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DefaultMembership(memberships, key.UserID), nil
+}
+
+func intersect(values, allowed []string) []string {
+	set := make(map[string]bool, len(allowed))
+	for _, value := range allowed {
+		set[value] = true
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if set[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -10,15 +10,28 @@ type GetDataByTokenHandler interface {
 }
 
 type getDataByTokenHandler struct {
-	repo domain.UserRepository
+	validate ValidateTokenHandler
 }
 
-func NewGetDataByTokenHandler(repo domain.UserRepository) GetDataByTokenHandler {
+func NewGetDataByTokenHandler(validate ValidateTokenHandler) GetDataByTokenHandler {
 	return &getDataByTokenHandler{
-		repo: repo,
+		validate: validate,
 	}
 }
 
 func (r *getDataByTokenHandler) Handle(ctx context.Context, token string) (*domain.GetData, error) {
-	return r.repo.GetDataByToken(ctx, token)
+	result, err := r.validate.Handle(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.GetData{
+		ID:            result.User.ID,
+		Email:         result.User.Email,
+		EmailVerified: result.User.EmailVerified(),
+		FullName:      result.User.FullName,
+		PhoneNumber:   result.User.PhoneNumber,
+		Role:          result.User.Role,
+		CreatedAt:     result.User.CreatedAt,
+	}, nil
 }
@@ -0,0 +1,25 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type GetLockoutsHandler interface {
+	Handle(ctx context.Context) ([]*domain.LoginLockout, error)
+}
+
+type getLockoutsHandler struct {
+	repo domain.LoginAttemptRepository
+}
+
+func NewGetLockoutsHandler(repo domain.LoginAttemptRepository) *getLockoutsHandler {
+	return &getLockoutsHandler{repo: repo}
+}
+
+// Handle возвращает действующие блокировки входа
+func (h *getLockoutsHandler) Handle(ctx context.Context) ([]*domain.LoginLockout, error) {
+	return h.repo.GetActiveLockouts(ctx, time.Now())
+}
@@ -4,61 +4,161 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
-
+	"github.com/jackc/pgx/v5"
+	"marketai/auth/internal/app/audit"
 	"marketai/auth/internal/app/dto"
-	"marketai/auth/internal/config"
+	"marketai/auth/internal/app/lockout"
+	"marketai/auth/internal/app/passwords"
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/app/twofactor"
 	domain "marketai/auth/internal/domain"
-	"marketai/pkgAuth/jwt"
 )
 
 type LoginCommandResult struct {
-	Token    string
-	UserID   string
-	FullName string
+	Token        string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	UserID       string
+	FullName     string
+	Workspace    *domain.Membership
+	// TwoFactor - пароль верный, но для входа нужен второй фактор, токены не выпущены
+	TwoFactor *twofactor.Challenge
 }
 
 type LoginCommandHandlerResult struct {
-	userRepo domain.UserRepository
-	config   *config.Config
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	issuer        *token.Issuer
+	guard         *lockout.Guard
+	twoFactor     *twofactor.Service
+	hasher        *passwords.Hasher
+	audit         *audit.Recorder
 }
 
 type LoginCommandHandler interface {
 	Handle(ctx context.Context, cmd dto.LoginCommand) (*LoginCommandResult, error)
 }
 
-func NewLoginCommandHandler(userRepo domain.UserRepository, cfg *config.Config) *LoginCommandHandlerResult {
+func NewLoginCommandHandler(
+	userRepo domain.UserRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	issuer *token.Issuer,
+	guard *lockout.Guard,
+	twoFactor *twofactor.Service,
+	hasher *passwords.Hasher,
+	recorder *audit.Recorder,
+) *LoginCommandHandlerResult {
 	return &LoginCommandHandlerResult{
-		userRepo: userRepo,
-		config:   cfg,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		issuer:        issuer,
+		guard:         guard,
+		twoFactor:     twoFactor,
+		hasher:        hasher,
+		audit:         recorder,
 	}
 }
 
-func (h *LoginCommandHandlerResult) Handle(ctx context.Context, cmd dto.LoginCommand) (*LoginCommandResult, error) {
+func (h *LoginCommandHandlerResult) Handle(ctx context.Context, cmd dto.LoginCommand) (_ *LoginCommandResult, err error) {
+	login := cmd.Email
+	if login == "" {
+		login = cmd.PhoneNumber
+	}
+	event := &domain.SecurityEvent{Type: domain.SecurityEventLoginPassword, Login: login}
+	defer func() { h.audit.Record(ctx, event, err) }()
+
+	// Заблокированный логин или адрес отклоняется до проверки пароля
+	if err := h.guard.Reserve(ctx, login, cmd.ClientIP); err != nil {
+		return nil, err
+	}
+
 	user, err := h.userRepo.GetUserByUsername(ctx, cmd.Email, cmd.PhoneNumber)
-	if err != nil {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return nil, fmt.Errorf("ошибка при получении пользователя: %w", err)
 	}
-	if user == nil {
-		return nil, errors.New("неверные учетные данные")
+	var passwordOK, rehash bool
+	if user != nil {
+		passwordOK, rehash = h.hasher.Verify(user.PasswordHash, cmd.Password)
+	} else {
+		h.hasher.VerifyDummy(cmd.Password)
+	}
+	if !passwordOK {
+		if err := h.guard.Fail(ctx, login, cmd.ClientIP); err != nil {
+			return nil, fmt.Errorf("ошибка при учете неудачного входа: %w", err)
+		}
+		return nil, domain.ErrInvalidCredentials
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(cmd.Password))
+	event.UserID = user.ID
+	if rehash {
+		h.rehash(ctx, user.ID, cmd.Password)
+	}
+
+	membership, err := h.resolveWorkspace(ctx, user.ID, cmd.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
 
+	// Пока не введен второй фактор, попытка остается засчитанной: счетчики
+	// сбрасываются при завершении входа, иначе пароль можно было бы перебирать
+	// без ограничений, никогда не вводя код
+	challenge, err := h.twoFactor.Challenge(ctx, user, membership, login, cmd.ClientIP)
 	if err != nil {
-		return nil, fmt.Errorf("неверные учетные данные: %w", err)
+		return nil, err
+	}
+	if challenge != nil {
+		event.Details = map[string]string{"two_factor": "required"}
+		return &LoginCommandResult{
+			UserID:    user.ID,
+			FullName:  user.FullName,
+			Workspace: membership,
+			TwoFactor: challenge,
+		}, nil
 	}
 
-	token, err := jwt.GenerateToken(user.ID, user.Role, h.config.JWTSecret, time.Hour*24)
+	if err := h.guard.Succeed(ctx, login, cmd.ClientIP); err != nil {
+		return nil, fmt.Errorf("ошибка при сбросе счетчика входа: %w", err)
+	}
+	pair, err := h.issuer.Issue(ctx, user, membership)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при генерации JWT токена: %w", err)
+		return nil, err
 	}
 
 	return &LoginCommandResult{
-		Token:    token,
-		UserID:   user.ID,
-		FullName: user.FullName,
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+		UserID:       user.ID,
+		FullName:     user.FullName,
+		Workspace:    membership,
 	}, nil
 }
+
+// rehash заменяет хеш пароля, полученный прежним алгоритмом или параметрами.
+// Пароль известен только при входе, поэтому хеши обновляются постепенно;
+// ошибка не мешает входу.
+func (h *LoginCommandHandlerResult) rehash(ctx context.Context, userID, password string) {
+	hash, err := h.hasher.Hash(password)
+	if err == nil {
+		err = h.userRepo.UpdatePassword(ctx, userID, hash)
+	}
+	if err != nil {
+		log.Printf("Ошибка при обновлении хеша пароля пользователя %s: %v", userID, err)
+	}
+}
+
+func (h *LoginCommandHandlerResult) resolveWorkspace(ctx context.Context, userID, workspaceID string) (*domain.Membership, error) {
+	if workspaceID != "" {
+		return h.workspaceRepo.GetMembership(ctx, workspaceID, userID)
+	}
+
+	memberships, err := h.workspaceRepo.GetUserWorkspaces(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении рабочих пространств: %w", err)
+	}
+
+	return domain.DefaultMembership(memberships, userID), nil
+}
@@ -0,0 +1,29 @@
+package query
+
+import (
+	"sort"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type OAuthProvidersHandler interface {
+	Handle() []string
+}
+
+type oauthProvidersHandler struct {
+	providers domain.IdentityProviders
+}
+
+func NewOAuthProvidersHandler(providers domain.IdentityProviders) *oauthProvidersHandler {
+	return &oauthProvidersHandler{providers: providers}
+}
+
+// Handle возвращает имена включенных провайдеров для кнопок входа на фронтенде
+func (h *oauthProvidersHandler) Handle() []string {
+	names := make([]string, 0, len(h.providers))
+	for name := range h.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,23 @@
+package query
+
+import (
+	"context"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type GetProfileHandler interface {
+	Handle(ctx context.Context, userID string) (*domain.User, error)
+}
+
+type getProfileHandler struct {
+	userRepo domain.UserRepository
+}
+
+func NewGetProfileHandler(userRepo domain.UserRepository) *getProfileHandler {
+	return &getProfileHandler{userRepo: userRepo}
+}
+
+func (h *getProfileHandler) Handle(ctx context.Context, userID string) (*domain.User, error) {
+	return h.userRepo.GetUserByID(ctx, userID)
+}
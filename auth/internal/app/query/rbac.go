@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type ListRolesHandler interface {
+	Handle(ctx context.Context) ([]*domain.Role, error)
+}
+
+type listRolesHandler struct {
+	rbacRepo domain.RBACRepository
+}
+
+func NewListRolesHandler(rbacRepo domain.RBACRepository) *listRolesHandler {
+	return &listRolesHandler{rbacRepo: rbacRepo}
+}
+
+func (h *listRolesHandler) Handle(ctx context.Context) ([]*domain.Role, error) {
+	return h.rbacRepo.ListRoles(ctx)
+}
+
+type ListPermissionsHandler interface {
+	Handle(ctx context.Context) ([]*domain.Permission, error)
+}
+
+type listPermissionsHandler struct {
+	rbacRepo domain.RBACRepository
+}
+
+func NewListPermissionsHandler(rbacRepo domain.RBACRepository) *listPermissionsHandler {
+	return &listPermissionsHandler{rbacRepo: rbacRepo}
+}
+
+func (h *listPermissionsHandler) Handle(ctx context.Context) ([]*domain.Permission, error) {
+	return h.rbacRepo.ListPermissions(ctx)
+}
+
+type GetUserAccessHandler interface {
+	Handle(ctx context.Context, userID string) (*domain.Access, error)
+}
+
+type getUserAccessHandler struct {
+	userRepo domain.UserRepository
+	rbacRepo domain.RBACRepository
+}
+
+func NewGetUserAccessHandler(userRepo domain.UserRepository, rbacRepo domain.RBACRepository) *getUserAccessHandler {
+	return &getUserAccessHandler{
+		userRepo: userRepo,
+		rbacRepo: rbacRepo,
+	}
+}
+
+func (h *getUserAccessHandler) Handle(ctx context.Context, userID string) (*domain.Access, error) {
+	if _, err := h.userRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return h.rbacRepo.GetUserAccess(ctx, userID)
+}
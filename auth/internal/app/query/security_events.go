@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type ListSecurityEventsQuery struct {
+	UserID   string
+	Type     string
+	Outcome  string
+	IP       string
+	From     *time.Time
+	To       *time.Time
+	Page     int
+	PageSize int
+}
+
+type ListSecurityEventsResult struct {
+	Events   []*domain.SecurityEvent
+	Total    int
+	Page     int
+	PageSize int
+}
+
+type ListSecurityEventsHandler interface {
+	Handle(ctx context.Context, q ListSecurityEventsQuery) (*ListSecurityEventsResult, error)
+}
+
+type listSecurityEventsHandler struct {
+	eventRepo domain.SecurityEventRepository
+}
+
+func NewListSecurityEventsHandler(eventRepo domain.SecurityEventRepository) *listSecurityEventsHandler {
+	return &listSecurityEventsHandler{eventRepo: eventRepo}
+}
+
+func (h *listSecurityEventsHandler) Handle(ctx context.Context, q ListSecurityEventsQuery) (*ListSecurityEventsResult, error) {
+	page, pageSize := pagination(q.Page, q.PageSize)
+
+	events, total, err := h.eventRepo.ListSecurityEvents(ctx, domain.SecurityEventFilter{
+		UserID:  q.UserID,
+		Type:    domain.SecurityEventType(q.Type),
+		Outcome: domain.SecurityOutcome(q.Outcome),
+		IP:      q.IP,
+		From:    q.From,
+		To:      q.To,
+		Limit:   pageSize,
+		Offset:  (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListSecurityEventsResult{
+		Events:   events,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
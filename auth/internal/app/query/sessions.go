@@ -0,0 +1,25 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	domain "marketai/auth/internal/domain"
+)
+
+type ListSessionsHandler interface {
+	Handle(ctx context.Context, userID string) ([]*domain.Session, error)
+}
+
+type listSessionsHandler struct {
+	sessionRepo domain.SessionRepository
+}
+
+func NewListSessionsHandler(sessionRepo domain.SessionRepository) *listSessionsHandler {
+	return &listSessionsHandler{sessionRepo: sessionRepo}
+}
+
+// Handle возвращает действующие сессии пользователя, последние активные первыми
+func (h *listSessionsHandler) Handle(ctx context.Context, userID string) ([]*domain.Session, error) {
+	return h.sessionRepo.ListUserSessions(ctx, userID, time.Now())
+}
@@ -0,0 +1,45 @@
+package query
+
+import (
+	"context"
+
+	"marketai/auth/internal/app/twofactor"
+)
+
+type TwoFactorStatus struct {
+	Enabled bool
+	// Required - второй фактор требует роль пользователя или одно из его пространств
+	Required          bool
+	RecoveryCodesLeft int
+}
+
+type GetTwoFactorStatusHandler interface {
+	Handle(ctx context.Context, userID string) (*TwoFactorStatus, error)
+}
+
+type getTwoFactorStatusHandler struct {
+	twoFactor *twofactor.Service
+}
+
+func NewGetTwoFactorStatusHandler(twoFactor *twofactor.Service) *getTwoFactorStatusHandler {
+	return &getTwoFactorStatusHandler{twoFactor: twoFactor}
+}
+
+func (h *getTwoFactorStatusHandler) Handle(ctx context.Context, userID string) (*TwoFactorStatus, error) {
+	enabled, err := h.twoFactor.Enabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	required, err := h.twoFactor.Required(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TwoFactorStatus{Enabled: enabled, Required: required}
+	if enabled {
+		if status.RecoveryCodesLeft, err = h.twoFactor.RecoveryCodesLeft(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"marketai/auth/internal/app/token"
+	domain "marketai/auth/internal/domain"
+	"marketai/pkgAuth/jwt"
+)
+
+type ValidateTokenResult struct {
+	Claims *jwt.Claims
+	User   *domain.User
+}
+
+type ValidateTokenHandler interface {
+	Handle(ctx context.Context, accessToken string) (*ValidateTokenResult, error)
+}
+
+type validateTokenHandler struct {
+	userRepo domain.UserRepository
+	denylist *token.Denylist
+	verifier jwt.Verifier
+}
+
+func NewValidateTokenHandler(userRepo domain.UserRepository, denylist *token.Denylist, verifier jwt.Verifier) *validateTokenHandler {
+	return &validateTokenHandler{
+		userRepo: userRepo,
+		denylist: denylist,
+		verifier: verifier,
+	}
+}
+
+// Handle проверяет подпись и срок действия токена, отсутствие его в списке
+// отзыва и существование пользователя
+func (h *validateTokenHandler) Handle(ctx context.Context, accessToken string) (*ValidateTokenResult, error) {
+	claims, err := h.verifier.Verify(ctx, accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTokenInvalid, err)
+	}
+
+	revoked, err := h.denylist.IsRevoked(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при проверке списка отзыва: %w", err)
+	}
+	if revoked {
+		return nil, domain.ErrTokenRevoked
+	}
+
+	user, err := h.userRepo.GetUserByID(ctx, claims.UserID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidateTokenResult{
+		Claims: claims,
+		User:   user,
+	}, nil
+}
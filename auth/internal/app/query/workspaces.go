@@ -0,0 +1,112 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"marketai/auth/internal/app/token"
+	domain "marketai/auth/internal/domain"
+)
+
+type GetUserWorkspacesHandler interface {
+	Handle(ctx context.Context, userID string) ([]*domain.Membership, error)
+}
+
+type getUserWorkspacesHandler struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewGetUserWorkspacesHandler(workspaceRepo domain.WorkspaceRepository) *getUserWorkspacesHandler {
+	return &getUserWorkspacesHandler{workspaceRepo: workspaceRepo}
+}
+
+func (h *getUserWorkspacesHandler) Handle(ctx context.Context, userID string) ([]*domain.Membership, error) {
+	return h.workspaceRepo.GetUserWorkspaces(ctx, userID)
+}
+
+type GetWorkspaceMembersQuery struct {
+	WorkspaceID string
+	UserID      string
+}
+
+type GetWorkspaceMembersHandler interface {
+	Handle(ctx context.Context, query GetWorkspaceMembersQuery) ([]*domain.WorkspaceMember, error)
+}
+
+type getWorkspaceMembersHandler struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewGetWorkspaceMembersHandler(workspaceRepo domain.WorkspaceRepository) *getWorkspaceMembersHandler {
+	return &getWorkspaceMembersHandler{workspaceRepo: workspaceRepo}
+}
+
+func (h *getWorkspaceMembersHandler) Handle(ctx context.Context, query GetWorkspaceMembersQuery) ([]*domain.WorkspaceMember, error) {
+	// Список участников доступен любому участнику пространства
+	if _, err := h.workspaceRepo.GetMembership(ctx, query.WorkspaceID, query.UserID); err != nil {
+		return nil, err
+	}
+
+	return h.workspaceRepo.GetWorkspaceMembers(ctx, query.WorkspaceID)
+}
+
+type SwitchWorkspaceQuery struct {
+	WorkspaceID string
+	UserID      string
+	// SessionID - сессия токена, из которого переключаются
+	SessionID string
+}
+
+type SwitchWorkspaceResult struct {
+	Token        string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	Workspace    *domain.Membership
+}
+
+type SwitchWorkspaceHandler interface {
+	Handle(ctx context.Context, query SwitchWorkspaceQuery) (*SwitchWorkspaceResult, error)
+}
+
+type switchWorkspaceHandler struct {
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	issuer        *token.Issuer
+}
+
+func NewSwitchWorkspaceHandler(
+	userRepo domain.UserRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	issuer *token.Issuer,
+) *switchWorkspaceHandler {
+	return &switchWorkspaceHandler{
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		issuer:        issuer,
+	}
+}
+
+// Handle выпускает новую пару токенов с claims выбранного пространства в текущей сессии
+func (h *switchWorkspaceHandler) Handle(ctx context.Context, query SwitchWorkspaceQuery) (*SwitchWorkspaceResult, error) {
+	membership, err := h.workspaceRepo.GetMembership(ctx, query.WorkspaceID, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.userRepo.GetUserByID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := h.issuer.Switch(ctx, user, membership, query.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SwitchWorkspaceResult{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+		Workspace:    membership,
+	}, nil
+}
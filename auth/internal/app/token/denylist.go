@@ -0,0 +1,143 @@
+package token
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domain "marketai/auth/internal/domain"
+	"marketai/pkgAuth/jwt"
+)
+
+const defaultDenylistRefresh = 30 * time.Second
+
+// Denylist - кеш списка отзыва токенов доступа поверх Postgres. Отзывы этого
+// экземпляра попадают в кеш сразу, отзывы других экземпляров сервиса -
+// при следующей перезагрузке кеша, не позже чем через refreshInterval.
+// Отзывы хранятся maxAccessTTL - наибольший срок действия токенов доступа,
+// включая токены входа от имени пользователя (Issuer.MaxAccessTTL).
+type Denylist struct {
+	repo            domain.TokenRevocationRepository
+	maxAccessTTL    time.Duration
+	refreshInterval time.Duration
+
+	mu       sync.RWMutex
+	tokens   map[string]time.Time
+	users    map[string]time.Time
+	sessions map[string]time.Time
+	loadedAt time.Time
+}
+
+func NewDenylist(repo domain.TokenRevocationRepository, maxAccessTTL, refreshInterval time.Duration) *Denylist {
+	if maxAccessTTL <= 0 {
+		maxAccessTTL = defaultAccessTTL
+	}
+	if refreshInterval <= 0 {
+		refreshInterval = defaultDenylistRefresh
+	}
+	return &Denylist{
+		repo:            repo,
+		maxAccessTTL:    maxAccessTTL,
+		refreshInterval: refreshInterval,
+		tokens:          make(map[string]time.Time),
+		users:           make(map[string]time.Time),
+		sessions:        make(map[string]time.Time),
+	}
+}
+
+// Revoke отзывает один токен доступа до истечения его срока действия
+func (d *Denylist) Revoke(ctx context.Context, claims *jwt.Claims) error {
+	if claims.ID == "" {
+		return nil
+	}
+
+	expiresAt := time.Unix(claims.Exp, 0)
+	if err := d.repo.RevokeToken(ctx, &domain.RevokedToken{
+		JTI:       claims.ID,
+		UserID:    claims.UserID,
+		ExpiresAt: expiresAt,
+		RevokedAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.tokens[claims.ID] = expiresAt
+	d.mu.Unlock()
+	return nil
+}
+
+// RevokeUser отзывает все токены доступа пользователя, выпущенные до текущего момента
+func (d *Denylist) RevokeUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	if err := d.repo.RevokeUserTokensBefore(ctx, userID, now); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	if now.After(d.users[userID]) {
+		d.users[userID] = now
+	}
+	d.mu.Unlock()
+	return nil
+}
+
+// RevokeSession отзывает токены доступа завершенной сессии. Завершение уже
+// сохранено в sessions, другие экземпляры увидят его при перезагрузке кеша.
+func (d *Denylist) RevokeSession(sessionID string) {
+	d.mu.Lock()
+	d.sessions[sessionID] = time.Now()
+	d.mu.Unlock()
+}
+
+// IsRevoked проверяет токен по кешу, при устаревании кеша перезагружает его из базы
+func (d *Denylist) IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	if err := d.refreshIfStale(ctx); err != nil {
+		return false, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if _, ok := d.tokens[claims.ID]; ok && claims.ID != "" {
+		return true, nil
+	}
+	// Токен, выпущенный в момент отметки, тоже отозван: иначе при секундной
+	// точности iat переживает отзыв токен, выпущенный в ту же секунду
+	if before, ok := d.users[claims.UserID]; ok && !claims.IssuedAt().After(before) {
+		return true, nil
+	}
+	if _, ok := d.sessions[claims.SessionID]; ok && claims.SessionID != "" {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (d *Denylist) refreshIfStale(ctx context.Context) error {
+	d.mu.RLock()
+	stale := time.Since(d.loadedAt) >= d.refreshInterval
+	d.mu.RUnlock()
+	if !stale {
+		return nil
+	}
+
+	// Отметки и сессии, завершенные раньше наибольшего времени жизни токена доступа, уже ничего не отзывают
+	now := time.Now()
+	since := now.Add(-d.maxAccessTTL)
+	if err := d.repo.DeleteExpiredRevocations(ctx, now, since); err != nil {
+		return err
+	}
+
+	revocations, err := d.repo.GetRevocations(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.tokens = revocations.Tokens
+	d.users = revocations.Users
+	d.sessions = revocations.Sessions
+	d.loadedAt = now
+	d.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,179 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+	"marketai/pkgAuth/jwt"
+)
+
+type fakeRevocationRepo struct {
+	revocations *domain.Revocations
+}
+
+func (r *fakeRevocationRepo) RevokeToken(context.Context, *domain.RevokedToken) error { return nil }
+
+func (r *fakeRevocationRepo) RevokeUserTokensBefore(context.Context, string, time.Time) error {
+	return nil
+}
+
+func (r *fakeRevocationRepo) GetRevocations(context.Context, time.Time) (*domain.Revocations, error) {
+	return r.revocations, nil
+}
+
+func (r *fakeRevocationRepo) DeleteExpiredRevocations(context.Context, time.Time, time.Time) error {
+	return nil
+}
+
+func TestDenylistIsRevoked(t *testing.T) {
+	// Отметка с дробной секундой, как после time.Now()
+	before := time.Unix(1_700_000_000, 400_000_000)
+
+	repo := &fakeRevocationRepo{revocations: &domain.Revocations{
+		Tokens:   map[string]time.Time{"revoked-jti": before.Add(time.Hour)},
+		Users:    map[string]time.Time{"user-1": before},
+		Sessions: map[string]time.Time{"revoked-sid": before},
+	}}
+	d := NewDenylist(repo, time.Hour, time.Hour)
+
+	tests := []struct {
+		name   string
+		claims jwt.Claims
+		want   bool
+	}{
+		{
+			name:   "revoked jti",
+			claims: jwt.Claims{ID: "revoked-jti", UserID: "user-2"},
+			want:   true,
+		},
+		{
+			name:   "unknown jti",
+			claims: jwt.Claims{ID: "other-jti", UserID: "user-2"},
+			want:   false,
+		},
+		{
+			name:   "issued before user revocation",
+			claims: jwt.Claims{UserID: "user-1", Iat: before.Unix() - 1},
+			want:   true,
+		},
+		{
+			name:   "issued in the same second without iat_ms",
+			claims: jwt.Claims{UserID: "user-1", Iat: before.Unix()},
+			want:   true,
+		},
+		{
+			name:   "issued in the same second before revocation",
+			claims: jwt.Claims{UserID: "user-1", Iat: before.Unix(), IatMs: before.UnixMilli() - 100},
+			want:   true,
+		},
+		{
+			name:   "issued in the same second after revocation",
+			claims: jwt.Claims{UserID: "user-1", Iat: before.Unix(), IatMs: before.UnixMilli() + 100},
+			want:   false,
+		},
+		{
+			name:   "issued after user revocation",
+			claims: jwt.Claims{UserID: "user-1", Iat: before.Unix() + 1},
+			want:   false,
+		},
+		{
+			name:   "other user",
+			claims: jwt.Claims{UserID: "user-2", Iat: before.Unix() - 1},
+			want:   false,
+		},
+		{
+			name:   "revoked session",
+			claims: jwt.Claims{UserID: "user-2", SessionID: "revoked-sid", Iat: before.Unix() + 1},
+			want:   true,
+		},
+		{
+			name:   "empty jti and session are not matched",
+			claims: jwt.Claims{UserID: "user-2"},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.IsRevoked(context.Background(), &tt.claims)
+			if err != nil {
+				t.Fatalf("IsRevoked: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsRevoked = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// windowRevocationRepo, как Postgres, возвращает только отзывы не раньше since
+type windowRevocationRepo struct {
+	fakeRevocationRepo
+}
+
+func (r *windowRevocationRepo) GetRevocations(_ context.Context, since time.Time) (*domain.Revocations, error) {
+	revocations := &domain.Revocations{
+		Tokens:   map[string]time.Time{},
+		Users:    map[string]time.Time{},
+		Sessions: map[string]time.Time{},
+	}
+	for jti, expiresAt := range r.revocations.Tokens {
+		if expiresAt.After(since) {
+			revocations.Tokens[jti] = expiresAt
+		}
+	}
+	for userID, before := range r.revocations.Users {
+		if !before.Before(since) {
+			revocations.Users[userID] = before
+		}
+	}
+	for sessionID, revokedAt := range r.revocations.Sessions {
+		if !revokedAt.Before(since) {
+			revocations.Sessions[sessionID] = revokedAt
+		}
+	}
+	return revocations, nil
+}
+
+func TestDenylistKeepsImpersonationRevocations(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Tokens.AccessTTL = 15 * time.Minute
+	cfg.Tokens.ImpersonationTTL = 2 * time.Hour
+	issuer := NewIssuer(nil, nil, nil, nil, nil, nil, cfg)
+
+	// Токен входа от имени выпущен 100 минут назад и еще действует, пользователя
+	// заблокировали и сессию завершили 90 минут назад - дольше AccessTTL
+	now := time.Now()
+	issuedAt := now.Add(-100 * time.Minute)
+	revokedAt := now.Add(-90 * time.Minute)
+	repo := &windowRevocationRepo{fakeRevocationRepo{revocations: &domain.Revocations{
+		Tokens:   map[string]time.Time{"impersonation-jti": issuedAt.Add(cfg.Tokens.ImpersonationTTL)},
+		Users:    map[string]time.Time{"blocked-user": revokedAt},
+		Sessions: map[string]time.Time{"impersonation-sid": revokedAt},
+	}}}
+	d := NewDenylist(repo, issuer.MaxAccessTTL(), time.Hour)
+
+	tests := []struct {
+		name   string
+		claims jwt.Claims
+	}{
+		{"revoked jti", jwt.Claims{ID: "impersonation-jti", UserID: "user-2", Iat: issuedAt.Unix()}},
+		{"blocked user", jwt.Claims{UserID: "blocked-user", Iat: issuedAt.Unix()}},
+		{"ended session", jwt.Claims{UserID: "user-2", SessionID: "impersonation-sid", Iat: issuedAt.Unix()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.IsRevoked(context.Background(), &tt.claims)
+			if err != nil {
+				t.Fatalf("IsRevoked: %v", err)
+			}
+			if !got {
+				t.Errorf("IsRevoked = false, want true for a token still valid for %s", cfg.Tokens.ImpersonationTTL)
+			}
+		})
+	}
+}
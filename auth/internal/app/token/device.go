@@ -0,0 +1,46 @@
+package token
+
+import "strings"
+
+// userAgentBrowsers и userAgentPlatforms - признаки в User-Agent в порядке
+// проверки: Edge и Opera содержат "Chrome", Chrome содержит "Safari"
+var (
+	userAgentBrowsers = []struct{ marker, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"YaBrowser/", "Яндекс Браузер"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"okhttp/", "Android приложение"},
+		{"CFNetwork/", "iOS приложение"},
+	}
+	userAgentPlatforms = []struct{ marker, name string }{
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// deviceName - название устройства сессии по User-Agent, например
+// "Chrome, Windows". Для неизвестного клиента возвращает пустую строку.
+func deviceName(userAgent string) string {
+	var parts []string
+	for _, b := range userAgentBrowsers {
+		if strings.Contains(userAgent, b.marker) {
+			parts = append(parts, b.name)
+			break
+		}
+	}
+	for _, p := range userAgentPlatforms {
+		if strings.Contains(userAgent, p.marker) {
+			parts = append(parts, p.name)
+			break
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,58 @@
+package token
+
+import (
+	"fmt"
+	"os"
+
+	"marketai/auth/internal/config"
+	"marketai/pkgAuth/jwt"
+)
+
+// NewKeySet собирает набор ключей подписи из конфигурации. Активный ключ
+// подписывает новые токены, выводимые из оборота ключи остаются в JWKS, пока
+// не истекут подписанные ими токены.
+func NewKeySet(cfg *config.Config) (*jwt.KeySet, error) {
+	validation := jwt.ValidationOptions{
+		Issuer:    cfg.Tokens.Issuer,
+		Audience:  cfg.Tokens.Audience,
+		ClockSkew: cfg.Tokens.ClockSkew,
+	}
+
+	keys := cfg.Tokens.Keys
+	if keys.Active.PrivateKeyFile == "" {
+		return jwt.NewKeySet(jwt.NewHMACKey("", cfg.JWTSecret)).WithValidation(validation), nil
+	}
+
+	active, err := loadSigningKey(keys.Active)
+	if err != nil {
+		return nil, err
+	}
+
+	retiring := make([]*jwt.SigningKey, 0, len(keys.Retiring))
+	for _, keyCfg := range keys.Retiring {
+		key, err := loadSigningKey(keyCfg)
+		if err != nil {
+			return nil, err
+		}
+		retiring = append(retiring, key)
+	}
+
+	return jwt.NewKeySet(active, retiring...).WithValidation(validation), nil
+}
+
+func loadSigningKey(keyCfg config.SigningKeyConfig) (*jwt.SigningKey, error) {
+	if keyCfg.ID == "" {
+		return nil, fmt.Errorf("не задан id ключа %s", keyCfg.PrivateKeyFile)
+	}
+
+	data, err := os.ReadFile(keyCfg.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ключа %s: %w", keyCfg.ID, err)
+	}
+
+	key, err := jwt.ParsePrivateKeyPEM(keyCfg.ID, data)
+	if err != nil {
+		return nil, fmt.Errorf("ключ %s: %w", keyCfg.ID, err)
+	}
+	return key, nil
+}
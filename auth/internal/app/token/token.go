@@ -0,0 +1,324 @@
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+	"marketai/pkgAuth/jwt"
+)
+
+const (
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+)
+
+// Pair - короткоживущий токен доступа и refresh токен для его обновления
+type Pair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	Membership   *domain.Membership
+}
+
+// Issuer выпускает и обновляет токены пользователя
+type Issuer struct {
+	refreshRepo   domain.RefreshTokenRepository
+	sessionRepo   domain.SessionRepository
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	rbacRepo      domain.RBACRepository
+	keys          *jwt.KeySet
+	issuer        string
+	audience      string
+	accessTTL     time.Duration
+	refreshTTL    time.Duration
+	// impersonationTTL - время жизни токена администратора, вошедшего от имени пользователя
+	impersonationTTL time.Duration
+}
+
+func NewIssuer(
+	refreshRepo domain.RefreshTokenRepository,
+	sessionRepo domain.SessionRepository,
+	userRepo domain.UserRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	rbacRepo domain.RBACRepository,
+	keys *jwt.KeySet,
+	cfg *config.Config,
+) *Issuer {
+	issuer := &Issuer{
+		refreshRepo:   refreshRepo,
+		sessionRepo:   sessionRepo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		rbacRepo:      rbacRepo,
+		keys:          keys,
+		issuer:        cfg.Tokens.Issuer,
+		audience:      cfg.Tokens.Audience,
+		accessTTL:     cfg.Tokens.AccessTTL,
+		refreshTTL:    cfg.Tokens.RefreshTTL,
+
+		impersonationTTL: cfg.Tokens.ImpersonationTTL,
+	}
+	if issuer.accessTTL <= 0 {
+		issuer.accessTTL = defaultAccessTTL
+	}
+	if issuer.refreshTTL <= 0 {
+		issuer.refreshTTL = defaultRefreshTTL
+	}
+	if issuer.impersonationTTL <= 0 {
+		issuer.impersonationTTL = issuer.accessTTL
+	}
+	return issuer
+}
+
+// MaxAccessTTL - наибольший срок действия выпускаемых токенов доступа. Столько
+// завершенная сессия должна храниться, чтобы ее sid оставался в списке отзыва.
+func (i *Issuer) MaxAccessTTL() time.Duration {
+	return max(i.accessTTL, i.impersonationTTL)
+}
+
+// Issue выпускает пару токенов для нового входа и начинает новую сессию
+// с устройством, IP и User-Agent клиента из контекста запроса
+func (i *Issuer) Issue(ctx context.Context, user *domain.User, membership *domain.Membership) (*Pair, error) {
+	sessionID := uuid.New().String()
+	client := audit.ClientFromContext(ctx)
+
+	return i.issue(ctx, user, membership, sessionID, func(refresh *domain.RefreshToken) error {
+		return i.sessionRepo.CreateSession(ctx, &domain.Session{
+			ID:         sessionID,
+			UserID:     user.ID,
+			Device:     deviceName(client.UserAgent),
+			IP:         client.IP,
+			UserAgent:  client.UserAgent,
+			CreatedAt:  refresh.CreatedAt,
+			LastSeenAt: refresh.CreatedAt,
+			ExpiresAt:  refresh.ExpiresAt,
+		}, refresh)
+	})
+}
+
+// Switch выпускает пару токенов с другим пространством в той же сессии:
+// прежние refresh токены сессии заменяются новым. Токен без сессии,
+// выпущенный до их появления, начинает новую.
+func (i *Issuer) Switch(ctx context.Context, user *domain.User, membership *domain.Membership, sessionID string) (*Pair, error) {
+	if sessionID == "" {
+		return i.Issue(ctx, user, membership)
+	}
+	return i.issue(ctx, user, membership, sessionID, func(refresh *domain.RefreshToken) error {
+		return i.refreshRepo.ReplaceSessionTokens(ctx, refresh)
+	})
+}
+
+// Refresh обменивает refresh токен на новую пару. Предъявленный токен отзывается;
+// повторное предъявление уже отозванного при ротации токена означает его утечку,
+// поэтому отзывается все семейство.
+func (i *Issuer) Refresh(ctx context.Context, rawToken string) (*Pair, error) {
+	current, err := i.refreshRepo.GetRefreshTokenByHash(ctx, HashOpaqueToken(rawToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		if current.ReplacedBy != nil {
+			return nil, i.revokeReused(ctx, current.FamilyID)
+		}
+		return nil, domain.ErrRefreshTokenInvalid
+	}
+	if current.Expired(time.Now()) {
+		return nil, domain.ErrRefreshTokenInvalid
+	}
+
+	user, err := i.userRepo.GetUserByID(ctx, current.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Роль в пространстве могла измениться с момента входа, берем актуальную
+	membership, err := i.membership(ctx, user.ID, current.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := i.issue(ctx, user, membership, current.FamilyID, func(refresh *domain.RefreshToken) error {
+		return i.refreshRepo.RotateRefreshToken(ctx, current.ID, refresh)
+	})
+	if errors.Is(err, domain.ErrRefreshTokenReused) {
+		return nil, i.revokeReused(ctx, current.FamilyID)
+	}
+	return pair, err
+}
+
+// Impersonate выпускает администратору adminID токен доступа от имени user в его
+// пространстве по умолчанию. Refresh токен не выпускается, в claim act - администратор.
+func (i *Issuer) Impersonate(ctx context.Context, user *domain.User, adminID string) (string, time.Duration, error) {
+	if user.Blocked() {
+		return "", 0, domain.ErrUserBlocked
+	}
+
+	membership, err := i.membership(ctx, user.ID, "")
+	if err != nil {
+		return "", 0, err
+	}
+
+	claims, err := i.claims(ctx, user, membership)
+	if err != nil {
+		return "", 0, err
+	}
+	claims.Actor = &jwt.Actor{Subject: adminID}
+
+	accessToken, err := i.keys.Sign(*claims, i.impersonationTTL)
+	if err != nil {
+		return "", 0, fmt.Errorf("ошибка при генерации JWT токена: %w", err)
+	}
+	return accessToken, i.impersonationTTL, nil
+}
+
+// Revoke завершает сессию refresh токена - выход из одной сессии
+func (i *Issuer) Revoke(ctx context.Context, rawToken string) error {
+	current, err := i.refreshRepo.GetRefreshTokenByHash(ctx, HashOpaqueToken(rawToken))
+	if err != nil {
+		return err
+	}
+	return i.refreshRepo.RevokeTokenFamily(ctx, current.FamilyID)
+}
+
+// RevokeAll завершает все сессии пользователя и отзывает их refresh токены
+func (i *Issuer) RevokeAll(ctx context.Context, userID string) error {
+	return i.refreshRepo.RevokeUserTokens(ctx, userID)
+}
+
+// issue выпускает пару токенов сессии sessionID, новый refresh токен сохраняет store
+func (i *Issuer) issue(
+	ctx context.Context,
+	user *domain.User,
+	membership *domain.Membership,
+	sessionID string,
+	store func(refresh *domain.RefreshToken) error,
+) (*Pair, error) {
+	// Заблокированный пользователь не может ни войти, ни обновить токен
+	if user.Blocked() {
+		return nil, domain.ErrUserBlocked
+	}
+
+	accessToken, err := i.accessToken(ctx, user, membership, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации JWT токена: %w", err)
+	}
+
+	rawRefresh, err := NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации refresh токена: %w", err)
+	}
+
+	now := time.Now()
+	refresh := &domain.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  sessionID,
+		TokenHash: HashOpaqueToken(rawRefresh),
+		ExpiresAt: now.Add(i.refreshTTL),
+		CreatedAt: now,
+	}
+	if membership != nil {
+		refresh.WorkspaceID = membership.Workspace.ID
+	}
+
+	if err := store(refresh); err != nil {
+		return nil, err
+	}
+
+	return &Pair{
+		AccessToken:  accessToken,
+		RefreshToken: rawRefresh,
+		ExpiresIn:    i.accessTTL,
+		Membership:   membership,
+	}, nil
+}
+
+func (i *Issuer) accessToken(ctx context.Context, user *domain.User, membership *domain.Membership, sessionID string) (string, error) {
+	claims, err := i.claims(ctx, user, membership)
+	if err != nil {
+		return "", err
+	}
+	claims.SessionID = sessionID
+	return i.keys.Sign(*claims, i.accessTTL)
+}
+
+// claims - роли и права пользователя и его роль в выбранном пространстве.
+// Если пространства нет, токен выпускается без workspace claims.
+func (i *Issuer) claims(ctx context.Context, user *domain.User, membership *domain.Membership) (*jwt.Claims, error) {
+	access, err := i.rbacRepo.GetUserAccess(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении ролей: %w", err)
+	}
+
+	claims := &jwt.Claims{
+		Issuer:        i.issuer,
+		UserID:        user.ID,
+		Role:          access.PrimaryRole(),
+		Roles:         access.Roles,
+		Scope:         strings.Join(access.Permissions, " "),
+		EmailVerified: user.EmailVerified(),
+	}
+	if i.audience != "" {
+		claims.Audience = jwt.Audience{i.audience}
+	}
+	if membership != nil {
+		claims.WorkspaceID = membership.Workspace.ID
+		claims.WorkspaceRole = string(membership.Role)
+	}
+	return claims, nil
+}
+
+func (i *Issuer) membership(ctx context.Context, userID, workspaceID string) (*domain.Membership, error) {
+	if workspaceID != "" {
+		membership, err := i.workspaceRepo.GetMembership(ctx, workspaceID, userID)
+		if err == nil {
+			return membership, nil
+		}
+		if !errors.Is(err, domain.ErrNotWorkspaceMember) {
+			return nil, err
+		}
+	}
+
+	// Пользователя исключили из пространства - переключаем на пространство по умолчанию
+	memberships, err := i.workspaceRepo.GetUserWorkspaces(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DefaultMembership(memberships, userID), nil
+}
+
+func (i *Issuer) revokeReused(ctx context.Context, familyID string) error {
+	if err := i.refreshRepo.RevokeTokenFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return domain.ErrRefreshTokenReused
+}
+
+// HashOpaqueToken - в базе хранится SHA-256 непрозрачного токена (refresh,
+// ссылки из писем), сам токен знает только клиент
+func HashOpaqueToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewOpaqueToken - 256 бит случайных данных в base64url
+func NewOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
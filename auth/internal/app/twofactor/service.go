@@ -0,0 +1,304 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"marketai/auth/internal/app/token"
+	"marketai/auth/internal/config"
+	domain "marketai/auth/internal/domain"
+)
+
+const (
+	defaultIssuer        = "MarketAI"
+	defaultChallengeTTL  = 5 * time.Minute
+	defaultMaxAttempts   = 5
+	defaultRecoveryCodes = 10
+	// recoveryCodeBytes - 40 бит на код, в base32 это 8 символов
+	recoveryCodeBytes = 5
+)
+
+// Challenge - вход, для завершения которого нужен второй фактор
+type Challenge struct {
+	Token string
+	// Enrollment - второй фактор обязателен, но не подключен: сначала нужно его подключить
+	Enrollment bool
+	ExpiresIn  time.Duration
+}
+
+// Setup - данные для подключения приложения-аутентификатора
+type Setup struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// Service проверяет второй фактор: TOTP из приложения-аутентификатора или
+// одноразовый резервный код. Вход по паролю, коду из SMS или через внешнего
+// провайдера при включенном втором факторе завершается не выдачей токенов,
+// а Challenge, который клиент подтверждает кодом.
+type Service struct {
+	repo          domain.TwoFactorRepository
+	issuer        string
+	challengeTTL  time.Duration
+	maxAttempts   int
+	recoveryCodes int
+}
+
+func NewService(repo domain.TwoFactorRepository, cfg *config.Config) *Service {
+	c := cfg.TwoFactor
+	issuer := c.Issuer
+	if issuer == "" {
+		issuer = defaultIssuer
+	}
+	return &Service{
+		repo:          repo,
+		issuer:        issuer,
+		challengeTTL:  durationOr(c.ChallengeTTL, defaultChallengeTTL),
+		maxAttempts:   intOr(c.MaxAttempts, defaultMaxAttempts),
+		recoveryCodes: intOr(c.RecoveryCodes, defaultRecoveryCodes),
+	}
+}
+
+// Challenge возвращает nil, если второй фактор для входа не нужен: он не
+// подключен и не требуется ролью пользователя или его рабочим пространством.
+// login и ip - вход по паролю, его счетчики неудач сбрасываются после второго фактора.
+func (s *Service) Challenge(
+	ctx context.Context,
+	user *domain.User,
+	membership *domain.Membership,
+	login, ip string,
+) (*Challenge, error) {
+	// Заблокированному пользователю незачем вводить код: токены ему не выдадут
+	if user.Blocked() {
+		return nil, domain.ErrUserBlocked
+	}
+
+	enabled, err := s.Enabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	required, err := s.repo.TwoFactorRequired(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при проверке политики второго фактора: %w", err)
+	}
+	if !enabled && !required {
+		return nil, nil
+	}
+
+	challengeToken, err := token.NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации токена входа: %w", err)
+	}
+
+	now := time.Now()
+	challenge := &domain.TwoFactorChallenge{
+		UserID:     user.ID,
+		TokenHash:  token.HashOpaqueToken(challengeToken),
+		Enrollment: !enabled,
+		Login:      login,
+		ClientIP:   ip,
+		ExpiresAt:  now.Add(s.challengeTTL),
+		CreatedAt:  now,
+	}
+	if membership != nil {
+		challenge.WorkspaceID = membership.Workspace.ID
+	}
+	if err := s.repo.CreateChallenge(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("ошибка при сохранении входа: %w", err)
+	}
+
+	return &Challenge{Token: challengeToken, Enrollment: challenge.Enrollment, ExpiresIn: s.challengeTTL}, nil
+}
+
+// Pending возвращает незавершенный вход по токену. Просроченный, завершенный
+// и исчерпавший попытки вход не отличаются от несуществующего.
+func (s *Service) Pending(ctx context.Context, challengeToken string) (*domain.TwoFactorChallenge, error) {
+	challenge, err := s.repo.GetChallengeByHash(ctx, token.HashOpaqueToken(challengeToken))
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UsedAt != nil || time.Now().After(challenge.ExpiresAt) {
+		return nil, domain.ErrTwoFactorChallengeInvalid
+	}
+	if challenge.Attempts >= s.maxAttempts {
+		return nil, domain.ErrTwoFactorAttemptsExceeded
+	}
+	return challenge, nil
+}
+
+// ReserveAttempt засчитывает попытку ввода кода до его проверки и возвращает
+// число попыток. После исчерпания лимита возвращается ErrTwoFactorAttemptsExceeded.
+func (s *Service) ReserveAttempt(ctx context.Context, challenge *domain.TwoFactorChallenge) (int, error) {
+	attempts, err := s.repo.ReserveChallengeAttempt(ctx, challenge.ID, s.maxAttempts)
+	if err != nil && !errors.Is(err, domain.ErrTwoFactorAttemptsExceeded) {
+		return 0, fmt.Errorf("ошибка при учете попытки входа: %w", err)
+	}
+	return attempts, err
+}
+
+// Fail возвращает ошибку для клиента после неверного кода в попытке attempts
+func (s *Service) Fail(attempts int) error {
+	if attempts >= s.maxAttempts {
+		return domain.ErrTwoFactorAttemptsExceeded
+	}
+	return domain.ErrTwoFactorCodeInvalid
+}
+
+func (s *Service) Complete(ctx context.Context, challenge *domain.TwoFactorChallenge) error {
+	return s.repo.ConsumeChallenge(ctx, challenge.ID, time.Now())
+}
+
+func (s *Service) Enabled(ctx context.Context, userID string) (bool, error) {
+	secret, err := s.repo.GetTOTP(ctx, userID)
+	if errors.Is(err, domain.ErrTwoFactorNotEnabled) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении второго фактора: %w", err)
+	}
+	return secret.Confirmed(), nil
+}
+
+func (s *Service) Required(ctx context.Context, userID string) (bool, error) {
+	return s.repo.TwoFactorRequired(ctx, userID)
+}
+
+// StartSetup генерирует новый секрет. Пока он не подтвержден кодом, второй
+// фактор не действует, а повторный вызов заменяет секрет.
+func (s *Service) StartSetup(ctx context.Context, user *domain.User) (*Setup, error) {
+	secret, err := NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации секрета: %w", err)
+	}
+	if err := s.repo.SaveTOTP(ctx, &domain.TOTPSecret{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	account := user.Email
+	if account == "" {
+		account = user.PhoneNumber
+	}
+	return &Setup{Secret: secret, ProvisioningURI: ProvisioningURI(s.issuer, account, secret)}, nil
+}
+
+// Confirm включает второй фактор по первому коду из приложения и возвращает
+// резервные коды. Коды показываются один раз, в базе хранятся только хеши.
+func (s *Service) Confirm(ctx context.Context, userID, code string) ([]string, error) {
+	secret, err := s.repo.GetTOTP(ctx, userID)
+	if errors.Is(err, domain.ErrTwoFactorNotEnabled) {
+		return nil, domain.ErrTwoFactorSetupNotStarted
+	}
+	if err != nil {
+		return nil, err
+	}
+	if secret.Confirmed() {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	now := time.Now()
+	step, ok := Verify(secret.Secret, normalizeCode(code), now)
+	if !ok {
+		return nil, domain.ErrTwoFactorCodeInvalid
+	}
+
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ConfirmTOTP(ctx, userID, step, now, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Verify проверяет код подключенного второго фактора: шестизначный код
+// TOTP принимается один раз, иначе код проверяется как резервный
+func (s *Service) Verify(ctx context.Context, userID, code string) error {
+	secret, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !secret.Confirmed() {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	code = normalizeCode(code)
+	if len(code) == totpDigits {
+		step, ok := Verify(secret.Secret, code, time.Now())
+		if !ok || step <= secret.LastUsedStep {
+			return domain.ErrTwoFactorCodeInvalid
+		}
+		return s.repo.UseTOTPStep(ctx, userID, step)
+	}
+
+	return s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code), time.Now())
+}
+
+// RegenerateRecoveryCodes заменяет все резервные коды новыми
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes, time.Now()); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *Service) RecoveryCodesLeft(ctx context.Context, userID string) (int, error) {
+	return s.repo.CountRecoveryCodes(ctx, userID)
+}
+
+func (s *Service) Disable(ctx context.Context, userID string) error {
+	return s.repo.DeleteTOTP(ctx, userID)
+}
+
+func (s *Service) newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, s.recoveryCodes)
+	hashes := make([]string, s.recoveryCodes)
+	buf := make([]byte, recoveryCodeBytes)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("ошибка при генерации резервных кодов: %w", err)
+		}
+		code := strings.ToLower(base32NoPadding.EncodeToString(buf))
+		codes[i] = code[:4] + "-" + code[4:]
+		hashes[i] = hashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeCode убирает пробелы и дефисы, которые пользователь мог ввести вместе с кодом
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func durationOr(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+func intOr(n, def int) int {
+	if n <= 0 {
+		return def
+	}
+	return n
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"marketai/pkg/bootstrap"
 	"marketai/pkg/grpc"
 	"marketai/pkg/logger"
@@ -18,6 +20,165 @@ type (
 		GrpcServer *grpc.ServerConfig       `mapstructure:"grpc" validate:"required"`
 
 		JWTSecret string `mapstructure:"jwt_secret" env:"JWT_SECRET"`
+
+		Tokens struct {
+			// AccessTTL - время жизни JWT токена доступа
+			AccessTTL time.Duration `mapstructure:"access_ttl"`
+			// RefreshTTL - время жизни refresh токена
+			RefreshTTL time.Duration `mapstructure:"refresh_ttl"`
+			// DenylistRefresh - как часто перечитывать список отозванных токенов из базы
+			DenylistRefresh time.Duration `mapstructure:"denylist_refresh"`
+			// Issuer и Audience записываются в iss и aud токена и проверяются при валидации
+			Issuer   string `mapstructure:"issuer"`
+			Audience string `mapstructure:"audience"`
+			// ImpersonationTTL - время жизни токена для входа администратора от имени
+			// пользователя, по умолчанию равно AccessTTL
+			ImpersonationTTL time.Duration `mapstructure:"impersonation_ttl"`
+			// ClockSkew - допустимое расхождение часов при проверке exp, nbf и iat
+			ClockSkew time.Duration `mapstructure:"clock_skew"`
+			// Keys - ключи подписи токенов. Если активный ключ не задан,
+			// токены подписываются HS256 на JWTSecret, а JWKS пуст
+			Keys struct {
+				Active   SigningKeyConfig   `mapstructure:"active"`
+				Retiring []SigningKeyConfig `mapstructure:"retiring"`
+			} `mapstructure:"keys"`
+		} `mapstructure:"tokens"`
+
+		Mail struct {
+			// Driver - smtp или file. file сохраняет письма в Dir, а при пустом Dir пишет их в лог
+			Driver string `mapstructure:"driver"`
+			From   string `mapstructure:"from"`
+			Dir    string `mapstructure:"dir"`
+			SMTP   struct {
+				Host     string `mapstructure:"host"`
+				Port     string `mapstructure:"port"`
+				Username string `mapstructure:"username"`
+				Password string `mapstructure:"password"`
+			} `mapstructure:"smtp"`
+		} `mapstructure:"mail"`
+
+		Email struct {
+			// VerifyURL, ResetURL и ChangeURL - страницы фронтенда, токен передается в параметре token
+			VerifyURL       string        `mapstructure:"verify_url"`
+			ResetURL        string        `mapstructure:"reset_url"`
+			ChangeURL       string        `mapstructure:"change_url"`
+			VerificationTTL time.Duration `mapstructure:"verification_ttl"`
+			ResetTTL        time.Duration `mapstructure:"reset_ttl"`
+		} `mapstructure:"email"`
+
+		OTP struct {
+			CodeLength int           `mapstructure:"code_length"`
+			TTL        time.Duration `mapstructure:"ttl"`
+			// MaxAttempts - попыток ввода одного кода
+			MaxAttempts int `mapstructure:"max_attempts"`
+			// ResendInterval - не чаще одного кода на номер за интервал
+			ResendInterval time.Duration `mapstructure:"resend_interval"`
+			// MaxPerHour - не больше кодов на номер за час
+			MaxPerHour int `mapstructure:"max_per_hour"`
+		} `mapstructure:"otp"`
+
+		OAuth struct {
+			// StateTTL - сколько ждать возврата пользователя от провайдера
+			StateTTL time.Duration `mapstructure:"state_ttl"`
+			// Providers - провайдеры по имени, включены те, у которых задан client_id
+			Providers map[string]OAuthProviderConfig `mapstructure:"providers"`
+		} `mapstructure:"oauth"`
+
+		Lockout struct {
+			// Window - неудачи старше окна не учитываются
+			Window time.Duration `mapstructure:"window"`
+			// DelayAfter - с какой неудачи включается задержка: BaseDelay, затем вдвое больше, но не больше MaxDelay
+			DelayAfter int           `mapstructure:"delay_after"`
+			BaseDelay  time.Duration `mapstructure:"base_delay"`
+			MaxDelay   time.Duration `mapstructure:"max_delay"`
+			// MaxAccountFailures и MaxIPFailures - после скольких неудач вход блокируется на Duration
+			MaxAccountFailures int           `mapstructure:"max_account_failures"`
+			MaxIPFailures      int           `mapstructure:"max_ip_failures"`
+			Duration           time.Duration `mapstructure:"duration"`
+		} `mapstructure:"lockout"`
+
+		APIKeys struct {
+			// MaxPerUser - действующих ключей у одного пользователя
+			MaxPerUser int `mapstructure:"max_per_user"`
+			// MaxTTL - наибольший срок действия ключа, 0 - ключи могут быть бессрочными
+			MaxTTL time.Duration `mapstructure:"max_ttl"`
+		} `mapstructure:"api_keys"`
+
+		TwoFactor struct {
+			// Issuer - название сервиса в приложении-аутентификаторе
+			Issuer string `mapstructure:"issuer"`
+			// ChallengeTTL - сколько ждать кода второго фактора после ввода пароля
+			ChallengeTTL time.Duration `mapstructure:"challenge_ttl"`
+			// MaxAttempts - попыток ввода кода на один вход
+			MaxAttempts int `mapstructure:"max_attempts"`
+			// RecoveryCodes - сколько резервных кодов выдается за раз
+			RecoveryCodes int `mapstructure:"recovery_codes"`
+			// EncryptionKey - ключ шифрования секретов TOTP в базе, переопределяется TOTP_ENCRYPTION_KEY
+			EncryptionKey string `mapstructure:"encryption_key"`
+		} `mapstructure:"two_factor"`
+
+		Passwords struct {
+			MinLength int `mapstructure:"min_length"`
+			MaxLength int `mapstructure:"max_length"`
+			// MinCharacterClasses - сколько классов символов из четырех (строчные,
+			// заглавные буквы, цифры, остальные символы) должно быть в пароле
+			MinCharacterClasses int `mapstructure:"min_character_classes"`
+			// MaxEmailSimilarity - доля совпадения пароля с именем из email (0..1),
+			// начиная с которой пароль отклоняется; 0 - не проверять
+			MaxEmailSimilarity float64 `mapstructure:"max_email_similarity"`
+			// BreachedFile - файл SHA-1 хешей паролей из утечек в формате HIBP
+			// (HASH или HASH:COUNT в строке, по возрастанию хеша), пусто - не проверять
+			BreachedFile string `mapstructure:"breached_file"`
+
+			Hash struct {
+				// Algorithm - bcrypt или argon2id. Хеши другого алгоритма или с
+				// другими параметрами заменяются при следующем входе
+				Algorithm  string `mapstructure:"algorithm"`
+				BcryptCost int    `mapstructure:"bcrypt_cost"`
+				Argon2     struct {
+					// Memory - память в КиБ
+					Memory      uint32 `mapstructure:"memory"`
+					Iterations  uint32 `mapstructure:"iterations"`
+					Parallelism uint8  `mapstructure:"parallelism"`
+				} `mapstructure:"argon2"`
+			} `mapstructure:"hash"`
+		} `mapstructure:"passwords"`
+
+		Audit struct {
+			// Retention - сколько хранить события журнала безопасности, 0 - бессрочно
+			Retention time.Duration `mapstructure:"retention"`
+			// CleanupInterval - как часто удалять события старше Retention
+			CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+			// RetentionDBUser и RetentionDBPassword - роль базы с правом DELETE на security_events
+			// для очистки по сроку хранения, переопределяются AUDIT_RETENTION_DB_USER и
+			// AUDIT_RETENTION_DB_PASSWORD. Без роли очистка отключена.
+			RetentionDBUser     string `mapstructure:"retention_db_user"`
+			RetentionDBPassword string `mapstructure:"retention_db_password"`
+			// LogSink - дублировать события в лог сервиса, а через logger.kafkaLogger в Kafka
+			LogSink bool `mapstructure:"log_sink"`
+		} `mapstructure:"audit"`
+	}
+
+	// OAuthProviderConfig - внешний провайдер входа. Type - oidc, yandex или vk.
+	// Для oidc адреса берутся из discovery документа Issuer, если не заданы явно,
+	// поэтому для локальной проверки достаточно указать issuer мок-провайдера.
+	OAuthProviderConfig struct {
+		Type         string   `mapstructure:"type"`
+		ClientID     string   `mapstructure:"client_id"`
+		ClientSecret string   `mapstructure:"client_secret"`
+		RedirectURL  string   `mapstructure:"redirect_url"`
+		Issuer       string   `mapstructure:"issuer"`
+		AuthURL      string   `mapstructure:"auth_url"`
+		TokenURL     string   `mapstructure:"token_url"`
+		UserInfoURL  string   `mapstructure:"userinfo_url"`
+		JWKSURL      string   `mapstructure:"jwks_url"`
+		Scopes       []string `mapstructure:"scopes"`
+	}
+
+	// SigningKeyConfig - ключ RSA (RS256) или Ed25519 (EdDSA) в PEM файле
+	SigningKeyConfig struct {
+		ID             string `mapstructure:"id"`
+		PrivateKeyFile string `mapstructure:"private_key_file"`
 	}
 
 	ServerConfig struct {
@@ -3,6 +3,7 @@ package config
 import (
 	"marketai/pkg/postgresql"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -31,6 +32,10 @@ func mapEnv(config *Config, secrets *Secrets) {
 	postgresDbName := os.Getenv("POSTGRES_DB_NAME")
 	postgresDbUser := os.Getenv("POSTGRES_DB_USER")
 	postgresDbPassword := os.Getenv("POSTGRES_DB_PASSWORD")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	totpEncryptionKey := os.Getenv("TOTP_ENCRYPTION_KEY")
+	retentionDBUser := os.Getenv("AUDIT_RETENTION_DB_USER")
+	retentionDBPassword := os.Getenv("AUDIT_RETENTION_DB_PASSWORD")
 
 	config.Http.Port = httpPort
 	config.GrpcServer.Port = grpcPort
@@ -40,4 +45,21 @@ func mapEnv(config *Config, secrets *Secrets) {
 	config.Postgres.DBName = postgresDbName
 	secrets.Postgres.User = postgresDbUser
 	secrets.Postgres.Password = postgresDbPassword
+	if smtpPassword != "" {
+		config.Mail.SMTP.Password = smtpPassword
+	}
+	if totpEncryptionKey != "" {
+		config.TwoFactor.EncryptionKey = totpEncryptionKey
+	}
+	if retentionDBUser != "" {
+		config.Audit.RetentionDBUser = retentionDBUser
+		config.Audit.RetentionDBPassword = retentionDBPassword
+	}
+	// Секреты провайдеров входа: OAUTH_<ИМЯ>_CLIENT_SECRET, например OAUTH_YANDEX_CLIENT_SECRET
+	for name, provider := range config.OAuth.Providers {
+		if secret := os.Getenv("OAUTH_" + strings.ToUpper(name) + "_CLIENT_SECRET"); secret != "" {
+			provider.ClientSecret = secret
+			config.OAuth.Providers[name] = provider
+		}
+	}
 }
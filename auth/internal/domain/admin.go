@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrUserBlocked        = errors.New("учетная запись заблокирована")
+	ErrSelfAdminAction    = errors.New("действие недоступно для собственной учетной записи")
+	ErrImpersonateAdmin   = errors.New("нельзя войти от имени администратора")
+	ErrImpersonateWider   = errors.New("нельзя войти от имени пользователя с правами, которых нет у администратора")
+	ErrImpersonationToken = errors.New("действие недоступно при входе от имени пользователя")
+)
+
+// UserFilter - условия поиска пользователей в админке
+type UserFilter struct {
+	// Query - подстрока email, имени или номера телефона
+	Query string
+	Role  string
+	// Blocked - только заблокированные (true) или только активные (false), nil - все
+	Blocked *bool
+	Limit   int
+	Offset  int
+}
+
+// UserPage - страница результатов поиска и общее число найденных пользователей
+type UserPage struct {
+	Users []*User
+	Total int
+}
+
+type AdminAction string
+
+const (
+	AdminActionBlock          AdminAction = "user.block"
+	AdminActionUnblock        AdminAction = "user.unblock"
+	AdminActionSetRoles       AdminAction = "user.roles"
+	AdminActionPasswordReset  AdminAction = "user.password_reset"
+	AdminActionImpersonate    AdminAction = "user.impersonate"
+	AdminActionResetTwoFactor AdminAction = "user.two_factor_reset"
+	AdminActionUnlockLogin    AdminAction = "login.unlock"
+	AdminActionSaveRole       AdminAction = "role.save"
+	AdminActionDeleteRole     AdminAction = "role.delete"
+)
+
+// AdminActionRecord - запись журнала действий администраторов
+type AdminActionRecord struct {
+	ID           string            `json:"id"`
+	AdminID      string            `json:"admin_id"`
+	Action       AdminAction       `json:"action"`
+	TargetUserID string            `json:"target_user_id,omitempty"`
+	Details      map[string]string `json:"details"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+type AdminActionFilter struct {
+	AdminID      string
+	TargetUserID string
+	Action       AdminAction
+	Limit        int
+	Offset       int
+}
+
+type AdminRepository interface {
+	ListUsers(ctx context.Context, filter UserFilter) (*UserPage, error)
+	// SetUserBlocked блокирует пользователя (blockedAt не nil) или снимает блокировку
+	SetUserBlocked(ctx context.Context, userID string, blockedAt *time.Time, reason string) error
+	RecordAction(ctx context.Context, record *AdminActionRecord) error
+	ListActions(ctx context.Context, filter AdminActionFilter) ([]*AdminActionRecord, int, error)
+}
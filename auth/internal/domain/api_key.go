@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// APIKeyPrefix - начало каждого ключа, по нему ключ узнается в логах и сканерами секретов
+const APIKeyPrefix = "mai_"
+
+var (
+	ErrAPIKeyNotFound = errors.New("API ключ не найден")
+	ErrAPIKeyInvalid  = errors.New("API ключ недействителен")
+	ErrAPIKeyScope    = errors.New("у пользователя нет прав, запрошенных для ключа")
+	ErrAPIKeyScopes   = errors.New("укажите хотя бы одно право ключа")
+	ErrAPIKeyLimit    = errors.New("достигнуто максимальное число API ключей")
+	ErrAPIKeyExpiry   = errors.New("срок действия ключа должен быть в будущем")
+)
+
+// APIKey - персональный ключ для доступа к API без пароля. Сам ключ показывается
+// один раз при создании, в базе хранится его SHA-256.
+type APIKey struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	// WorkspaceID - пространство, от имени которого действует ключ
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	Name        string `json:"name"`
+	// Prefix - начало ключа, чтобы пользователь отличал ключи в списке
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+type APIKeyRepository interface {
+	// CreateAPIKey сохраняет ключ, ErrAPIKeyLimit если у пользователя уже maxActive действующих ключей
+	CreateAPIKey(ctx context.Context, key *APIKey, maxActive int) error
+	// GetAPIKeyByHash возвращает ключ по хешу, ErrAPIKeyInvalid если его нет
+	GetAPIKeyByHash(ctx context.Context, hash string) (*APIKey, error)
+	ListUserAPIKeys(ctx context.Context, userID string) ([]*APIKey, error)
+	// RevokeAPIKey отзывает ключ пользователя, ErrAPIKeyNotFound для чужого или отозванного
+	RevokeAPIKey(ctx context.Context, userID, keyID string, at time.Time) error
+	// TouchAPIKey обновляет время последнего использования не чаще раза в минуту
+	TouchAPIKey(ctx context.Context, keyID string, at time.Time) error
+	// RevokeUserAPIKeys отзывает все действующие ключи пользователя
+	RevokeUserAPIKeys(ctx context.Context, userID string, at time.Time) error
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrOAuthProviderUnknown = errors.New("неизвестный провайдер входа")
+	ErrOAuthStateInvalid    = errors.New("сессия входа через провайдера недействительна или устарела")
+	ErrOAuthExchange        = errors.New("провайдер отклонил код авторизации")
+	ErrOAuthEmailRequired   = errors.New("провайдер не передал email")
+	// ErrOAuthAccountExists - email занят, а провайдер или сам аккаунт не подтвердили,
+	// что адрес принадлежит пользователю
+	ErrOAuthAccountExists = errors.New("пользователь с таким email уже существует: войдите по паролю или подтвердите email, затем войдите через провайдера")
+	ErrIdentityNotFound   = errors.New("внешний аккаунт не привязан")
+)
+
+// Identity - аккаунт пользователя у внешнего провайдера (Яндекс ID, VK ID, Google)
+type Identity struct {
+	ID        string
+	UserID    string
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+// ExternalProfile - данные пользователя, полученные от провайдера после обмена кода
+type ExternalProfile struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FullName      string
+}
+
+// OAuthState - параметры начатого входа через провайдера: state, PKCE verifier и nonce.
+// Хранится до возврата пользователя от провайдера, state в базе хранится хешем.
+type OAuthState struct {
+	StateHash    string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	// BrowserHash - хеш значения cookie, выданной браузеру при старте входа
+	BrowserHash string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// OAuthExchange - данные для обмена кода авторизации на профиль
+type OAuthExchange struct {
+	Code         string
+	State        string
+	CodeVerifier string
+	Nonce        string
+	// DeviceID - передается VK ID вместе с кодом и нужен для обмена
+	DeviceID string
+}
+
+// IdentityProvider - порт внешнего провайдера authorization code flow
+type IdentityProvider interface {
+	Name() string
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, exchange OAuthExchange) (*ExternalProfile, error)
+}
+
+// IdentityProviders - включенные провайдеры по имени из конфигурации
+type IdentityProviders map[string]IdentityProvider
+
+func (p IdentityProviders) Get(name string) (IdentityProvider, error) {
+	provider, ok := p[name]
+	if !ok {
+		return nil, ErrOAuthProviderUnknown
+	}
+	return provider, nil
+}
+
+type IdentityRepository interface {
+	GetIdentity(ctx context.Context, provider, subject string) (*Identity, error)
+	LinkIdentity(ctx context.Context, identity *Identity) error
+	// CreateUserWithIdentity в одной транзакции создает пользователя, его личное пространство и привязку
+	CreateUserWithIdentity(ctx context.Context, user *User, workspace *Workspace, identity *Identity) error
+}
+
+type OAuthStateRepository interface {
+	CreateOAuthState(ctx context.Context, state *OAuthState) error
+	// ConsumeOAuthState возвращает и удаляет действующее состояние, иначе ErrOAuthStateInvalid
+	ConsumeOAuthState(ctx context.Context, stateHash string) (*OAuthState, error)
+	// DeleteExpiredOAuthStates удаляет брошенные входы и возвращает их количество
+	DeleteExpiredOAuthStates(ctx context.Context) (int64, error)
+}
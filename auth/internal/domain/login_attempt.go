@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("неверные учетные данные")
+	ErrLoginThrottled     = errors.New("слишком много неудачных попыток входа, повторите позже")
+	ErrLoginLocked        = errors.New("вход временно заблокирован из-за неудачных попыток")
+	ErrLockoutNotFound    = errors.New("блокировка не найдена")
+)
+
+// LoginBlockedError - вход отклонен до проверки пароля. Оборачивает
+// ErrLoginThrottled или ErrLoginLocked и сообщает, когда можно повторить.
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%s (через %s)", e.Err.Error(), e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// LoginFailure - счетчик неудачных входов по ключу: учетной записи
+// (account:<email или телефон>) или адресу (ip:<адрес>)
+type LoginFailure struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginLockout - запись аудита о блокировке входа
+type LoginLockout struct {
+	ID          string     `json:"id"`
+	Key         string     `json:"key"`
+	Failures    int        `json:"failures"`
+	IP          string     `json:"ip"`
+	LockedUntil time.Time  `json:"locked_until"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty"`
+	UnlockedBy  *string    `json:"unlocked_by,omitempty"`
+}
+
+// LoginAttemptRepository хранит счетчики в Postgres, поэтому ограничения общие для всех экземпляров сервиса
+type LoginAttemptRepository interface {
+	GetLoginFailures(ctx context.Context, keys []string) ([]*LoginFailure, error)
+	// ReserveLoginAttempt в одной транзакции блокирует счетчики keys и передает
+	// их в check. Если check не вернул ошибку, попытка сразу засчитывается как
+	// неудачная (неудача раньше window начинает счет заново): параллельные
+	// попытки видят ее еще до проверки пароля.
+	ReserveLoginAttempt(
+		ctx context.Context,
+		keys []string,
+		window time.Duration,
+		at time.Time,
+		check func(failures []*LoginFailure) error,
+	) ([]*LoginFailure, error)
+	// RefundLoginAttempt возвращает попытку, зарезервированную под успешный вход
+	RefundLoginAttempt(ctx context.Context, key string) error
+	// LockLogin блокирует ключ до lockout.LockedUntil и записывает блокировку в аудит
+	LockLogin(ctx context.Context, lockout *LoginLockout) error
+	ResetLoginFailures(ctx context.Context, key string) error
+	// UnlockLogin снимает блокировку и сбрасывает счетчик, иначе ErrLockoutNotFound
+	UnlockLogin(ctx context.Context, key, unlockedBy string, at time.Time) error
+	GetActiveLockouts(ctx context.Context, at time.Time) ([]*LoginLockout, error)
+}
@@ -2,9 +2,17 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var (
+	ErrUserNotFound  = errors.New("пользователь не найден")
+	ErrEmailTaken    = errors.New("email уже используется другим пользователем")
+	ErrPhoneTaken    = errors.New("номер телефона уже используется другим пользователем")
+	ErrWrongPassword = errors.New("неверный текущий пароль")
+)
+
 type User struct {
 	ID           string    `json:"id"`
 	FullName     string    `json:"fullName"`
@@ -14,17 +22,48 @@ type User struct {
 	Role         string    `json:"role"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	// EmailVerifiedAt - когда пользователь подтвердил email, nil для неподтвержденного
+	EmailVerifiedAt *time.Time `json:"-"`
+	// BlockedAt - когда администратор заблокировал пользователя, nil для активного
+	BlockedAt     *time.Time `json:"-"`
+	BlockedReason string     `json:"-"`
+}
+
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) Blocked() bool {
+	return u.BlockedAt != nil
 }
 
 type GetData struct {
-	ID    string `json:"id"`
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	FullName      string    `json:"fullName"`
+	PhoneNumber   string    `json:"phoneNumber"`
+	Role          string    `json:"role"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 type UserRepository interface {
 	GetUserByUsername(ctx context.Context, email string, phoneNumber string) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	GetUserByID(ctx context.Context, id string) (*User, error)
+	GetUserByPhone(ctx context.Context, phoneNumber string) (*User, error)
 	CreateUser(ctx context.Context, user *User) error
-
-	GetDataByToken(ctx context.Context, token string) (*GetData, error)
+	// CreateUserWithWorkspace в одной транзакции создает пользователя и его личное рабочее
+	// пространство, ErrEmailTaken или ErrPhoneTaken если email или номер телефона заняты
+	CreateUserWithWorkspace(ctx context.Context, user *User, workspace *Workspace) error
+	MarkEmailVerified(ctx context.Context, userID string, at time.Time) error
+	UpdatePassword(ctx context.Context, userID, passwordHash string) error
+	// UpdateProfile сохраняет FullName и PhoneNumber пользователя, ErrPhoneTaken если номер занят
+	UpdateProfile(ctx context.Context, user *User) error
+	// UpdateEmail меняет подтвержденный адрес, ErrEmailTaken если он занят
+	UpdateEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error
+	// AnonymizeUser удаляет учетную запись: персональные данные стираются, запись
+	// остается для ссылок из других таблиц. ErrLastWorkspaceOwner, если без
+	// пользователя в его пространстве с другими участниками не останется владельца
+	AnonymizeUser(ctx context.Context, userID string, at time.Time) error
 }
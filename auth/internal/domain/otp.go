@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrOTPRateLimited      = errors.New("слишком много запросов кода, попробуйте позже")
+	ErrOTPInvalid          = errors.New("неверный или просроченный код")
+	ErrOTPAttemptsExceeded = errors.New("превышено число попыток ввода кода, запросите новый код")
+)
+
+// Назначение одноразового кода: код одного назначения не принимается для другого
+const (
+	OTPPurposeLogin       = "login"
+	OTPPurposePhoneChange = "phone_change"
+)
+
+// OTPCode - одноразовый код по номеру телефона: для входа или подтверждения
+// нового номера. Хранится только хеш кода.
+type OTPCode struct {
+	ID          string
+	PhoneNumber string
+	Purpose     string
+	CodeHash    string
+	Attempts    int
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	ConsumedAt  *time.Time
+}
+
+type OTPRepository interface {
+	// CreateOTP под блокировкой номера проверяет, что с последнего кода того же
+	// назначения прошло resendInterval и за час выпущено меньше maxPerHour кодов
+	// любого назначения, и сохраняет код. ErrOTPRateLimited при превышении лимита
+	CreateOTP(ctx context.Context, code *OTPCode, resendInterval time.Duration, maxPerHour int) error
+	// GetLatestOTP возвращает последний выпущенный на номер код назначения purpose или nil
+	GetLatestOTP(ctx context.Context, purpose, phoneNumber string) (*OTPCode, error)
+	// ReserveOTPAttempt атомарно засчитывает попытку ввода и возвращает номер
+	// попытки, ErrOTPAttemptsExceeded если попытки кода уже исчерпаны
+	ReserveOTPAttempt(ctx context.Context, id string, maxAttempts int) (int, error)
+	// ConsumeOTP гасит код, ErrOTPInvalid если код уже использован
+	ConsumeOTP(ctx context.Context, id string) error
+}
+
+// SMSSender - порт отправки SMS
+type SMSSender interface {
+	Send(ctx context.Context, phoneNumber, text string) error
+}
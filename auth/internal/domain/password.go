@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrPasswordTooShort       = errors.New("пароль слишком короткий")
+	ErrPasswordTooLong        = errors.New("пароль слишком длинный")
+	ErrPasswordTooSimple      = errors.New("пароль слишком простой")
+	ErrPasswordSimilarToEmail = errors.New("пароль похож на email")
+	ErrPasswordBreached       = errors.New("пароль встречается в утечках данных, выберите другой")
+)
+
+// IsPasswordPolicyError - пароль отклонен политикой паролей
+func IsPasswordPolicyError(err error) bool {
+	return errors.Is(err, ErrPasswordTooShort) ||
+		errors.Is(err, ErrPasswordTooLong) ||
+		errors.Is(err, ErrPasswordTooSimple) ||
+		errors.Is(err, ErrPasswordSimilarToEmail) ||
+		errors.Is(err, ErrPasswordBreached)
+}
+
+// BreachedPasswords - хеши паролей из утечек с доступом по k-анонимности, как
+// в API Have I Been Pwned: по первым пяти символам SHA-1 пароля (верхний
+// регистр, hex) возвращаются окончания всех хешей с этим началом. Пароль и
+// его полный хеш источнику не передаются.
+type BreachedPasswords interface {
+	Range(ctx context.Context, prefix string) ([]string, error)
+}
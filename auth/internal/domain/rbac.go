@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Встроенные роли. Новому пользователю всегда назначается RoleUser,
+// клиент роль не выбирает
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Права, которые проверяют сервисы. Токен переносит права ролей пользователя в claim scope
+const (
+	PermissionCardsRead          = "cards:read"
+	PermissionCardsWrite         = "cards:write"
+	PermissionUsersRead          = "users:read"
+	PermissionUsersManage        = "users:manage"
+	PermissionRolesManage        = "roles:manage"
+	PermissionLockoutsManage     = "lockouts:manage"
+	PermissionAuditRead          = "audit:read"
+	PermissionKeywordsManage     = "keywords:manage"
+	PermissionIntegrationsManage = "integrations:manage"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("роль не найдена")
+	ErrUnknownPermission = errors.New("неизвестное право")
+	ErrBuiltinRole       = errors.New("встроенную роль нельзя удалить")
+	ErrRoleInUse         = errors.New("роль назначена пользователям")
+	ErrLastAdmin         = errors.New("должен остаться хотя бы один администратор")
+	ErrRolesRequired     = errors.New("пользователю нужна хотя бы одна роль")
+	ErrInvalidRoleName   = errors.New("имя роли: латинские буквы в нижнем регистре, цифры, _ и -, до 50 символов")
+)
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Builtin     bool     `json:"builtin"`
+	Permissions []string `json:"permissions"`
+	// RequireTwoFactor - пользователи с этой ролью входят только со вторым фактором
+	RequireTwoFactor bool      `json:"require_two_factor"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Access - роли пользователя и объединение их прав
+type Access struct {
+	Roles       []string
+	Permissions []string
+}
+
+func (a *Access) HasRole(role string) bool {
+	for _, r := range a.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// PrimaryRole - роль для claim role и users.role, которые читают старые клиенты
+func (a *Access) PrimaryRole() string {
+	if a.HasRole(RoleAdmin) {
+		return RoleAdmin
+	}
+	if len(a.Roles) > 0 && !a.HasRole(RoleUser) {
+		return a.Roles[0]
+	}
+	return RoleUser
+}
+
+type RBACRepository interface {
+	ListPermissions(ctx context.Context) ([]*Permission, error)
+	ListRoles(ctx context.Context) ([]*Role, error)
+	// SaveRole создает роль или заменяет описание и набор прав существующей,
+	// ErrUnknownPermission для права не из каталога
+	SaveRole(ctx context.Context, role *Role) error
+	// DeleteRole удаляет роль, ErrBuiltinRole для встроенной и ErrRoleInUse для назначенной
+	DeleteRole(ctx context.Context, name string) error
+	GetUserAccess(ctx context.Context, userID string) (*Access, error)
+	// SetUserRoles заменяет роли пользователя, ErrRoleNotFound для неизвестной роли и
+	// ErrLastAdmin, если у единственного администратора снимается роль администратора
+	SetUserRoles(ctx context.Context, userID string, roles []string, assignedBy string) error
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("refresh токен недействителен")
+	// ErrRefreshTokenReused - предъявлен уже использованный токен, вся цепочка отозвана
+	ErrRefreshTokenReused = errors.New("refresh токен использован повторно")
+)
+
+// RefreshToken - непрозрачный токен обновления. В базе хранится только хеш.
+// Токены, полученные ротацией из одного входа, образуют семейство (FamilyID):
+// при повторном использовании старого токена отзывается все семейство.
+// Семейство - это сессия пользователя, FamilyID совпадает с ID сессии.
+type RefreshToken struct {
+	ID          string
+	UserID      string
+	FamilyID    string
+	WorkspaceID string
+	TokenHash   string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	RevokedAt   *time.Time
+	// ReplacedBy - токен, выданный взамен при ротации
+	ReplacedBy *string
+}
+
+func (t *RefreshToken) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+type RefreshTokenRepository interface {
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*RefreshToken, error)
+	// RotateRefreshToken отзывает старый токен и сохраняет новый в одной транзакции.
+	// Если старый токен уже отозван, возвращает ErrRefreshTokenReused.
+	// Сессия токена продлевается до срока действия нового токена.
+	RotateRefreshToken(ctx context.Context, oldID string, next *RefreshToken) error
+	// ReplaceSessionTokens заменяет все действующие токены сессии новым. Если
+	// сессия завершена или истекла, возвращает ErrSessionNotFound.
+	ReplaceSessionTokens(ctx context.Context, next *RefreshToken) error
+	// RevokeTokenFamily и RevokeUserTokens завершают и сессии отзываемых токенов
+	RevokeTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserTokens(ctx context.Context, userID string) error
+}
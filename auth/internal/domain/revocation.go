@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTokenInvalid = errors.New("недействительный токен")
+	ErrTokenRevoked = errors.New("токен отозван")
+)
+
+// RevokedToken - отозванный токен доступа. Запись нужна только до истечения
+// срока действия токена, после этого он отклоняется и без нее.
+type RevokedToken struct {
+	JTI       string
+	UserID    string
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+// Revocations - снимок списка отзыва: отозванные токены по jti, отметки
+// "выход из всех сессий" по пользователям и завершенные сессии. Токены
+// пользователя, выпущенные раньше отметки, и токены завершенных сессий
+// считаются отозванными.
+type Revocations struct {
+	Tokens   map[string]time.Time
+	Users    map[string]time.Time
+	Sessions map[string]time.Time
+}
+
+type TokenRevocationRepository interface {
+	RevokeToken(ctx context.Context, token *RevokedToken) error
+	RevokeUserTokensBefore(ctx context.Context, userID string, before time.Time) error
+	// GetRevocations возвращает действующие отзывы токенов, отметки пользователей
+	// и сессии, завершенные не раньше since
+	GetRevocations(ctx context.Context, since time.Time) (*Revocations, error)
+	// DeleteExpiredRevocations удаляет отзывы истекших к now токенов и отметки
+	// пользователей старше since
+	DeleteExpiredRevocations(ctx context.Context, now, since time.Time) error
+}
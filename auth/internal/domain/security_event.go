@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type SecurityEventType string
+
+const (
+	SecurityEventLoginPassword    SecurityEventType = "login.password"
+	SecurityEventLoginOTP         SecurityEventType = "login.otp"
+	SecurityEventLoginOAuth       SecurityEventType = "login.oauth"
+	SecurityEventLoginTwoFactor   SecurityEventType = "login.two_factor"
+	SecurityEventRegister         SecurityEventType = "user.register"
+	SecurityEventAccountDelete    SecurityEventType = "user.delete"
+	SecurityEventPasswordChange   SecurityEventType = "password.change"
+	SecurityEventPasswordReset    SecurityEventType = "password.reset"
+	SecurityEventEmailChange      SecurityEventType = "email.change"
+	SecurityEventPhoneChange      SecurityEventType = "phone.change"
+	SecurityEventRolesChange      SecurityEventType = "roles.change"
+	SecurityEventTwoFactorEnable  SecurityEventType = "two_factor.enable"
+	SecurityEventTwoFactorDisable SecurityEventType = "two_factor.disable"
+	SecurityEventAPIKeyCreate     SecurityEventType = "api_key.create"
+	SecurityEventAPIKeyRevoke     SecurityEventType = "api_key.revoke"
+	SecurityEventSessionRevoke    SecurityEventType = "session.revoke"
+)
+
+type SecurityOutcome string
+
+const (
+	SecurityOutcomeSuccess SecurityOutcome = "success"
+	SecurityOutcomeFailure SecurityOutcome = "failure"
+)
+
+// SecurityEvent - запись журнала безопасности. Журнал только дополняется,
+// записи удаляются лишь по истечении срока хранения.
+type SecurityEvent struct {
+	ID      string            `json:"id"`
+	Type    SecurityEventType `json:"type"`
+	Outcome SecurityOutcome   `json:"outcome"`
+	UserID  string            `json:"user_id,omitempty"`
+	// ActorID - кто совершил действие, если не сам пользователь
+	ActorID string `json:"actor_id,omitempty"`
+	// Login - введенный логин: при неудачном входе пользователь может быть неизвестен
+	Login     string `json:"login,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	// Reason - причина неудачи
+	Reason    string            `json:"reason,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type SecurityEventFilter struct {
+	UserID  string
+	Type    SecurityEventType
+	Outcome SecurityOutcome
+	IP      string
+	// From и To ограничивают время события, nil - без ограничения
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+type SecurityEventRepository interface {
+	RecordSecurityEvent(ctx context.Context, event *SecurityEvent) error
+	ListSecurityEvents(ctx context.Context, filter SecurityEventFilter) ([]*SecurityEvent, int, error)
+}
+
+// SecurityEventPurger удаляет события по сроку хранения. Работает под отдельной
+// ролью базы: у роли приложения нет права удалять события журнала.
+type SecurityEventPurger interface {
+	// DeleteSecurityEventsBefore удаляет события старше before и возвращает их число
+	DeleteSecurityEventsBefore(ctx context.Context, before time.Time) (int64, error)
+}
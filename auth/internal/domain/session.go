@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrSessionNotFound = errors.New("сессия не найдена")
+
+// Session - вход пользователя с одного устройства. Сессию продолжают refresh
+// токены одного семейства: ID сессии совпадает с их FamilyID и передается
+// в claim sid токенов доступа, поэтому завершение сессии отзывает и их.
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"-"`
+	Device    string    `json:"device"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+	// LastSeenAt - вход или последнее обновление токена
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"-"`
+}
+
+type SessionRepository interface {
+	// CreateSession сохраняет сессию вместе с ее первым refresh токеном
+	CreateSession(ctx context.Context, session *Session, first *RefreshToken) error
+	// ListUserSessions возвращает незавершенные и не истекшие сессии, последние активные первыми
+	ListUserSessions(ctx context.Context, userID string, now time.Time) ([]*Session, error)
+	// RevokeSession завершает сессию пользователя и отзывает ее refresh токены.
+	// Если действующей сессии нет, возвращает ErrSessionNotFound.
+	RevokeSession(ctx context.Context, userID, sessionID string, now time.Time) error
+	// DeleteEndedSessions удаляет сессии, завершенные или истекшие до before,
+	// вместе с их refresh токенами и возвращает их количество
+	DeleteEndedSessions(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTwoFactorNotEnabled       = errors.New("двухфакторная аутентификация не подключена")
+	ErrTwoFactorAlreadyEnabled   = errors.New("двухфакторная аутентификация уже подключена")
+	ErrTwoFactorSetupNotStarted  = errors.New("сначала начните подключение двухфакторной аутентификации")
+	ErrTwoFactorCodeInvalid      = errors.New("неверный код подтверждения")
+	ErrTwoFactorChallengeInvalid = errors.New("время на подтверждение входа истекло, войдите заново")
+	ErrTwoFactorAttemptsExceeded = errors.New("превышено число попыток ввода кода, войдите заново")
+	ErrTwoFactorRequired         = errors.New("двухфакторная аутентификация обязательна для вашей учетной записи")
+)
+
+// TOTPSecret - секрет приложения-аутентификатора (RFC 6238)
+type TOTPSecret struct {
+	UserID string
+	// Secret - ключ в base32, как в provisioning URI
+	Secret      string
+	ConfirmedAt *time.Time
+	// LastUsedStep - последний принятый интервал, защищает от повторного использования кода
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+func (s *TOTPSecret) Confirmed() bool {
+	return s.ConfirmedAt != nil
+}
+
+// TwoFactorChallenge - вход, ожидающий второго фактора. Клиент получает
+// непрозрачный токен, в базе хранится его хеш.
+type TwoFactorChallenge struct {
+	ID          string
+	UserID      string
+	TokenHash   string
+	WorkspaceID string
+	// Enrollment - второй фактор обязателен, но еще не подключен
+	Enrollment bool
+	Attempts   int
+	// Login и ClientIP - вход по паролю, счетчики неудач которого сбрасываются
+	// только после второго фактора. Пустые для входа по SMS и через провайдера.
+	Login     string
+	ClientIP  string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type TwoFactorRepository interface {
+	// GetTOTP возвращает секрет пользователя, ErrTwoFactorNotEnabled если подключение не начиналось
+	GetTOTP(ctx context.Context, userID string) (*TOTPSecret, error)
+	// SaveTOTP начинает подключение заново, заменяя неподтвержденный секрет
+	SaveTOTP(ctx context.Context, secret *TOTPSecret) error
+	// ConfirmTOTP подтверждает секрет и заменяет коды восстановления в одной транзакции
+	ConfirmTOTP(ctx context.Context, userID string, step int64, at time.Time, recoveryCodeHashes []string) error
+	// UseTOTPStep запоминает принятый интервал, ErrTwoFactorCodeInvalid если он уже использован
+	UseTOTPStep(ctx context.Context, userID string, step int64) error
+	// DeleteTOTP отключает второй фактор и удаляет коды восстановления
+	DeleteTOTP(ctx context.Context, userID string) error
+
+	ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string, at time.Time) error
+	// UseRecoveryCode гасит код восстановления, ErrTwoFactorCodeInvalid если такого нет
+	UseRecoveryCode(ctx context.Context, userID, hash string, at time.Time) error
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+
+	// TwoFactorRequired - второй фактор требует одна из ролей пользователя или одно из его пространств
+	TwoFactorRequired(ctx context.Context, userID string) (bool, error)
+	SetWorkspaceTwoFactor(ctx context.Context, workspaceID string, required bool) error
+
+	CreateChallenge(ctx context.Context, challenge *TwoFactorChallenge) error
+	// GetChallengeByHash возвращает вход, ErrTwoFactorChallengeInvalid если его нет
+	GetChallengeByHash(ctx context.Context, hash string) (*TwoFactorChallenge, error)
+	// ReserveChallengeAttempt засчитывает попытку ввода кода до проверки и возвращает
+	// число попыток, ErrTwoFactorAttemptsExceeded если лимит уже исчерпан
+	ReserveChallengeAttempt(ctx context.Context, id string, maxAttempts int) (int, error)
+	// ConsumeChallenge гасит вход, ErrTwoFactorChallengeInvalid если он уже использован
+	ConsumeChallenge(ctx context.Context, id string, at time.Time) error
+}
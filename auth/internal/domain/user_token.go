@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrUserTokenInvalid = errors.New("ссылка недействительна или устарела")
+	ErrEmailNotVerified = errors.New("email не подтвержден")
+)
+
+// UserTokenPurpose - назначение одноразового токена из письма
+type UserTokenPurpose string
+
+const (
+	UserTokenEmailVerification UserTokenPurpose = "email_verification"
+	UserTokenPasswordReset     UserTokenPurpose = "password_reset"
+	UserTokenEmailChange       UserTokenPurpose = "email_change"
+)
+
+// UserToken - одноразовый токен со ссылки из письма. В базе хранится только хеш.
+type UserToken struct {
+	ID        string
+	UserID    string
+	Purpose   UserTokenPurpose
+	TokenHash string
+	// Payload - данные для назначения токена, для смены email - новый адрес
+	Payload   string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+type UserTokenRepository interface {
+	CreateUserToken(ctx context.Context, token *UserToken) error
+	// GetUserToken возвращает действующий токен, не гася его, иначе ErrUserTokenInvalid
+	GetUserToken(ctx context.Context, purpose UserTokenPurpose, tokenHash string) (*UserToken, error)
+	// ConsumeUserToken гасит действующий токен и возвращает его, иначе ErrUserTokenInvalid
+	ConsumeUserToken(ctx context.Context, purpose UserTokenPurpose, tokenHash string) (*UserToken, error)
+	// InvalidateUserTokens гасит все неиспользованные токены пользователя с этим назначением
+	InvalidateUserTokens(ctx context.Context, userID string, purpose UserTokenPurpose) error
+}
+
+// Mail - письмо пользователю
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer - порт отправки писем
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+	WorkspaceRoleEditor WorkspaceRole = "editor"
+	WorkspaceRoleViewer WorkspaceRole = "viewer"
+)
+
+var (
+	ErrWorkspaceNotFound    = errors.New("рабочее пространство не найдено")
+	ErrNotWorkspaceMember   = errors.New("пользователь не состоит в рабочем пространстве")
+	ErrWorkspaceForbidden   = errors.New("недостаточно прав в рабочем пространстве")
+	ErrLastWorkspaceOwner   = errors.New("в рабочем пространстве должен остаться хотя бы один владелец")
+	ErrMemberAlreadyExists  = errors.New("пользователь уже состоит в рабочем пространстве")
+	ErrInvalidWorkspaceRole = errors.New("неизвестная роль в рабочем пространстве")
+)
+
+func (r WorkspaceRole) Valid() bool {
+	switch r {
+	case WorkspaceRoleOwner, WorkspaceRoleEditor, WorkspaceRoleViewer:
+		return true
+	}
+	return false
+}
+
+// CanManageMembers - приглашать, удалять участников и менять им роли может только владелец
+func (r WorkspaceRole) CanManageMembers() bool {
+	return r == WorkspaceRoleOwner
+}
+
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID string        `json:"workspace_id"`
+	UserID      string        `json:"user_id"`
+	Email       string        `json:"email"`
+	FullName    string        `json:"fullName"`
+	Role        WorkspaceRole `json:"role"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// Membership - рабочее пространство вместе с ролью пользователя в нем
+type Membership struct {
+	Workspace *Workspace
+	Role      WorkspaceRole
+}
+
+type WorkspaceRepository interface {
+	// CreateWorkspace создает пространство и добавляет его создателя владельцем
+	CreateWorkspace(ctx context.Context, workspace *Workspace) error
+	GetMembership(ctx context.Context, workspaceID, userID string) (*Membership, error)
+	GetUserWorkspaces(ctx context.Context, userID string) ([]*Membership, error)
+	GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]*WorkspaceMember, error)
+	AddMember(ctx context.Context, member *WorkspaceMember) error
+	UpdateMemberRole(ctx context.Context, workspaceID, userID string, role WorkspaceRole) error
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+	CountOwners(ctx context.Context, workspaceID string) (int, error)
+}
+
+// DefaultMembership выбирает пространство для входа, если клиент его не указал:
+// личное пространство пользователя, а если его нет - самое раннее из доступных.
+// Список ожидается отсортированным по дате создания.
+func DefaultMembership(memberships []*Membership, userID string) *Membership {
+	for _, m := range memberships {
+		if m.Workspace.OwnerID == userID {
+			return m
+		}
+	}
+	if len(memberships) > 0 {
+		return memberships[0]
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+package ports
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/auth/internal/app/query"
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Блокировки входа
+// @Description	Действующие блокировки входа по учетной записи и IP. Только для администраторов.
+// @Tags			admin
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		domain.LoginLockout
+// @Failure		403	{object}	problem.Problem	"Недостаточно прав"
+// @Router			/admin/lockouts [get]
+func (rc *httpServer) listLockoutsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		lockouts, err := a.Queries.GetLockouts.Handle(c.Request().Context())
+		if err != nil {
+			log.Printf("Ошибка получения блокировок входа: %v", err)
+			return problem.ErrInternal
+		}
+		if lockouts == nil {
+			lockouts = []*domain.LoginLockout{}
+		}
+
+		return c.JSON(http.StatusOK, lockouts)
+	}
+}
+
+// @Summary		Снятие блокировки входа
+// @Description	Снимает блокировку и сбрасывает счетчик неудачных попыток для логина или IP. Только для администраторов.
+// @Tags			admin
+// @Accept			json
+// @Security		BearerAuth
+// @Param			input	body	dto.UnlockLoginRequest	true	"Логин или IP"
+// @Success		204
+// @Failure		400	{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		404	{object}	problem.Problem	"Блокировка не найдена"
+// @Router			/admin/lockouts/unlock [post]
+func (rc *httpServer) unlockLoginHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.UnlockLoginRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		req.Login, req.IP = strings.TrimSpace(req.Login), strings.TrimSpace(req.IP)
+		if (req.Login == "") == (req.IP == "") {
+			return errLockoutTargetRequired
+		}
+
+		admin := claimsFromContext(c)
+		err := a.Commands.UnlockLogin.Handle(c.Request().Context(), command.UnlockLoginCommand{
+			Login:   req.Login,
+			IP:      req.IP,
+			AdminID: admin.UserID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Пользователи
+// @Description	Поиск пользователей по email, имени или телефону с фильтрами и постраничным выводом. Требуется право users:read.
+// @Tags			admin
+// @Produce		json
+// @Security		BearerAuth
+// @Param			q			query		string	false	"Поиск по email, имени или телефону"
+// @Param			role		query		string	false	"Роль"
+// @Param			blocked		query		bool	false	"true - заблокированные, false - активные"
+// @Param			page		query		int		false	"Страница, с 1"
+// @Param			page_size	query		int		false	"Размер страницы, до 100"
+// @Success		200			{object}	dto.ListUsersResponse
+// @Failure		403			{object}	problem.Problem	"Недостаточно прав"
+// @Router			/admin/users [get]
+func (rc *httpServer) listUsersHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ListUsersRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		var blocked *bool
+		if req.Blocked != "" {
+			value := req.Blocked == "true"
+			blocked = &value
+		}
+
+		result, err := a.Queries.ListUsers.Handle(c.Request().Context(), query.ListUsersQuery{
+			Search:   req.Query,
+			Role:     req.Role,
+			Blocked:  blocked,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		response := dto.ListUsersResponse{
+			Users:    make([]dto.AdminUserResponse, 0, len(result.Users)),
+			Total:    result.Total,
+			Page:     result.Page,
+			PageSize: result.PageSize,
+		}
+		for _, user := range result.Users {
+			response.Users = append(response.Users, adminUserResponse(user))
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// @Summary		Пользователь
+// @Description	Данные пользователя, его роли, права и рабочие пространства. Требуется право users:read.
+// @Tags			admin
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"ID пользователя"
+// @Success		200	{object}	dto.AdminUserResponse
+// @Failure		404	{object}	problem.Problem	"Пользователь не найден"
+// @Router			/admin/users/{id} [get]
+func (rc *httpServer) getUserHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := a.Queries.GetUser.Handle(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return domainError(err)
+		}
+
+		response := adminUserResponse(result.User)
+		response.Roles = result.Access.Roles
+		response.Permissions = result.Access.Permissions
+		for _, m := range result.Memberships {
+			response.Workspaces = append(response.Workspaces, workspaceResponse(m))
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// @Summary		Блокировка пользователя
+// @Description	Блокирует вход пользователя и завершает все его сессии. Требуется право users:manage.
+// @Tags			admin
+// @Accept			json
+// @Security		BearerAuth
+// @Param			id		path	string					true	"ID пользователя"
+// @Param			input	body	dto.BlockUserRequest	false	"Причина"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"Пользователь не найден"
+// @Failure		409	{object}	problem.Problem	"Нельзя заблокировать себя"
+// @Router			/admin/users/{id}/block [post]
+func (rc *httpServer) blockUserHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.BlockUserRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		err := a.Commands.BlockUser.Handle(c.Request().Context(), command.BlockUserCommand{
+			UserID:  c.Param("id"),
+			AdminID: claimsFromContext(c).UserID,
+			Reason:  req.Reason,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Разблокировка пользователя
+// @Description	Снимает блокировку пользователя. Требуется право users:manage.
+// @Tags			admin
+// @Security		BearerAuth
+// @Param			id	path	string	true	"ID пользователя"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"Пользователь не найден"
+// @Router			/admin/users/{id}/unblock [post]
+func (rc *httpServer) unblockUserHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := a.Commands.UnblockUser.Handle(c.Request().Context(), command.UnblockUserCommand{
+			UserID:  c.Param("id"),
+			AdminID: claimsFromContext(c).UserID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Принудительная смена пароля
+// @Description	Сбрасывает пароль пользователя, завершает все его сессии и отправляет ссылку для установки нового пароля. Требуется право users:manage.
+// @Tags			admin
+// @Security		BearerAuth
+// @Param			id	path	string	true	"ID пользователя"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"Пользователь не найден"
+// @Router			/admin/users/{id}/password-reset [post]
+func (rc *httpServer) forcePasswordResetHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := a.Commands.ForcePasswordReset.Handle(c.Request().Context(), command.ForcePasswordResetCommand{
+			UserID:  c.Param("id"),
+			AdminID: claimsFromContext(c).UserID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Вход от имени пользователя
+// @Description	Выпускает токен доступа от имени пользователя для поддержки, без refresh токена. В токене claim act с ID администратора. Требуется право users:manage.
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string					true	"ID пользователя"
+// @Param			input	body		dto.ImpersonateRequest	true	"Причина входа"
+// @Success		200		{object}	dto.ImpersonateResponse
+// @Failure		403		{object}	problem.Problem	"Пользователь заблокирован, является администратором или имеет права, которых нет у администратора"
+// @Failure		404		{object}	problem.Problem	"Пользователь не найден"
+// @Router			/admin/users/{id}/impersonate [post]
+func (rc *httpServer) impersonateHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ImpersonateRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		result, err := a.Commands.Impersonate.Handle(c.Request().Context(), command.ImpersonateCommand{
+			UserID:  c.Param("id"),
+			AdminID: claimsFromContext(c).UserID,
+			Reason:  req.Reason,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.ImpersonateResponse{
+			Token:     result.Token,
+			ExpiresIn: int64(result.ExpiresIn.Seconds()),
+		})
+	}
+}
+
+// @Summary		Журнал действий администраторов
+// @Description	Действия администраторов, новые первыми. Требуется право users:read.
+// @Tags			admin
+// @Produce		json
+// @Security		BearerAuth
+// @Param			admin_id	query		string	false	"ID администратора"
+// @Param			user_id		query		string	false	"ID пользователя, над которым выполнено действие"
+// @Param			action		query		string	false	"Действие, например user.block"
+// @Param			page		query		int		false	"Страница, с 1"
+// @Param			page_size	query		int		false	"Размер страницы, до 100"
+// @Success		200			{object}	dto.AdminActionsResponse
+// @Router			/admin/audit [get]
+func (rc *httpServer) listAdminActionsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ListAdminActionsRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		result, err := a.Queries.ListAdminActions.Handle(c.Request().Context(), query.ListAdminActionsQuery{
+			AdminID:      req.AdminID,
+			TargetUserID: req.UserID,
+			Action:       req.Action,
+			Page:         req.Page,
+			PageSize:     req.PageSize,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+		if result.Actions == nil {
+			result.Actions = []*domain.AdminActionRecord{}
+		}
+
+		return c.JSON(http.StatusOK, dto.AdminActionsResponse{
+			Actions:  result.Actions,
+			Total:    result.Total,
+			Page:     result.Page,
+			PageSize: result.PageSize,
+		})
+	}
+}
+
+func adminUserResponse(user *domain.User) dto.AdminUserResponse {
+	return dto.AdminUserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified(),
+		FullName:      user.FullName,
+		PhoneNumber:   user.PhoneNumber,
+		Role:          user.Role,
+		Blocked:       user.Blocked(),
+		BlockedAt:     user.BlockedAt,
+		BlockedReason: user.BlockedReason,
+		CreatedAt:     user.CreatedAt,
+	}
+}
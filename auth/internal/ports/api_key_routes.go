@@ -0,0 +1,95 @@
+package ports
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Создание API ключа
+// @Description	Выпускает ключ для доступа к API cards по заголовку X-API-Key. Ключ возвращается только в этом ответе, права ключа - подмножество прав пользователя.
+// @Tags			api-keys
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			input	body		dto.CreateAPIKeyRequest	true	"Название, права, пространство и срок действия"
+// @Success		201		{object}	dto.CreateAPIKeyResponse
+// @Failure		400		{object}	problem.Problem	"Неверные права или срок действия"
+// @Failure		403		{object}	problem.Problem	"Нет доступа к пространству"
+// @Failure		409		{object}	problem.Problem	"Достигнуто максимальное число ключей"
+// @Router			/api-keys [post]
+func (rc *httpServer) createAPIKeyHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.CreateAPIKeyRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		result, err := a.Commands.CreateAPIKey.Handle(c.Request().Context(), command.CreateAPIKeyCommand{
+			UserID:      claimsFromContext(c).UserID,
+			Name:        req.Name,
+			WorkspaceID: req.WorkspaceID,
+			Scopes:      req.Scopes,
+			ExpiresAt:   req.ExpiresAt,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusCreated, dto.CreateAPIKeyResponse{
+			Key:    result.RawKey,
+			APIKey: result.Key,
+		})
+	}
+}
+
+// @Summary		API ключи
+// @Description	Ключи пользователя, включая отозванные и просроченные. Сами ключи не возвращаются.
+// @Tags			api-keys
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}	domain.APIKey
+// @Router			/api-keys [get]
+func (rc *httpServer) listAPIKeysHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		keys, err := a.Queries.ListAPIKeys.Handle(c.Request().Context(), claimsFromContext(c).UserID)
+		if err != nil {
+			return domainError(err)
+		}
+		if keys == nil {
+			keys = []*domain.APIKey{}
+		}
+
+		return c.JSON(http.StatusOK, keys)
+	}
+}
+
+// @Summary		Отзыв API ключа
+// @Tags			api-keys
+// @Security		BearerAuth
+// @Param			id	path	string	true	"ID ключа"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"API ключ не найден"
+// @Router			/api-keys/{id} [delete]
+func (rc *httpServer) revokeAPIKeyHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := a.Commands.RevokeAPIKey.Handle(c.Request().Context(), command.RevokeAPIKeyCommand{
+			UserID: claimsFromContext(c).UserID,
+			KeyID:  c.Param("id"),
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,111 @@
+package ports
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Подтверждение email
+// @Description	Подтверждает email по токену из письма. Токен одноразовый.
+// @Tags			auth
+// @Accept			json
+// @Param			input	body	dto.VerifyEmailRequest	true	"Токен из письма"
+// @Success		204
+// @Failure		400	{object}	problem.Problem	"Ссылка недействительна или устарела"
+// @Router			/verify-email [post]
+func (rc *httpServer) verifyEmailHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.VerifyEmailRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		if err := a.Commands.VerifyEmail.Handle(c.Request().Context(), req.Token); err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Повторная отправка письма подтверждения
+// @Description	Отправляет новую ссылку подтверждения email, предыдущие ссылки перестают действовать.
+// @Tags			auth
+// @Security		BearerAuth
+// @Success		202
+// @Failure		401	{object}	problem.Problem	"Неверный токен"
+// @Router			/verify-email/send [post]
+func (rc *httpServer) sendVerificationHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := a.Commands.SendVerification.Handle(c.Request().Context(), claimsFromContext(c).UserID); err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	}
+}
+
+// @Summary		Запрос смены пароля
+// @Description	Отправляет ссылку для смены пароля. Ответ не зависит от того, зарегистрирован ли email.
+// @Tags			auth
+// @Accept			json
+// @Param			input	body	dto.ForgotPasswordRequest	true	"Email пользователя"
+// @Success		202
+// @Failure		400	{object}	problem.Problem	"Неверный формат запроса"
+// @Router			/password/forgot [post]
+func (rc *httpServer) forgotPasswordHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ForgotPasswordRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		if err := a.Commands.ForgotPassword.Handle(c.Request().Context(), req.Email); err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	}
+}
+
+// @Summary		Смена пароля по ссылке
+// @Description	Устанавливает новый пароль по токену из письма и завершает все сессии пользователя.
+// @Tags			auth
+// @Accept			json
+// @Param			input	body	dto.ResetPasswordRequest	true	"Токен из письма и новый пароль"
+// @Success		204
+// @Failure		400	{object}	problem.Problem	"Ссылка недействительна или устарела, пароль не соответствует политике"
+// @Router			/password/reset [post]
+func (rc *httpServer) resetPasswordHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ResetPasswordRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		err := a.Commands.ResetPassword.Handle(c.Request().Context(), command.ResetPasswordCommand{
+			Token:    req.Token,
+			Password: req.Password,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
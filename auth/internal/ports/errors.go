@@ -0,0 +1,176 @@
+package ports
+
+import (
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// Ошибки API сервиса авторизации, не связанные с доменными ошибками
+var (
+	errSessionEnded          = problem.New(http.StatusUnauthorized, "session_ended", "Сессия завершена")
+	errLockoutTargetRequired = problem.New(http.StatusBadRequest, "lockout_target_required", "Укажите логин или IP адрес")
+	errRoleUnknown           = problem.New(http.StatusBadRequest, "role_unknown", "Неизвестная роль")
+)
+
+// domainErrors сопоставляет доменные ошибки с ответами API. Русский текст
+// берется из самой доменной ошибки.
+var domainErrors = problem.Mapping{
+	{Target: domain.ErrInvalidCredentials, Status: http.StatusUnauthorized, Code: "invalid_credentials"},
+	{Target: domain.ErrLoginThrottled, Status: http.StatusTooManyRequests, Code: "login_throttled"},
+	{Target: domain.ErrLoginLocked, Status: http.StatusLocked, Code: "login_locked"},
+	{Target: domain.ErrLockoutNotFound, Status: http.StatusNotFound, Code: "lockout_not_found"},
+
+	{Target: domain.ErrPasswordTooShort, Status: http.StatusBadRequest, Code: "password_too_short"},
+	{Target: domain.ErrPasswordTooLong, Status: http.StatusBadRequest, Code: "password_too_long"},
+	{Target: domain.ErrPasswordTooSimple, Status: http.StatusBadRequest, Code: "password_too_simple"},
+	{Target: domain.ErrPasswordSimilarToEmail, Status: http.StatusBadRequest, Code: "password_similar_to_email"},
+	{Target: domain.ErrPasswordBreached, Status: http.StatusBadRequest, Code: "password_breached"},
+
+	{Target: domain.ErrUserNotFound, Status: http.StatusNotFound, Code: "user_not_found"},
+	{Target: domain.ErrEmailTaken, Status: http.StatusConflict, Code: "email_taken"},
+	{Target: domain.ErrPhoneTaken, Status: http.StatusConflict, Code: "phone_taken"},
+	{Target: domain.ErrWrongPassword, Status: http.StatusForbidden, Code: "wrong_password"},
+	{Target: domain.ErrUserTokenInvalid, Status: http.StatusBadRequest, Code: "link_invalid"},
+	{Target: domain.ErrEmailNotVerified, Status: http.StatusForbidden, Code: "email_not_verified"},
+
+	{Target: domain.ErrTokenInvalid, Status: http.StatusUnauthorized, Code: "token_invalid"},
+	{Target: domain.ErrTokenRevoked, Status: http.StatusUnauthorized, Code: "token_revoked"},
+	{Target: domain.ErrRefreshTokenInvalid, Status: http.StatusUnauthorized, Code: "refresh_token_invalid"},
+	{Target: domain.ErrRefreshTokenReused, Status: http.StatusUnauthorized, Code: "refresh_token_reused"},
+	{Target: domain.ErrSessionNotFound, Status: http.StatusNotFound, Code: "session_not_found"},
+
+	{Target: domain.ErrOAuthProviderUnknown, Status: http.StatusNotFound, Code: "oauth_provider_unknown"},
+	{Target: domain.ErrOAuthStateInvalid, Status: http.StatusBadRequest, Code: "oauth_state_invalid"},
+	{Target: domain.ErrOAuthExchange, Status: http.StatusUnauthorized, Code: "oauth_exchange_failed"},
+	{Target: domain.ErrOAuthEmailRequired, Status: http.StatusBadRequest, Code: "oauth_email_required"},
+	{Target: domain.ErrOAuthAccountExists, Status: http.StatusConflict, Code: "oauth_account_exists"},
+	{Target: domain.ErrIdentityNotFound, Status: http.StatusNotFound, Code: "identity_not_found"},
+
+	{Target: domain.ErrOTPRateLimited, Status: http.StatusTooManyRequests, Code: "otp_rate_limited"},
+	{Target: domain.ErrOTPInvalid, Status: http.StatusUnauthorized, Code: "otp_invalid"},
+	{Target: domain.ErrOTPAttemptsExceeded, Status: http.StatusTooManyRequests, Code: "otp_attempts_exceeded"},
+
+	{Target: domain.ErrTwoFactorNotEnabled, Status: http.StatusBadRequest, Code: "two_factor_not_enabled"},
+	{Target: domain.ErrTwoFactorAlreadyEnabled, Status: http.StatusConflict, Code: "two_factor_already_enabled"},
+	{Target: domain.ErrTwoFactorSetupNotStarted, Status: http.StatusBadRequest, Code: "two_factor_setup_not_started"},
+	{Target: domain.ErrTwoFactorCodeInvalid, Status: http.StatusUnauthorized, Code: "two_factor_code_invalid"},
+	{Target: domain.ErrTwoFactorChallengeInvalid, Status: http.StatusUnauthorized, Code: "two_factor_challenge_invalid"},
+	{Target: domain.ErrTwoFactorAttemptsExceeded, Status: http.StatusTooManyRequests, Code: "two_factor_attempts_exceeded"},
+	{Target: domain.ErrTwoFactorRequired, Status: http.StatusForbidden, Code: "two_factor_required"},
+
+	{Target: domain.ErrUserBlocked, Status: http.StatusForbidden, Code: "user_blocked"},
+	{Target: domain.ErrSelfAdminAction, Status: http.StatusConflict, Code: "self_admin_action"},
+	{Target: domain.ErrImpersonateAdmin, Status: http.StatusForbidden, Code: "impersonate_admin"},
+	{Target: domain.ErrImpersonateWider, Status: http.StatusForbidden, Code: "impersonate_wider_access"},
+	{Target: domain.ErrImpersonationToken, Status: http.StatusForbidden, Code: "impersonation_token"},
+
+	{Target: domain.ErrAPIKeyNotFound, Status: http.StatusNotFound, Code: "api_key_not_found"},
+	{Target: domain.ErrAPIKeyInvalid, Status: http.StatusUnauthorized, Code: "api_key_invalid"},
+	{Target: domain.ErrAPIKeyScope, Status: http.StatusBadRequest, Code: "api_key_scope_denied"},
+	{Target: domain.ErrAPIKeyScopes, Status: http.StatusBadRequest, Code: "api_key_scopes_required"},
+	{Target: domain.ErrAPIKeyLimit, Status: http.StatusConflict, Code: "api_key_limit"},
+	{Target: domain.ErrAPIKeyExpiry, Status: http.StatusBadRequest, Code: "api_key_expiry_invalid"},
+
+	{Target: domain.ErrWorkspaceNotFound, Status: http.StatusNotFound, Code: "workspace_not_found"},
+	{Target: domain.ErrNotWorkspaceMember, Status: http.StatusForbidden, Code: "not_workspace_member"},
+	{Target: domain.ErrWorkspaceForbidden, Status: http.StatusForbidden, Code: "workspace_forbidden"},
+	{Target: domain.ErrLastWorkspaceOwner, Status: http.StatusConflict, Code: "last_workspace_owner"},
+	{Target: domain.ErrMemberAlreadyExists, Status: http.StatusConflict, Code: "member_already_exists"},
+	{Target: domain.ErrInvalidWorkspaceRole, Status: http.StatusBadRequest, Code: "workspace_role_invalid"},
+
+	{Target: domain.ErrRoleNotFound, Status: http.StatusNotFound, Code: "role_not_found"},
+	{Target: domain.ErrUnknownPermission, Status: http.StatusBadRequest, Code: "permission_unknown"},
+	{Target: domain.ErrBuiltinRole, Status: http.StatusConflict, Code: "role_builtin"},
+	{Target: domain.ErrRoleInUse, Status: http.StatusConflict, Code: "role_in_use"},
+	{Target: domain.ErrLastAdmin, Status: http.StatusConflict, Code: "last_admin"},
+	{Target: domain.ErrRolesRequired, Status: http.StatusBadRequest, Code: "roles_required"},
+	{Target: domain.ErrInvalidRoleName, Status: http.StatusBadRequest, Code: "role_name_invalid"},
+}
+
+func init() {
+	problem.RegisterMessages(language.English, map[string]string{
+		errSessionEnded.Code:          "Session has ended",
+		errLockoutTargetRequired.Code: "Specify a login or an IP address",
+		errRoleUnknown.Code:           "Unknown role",
+
+		"invalid_credentials": "Invalid credentials",
+		"login_throttled":     "Too many failed login attempts, try again later",
+		"login_locked":        "Login is temporarily locked after failed attempts",
+		"lockout_not_found":   "Lockout not found",
+
+		"password_too_short":        "Password is too short",
+		"password_too_long":         "Password is too long",
+		"password_too_simple":       "Password is too simple",
+		"password_similar_to_email": "Password is too similar to the email",
+		"password_breached":         "Password appears in data breaches, choose another one",
+
+		"user_not_found":     "User not found",
+		"email_taken":        "Email is already used by another user",
+		"phone_taken":        "Phone number is already used by another user",
+		"wrong_password":     "Current password is incorrect",
+		"link_invalid":       "Link is invalid or has expired",
+		"email_not_verified": "Email is not verified",
+
+		"token_invalid":         "Invalid token",
+		"token_revoked":         "Token has been revoked",
+		"refresh_token_invalid": "Refresh token is invalid",
+		"refresh_token_reused":  "Refresh token has already been used",
+		"session_not_found":     "Session not found",
+
+		"oauth_provider_unknown": "Unknown login provider",
+		"oauth_state_invalid":    "Provider login session is invalid or has expired",
+		"oauth_exchange_failed":  "Provider rejected the authorization code",
+		"oauth_email_required":   "Provider did not share an email",
+		"oauth_account_exists":   "A user with this email already exists, sign in with a password",
+		"identity_not_found":     "External account is not linked",
+
+		"otp_rate_limited":      "Too many code requests, try again later",
+		"otp_invalid":           "Code is invalid or has expired",
+		"otp_attempts_exceeded": "Too many code attempts, request a new code",
+
+		"two_factor_not_enabled":       "Two-factor authentication is not enabled",
+		"two_factor_already_enabled":   "Two-factor authentication is already enabled",
+		"two_factor_setup_not_started": "Start two-factor authentication setup first",
+		"two_factor_code_invalid":      "Invalid verification code",
+		"two_factor_challenge_invalid": "Login confirmation has expired, sign in again",
+		"two_factor_attempts_exceeded": "Too many code attempts, sign in again",
+		"two_factor_required":          "Two-factor authentication is required for your account",
+
+		"user_blocked":             "Account is blocked",
+		"self_admin_action":        "Action is not available for your own account",
+		"impersonate_admin":        "Cannot sign in as an administrator",
+		"impersonate_wider_access": "Cannot sign in as a user with permissions you do not have",
+		"impersonation_token":      "Action is not available while signed in as another user",
+
+		"api_key_not_found":       "API key not found",
+		"api_key_invalid":         "API key is invalid",
+		"api_key_scope_denied":    "User lacks permissions requested for the key",
+		"api_key_scopes_required": "Specify at least one key permission",
+		"api_key_limit":           "Maximum number of API keys reached",
+		"api_key_expiry_invalid":  "Key expiration must be in the future",
+
+		"workspace_not_found":    "Workspace not found",
+		"not_workspace_member":   "User is not a member of the workspace",
+		"workspace_forbidden":    "Insufficient permissions in the workspace",
+		"last_workspace_owner":   "Workspace must keep at least one owner",
+		"member_already_exists":  "User is already a member of the workspace",
+		"workspace_role_invalid": "Unknown workspace role",
+
+		"role_not_found":     "Role not found",
+		"permission_unknown": "Unknown permission",
+		"role_builtin":       "Built-in role cannot be deleted",
+		"role_in_use":        "Role is assigned to users",
+		"last_admin":         "At least one administrator must remain",
+		"roles_required":     "User needs at least one role",
+		"role_name_invalid":  "Role name: lowercase latin letters, digits, _ and -, up to 50 characters",
+	})
+}
+
+// domainError переводит ошибку приложения в ошибку API. Непредвиденные
+// ошибки становятся 500, их текст попадает только в лог.
+func domainError(err error) error {
+	return domainErrors.Map(err)
+}
@@ -2,18 +2,24 @@ package ports
 
 import (
 	"context"
+	"errors"
 	"marketai/auth/internal/app"
+	"marketai/auth/internal/config"
+	"marketai/auth/internal/domain"
 	auth_grpc_api "marketai/auth/proto/generated-source"
+	"marketai/pkg/http/problem"
 )
 
 type grpcServiceImpl struct {
 	appCQRS *app.AppCQRS
+	config  *config.Config
 	auth_grpc_api.UnimplementedAuthServiceServer
 }
 
-func newGrpcServer(appCQRS *app.AppCQRS) auth_grpc_api.AuthServiceServer {
+func newGrpcServer(appCQRS *app.AppCQRS, cfg *config.Config) auth_grpc_api.AuthServiceServer {
 	return &grpcServiceImpl{
 		appCQRS: appCQRS,
+		config:  cfg,
 	}
 }
 
@@ -23,11 +29,17 @@ func (s *grpcServiceImpl) GetUserData(
 ) (*auth_grpc_api.GetUserDataResponse, error) {
 	user, err := s.appCQRS.Queries.GetUserByToken.Handle(ctx, req.Token)
 	if err != nil {
-		return nil, err
+		return nil, domainError(err)
 	}
 
 	return &auth_grpc_api.GetUserDataResponse{
-		Email: user.Email,
+		Email:         user.Email,
+		UserId:        user.ID,
+		FullName:      user.FullName,
+		PhoneNumber:   user.PhoneNumber,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+		CreatedAt:     user.CreatedAt.Unix(),
 	}, nil
 }
 
@@ -35,16 +47,52 @@ func (s *grpcServiceImpl) ValidateToken(
 	ctx context.Context,
 	req *auth_grpc_api.ValidateTokenRequest,
 ) (*auth_grpc_api.ValidateTokenResponse, error) {
-	user, err := s.appCQRS.Queries.GetUserByToken.Handle(ctx, req.Token)
-	if err != nil {
+	result, err := s.appCQRS.Queries.ValidateToken.Handle(ctx, req.Token)
+	if errors.Is(err, domain.ErrTokenInvalid) || errors.Is(err, domain.ErrTokenRevoked) {
 		return &auth_grpc_api.ValidateTokenResponse{
 			Valid: false,
 		}, nil
 	}
+	if err != nil {
+		return nil, problem.Internal(err)
+	}
 
 	return &auth_grpc_api.ValidateTokenResponse{
-		Valid:  true,
-		UserId: user.ID,
-		Role:   user.Role,
+		Valid:         true,
+		UserId:        result.User.ID,
+		Email:         result.User.Email,
+		EmailVerified: result.User.EmailVerified(),
+		Role:          result.User.Role,
+		WorkspaceId:   result.Claims.WorkspaceID,
+		WorkspaceRole: result.Claims.WorkspaceRole,
+		Permissions:   result.Claims.Permissions(),
 	}, nil
 }
+
+func (s *grpcServiceImpl) ValidateAPIKey(
+	ctx context.Context,
+	req *auth_grpc_api.ValidateAPIKeyRequest,
+) (*auth_grpc_api.ValidateAPIKeyResponse, error) {
+	result, err := s.appCQRS.Queries.ValidateAPIKey.Handle(ctx, req.ApiKey)
+	if errors.Is(err, domain.ErrAPIKeyInvalid) || errors.Is(err, domain.ErrUserBlocked) {
+		return &auth_grpc_api.ValidateAPIKeyResponse{
+			Valid: false,
+		}, nil
+	}
+	if err != nil {
+		return nil, problem.Internal(err)
+	}
+
+	response := &auth_grpc_api.ValidateAPIKeyResponse{
+		Valid:         true,
+		KeyId:         result.Key.ID,
+		UserId:        result.User.ID,
+		EmailVerified: result.User.EmailVerified(),
+		Permissions:   result.Permissions,
+	}
+	if result.Membership != nil {
+		response.WorkspaceId = result.Membership.Workspace.ID
+		response.WorkspaceRole = string(result.Membership.Role)
+	}
+	return response, nil
+}
@@ -1,15 +1,20 @@
 package ports
 
 import (
-	"fmt"
+	"errors"
 	"log"
 	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
 	"marketai/auth/internal/app/dto"
 	"marketai/auth/internal/config"
 	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+	"marketai/pkg/http/ratelimit"
 	"marketai/pkg/logger"
 	"marketai/pkgAuth/jwt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
@@ -24,115 +29,256 @@ type httpServer struct {
 	Echo      *echo.Echo
 	Logger    logger.AppLog
 	Validator *validator.Validate
+	KeySet    *jwt.KeySet
+	// RateLimiter применяет лимиты по пользователю после authMiddleware
+	RateLimiter *ratelimit.Limiter `optional:"true"`
 }
 
 // UserContextKey - ключ для хранения информации о пользователе в контексте запроса.
 type UserContextKey string
 
 func registerRoutes(s httpServer, a *app.AppCQRS) {
+	s.Validator.RegisterTagNameFunc(jsonFieldName)
+
 	s.Echo.Use(middleware.CORS())
+	s.Echo.Use(clientInfo())
+	s.Echo.Add(http.MethodGet, "/.well-known/jwks.json", s.jwksHandler())
+
 	withAuth := s.Echo.Group(s.Config.Http.ApiBasePath)
 
 	withAuth.Add(http.MethodPost, "/login", s.loginHandler(a))
 	withAuth.Add(http.MethodPost, "/register", s.registerHandler(a))
+	withAuth.Add(http.MethodPost, "/login/otp/request", s.requestOTPHandler(a))
+	withAuth.Add(http.MethodPost, "/login/otp/verify", s.verifyOTPHandler(a))
+	withAuth.Add(http.MethodPost, "/login/2fa", s.twoFactorLoginHandler(a))
+	withAuth.Add(http.MethodPost, "/login/2fa/enroll", s.twoFactorEnrollHandler(a))
+	withAuth.Add(http.MethodGet, "/oauth/providers", s.oauthProvidersHandler(a))
+	withAuth.Add(http.MethodPost, "/oauth/:provider/authorize", s.startOAuthHandler(a))
+	withAuth.Add(http.MethodPost, "/oauth/:provider/callback", s.oauthCallbackHandler(a))
+
+	withAuth.Add(http.MethodPost, "/validate", s.validateTokenHandler(a))
+
+	withAuth.Add(http.MethodPost, "/refresh", s.refreshTokenHandler(a))
+	withAuth.Add(http.MethodPost, "/logout", s.logoutHandler(a))
+	withAuth.Add(http.MethodPost, "/logout-all", s.logoutAllHandler(a), s.authMiddleware(a), denyImpersonation())
+
+	withAuth.Add(http.MethodPost, "/verify-email", s.verifyEmailHandler(a))
+	withAuth.Add(http.MethodPost, "/verify-email/send", s.sendVerificationHandler(a), s.authMiddleware(a))
+	withAuth.Add(http.MethodPost, "/password/forgot", s.forgotPasswordHandler(a))
+	withAuth.Add(http.MethodPost, "/password/reset", s.resetPasswordHandler(a))
 
-	withAuth.Add(http.MethodPost, "/validate", s.validateTokenHandler())
+	withAuth.Add(http.MethodPost, "/me/email/confirm", s.confirmEmailChangeHandler(a))
+	me := withAuth.Group("/me", s.authMiddleware(a))
+	me.Add(http.MethodGet, "", s.getProfileHandler(a))
+	me.Add(http.MethodPatch, "", s.updateProfileHandler(a), denyImpersonation())
+	me.Add(http.MethodPost, "/phone/confirm", s.confirmPhoneHandler(a), denyImpersonation())
+	me.Add(http.MethodDelete, "", s.deleteAccountHandler(a), denyImpersonation())
+	me.Add(http.MethodPost, "/password", s.changePasswordHandler(a), denyImpersonation())
+	me.Add(http.MethodPost, "/email", s.changeEmailHandler(a), denyImpersonation())
+	me.Add(http.MethodGet, "/2fa", s.twoFactorStatusHandler(a))
+	me.Add(http.MethodGet, "/security-events", s.mySecurityEventsHandler(a))
+	twoFactor := me.Group("/2fa", denyImpersonation())
+	twoFactor.Add(http.MethodPost, "/setup", s.startTwoFactorSetupHandler(a))
+	twoFactor.Add(http.MethodPost, "/confirm", s.confirmTwoFactorHandler(a))
+	twoFactor.Add(http.MethodPost, "/disable", s.disableTwoFactorHandler(a))
+	twoFactor.Add(http.MethodPost, "/recovery-codes", s.regenerateRecoveryCodesHandler(a))
 
+	sessions := withAuth.Group("/sessions", s.authMiddleware(a))
+	sessions.Add(http.MethodGet, "", s.listSessionsHandler(a))
+	sessions.Add(http.MethodDelete, "/:id", s.revokeSessionHandler(a), denyImpersonation())
+
+	apiKeys := withAuth.Group("/api-keys", s.authMiddleware(a), denyImpersonation())
+	apiKeys.Add(http.MethodGet, "", s.listAPIKeysHandler(a))
+	apiKeys.Add(http.MethodPost, "", s.createAPIKeyHandler(a))
+	apiKeys.Add(http.MethodDelete, "/:id", s.revokeAPIKeyHandler(a))
+
+	admin := withAuth.Group("/admin", s.authMiddleware(a))
+	lockouts := admin.Group("/lockouts", jwt.EchoRequirePermission(domain.PermissionLockoutsManage))
+	lockouts.Add(http.MethodGet, "", s.listLockoutsHandler(a))
+	lockouts.Add(http.MethodPost, "/unlock", s.unlockLoginHandler(a))
+	admin.Add(http.MethodGet, "/users", s.listUsersHandler(a), jwt.EchoRequirePermission(domain.PermissionUsersRead))
+	admin.Add(http.MethodGet, "/users/:id", s.getUserHandler(a), jwt.EchoRequirePermission(domain.PermissionUsersRead))
+	admin.Add(http.MethodGet, "/audit", s.listAdminActionsHandler(a), jwt.EchoRequirePermission(domain.PermissionUsersRead))
+	admin.Add(http.MethodGet, "/security-events", s.listSecurityEventsHandler(a), jwt.EchoRequirePermission(domain.PermissionAuditRead))
+	users := admin.Group("/users/:id", jwt.EchoRequirePermission(domain.PermissionUsersManage))
+	users.Add(http.MethodPost, "/block", s.blockUserHandler(a))
+	users.Add(http.MethodPost, "/unblock", s.unblockUserHandler(a))
+	users.Add(http.MethodPost, "/password-reset", s.forcePasswordResetHandler(a))
+	users.Add(http.MethodPost, "/2fa/reset", s.resetTwoFactorHandler(a))
+	users.Add(http.MethodPost, "/impersonate", s.impersonateHandler(a))
+	roles := admin.Group("", jwt.EchoRequirePermission(domain.PermissionRolesManage))
+	roles.Add(http.MethodGet, "/permissions", s.listPermissionsHandler(a))
+	roles.Add(http.MethodGet, "/roles", s.listRolesHandler(a))
+	roles.Add(http.MethodPut, "/roles/:name", s.saveRoleHandler(a))
+	roles.Add(http.MethodDelete, "/roles/:name", s.deleteRoleHandler(a))
+	roles.Add(http.MethodGet, "/users/:id/roles", s.getUserRolesHandler(a))
+	roles.Add(http.MethodPut, "/users/:id/roles", s.setUserRolesHandler(a))
+
+	workspaces := withAuth.Group("/workspaces", s.authMiddleware(a))
+	workspaces.Add(http.MethodGet, "", s.listWorkspacesHandler(a))
+	workspaces.Add(http.MethodPost, "", s.createWorkspaceHandler(a))
+	workspaces.Add(http.MethodGet, "/:id/members", s.listMembersHandler(a))
+	workspaces.Add(http.MethodPost, "/:id/members", s.addMemberHandler(a))
+	workspaces.Add(http.MethodPatch, "/:id/members/:userId", s.updateMemberRoleHandler(a))
+	workspaces.Add(http.MethodDelete, "/:id/members/:userId", s.removeMemberHandler(a))
+	workspaces.Add(http.MethodPost, "/:id/switch", s.switchWorkspaceHandler(a), denyImpersonation())
+	workspaces.Add(http.MethodPut, "/:id/two-factor", s.workspaceTwoFactorHandler(a), denyImpersonation())
 }
 
 // @Summary		Аутентификация пользователя
-// @Description	Вход пользователя в систему и получение JWT токена.
+// @Description	Вход пользователя в систему и получение JWT токена. При включенном втором факторе вместо токенов возвращается two_factor_required и challenge_token для /login/2fa.
 // @Tags			auth
 // @Accept			json
 // @Produce		json
 // @Param			input	body		dto.LoginCommand	true	"Данные для входа"
 // @Success		200		{object}	map[string]string	"Успешный вход, возвращает JWT токен"
-// @Failure		400		{string}	string				"Неверный формат запроса"
-// @Failure		401		{string}	string				"Неверные учетные данные"
+// @Failure		400		{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		401		{object}	problem.Problem	"Неверные учетные данные"
+// @Failure		423		{object}	problem.Problem	"Вход временно заблокирован"
+// @Failure		429		{object}	problem.Problem	"Слишком частые попытки входа"
 // @Router			/login [post]
 func (rc *httpServer) loginHandler(a *app.AppCQRS) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 		var req dto.LoginCommand
 		if err := c.Bind(&req); err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Неверный формат запроса")
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
 		}
+		req.ClientIP = c.RealIP()
 
 		result, err := a.Queries.Login.Handle(ctx, req)
 		if err != nil {
-			log.Printf("Ошибка при входе пользователя %s: %v", req.Email, err)
-			return echo.NewHTTPError(http.StatusBadRequest, "Неверные учетные данные")
+			return loginError(c, err)
+		}
+		if result.TwoFactor != nil {
+			return c.JSON(http.StatusOK, twoFactorChallengeResponse(result.TwoFactor))
 		}
 
-		return c.JSON(http.StatusOK, map[string]interface{}{
-			"token": result.Token,
+		response := map[string]interface{}{
+			"token":         result.Token,
+			"refresh_token": result.RefreshToken,
+			"expires_in":    int64(result.ExpiresIn.Seconds()),
 			"user": map[string]interface{}{
 				"id":       result.UserID,
 				"email":    req.Email,
 				"fullname": result.FullName,
 			},
-		})
+		}
+		if result.Workspace != nil {
+			response["workspace"] = workspaceResponse(result.Workspace)
+		}
+
+		return c.JSON(http.StatusOK, response)
 	}
 }
 
+func loginError(c echo.Context, err error) error {
+	var blocked *domain.LoginBlockedError
+	if errors.As(err, &blocked) {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+	}
+
+	return domainError(err)
+}
+
 // @Summary		Регистрация нового пользователя
-// @Description	Регистрация нового пользователя в системе.
+// @Description	Регистрация нового пользователя в системе. Номер телефона необязателен и указывается в формате E.164.
+// @Description	При неверных полях возвращается список ошибок по каждому полю, нарушение политики паролей - ошибка поля password с правилом password_policy.
 // @Tags			auth
 // @Accept			json
 // @Produce		json
-// @Param			input	body		dto.RegisterUserCommand	true	"Данные для регистрации"
-// @Success		200		{object}	map[string]string		"Пользователь успешно зарегистрирован"
-// @Failure		400		{string}	string					"Неверный формат запроса"
-// @Failure		409		{string}	string					"Пользователь с таким именем уже существует"
+// @Param			input	body		dto.RegisterRequest				true	"Данные для регистрации"
+// @Success		200		{object}	dto.RegisterResponse			"Пользователь успешно зарегистрирован"
+// @Failure		400		{object}	problem.Problem					"Неверные данные или пароль не соответствует политике"
+// @Failure		409		{object}	problem.Problem					"Email или номер телефона уже используется"
 // @Router			/register [post]
 func (rc *httpServer) registerHandler(a *app.AppCQRS) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-		var req domain.User
+		var req dto.RegisterRequest
 
 		if err := c.Bind(&req); err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Неверный формат запроса")
+			return problem.ErrBadRequest
 		}
-
-		if req.Role == "" {
-			req.Role = "user"
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
 		}
 
-		result, err := a.Commands.Register.Handle(ctx, req)
+		result, err := a.Commands.Register.Handle(ctx, command.RegisterUserCommand{
+			FullName:    req.FullName,
+			Email:       req.Email,
+			PhoneNumber: req.PhoneNumber,
+			Password:    req.Password,
+		})
 		if err != nil {
-			log.Printf("Ошибка при регистрации пользователя %s: %v", req.Email, err)
-			return echo.NewHTTPError(http.StatusConflict, fmt.Errorf("ошибка: %s", err.Error()), err)
+			return registerError(err)
 		}
 
-		return c.JSON(http.StatusOK, map[string]interface{}{
-			"token": result.Token,
-			"user": map[string]interface{}{
-				"id":       result.UserID,
-				"fullname": result.User.FullName,
-				"email":    result.User.Email,
+		// Письмо не должно мешать регистрации: ссылку можно запросить повторно
+		if err := a.Commands.SendVerification.Handle(ctx, result.UserID); err != nil {
+			log.Printf("Ошибка отправки письма подтверждения %s: %v", req.Email, err)
+		}
+
+		return c.JSON(http.StatusOK, dto.RegisterResponse{
+			Token:        result.Token,
+			RefreshToken: result.RefreshToken,
+			ExpiresIn:    int64(result.ExpiresIn.Seconds()),
+			User: dto.RegisterUserResponse{
+				ID:       result.UserID,
+				FullName: result.User.FullName,
+				Email:    result.User.Email,
 			},
+			Workspace: workspaceResponse(result.Workspace),
 		})
 	}
 }
 
-func (rc *httpServer) validateTokenHandler() echo.HandlerFunc {
+func registerError(err error) error {
+	if domain.IsPasswordPolicyError(err) {
+		return fieldsError(problem.FieldError{Field: "password", Rule: "password_policy", Message: err.Error()})
+	}
+
+	return domainError(err)
+}
+
+func (rc *httpServer) validateTokenHandler(a *app.AppCQRS) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var req dto.ValidateTokenRequest
 		if err := c.Bind(&req); err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "неверный формат запроса")
+			return problem.ErrBadRequest
 		}
 
-		claims, err := jwt.ValidateToken(req.Token, rc.Config.JWTSecret)
+		result, err := a.Queries.ValidateToken.Handle(c.Request().Context(), req.Token)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusUnauthorized, "неверный токен")
+			return tokenError(err)
 		}
 
 		response := dto.ValidateTokenResponse{
-			Valid:  true,
-			UserID: claims.UserID,
-			Role:   claims.Role,
+			Valid:         true,
+			UserID:        result.User.ID,
+			Email:         result.User.Email,
+			EmailVerified: result.User.EmailVerified(),
+			Role:          result.User.Role,
+			WorkspaceID:   result.Claims.WorkspaceID,
+			WorkspaceRole: result.Claims.WorkspaceRole,
 		}
 
 		return c.JSON(http.StatusOK, response)
 	}
 }
+
+// @Summary		Открытые ключи подписи токенов
+// @Description	JWKS с активным и выводимыми из оборота ключами. Пуст, если токены подписываются HS256.
+// @Tags			auth
+// @Produce		json
+// @Success		200	{object}	jwt.JWKS
+// @Router			/.well-known/jwks.json [get]
+func (rc *httpServer) jwksHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "public, max-age=300")
+		return c.JSON(http.StatusOK, rc.KeySet.JWKS())
+	}
+}
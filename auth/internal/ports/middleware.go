@@ -0,0 +1,103 @@
+package ports
+
+import (
+	"errors"
+	"strings"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/audit"
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/ratelimit"
+	"marketai/pkgAuth/jwt"
+
+	"github.com/labstack/echo/v4"
+)
+
+const userContextKey UserContextKey = "user"
+
+// authMiddleware проверяет Bearer токен, в том числе по списку отзыва, сохраняет claims
+// в контексте запроса и применяет лимиты запросов по пользователю
+func (rc *httpServer) authMiddleware(a *app.AppCQRS) echo.MiddlewareFunc {
+	limit := rc.RateLimiter.Authenticated(rateLimitSubject)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		next = limit(next)
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
+			if authHeader == "" {
+				return jwt.ErrAuthRequired
+			}
+
+			accessToken, ok := bearerToken(authHeader)
+			if !ok {
+				return jwt.ErrAuthMalformed
+			}
+
+			result, err := a.Queries.ValidateToken.Handle(c.Request().Context(), accessToken)
+			if err != nil {
+				return tokenError(err)
+			}
+
+			c.Set(string(userContextKey), result.Claims)
+			c.SetRequest(c.Request().WithContext(jwt.ContextWithClaims(c.Request().Context(), result.Claims)))
+			return next(c)
+		}
+	}
+}
+
+// rateLimitSubject - пользователь из токена, проверенного authMiddleware
+func rateLimitSubject(c echo.Context) ratelimit.Subject {
+	claims, _ := c.Get(string(userContextKey)).(*jwt.Claims)
+	if claims == nil {
+		return ratelimit.Subject{}
+	}
+	return ratelimit.Subject{UserID: claims.UserID}
+}
+
+// clientInfo сохраняет IP и User-Agent клиента в контексте запроса для журнала событий безопасности
+func clientInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(audit.WithClient(req.Context(), audit.Client{
+				IP:        c.RealIP(),
+				UserAgent: req.UserAgent(),
+			})))
+			return next(c)
+		}
+	}
+}
+
+// denyImpersonation не пускает администратора, вошедшего от имени пользователя,
+// к смене учетных данных и к выпуску refresh токенов. Вызывается после authMiddleware
+func denyImpersonation() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if claims := claimsFromContext(c); claims != nil && claims.Impersonated() {
+				return domainError(domain.ErrImpersonationToken)
+			}
+			return next(c)
+		}
+	}
+}
+
+func bearerToken(authHeader string) (string, bool) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func tokenError(err error) error {
+	if errors.Is(err, jwt.ErrTokenExpired) && !errors.Is(err, domain.ErrTokenRevoked) {
+		return jwt.ErrAuthExpired.Wrap(err)
+	}
+
+	return domainError(err)
+}
+
+func claimsFromContext(c echo.Context) *jwt.Claims {
+	claims, _ := c.Get(string(userContextKey)).(*jwt.Claims)
+	return claims
+}
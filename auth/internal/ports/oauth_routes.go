@@ -0,0 +1,122 @@
+package ports
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Провайдеры входа
+// @Description	Имена включенных внешних провайдеров входа.
+// @Tags			oauth
+// @Produce		json
+// @Success		200	{object}	map[string][]string
+// @Router			/oauth/providers [get]
+func (rc *httpServer) oauthProvidersHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string][]string{
+			"providers": a.Queries.OAuthProviders.Handle(),
+		})
+	}
+}
+
+// @Summary		Начало входа через провайдера
+// @Description	Возвращает адрес страницы входа провайдера с state, nonce и PKCE challenge.
+// @Description	Ставит HttpOnly cookie oauth_browser, без которой callback не примет state.
+// @Tags			oauth
+// @Produce		json
+// @Param			provider	path		string	true	"Имя провайдера: yandex, vk, google"
+// @Success		200			{object}	dto.OAuthAuthorizeResponse
+// @Failure		404			{object}	problem.Problem	"Провайдер не найден"
+// @Router			/oauth/{provider}/authorize [post]
+func (rc *httpServer) startOAuthHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := a.Commands.StartOAuth.Handle(c.Request().Context(), c.Param("provider"))
+		if err != nil {
+			return domainError(err)
+		}
+
+		c.SetCookie(rc.oauthBrowserCookie(result.Browser, result.ExpiresAt))
+
+		return c.JSON(http.StatusOK, dto.OAuthAuthorizeResponse{
+			AuthorizationURL: result.AuthorizationURL,
+			State:            result.State,
+		})
+	}
+}
+
+// @Summary		Завершение входа через провайдера
+// @Description	Обменивает код авторизации на профиль провайдера и выдает JWT токен, как обычный вход.
+// @Description	При первом входе пользователь создается автоматически. Запрос должен прийти
+// @Description	из того же браузера, что и authorize, с cookie oauth_browser.
+// @Tags			oauth
+// @Accept			json
+// @Produce		json
+// @Param			provider	path		string					true	"Имя провайдера"
+// @Param			input		body		dto.OAuthCallbackRequest	true	"Код и state из redirect провайдера"
+// @Success		200			{object}	map[string]string			"Успешный вход, возвращает JWT токен"
+// @Failure		400			{object}	problem.Problem	"Неверный или просроченный state"
+// @Failure		401			{object}	problem.Problem	"Провайдер отклонил код"
+// @Failure		409			{object}	problem.Problem	"Email уже занят"
+// @Router			/oauth/{provider}/callback [post]
+func (rc *httpServer) oauthCallbackHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var req dto.OAuthCallbackRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		var browser string
+		if cookie, err := c.Cookie(oauthBrowserCookieName); err == nil {
+			browser = cookie.Value
+		}
+		// Cookie одноразовая, как и state
+		c.SetCookie(rc.oauthBrowserCookie("", time.Unix(0, 0)))
+
+		result, err := a.Commands.OAuthCallback.Handle(ctx, command.OAuthCallbackCommand{
+			Provider: c.Param("provider"),
+			Code:     req.Code,
+			State:    req.State,
+			DeviceID: req.DeviceID,
+			Browser:  browser,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		// Адрес, который провайдер не подтвердил, подтверждается обычным письмом
+		if result.Created && !result.User.EmailVerified() {
+			if err := a.Commands.SendVerification.Handle(ctx, result.User.ID); err != nil {
+				log.Printf("Ошибка отправки письма подтверждения %s: %v", result.User.Email, err)
+			}
+		}
+
+		return c.JSON(http.StatusOK, loginResponse(result))
+	}
+}
+
+// oauthBrowserCookieName - cookie, которой вход через провайдера привязан к браузеру
+const oauthBrowserCookieName = "oauth_browser"
+
+func (rc *httpServer) oauthBrowserCookie(value string, expiresAt time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     oauthBrowserCookieName,
+		Value:    value,
+		Path:     rc.Config.Http.ApiBasePath + "/oauth",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,100 @@
+package ports
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Запрос кода входа по SMS
+// @Description	Отправляет одноразовый код на номер телефона. Ответ не зависит от того, зарегистрирован ли номер.
+// @Tags			auth
+// @Accept			json
+// @Param			input	body	dto.RequestOTPRequest	true	"Номер телефона"
+// @Success		202
+// @Failure		400	{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		429	{object}	problem.Problem	"Слишком много запросов кода"
+// @Router			/login/otp/request [post]
+func (rc *httpServer) requestOTPHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.RequestOTPRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		req.PhoneNumber = strings.TrimSpace(req.PhoneNumber)
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		if err := a.Commands.RequestOTP.Handle(c.Request().Context(), req.PhoneNumber); err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	}
+}
+
+// @Summary		Вход по коду из SMS
+// @Description	Проверяет одноразовый код и выдает JWT токен, как обычный вход. При включенном втором факторе вместо токенов возвращается challenge_token для /login/2fa.
+// @Tags			auth
+// @Accept			json
+// @Produce		json
+// @Param			input	body		dto.VerifyOTPRequest	true	"Номер телефона и код"
+// @Success		200		{object}	map[string]string	"Успешный вход, возвращает JWT токен"
+// @Failure		401		{object}	problem.Problem	"Неверный или просроченный код"
+// @Failure		429		{object}	problem.Problem	"Превышено число попыток"
+// @Router			/login/otp/verify [post]
+func (rc *httpServer) verifyOTPHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.VerifyOTPRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		req.PhoneNumber = strings.TrimSpace(req.PhoneNumber)
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		result, err := a.Commands.VerifyOTP.Handle(c.Request().Context(), command.VerifyOTPCommand{
+			PhoneNumber: req.PhoneNumber,
+			Code:        req.Code,
+			WorkspaceID: req.WorkspaceID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, loginResponse(result))
+	}
+}
+
+// loginResponse - ответ входа без пароля в формате /login
+func loginResponse(result *command.LoginResult) map[string]interface{} {
+	if result.TwoFactor != nil {
+		return twoFactorChallengeResponse(result.TwoFactor)
+	}
+
+	response := map[string]interface{}{
+		"token":         result.Token,
+		"refresh_token": result.RefreshToken,
+		"expires_in":    int64(result.ExpiresIn.Seconds()),
+		"user": map[string]interface{}{
+			"id":       result.User.ID,
+			"email":    result.User.Email,
+			"fullname": result.User.FullName,
+		},
+	}
+	if result.Workspace != nil {
+		response["workspace"] = workspaceResponse(result.Workspace)
+	}
+	if len(result.RecoveryCodes) > 0 {
+		response["recovery_codes"] = result.RecoveryCodes
+	}
+	return response
+}
@@ -0,0 +1,247 @@
+package ports
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Профиль пользователя
+// @Tags			profile
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	dto.ProfileResponse
+// @Failure		401	{object}	problem.Problem	"Неверный токен"
+// @Router			/me [get]
+func (rc *httpServer) getProfileHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		user, err := a.Queries.GetProfile.Handle(c.Request().Context(), claimsFromContext(c).UserID)
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, profileResponse(user))
+	}
+}
+
+// @Summary		Изменение профиля
+// @Description	Меняет имя и номер телефона. Незаданные поля не меняются. Новый номер сохраняется только после подтверждения кодом из SMS через /me/phone/confirm, до этого он возвращается в pendingPhoneNumber. Пустой номер удаляет телефон сразу.
+// @Tags			profile
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			input	body		dto.UpdateProfileRequest	true	"Новые данные"
+// @Success		200		{object}	dto.ProfileResponse
+// @Failure		400		{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		409		{object}	problem.Problem	"Номер телефона уже используется"
+// @Failure		429		{object}	problem.Problem	"Слишком много запросов кода"
+// @Router			/me [patch]
+func (rc *httpServer) updateProfileHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.UpdateProfileRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if req.PhoneNumber != nil {
+			phone := strings.TrimSpace(*req.PhoneNumber)
+			req.PhoneNumber = &phone
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		result, err := a.Commands.UpdateProfile.Handle(c.Request().Context(), command.UpdateProfileCommand{
+			UserID:      claimsFromContext(c).UserID,
+			FullName:    req.FullName,
+			PhoneNumber: req.PhoneNumber,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		resp := profileResponse(result.User)
+		resp.PendingPhoneNumber = result.PendingPhoneNumber
+		return c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Summary		Подтверждение номера телефона
+// @Description	Проверяет код из SMS, отправленный на новый номер при изменении профиля, и сохраняет номер.
+// @Tags			profile
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			input	body		dto.ConfirmPhoneRequest	true	"Новый номер и код"
+// @Success		200		{object}	dto.ProfileResponse
+// @Failure		401		{object}	problem.Problem	"Неверный или просроченный код"
+// @Failure		409		{object}	problem.Problem	"Номер телефона уже используется"
+// @Failure		429		{object}	problem.Problem	"Превышено число попыток"
+// @Router			/me/phone/confirm [post]
+func (rc *httpServer) confirmPhoneHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ConfirmPhoneRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		req.PhoneNumber = strings.TrimSpace(req.PhoneNumber)
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		user, err := a.Commands.ConfirmPhone.Handle(c.Request().Context(), command.ConfirmPhoneCommand{
+			UserID:      claimsFromContext(c).UserID,
+			PhoneNumber: req.PhoneNumber,
+			Code:        req.Code,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, profileResponse(user))
+	}
+}
+
+// @Summary		Смена пароля
+// @Description	Меняет пароль после проверки текущего. Все сессии завершаются, в ответе новая пара токенов.
+// @Tags			profile
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			input	body		dto.ChangePasswordRequest	true	"Текущий и новый пароль"
+// @Success		200		{object}	map[string]string			"Новая пара токенов"
+// @Failure		400		{object}	problem.Problem	"Пароль не соответствует политике"
+// @Failure		403		{object}	problem.Problem	"Неверный текущий пароль"
+// @Router			/me/password [post]
+func (rc *httpServer) changePasswordHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ChangePasswordRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		claims := claimsFromContext(c)
+		result, err := a.Commands.ChangePassword.Handle(c.Request().Context(), command.ChangePasswordCommand{
+			UserID:          claims.UserID,
+			CurrentPassword: req.CurrentPassword,
+			NewPassword:     req.NewPassword,
+			WorkspaceID:     claims.WorkspaceID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, loginResponse(result))
+	}
+}
+
+// @Summary		Смена email
+// @Description	Отправляет ссылку подтверждения на новый адрес. Email меняется после перехода по ссылке.
+// @Tags			profile
+// @Accept			json
+// @Security		BearerAuth
+// @Param			input	body	dto.ChangeEmailRequest	true	"Новый email и текущий пароль"
+// @Success		202
+// @Failure		403	{object}	problem.Problem	"Неверный текущий пароль"
+// @Failure		409	{object}	problem.Problem	"Email уже используется"
+// @Router			/me/email [post]
+func (rc *httpServer) changeEmailHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ChangeEmailRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		if err := a.Commands.RequestEmailChange.Handle(c.Request().Context(), command.RequestEmailChangeCommand{
+			UserID:   claimsFromContext(c).UserID,
+			NewEmail: req.Email,
+			Password: req.Password,
+		}); err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	}
+}
+
+// @Summary		Подтверждение смены email
+// @Description	Меняет email на адрес, которому было отправлено письмо. Токен одноразовый.
+// @Tags			profile
+// @Accept			json
+// @Param			input	body	dto.ConfirmEmailChangeRequest	true	"Токен из письма"
+// @Success		204
+// @Failure		400	{object}	problem.Problem	"Ссылка недействительна или устарела"
+// @Failure		409	{object}	problem.Problem	"Email уже используется"
+// @Router			/me/email/confirm [post]
+func (rc *httpServer) confirmEmailChangeHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ConfirmEmailChangeRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		if err := a.Commands.ConfirmEmailChange.Handle(c.Request().Context(), req.Token); err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Удаление учетной записи
+// @Description	Удаляет учетную запись: персональные данные обезличиваются, все сессии завершаются.
+// @Tags			profile
+// @Accept			json
+// @Security		BearerAuth
+// @Param			input	body	dto.DeleteAccountRequest	true	"Текущий пароль"
+// @Success		204
+// @Failure		403	{object}	problem.Problem	"Неверный текущий пароль"
+// @Failure		409	{object}	problem.Problem	"Пользователь - единственный владелец общего пространства"
+// @Router			/me [delete]
+func (rc *httpServer) deleteAccountHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.DeleteAccountRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		if err := a.Commands.DeleteAccount.Handle(c.Request().Context(), command.DeleteAccountCommand{
+			UserID:   claimsFromContext(c).UserID,
+			Password: req.Password,
+		}); err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func profileResponse(user *domain.User) dto.ProfileResponse {
+	return dto.ProfileResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified(),
+		FullName:      user.FullName,
+		PhoneNumber:   user.PhoneNumber,
+		Role:          user.Role,
+		CreatedAt:     user.CreatedAt,
+	}
+}
@@ -0,0 +1,193 @@
+package ports
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Каталог прав
+// @Description	Все права, которые можно включить в роль. Требуется право roles:manage.
+// @Tags			admin
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		domain.Permission
+// @Failure		403	{object}	problem.Problem	"Недостаточно прав"
+// @Router			/admin/permissions [get]
+func (rc *httpServer) listPermissionsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		permissions, err := a.Queries.ListPermissions.Handle(c.Request().Context())
+		if err != nil {
+			return domainError(err)
+		}
+		if permissions == nil {
+			permissions = []*domain.Permission{}
+		}
+
+		return c.JSON(http.StatusOK, permissions)
+	}
+}
+
+// @Summary		Роли
+// @Description	Роли с их правами. Требуется право roles:manage.
+// @Tags			admin
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		domain.Role
+// @Failure		403	{object}	problem.Problem	"Недостаточно прав"
+// @Router			/admin/roles [get]
+func (rc *httpServer) listRolesHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		roles, err := a.Queries.ListRoles.Handle(c.Request().Context())
+		if err != nil {
+			return domainError(err)
+		}
+		if roles == nil {
+			roles = []*domain.Role{}
+		}
+
+		return c.JSON(http.StatusOK, roles)
+	}
+}
+
+// @Summary		Создание или изменение роли
+// @Description	Создает роль или заменяет ее описание и набор прав. Пользователи получат новые права при обновлении токена. Требуется право roles:manage.
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			name	path		string					true	"Имя роли"
+// @Param			input	body		dto.SaveRoleRequest		true	"Описание и права"
+// @Success		200		{object}	domain.Role
+// @Failure		400		{object}	problem.Problem	"Неизвестное право или неверное имя роли"
+// @Router			/admin/roles/{name} [put]
+func (rc *httpServer) saveRoleHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.SaveRoleRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		role, err := a.Commands.SaveRole.Handle(c.Request().Context(), command.SaveRoleCommand{
+			Name:             c.Param("name"),
+			Description:      req.Description,
+			Permissions:      req.Permissions,
+			RequireTwoFactor: req.RequireTwoFactor,
+			AdminID:          claimsFromContext(c).UserID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, role)
+	}
+}
+
+// @Summary		Удаление роли
+// @Description	Удаляет роль, не назначенную ни одному пользователю. Встроенные роли удалить нельзя. Требуется право roles:manage.
+// @Tags			admin
+// @Security		BearerAuth
+// @Param			name	path	string	true	"Имя роли"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"Роль не найдена"
+// @Failure		409	{object}	problem.Problem	"Роль встроенная или назначена пользователям"
+// @Router			/admin/roles/{name} [delete]
+func (rc *httpServer) deleteRoleHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := a.Commands.DeleteRole.Handle(c.Request().Context(), command.DeleteRoleCommand{
+			Name:    c.Param("name"),
+			AdminID: claimsFromContext(c).UserID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Роли пользователя
+// @Description	Роли пользователя и итоговый набор прав. Требуется право roles:manage.
+// @Tags			admin
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"ID пользователя"
+// @Success		200	{object}	dto.UserRolesResponse
+// @Failure		404	{object}	problem.Problem	"Пользователь не найден"
+// @Router			/admin/users/{id}/roles [get]
+func (rc *httpServer) getUserRolesHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID := c.Param("id")
+		access, err := a.Queries.GetUserAccess.Handle(c.Request().Context(), userID)
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, userRolesResponse(userID, access))
+	}
+}
+
+// @Summary		Назначение ролей
+// @Description	Заменяет роли пользователя. Его токены доступа отзываются, новые права действуют после /refresh. Требуется право roles:manage.
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string					true	"ID пользователя"
+// @Param			input	body		dto.SetUserRolesRequest	true	"Роли"
+// @Success		200		{object}	dto.UserRolesResponse
+// @Failure		400		{object}	problem.Problem	"Неизвестная роль"
+// @Failure		404		{object}	problem.Problem	"Пользователь не найден"
+// @Failure		409		{object}	problem.Problem	"Нельзя снять роль с последнего администратора"
+// @Router			/admin/users/{id}/roles [put]
+func (rc *httpServer) setUserRolesHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.SetUserRolesRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		userID := c.Param("id")
+		access, err := a.Commands.SetUserRoles.Handle(c.Request().Context(), command.SetUserRolesCommand{
+			UserID:  userID,
+			Roles:   req.Roles,
+			AdminID: claimsFromContext(c).UserID,
+		})
+		if errors.Is(err, domain.ErrRoleNotFound) {
+			return errRoleUnknown.Wrap(err)
+		}
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, userRolesResponse(userID, access))
+	}
+}
+
+func userRolesResponse(userID string, access *domain.Access) dto.UserRolesResponse {
+	response := dto.UserRolesResponse{
+		UserID:      userID,
+		Roles:       access.Roles,
+		Permissions: access.Permissions,
+	}
+	if response.Roles == nil {
+		response.Roles = []string{}
+	}
+	if response.Permissions == nil {
+		response.Permissions = []string{}
+	}
+	return response
+}
@@ -0,0 +1,111 @@
+package ports
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Обновление токена
+// @Description	Обменивает refresh токен на новую пару токенов. Предъявленный refresh токен отзывается,
+// @Description	повторное его предъявление отзывает все токены сессии.
+// @Tags			auth
+// @Accept			json
+// @Produce		json
+// @Param			input	body		dto.RefreshTokenRequest	true	"Refresh токен"
+// @Success		200		{object}	dto.RefreshTokenResponse
+// @Failure		400		{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		401		{object}	problem.Problem	"Refresh токен недействителен"
+// @Router			/refresh [post]
+func (rc *httpServer) refreshTokenHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.RefreshTokenRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		result, err := a.Commands.RefreshToken.Handle(c.Request().Context(), req.RefreshToken)
+		if err != nil {
+			return refreshTokenError(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.RefreshTokenResponse{
+			Token:        result.Token,
+			RefreshToken: result.RefreshToken,
+			ExpiresIn:    int64(result.ExpiresIn.Seconds()),
+		})
+	}
+}
+
+// @Summary		Выход
+// @Description	Отзывает refresh токен и все токены, выпущенные в той же сессии.
+// @Description	Если передан заголовок Authorization, токен доступа также попадает в список отзыва.
+// @Tags			auth
+// @Accept			json
+// @Param			input	body	dto.RefreshTokenRequest	true	"Refresh токен"
+// @Success		204
+// @Failure		400	{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		401	{object}	problem.Problem	"Refresh токен недействителен"
+// @Router			/logout [post]
+func (rc *httpServer) logoutHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.RefreshTokenRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		cmd := command.LogoutCommand{RefreshToken: req.RefreshToken}
+		if accessToken, ok := bearerToken(c.Request().Header.Get("Authorization")); ok {
+			// Просроченный или уже отозванный токен доступа выходу не мешает
+			if result, err := a.Queries.ValidateToken.Handle(c.Request().Context(), accessToken); err == nil {
+				cmd.Access = result.Claims
+			}
+		}
+
+		if err := a.Commands.Logout.Handle(c.Request().Context(), cmd); err != nil {
+			return refreshTokenError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Выход из всех сессий
+// @Description	Отзывает все refresh токены пользователя и все выпущенные ему токены доступа.
+// @Tags			auth
+// @Security		BearerAuth
+// @Success		204
+// @Failure		401	{object}	problem.Problem	"Неверный токен"
+// @Router			/logout-all [post]
+func (rc *httpServer) logoutAllHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims := claimsFromContext(c)
+
+		if err := a.Commands.LogoutAll.Handle(c.Request().Context(), claims); err != nil {
+			return refreshTokenError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func refreshTokenError(err error) error {
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domainErrors.Map(domain.ErrRefreshTokenInvalid).Wrap(err)
+	}
+
+	return domainError(err)
+}
@@ -0,0 +1,118 @@
+package ports
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/dto"
+	"marketai/auth/internal/app/query"
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Журнал событий безопасности
+// @Description	Входы, регистрации, смены пароля, email и ролей, подключение второго фактора и ключи API, новые первыми. Требуется право audit:read.
+// @Tags			admin
+// @Produce		json
+// @Security		BearerAuth
+// @Param			user_id		query		string	false	"ID пользователя"
+// @Param			type		query		string	false	"Тип события, например login.password"
+// @Param			outcome		query		string	false	"Результат: success или failure"
+// @Param			ip			query		string	false	"IP адрес клиента"
+// @Param			from		query		string	false	"Начало периода, RFC 3339"
+// @Param			to			query		string	false	"Конец периода, RFC 3339"
+// @Param			page		query		int		false	"Страница, с 1"
+// @Param			page_size	query		int		false	"Размер страницы, до 100"
+// @Success		200			{object}	dto.SecurityEventsResponse
+// @Failure		400			{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		403			{object}	problem.Problem	"Недостаточно прав"
+// @Router			/admin/security-events [get]
+func (rc *httpServer) listSecurityEventsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return rc.securityEvents(c, a, "")
+	}
+}
+
+// @Summary		Мои события безопасности
+// @Description	События безопасности текущего пользователя: входы, смены пароля и email, второй фактор, ключи API.
+// @Tags			profile
+// @Produce		json
+// @Security		BearerAuth
+// @Param			type		query		string	false	"Тип события, например login.password"
+// @Param			outcome		query		string	false	"Результат: success или failure"
+// @Param			from		query		string	false	"Начало периода, RFC 3339"
+// @Param			to			query		string	false	"Конец периода, RFC 3339"
+// @Param			page		query		int		false	"Страница, с 1"
+// @Param			page_size	query		int		false	"Размер страницы, до 100"
+// @Success		200			{object}	dto.SecurityEventsResponse
+// @Failure		400			{object}	problem.Problem	"Неверный формат запроса"
+// @Router			/me/security-events [get]
+func (rc *httpServer) mySecurityEventsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return rc.securityEvents(c, a, claimsFromContext(c).UserID)
+	}
+}
+
+// securityEvents отдает страницу журнала. Непустой userID заменяет фильтр
+// из запроса: пользователь видит только свои события.
+func (rc *httpServer) securityEvents(c echo.Context, a *app.AppCQRS, userID string) error {
+	var req dto.ListSecurityEventsRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.ErrBadRequest
+	}
+	if err := rc.Validator.Struct(req); err != nil {
+		return validationError(err)
+	}
+	if userID != "" {
+		req.UserID = userID
+	}
+
+	from, err := parseTimeParam(req.From)
+	if err != nil {
+		return fieldsError(problem.FieldError{Field: "from", Rule: "datetime", Message: "Неверный формат даты"})
+	}
+	to, err := parseTimeParam(req.To)
+	if err != nil {
+		return fieldsError(problem.FieldError{Field: "to", Rule: "datetime", Message: "Неверный формат даты"})
+	}
+
+	result, err := a.Queries.ListSecurityEvents.Handle(c.Request().Context(), query.ListSecurityEventsQuery{
+		UserID:   req.UserID,
+		Type:     req.Type,
+		Outcome:  req.Outcome,
+		IP:       req.IP,
+		From:     from,
+		To:       to,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	})
+	if err != nil {
+		log.Printf("Ошибка получения журнала событий безопасности: %v", err)
+		return problem.ErrInternal
+	}
+	if result.Events == nil {
+		result.Events = []*domain.SecurityEvent{}
+	}
+
+	return c.JSON(http.StatusOK, dto.SecurityEventsResponse{
+		Events:   result.Events,
+		Total:    result.Total,
+		Page:     result.Page,
+		PageSize: result.PageSize,
+	})
+}
+
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -1,9 +1,15 @@
 package ports
 
 import (
+	"marketai/auth/internal/adapters/breached"
+	"marketai/auth/internal/adapters/mail"
+	"marketai/auth/internal/adapters/oauth"
 	"marketai/auth/internal/adapters/postgres"
 	"marketai/auth/internal/adapters/postgres/migrations"
+	"marketai/auth/internal/adapters/sms"
 	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/passwords"
+	"marketai/auth/internal/app/token"
 	"marketai/auth/internal/config"
 	auth_grpc_api "marketai/auth/proto/generated-source"
 	"marketai/pkg/bootstrap"
@@ -31,8 +37,33 @@ func App() fx.Option {
 			fx.Provide(
 				app.NewAppCQRS,
 				postgres.NewAuthRepository,
+				postgres.NewWorkspaceRepository,
+				postgres.NewRefreshTokenRepository,
+				postgres.NewRevocationRepository,
+				postgres.NewUserTokenRepository,
+				mail.NewMailer,
+				postgres.NewOTPRepository,
+				sms.NewConsoleSender,
+				postgres.NewIdentityRepository,
+				postgres.NewOAuthStateRepository,
+				oauth.NewProviders,
+				postgres.NewLoginAttemptRepository,
+				postgres.NewRBACRepository,
+				postgres.NewAdminRepository,
+				postgres.NewAPIKeyRepository,
+				postgres.NewTwoFactorRepository,
+				postgres.NewSecurityEventRepository,
+				postgres.NewSessionRepository,
+				passwords.NewHasher,
+				breached.NewFileList,
+				token.NewKeySet,
 				newGrpcServer,
+				newTOTPSecretBox,
 			),
+			fx.Invoke(sealTOTPSecrets),
+			fx.Invoke(runSecurityEventRetention),
+			fx.Invoke(runOAuthStateCleanup),
+			fx.Invoke(runSessionCleanup),
 		),
 	)
 }
@@ -0,0 +1,67 @@
+package ports
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+)
+
+// @Summary		Активные сессии
+// @Description	Устройства, с которых выполнен вход: IP и User-Agent при входе, время входа и последнего обновления токена. Текущая сессия отмечена current.
+// @Tags			sessions
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}	dto.SessionResponse
+// @Router			/sessions [get]
+func (rc *httpServer) listSessionsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims := claimsFromContext(c)
+
+		sessions, err := a.Queries.ListSessions.Handle(c.Request().Context(), claims.UserID)
+		if err != nil {
+			return domainError(err)
+		}
+
+		response := make([]dto.SessionResponse, 0, len(sessions))
+		for _, s := range sessions {
+			response = append(response, dto.SessionResponse{
+				ID:         s.ID,
+				Device:     s.Device,
+				IP:         s.IP,
+				UserAgent:  s.UserAgent,
+				CreatedAt:  s.CreatedAt,
+				LastSeenAt: s.LastSeenAt,
+				ExpiresAt:  s.ExpiresAt,
+				Current:    s.ID == claims.SessionID,
+			})
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// @Summary		Завершение сессии
+// @Description	Выход на одном устройстве: refresh токены сессии отзываются, ее токены доступа перестают проходить проверку.
+// @Tags			sessions
+// @Security		BearerAuth
+// @Param			id	path	string	true	"ID сессии"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"Сессия не найдена"
+// @Router			/sessions/{id} [delete]
+func (rc *httpServer) revokeSessionHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := a.Commands.RevokeSession.Handle(c.Request().Context(), command.RevokeSessionCommand{
+			UserID:    claimsFromContext(c).UserID,
+			SessionID: c.Param("id"),
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
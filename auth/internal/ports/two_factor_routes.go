@@ -0,0 +1,281 @@
+package ports
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/auth/internal/app/twofactor"
+	"marketai/pkg/http/problem"
+)
+
+// @Summary		Завершение входа вторым фактором
+// @Description	Принимает код из приложения-аутентификатора или резервный код и выдает токены, как обычный вход. Если второй фактор подключался во время входа, в ответе есть recovery_codes.
+// @Tags			auth
+// @Accept			json
+// @Produce		json
+// @Param			input	body		dto.TwoFactorLoginRequest	true	"Токен входа и код"
+// @Success		200		{object}	map[string]string			"Успешный вход, возвращает JWT токен"
+// @Failure		401		{object}	problem.Problem	"Неверный код или истекший вход"
+// @Failure		429		{object}	problem.Problem	"Превышено число попыток"
+// @Router			/login/2fa [post]
+func (rc *httpServer) twoFactorLoginHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.TwoFactorLoginRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		result, err := a.Commands.CompleteTwoFactor.Handle(c.Request().Context(), command.CompleteTwoFactorLoginCommand{
+			ChallengeToken: req.ChallengeToken,
+			Code:           req.Code,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, loginResponse(result))
+	}
+}
+
+// @Summary		Подключение второго фактора при входе
+// @Description	Для входа с enrollment_required: выдает секрет приложения-аутентификатора. Первый код из приложения передается в /login/2fa.
+// @Tags			auth
+// @Accept			json
+// @Produce		json
+// @Param			input	body		dto.TwoFactorEnrollRequest	true	"Токен входа"
+// @Success		200		{object}	dto.TwoFactorSetupResponse
+// @Failure		401		{object}	problem.Problem	"Истекший вход"
+// @Failure		409		{object}	problem.Problem	"Второй фактор уже подключен"
+// @Router			/login/2fa/enroll [post]
+func (rc *httpServer) twoFactorEnrollHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.TwoFactorEnrollRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		setup, err := a.Commands.EnrollTwoFactor.Handle(c.Request().Context(), req.ChallengeToken)
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, twoFactorSetupResponse(setup))
+	}
+}
+
+// @Summary		Состояние второго фактора
+// @Tags			profile
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	dto.TwoFactorStatusResponse
+// @Router			/me/2fa [get]
+func (rc *httpServer) twoFactorStatusHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		status, err := a.Queries.GetTwoFactorStatus.Handle(c.Request().Context(), claimsFromContext(c).UserID)
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.TwoFactorStatusResponse{
+			Enabled:           status.Enabled,
+			Required:          status.Required,
+			RecoveryCodesLeft: status.RecoveryCodesLeft,
+		})
+	}
+}
+
+// @Summary		Начало подключения второго фактора
+// @Description	Выдает секрет и otpauth:// адрес для QR кода. Второй фактор включается после подтверждения кодом.
+// @Tags			profile
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{object}	dto.TwoFactorSetupResponse
+// @Failure		409	{object}	problem.Problem	"Второй фактор уже подключен"
+// @Router			/me/2fa/setup [post]
+func (rc *httpServer) startTwoFactorSetupHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setup, err := a.Commands.StartTwoFactor.Handle(c.Request().Context(), claimsFromContext(c).UserID)
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, twoFactorSetupResponse(setup))
+	}
+}
+
+// @Summary		Подтверждение второго фактора
+// @Description	Включает второй фактор по коду из приложения и возвращает резервные коды. Коды показываются один раз.
+// @Tags			profile
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			input	body		dto.TwoFactorCodeRequest	true	"Код из приложения"
+// @Success		200		{object}	dto.RecoveryCodesResponse
+// @Failure		401		{object}	problem.Problem	"Неверный код"
+// @Failure		409		{object}	problem.Problem	"Второй фактор уже подключен"
+// @Router			/me/2fa/confirm [post]
+func (rc *httpServer) confirmTwoFactorHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.TwoFactorCodeRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		codes, err := a.Commands.ConfirmTwoFactor.Handle(c.Request().Context(), command.ConfirmTwoFactorCommand{
+			UserID: claimsFromContext(c).UserID,
+			Code:   req.Code,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+	}
+}
+
+// @Summary		Отключение второго фактора
+// @Description	Требует пароль и код. Недоступно, если второй фактор обязателен для роли или рабочего пространства.
+// @Tags			profile
+// @Accept			json
+// @Security		BearerAuth
+// @Param			input	body	dto.DisableTwoFactorRequest	true	"Пароль и код"
+// @Success		204
+// @Failure		401	{object}	problem.Problem	"Неверный код"
+// @Failure		403	{object}	problem.Problem	"Неверный пароль или второй фактор обязателен"
+// @Router			/me/2fa/disable [post]
+func (rc *httpServer) disableTwoFactorHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.DisableTwoFactorRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		err := a.Commands.DisableTwoFactor.Handle(c.Request().Context(), command.DisableTwoFactorCommand{
+			UserID:   claimsFromContext(c).UserID,
+			Password: req.Password,
+			Code:     req.Code,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Новые резервные коды
+// @Description	Заменяет резервные коды новыми, старые перестают действовать. Требует код второго фактора.
+// @Tags			profile
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			input	body		dto.TwoFactorCodeRequest	true	"Код второго фактора"
+// @Success		200		{object}	dto.RecoveryCodesResponse
+// @Failure		401		{object}	problem.Problem	"Неверный код"
+// @Router			/me/2fa/recovery-codes [post]
+func (rc *httpServer) regenerateRecoveryCodesHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.TwoFactorCodeRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		codes, err := a.Commands.RegenerateCodes.Handle(c.Request().Context(), command.RegenerateRecoveryCodesCommand{
+			UserID: claimsFromContext(c).UserID,
+			Code:   req.Code,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+	}
+}
+
+// @Summary		Обязательный второй фактор в пространстве
+// @Description	Владелец пространства требует второй фактор от всех участников. Участники без второго фактора подключат его при следующем входе.
+// @Tags			workspaces
+// @Accept			json
+// @Security		BearerAuth
+// @Param			id		path	string							true	"ID пространства"
+// @Param			input	body	dto.WorkspaceTwoFactorRequest	true	"Требовать второй фактор"
+// @Success		204
+// @Failure		403	{object}	problem.Problem	"Недостаточно прав в пространстве"
+// @Router			/workspaces/{id}/two-factor [put]
+func (rc *httpServer) workspaceTwoFactorHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.WorkspaceTwoFactorRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		err := a.Commands.WorkspaceTwoFactor.Handle(c.Request().Context(), command.SetWorkspaceTwoFactorCommand{
+			WorkspaceID: c.Param("id"),
+			ActorID:     claimsFromContext(c).UserID,
+			Required:    req.Required,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Сброс второго фактора пользователя
+// @Description	Для пользователя, потерявшего устройство и резервные коды. Требуется право users:manage.
+// @Tags			admin
+// @Security		BearerAuth
+// @Param			id	path	string	true	"ID пользователя"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"Пользователь не найден"
+// @Router			/admin/users/{id}/2fa/reset [post]
+func (rc *httpServer) resetTwoFactorHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := a.Commands.ResetTwoFactor.Handle(c.Request().Context(), command.ResetTwoFactorCommand{
+			UserID:  c.Param("id"),
+			AdminID: claimsFromContext(c).UserID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// twoFactorChallengeResponse - ответ входа, ожидающего второго фактора
+func twoFactorChallengeResponse(challenge *twofactor.Challenge) map[string]interface{} {
+	return map[string]interface{}{
+		"two_factor_required": true,
+		"challenge_token":     challenge.Token,
+		"enrollment_required": challenge.Enrollment,
+		"expires_in":          int64(challenge.ExpiresIn.Seconds()),
+	}
+}
+
+func twoFactorSetupResponse(setup *twofactor.Setup) dto.TwoFactorSetupResponse {
+	return dto.TwoFactorSetupResponse{
+		Secret:          setup.Secret,
+		ProvisioningURI: setup.ProvisioningURI,
+	}
+}
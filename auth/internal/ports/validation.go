@@ -0,0 +1,66 @@
+package ports
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"marketai/pkg/http/problem"
+
+	"github.com/go-playground/validator"
+)
+
+// jsonFieldName возвращает имя поля из тега json, чтобы ошибки проверки
+// называли поля так же, как их видит клиент
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// validationError превращает ошибку валидатора в ответ 400 со списком неверных полей
+func validationError(err error) error {
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return problem.ErrValidation.Wrap(err)
+	}
+
+	fields := make([]problem.FieldError, 0, len(errs))
+	for _, fe := range errs {
+		fields = append(fields, problem.FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: fieldErrorMessage(fe),
+		})
+	}
+	return fieldsError(fields...)
+}
+
+func fieldsError(fields ...problem.FieldError) error {
+	return problem.ErrValidation.WithFields(fields...)
+}
+
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "Обязательное поле"
+	case "required_without":
+		return fmt.Sprintf("Обязательное поле, если не указано %s", fe.Param())
+	case "email":
+		return "Неверный формат email"
+	case "e164":
+		return "Номер телефона должен быть в формате E.164, например +79991234567"
+	case "max":
+		return fmt.Sprintf("Не длиннее %s символов", fe.Param())
+	case "min":
+		return fmt.Sprintf("Не короче %s символов", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("Допустимые значения: %s", fe.Param())
+	}
+	return "Неверное значение"
+}
@@ -0,0 +1,146 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"marketai/auth/internal/adapters/postgres"
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/config"
+	"marketai/pkg/encryption"
+	"marketai/pkg/postgresql"
+)
+
+const (
+	// oauthStateCleanupInterval - как часто удалять просроченные состояния входа через провайдера
+	oauthStateCleanupInterval = 10 * time.Minute
+	// sessionCleanupInterval - как часто удалять завершенные и истекшие сессии
+	sessionCleanupInterval = time.Hour
+)
+
+// runPeriodically вызывает fn каждые interval, пока приложение запущено.
+// При остановке дожидается завершения текущего прохода.
+func runPeriodically(lc fx.Lifecycle, interval time.Duration, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.StartStopHook(
+		func() {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						fn(ctx)
+					}
+				}
+			}()
+		},
+		func() {
+			cancel()
+			<-done
+		},
+	))
+}
+
+// runSecurityEventRetention удаляет события безопасности старше срока хранения.
+// Очистка подключается к базе отдельной ролью: у роли приложения нет права
+// удалять события, поэтому взлом сервиса не позволит стереть журнал.
+func runSecurityEventRetention(lc fx.Lifecycle, cfg *config.Config, logger *zap.Logger) error {
+	interval := cfg.Audit.CleanupInterval
+	if interval <= 0 || cfg.Audit.Retention <= 0 {
+		logger.Info("security event retention disabled")
+		return nil
+	}
+	if cfg.Audit.RetentionDBUser == "" {
+		logger.Warn("security event retention disabled: audit.retention_db_user is not set")
+		return nil
+	}
+
+	pgCfg := *cfg.PostgresConfig()
+	pgCfg.User = cfg.Audit.RetentionDBUser
+	pgCfg.Password = cfg.Audit.RetentionDBPassword
+	pgCfg.MaxConn, pgCfg.MinConn = 1, 0
+	pool, err := postgresql.NewPgxPool(&pgCfg)
+	if err != nil {
+		return fmt.Errorf("audit.retention_db_user: %w", err)
+	}
+	// Хук закрытия добавляется первым, поэтому выполнится после остановки очистки
+	lc.Append(fx.StopHook(pool.Close))
+
+	purge := command.NewPurgeSecurityEventsHandler(postgres.NewSecurityEventPurger(pool), cfg)
+	runPeriodically(lc, interval, func(ctx context.Context) {
+		deleted, err := purge.Handle(ctx)
+		if err != nil {
+			logger.Error("security event retention failed", zap.Error(err))
+			return
+		}
+		if deleted > 0 {
+			logger.Info("security events purged", zap.Int64("deleted", deleted))
+		}
+	})
+	return nil
+}
+
+// runOAuthStateCleanup удаляет брошенные входы через внешних провайдеров
+func runOAuthStateCleanup(lc fx.Lifecycle, a *app.AppCQRS, logger *zap.Logger) {
+	runPeriodically(lc, oauthStateCleanupInterval, func(ctx context.Context) {
+		deleted, err := a.Commands.PurgeOAuthStates.Handle(ctx)
+		if err != nil {
+			logger.Error("oauth state cleanup failed", zap.Error(err))
+			return
+		}
+		if deleted > 0 {
+			logger.Info("expired oauth states purged", zap.Int64("deleted", deleted))
+		}
+	})
+}
+
+// runSessionCleanup удаляет завершенные и истекшие сессии с их refresh токенами
+func runSessionCleanup(lc fx.Lifecycle, a *app.AppCQRS, logger *zap.Logger) {
+	runPeriodically(lc, sessionCleanupInterval, func(ctx context.Context) {
+		deleted, err := a.Commands.PurgeSessions.Handle(ctx)
+		if err != nil {
+			logger.Error("session cleanup failed", zap.Error(err))
+			return
+		}
+		if deleted > 0 {
+			logger.Info("ended sessions purged", zap.Int64("deleted", deleted))
+		}
+	})
+}
+
+func newTOTPSecretBox(cfg *config.Config) (*encryption.Box, error) {
+	box, err := encryption.NewBox(cfg.TwoFactor.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("two_factor.encryption_key: %w", err)
+	}
+	return box, nil
+}
+
+// sealTOTPSecrets при старте шифрует секреты TOTP, сохраненные до включения шифрования
+func sealTOTPSecrets(lc fx.Lifecycle, repo *postgres.TwoFactorRepository, logger *zap.Logger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			sealed, err := repo.SealSecrets(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt TOTP secrets: %w", err)
+			}
+			if sealed > 0 {
+				logger.Info("TOTP secrets encrypted", zap.Int("count", sealed))
+			}
+			return nil
+		},
+	})
+}
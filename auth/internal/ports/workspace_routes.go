@@ -0,0 +1,239 @@
+package ports
+
+import (
+	"errors"
+	"net/http"
+
+	"marketai/auth/internal/app"
+	"marketai/auth/internal/app/command"
+	"marketai/auth/internal/app/dto"
+	"marketai/auth/internal/app/query"
+	"marketai/auth/internal/domain"
+	"marketai/pkg/http/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary		Список рабочих пространств
+// @Description	Возвращает рабочие пространства текущего пользователя с его ролью в каждом.
+// @Tags			workspaces
+// @Produce		json
+// @Security		BearerAuth
+// @Success		200	{array}		dto.WorkspaceResponse
+// @Failure		401	{object}	problem.Problem	"Требуется авторизация"
+// @Router			/workspaces [get]
+func (rc *httpServer) listWorkspacesHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims := claimsFromContext(c)
+
+		memberships, err := a.Queries.GetUserWorkspaces.Handle(c.Request().Context(), claims.UserID)
+		if err != nil {
+			return workspaceError(err)
+		}
+
+		response := make([]dto.WorkspaceResponse, 0, len(memberships))
+		for _, m := range memberships {
+			response = append(response, workspaceResponse(m))
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// @Summary		Создание рабочего пространства
+// @Description	Создает рабочее пространство, текущий пользователь становится его владельцем.
+// @Tags			workspaces
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			input	body		dto.CreateWorkspaceRequest	true	"Название пространства"
+// @Success		201		{object}	dto.WorkspaceResponse
+// @Failure		400		{object}	problem.Problem	"Неверный формат запроса"
+// @Router			/workspaces [post]
+func (rc *httpServer) createWorkspaceHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.CreateWorkspaceRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		membership, err := a.Commands.CreateWorkspace.Handle(c.Request().Context(), command.CreateWorkspaceCommand{
+			UserID: claimsFromContext(c).UserID,
+			Name:   req.Name,
+		})
+		if err != nil {
+			return workspaceError(err)
+		}
+
+		return c.JSON(http.StatusCreated, workspaceResponse(membership))
+	}
+}
+
+// @Summary		Участники рабочего пространства
+// @Tags			workspaces
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"ID пространства"
+// @Success		200	{array}		domain.WorkspaceMember
+// @Failure		403	{object}	problem.Problem	"Пользователь не состоит в пространстве"
+// @Router			/workspaces/{id}/members [get]
+func (rc *httpServer) listMembersHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		members, err := a.Queries.GetWorkspaceMembers.Handle(c.Request().Context(), query.GetWorkspaceMembersQuery{
+			WorkspaceID: c.Param("id"),
+			UserID:      claimsFromContext(c).UserID,
+		})
+		if err != nil {
+			return workspaceError(err)
+		}
+
+		return c.JSON(http.StatusOK, members)
+	}
+}
+
+// @Summary		Добавление участника
+// @Description	Добавляет зарегистрированного пользователя в пространство. Доступно только владельцу.
+// @Tags			workspaces
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string					true	"ID пространства"
+// @Param			input	body		dto.AddMemberRequest	true	"Email и роль участника"
+// @Success		201		{object}	domain.WorkspaceMember
+// @Failure		403		{object}	problem.Problem	"Недостаточно прав"
+// @Failure		404		{object}	problem.Problem	"Пользователь не найден"
+// @Failure		409		{object}	problem.Problem	"Пользователь уже состоит в пространстве"
+// @Router			/workspaces/{id}/members [post]
+func (rc *httpServer) addMemberHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.AddMemberRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		member, err := a.Commands.AddMember.Handle(c.Request().Context(), command.AddMemberCommand{
+			WorkspaceID: c.Param("id"),
+			ActorID:     claimsFromContext(c).UserID,
+			Email:       req.Email,
+			Role:        domain.WorkspaceRole(req.Role),
+		})
+		if err != nil {
+			return workspaceError(err)
+		}
+
+		return c.JSON(http.StatusCreated, member)
+	}
+}
+
+// @Summary		Изменение роли участника
+// @Tags			workspaces
+// @Accept			json
+// @Security		BearerAuth
+// @Param			id		path	string						true	"ID пространства"
+// @Param			userId	path	string						true	"ID участника"
+// @Param			input	body	dto.UpdateMemberRoleRequest	true	"Новая роль"
+// @Success		204
+// @Failure		403	{object}	problem.Problem	"Недостаточно прав"
+// @Failure		409	{object}	problem.Problem	"Нельзя лишить пространство последнего владельца"
+// @Router			/workspaces/{id}/members/{userId} [patch]
+func (rc *httpServer) updateMemberRoleHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.UpdateMemberRoleRequest
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+		if err := rc.Validator.Struct(req); err != nil {
+			return validationError(err)
+		}
+
+		err := a.Commands.UpdateMemberRole.Handle(c.Request().Context(), command.UpdateMemberRoleCommand{
+			WorkspaceID: c.Param("id"),
+			ActorID:     claimsFromContext(c).UserID,
+			UserID:      c.Param("userId"),
+			Role:        domain.WorkspaceRole(req.Role),
+		})
+		if err != nil {
+			return workspaceError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Удаление участника
+// @Description	Владелец может удалить любого участника, остальные - только выйти сами.
+// @Tags			workspaces
+// @Security		BearerAuth
+// @Param			id		path	string	true	"ID пространства"
+// @Param			userId	path	string	true	"ID участника"
+// @Success		204
+// @Failure		403	{object}	problem.Problem	"Недостаточно прав"
+// @Failure		409	{object}	problem.Problem	"Нельзя удалить последнего владельца"
+// @Router			/workspaces/{id}/members/{userId} [delete]
+func (rc *httpServer) removeMemberHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := a.Commands.RemoveMember.Handle(c.Request().Context(), command.RemoveMemberCommand{
+			WorkspaceID: c.Param("id"),
+			ActorID:     claimsFromContext(c).UserID,
+			UserID:      c.Param("userId"),
+		})
+		if err != nil {
+			return workspaceError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Переключение рабочего пространства
+// @Description	Выпускает новую пару токенов с claims выбранного пространства в той же сессии, прежний refresh токен сессии перестает действовать.
+// @Tags			workspaces
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"ID пространства"
+// @Success		200	{object}	dto.SwitchWorkspaceResponse
+// @Failure		403	{object}	problem.Problem	"Пользователь не состоит в пространстве"
+// @Router			/workspaces/{id}/switch [post]
+func (rc *httpServer) switchWorkspaceHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims := claimsFromContext(c)
+
+		result, err := a.Queries.SwitchWorkspace.Handle(c.Request().Context(), query.SwitchWorkspaceQuery{
+			WorkspaceID: c.Param("id"),
+			UserID:      claims.UserID,
+			SessionID:   claims.SessionID,
+		})
+		if err != nil {
+			return workspaceError(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.SwitchWorkspaceResponse{
+			Token:        result.Token,
+			RefreshToken: result.RefreshToken,
+			ExpiresIn:    int64(result.ExpiresIn.Seconds()),
+			Workspace:    workspaceResponse(result.Workspace),
+		})
+	}
+}
+
+func workspaceError(err error) error {
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return errSessionEnded.Wrap(err)
+	}
+
+	return domainError(err)
+}
+
+func workspaceResponse(m *domain.Membership) dto.WorkspaceResponse {
+	return dto.WorkspaceResponse{
+		ID:      m.Workspace.ID,
+		Name:    m.Workspace.Name,
+		OwnerID: m.Workspace.OwnerID,
+		Role:    string(m.Role),
+	}
+}
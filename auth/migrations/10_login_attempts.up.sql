@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS login_failures (
+    key VARCHAR(320) PRIMARY KEY,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key VARCHAR(320) NOT NULL,
+    failures INT NOT NULL,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    unlocked_at TIMESTAMP WITH TIME ZONE,
+    unlocked_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_lockouts_key ON login_lockouts(key, created_at DESC);
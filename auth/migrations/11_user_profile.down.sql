@@ -0,0 +1,2 @@
+ALTER TABLE user_tokens DROP COLUMN IF EXISTS payload;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
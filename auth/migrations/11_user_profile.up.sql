@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- payload - данные токена из письма, например новый email при смене адреса
+ALTER TABLE user_tokens ADD COLUMN IF NOT EXISTS payload VARCHAR(255) NOT NULL DEFAULT '';
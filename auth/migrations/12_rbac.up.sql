@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    builtin BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL REFERENCES roles(name),
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('cards:read', 'Просмотр карточек'),
+    ('cards:write', 'Генерация и редактирование карточек'),
+    ('users:read', 'Просмотр пользователей'),
+    ('users:manage', 'Управление пользователями'),
+    ('roles:manage', 'Управление ролями и их назначением'),
+    ('lockouts:manage', 'Просмотр и снятие блокировок входа')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, description, builtin) VALUES
+    ('user', 'Пользователь сервиса', TRUE),
+    ('admin', 'Администратор', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('user', 'cards:read'),
+    ('user', 'cards:write'),
+    ('admin', 'cards:read'),
+    ('admin', 'cards:write'),
+    ('admin', 'users:read'),
+    ('admin', 'users:manage'),
+    ('admin', 'roles:manage'),
+    ('admin', 'lockouts:manage')
+ON CONFLICT DO NOTHING;
+
+-- Роль больше не задается клиентом: неизвестные роли из users.role сводятся к user
+UPDATE users SET role='user' WHERE role NOT IN (SELECT name FROM roles);
+
+INSERT INTO user_roles (user_id, role)
+SELECT id, role FROM users
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS admin_actions;
+
+ALTER TABLE users DROP COLUMN IF EXISTS blocked_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS blocked_at;
@@ -0,0 +1,6 @@
+ALTER TABLE workspaces DROP COLUMN IF EXISTS require_two_factor;
+ALTER TABLE roles DROP COLUMN IF EXISTS require_two_factor;
+
+DROP TABLE IF EXISTS two_factor_challenges;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS user_totp;
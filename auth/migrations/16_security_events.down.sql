@@ -0,0 +1,8 @@
+DELETE FROM role_permissions WHERE permission='audit:read';
+DELETE FROM permissions WHERE name='audit:read';
+
+DROP FUNCTION IF EXISTS anonymize_security_events(UUID);
+DROP TRIGGER IF EXISTS security_events_no_truncate ON security_events;
+DROP TRIGGER IF EXISTS security_events_append_only ON security_events;
+DROP FUNCTION IF EXISTS security_events_append_only();
+DROP TABLE IF EXISTS security_events;
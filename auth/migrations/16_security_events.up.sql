@@ -0,0 +1,103 @@
+CREATE TABLE IF NOT EXISTS security_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- event_type - login.password, user.register, password.change и т.д.
+    event_type VARCHAR(50) NOT NULL,
+    -- outcome - success или failure
+    outcome VARCHAR(20) NOT NULL,
+    -- user_id без внешнего ключа: журнал не должен зависеть от удаления пользователей
+    user_id UUID,
+    -- actor_id - кто совершил действие, если не сам пользователь (администратор)
+    actor_id UUID,
+    -- login - введенный логин, для неудачных входов пользователь может быть неизвестен
+    login VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_created_at ON security_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_user_id ON security_events(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_type ON security_events(event_type, created_at);
+
+-- Журнал только дополняется. Изменение разрешено лишь для стирания персональных
+-- данных удаленного пользователя: логин, адрес и User-Agent очищаются, остальное
+-- не меняется. TRUNCATE запрещен всем.
+CREATE OR REPLACE FUNCTION security_events_append_only() RETURNS trigger AS $$
+BEGIN
+    -- У TRUNCATE нет строк, поэтому условие на строки проверяется только для UPDATE
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.id = OLD.id
+            AND NEW.event_type = OLD.event_type
+            AND NEW.outcome = OLD.outcome
+            AND NEW.user_id IS NOT DISTINCT FROM OLD.user_id
+            AND NEW.actor_id IS NOT DISTINCT FROM OLD.actor_id
+            AND NEW.reason = OLD.reason
+            AND NEW.created_at = OLD.created_at
+            AND NEW.login = '' AND NEW.ip = '' AND NEW.user_agent = ''
+            AND OLD.details @> NEW.details
+        THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    RAISE EXCEPTION 'security_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS security_events_append_only ON security_events;
+CREATE TRIGGER security_events_append_only
+    BEFORE UPDATE ON security_events
+    FOR EACH ROW EXECUTE FUNCTION security_events_append_only();
+
+DROP TRIGGER IF EXISTS security_events_no_truncate ON security_events;
+CREATE TRIGGER security_events_no_truncate
+    BEFORE TRUNCATE ON security_events
+    FOR EACH STATEMENT EXECUTE FUNCTION security_events_append_only();
+
+-- Изменять и удалять события может только владелец таблицы (роль миграций).
+-- Роль приложения только добавляет и читает события, очистка по сроку хранения
+-- выполняется отдельной ролью с правом DELETE (audit.retention_db_user).
+REVOKE UPDATE, DELETE, TRUNCATE ON security_events FROM PUBLIC;
+DO $$
+DECLARE
+    grantee TEXT;
+BEGIN
+    FOR grantee IN
+        SELECT DISTINCT g.grantee
+        FROM information_schema.role_table_grants g
+        WHERE g.table_name = 'security_events'
+          AND g.privilege_type IN ('UPDATE', 'DELETE', 'TRUNCATE')
+          AND g.grantee NOT IN ('PUBLIC', current_user)
+    LOOP
+        EXECUTE format('REVOKE UPDATE, DELETE, TRUNCATE ON security_events FROM %I', grantee);
+    END LOOP;
+END;
+$$;
+
+-- anonymize_security_events стирает логин, адрес, User-Agent и адреса из
+-- подробностей в событиях пользователя при удалении учетной записи. Выполняется
+-- с правами владельца, поэтому роли приложения не нужно право UPDATE.
+CREATE OR REPLACE FUNCTION anonymize_security_events(target UUID) RETURNS BIGINT
+LANGUAGE plpgsql SECURITY DEFINER SET search_path = public AS $$
+DECLARE
+    affected BIGINT;
+BEGIN
+    UPDATE security_events e
+    SET login = '', ip = '', user_agent = '', details = e.details - 'from' - 'to'
+    FROM users u
+    WHERE u.id = target
+      AND (e.user_id = target
+           OR (e.login <> '' AND (lower(e.login) = lower(u.email) OR e.login = u.phone_number)));
+    GET DIAGNOSTICS affected = ROW_COUNT;
+    RETURN affected;
+END;
+$$;
+
+INSERT INTO permissions (name, description) VALUES
+    ('audit:read', 'Просмотр журнала безопасности')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'audit:read')
+ON CONFLICT DO NOTHING;
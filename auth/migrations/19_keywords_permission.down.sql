@@ -0,0 +1,2 @@
+DELETE FROM role_permissions WHERE permission='keywords:manage';
+DELETE FROM permissions WHERE name='keywords:manage';
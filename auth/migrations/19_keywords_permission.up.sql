@@ -0,0 +1,7 @@
+INSERT INTO permissions (name, description) VALUES
+    ('keywords:manage', 'Импорт SEO словаря, общего для всех пространств')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'keywords:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_otp_codes_phone_purpose_created;
+
+ALTER TABLE otp_codes DROP COLUMN IF EXISTS purpose;
+
+DROP INDEX IF EXISTS idx_users_phone_number_unique;
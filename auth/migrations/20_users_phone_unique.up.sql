@@ -0,0 +1,18 @@
+-- Номер телефона указывает на одного пользователя: дубликаты, сохраненные до
+-- подтверждения номера, снимаются со всех владельцев, кроме самого раннего
+UPDATE users u
+SET phone_number = ''
+WHERE u.phone_number <> '' AND u.deleted_at IS NULL
+    AND EXISTS (
+        SELECT 1 FROM users o
+        WHERE o.phone_number = u.phone_number AND o.deleted_at IS NULL
+            AND (o.created_at, o.id) < (u.created_at, u.id)
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_phone_number_unique
+    ON users(phone_number) WHERE phone_number <> '' AND deleted_at IS NULL;
+
+-- Коды входа и коды подтверждения нового номера не взаимозаменяемы
+ALTER TABLE otp_codes ADD COLUMN IF NOT EXISTS purpose VARCHAR(32) NOT NULL DEFAULT 'login';
+
+CREATE INDEX IF NOT EXISTS idx_otp_codes_phone_purpose_created ON otp_codes(phone_number, purpose, created_at DESC);
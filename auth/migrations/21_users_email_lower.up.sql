@@ -0,0 +1,20 @@
+-- Email сравнивается без учета регистра. Адреса, отличающиеся только
+-- регистром, не объединяются автоматически: миграция останавливается, пока
+-- дубликаты не будут разобраны вручную.
+DO $$
+DECLARE
+    duplicates BIGINT;
+BEGIN
+    SELECT COUNT(*) INTO duplicates
+    FROM (
+        SELECT lower(email) FROM users GROUP BY lower(email) HAVING COUNT(*) > 1
+    ) d;
+    IF duplicates > 0 THEN
+        RAISE EXCEPTION 'users: % email addresses differ only in case, resolve them before migrating', duplicates;
+    END IF;
+END
+$$;
+
+UPDATE users SET email = lower(email) WHERE email <> lower(email);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower_unique ON users(lower(email));
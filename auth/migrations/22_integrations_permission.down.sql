@@ -0,0 +1,2 @@
+DELETE FROM role_permissions WHERE permission='integrations:manage';
+DELETE FROM permissions WHERE name='integrations:manage';
@@ -0,0 +1,8 @@
+INSERT INTO permissions (name, description) VALUES
+    ('integrations:manage', 'Подписки на вебхуки пространства')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('user', 'integrations:manage'),
+    ('admin', 'integrations:manage')
+ON CONFLICT DO NOTHING;
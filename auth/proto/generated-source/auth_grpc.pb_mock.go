@@ -55,6 +55,26 @@ func (mr *MockAuthServiceClientMockRecorder) GetUserData(ctx, in interface{}, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserData", reflect.TypeOf((*MockAuthServiceClient)(nil).GetUserData), varargs...)
 }
 
+// ValidateAPIKey mocks base method.
+func (m *MockAuthServiceClient) ValidateAPIKey(ctx context.Context, in *ValidateAPIKeyRequest, opts ...grpc.CallOption) (*ValidateAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ValidateAPIKey", varargs...)
+	ret0, _ := ret[0].(*ValidateAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAPIKey indicates an expected call of ValidateAPIKey.
+func (mr *MockAuthServiceClientMockRecorder) ValidateAPIKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAPIKey", reflect.TypeOf((*MockAuthServiceClient)(nil).ValidateAPIKey), varargs...)
+}
+
 // ValidateToken mocks base method.
 func (m *MockAuthServiceClient) ValidateToken(ctx context.Context, in *ValidateTokenRequest, opts ...grpc.CallOption) (*ValidateTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +133,21 @@ func (mr *MockAuthServiceServerMockRecorder) GetUserData(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserData", reflect.TypeOf((*MockAuthServiceServer)(nil).GetUserData), arg0, arg1)
 }
 
+// ValidateAPIKey mocks base method.
+func (m *MockAuthServiceServer) ValidateAPIKey(arg0 context.Context, arg1 *ValidateAPIKeyRequest) (*ValidateAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(*ValidateAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAPIKey indicates an expected call of ValidateAPIKey.
+func (mr *MockAuthServiceServerMockRecorder) ValidateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAPIKey", reflect.TypeOf((*MockAuthServiceServer)(nil).ValidateAPIKey), arg0, arg1)
+}
+
 // ValidateToken mocks base method.
 func (m *MockAuthServiceServer) ValidateToken(arg0 context.Context, arg1 *ValidateTokenRequest) (*ValidateTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -6,6 +6,7 @@ import (
 	auth_grpc_api "marketai/auth/proto/generated-source"
 	"marketai/cards/internal/config"
 	"marketai/cards/internal/domain"
+	"marketai/pkg/http/problem"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -36,9 +37,11 @@ func (s *AuthGRPCService) ValidateToken(ctx context.Context, token string) (*dom
 		Token: token,
 	}
 
+	// Сбой вызова (auth недоступен, лимит запросов) - не отказ в доступе:
+	// ошибка сохраняет свой статус, 401 дает только Valid=false
 	resp, err := s.client.ValidateToken(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate token: %w", err)
+		return nil, problem.FromGRPC(err)
 	}
 
 	if !resp.Valid {
@@ -46,8 +49,34 @@ func (s *AuthGRPCService) ValidateToken(ctx context.Context, token string) (*dom
 	}
 
 	return &domain.UserInfo{
-		UserID: resp.UserId,
-		Role:   resp.Role,
+		UserID:        resp.UserId,
+		Role:          resp.Role,
+		WorkspaceID:   resp.WorkspaceId,
+		WorkspaceRole: domain.WorkspaceRole(resp.WorkspaceRole),
+		EmailVerified: resp.EmailVerified,
+		Permissions:   resp.Permissions,
+	}, nil
+}
+
+func (s *AuthGRPCService) ValidateAPIKey(ctx context.Context, key string) (*domain.UserInfo, error) {
+	resp, err := s.client.ValidateAPIKey(ctx, &auth_grpc_api.ValidateAPIKeyRequest{
+		ApiKey: key,
+	})
+	if err != nil {
+		return nil, problem.FromGRPC(err)
+	}
+
+	if !resp.Valid {
+		return nil, fmt.Errorf("invalid api key")
+	}
+
+	return &domain.UserInfo{
+		UserID:        resp.UserId,
+		WorkspaceID:   resp.WorkspaceId,
+		WorkspaceRole: domain.WorkspaceRole(resp.WorkspaceRole),
+		EmailVerified: resp.EmailVerified,
+		Permissions:   resp.Permissions,
+		APIKeyID:      resp.KeyId,
 	}, nil
 }
 
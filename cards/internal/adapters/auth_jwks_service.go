@@ -0,0 +1,114 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"marketai/cards/internal/config"
+	"marketai/cards/internal/domain"
+	"marketai/pkgAuth/jwt"
+)
+
+const defaultSessionCheckTTL = 30 * time.Second
+
+// JWKSAuthService проверяет подпись и claims токенов локально по открытым
+// ключам auth сервиса. Отзыв токена (завершение сессии sid, выход, блокировка)
+// виден только в auth, поэтому токен дополнительно подтверждается в auth по
+// gRPC, и подтверждение запоминается на sessionTTL: отозванный токен перестает
+// приниматься не позже чем через sessionTTL. API ключи проверяются только в auth.
+type JWKSAuthService struct {
+	verifier   jwt.Verifier
+	auth       domain.AuthService
+	sessionTTL time.Duration
+
+	mu        sync.Mutex
+	confirmed map[string]time.Time
+	sweptAt   time.Time
+}
+
+func NewJWKSAuthService(cfg *config.Config, auth domain.AuthService) *JWKSAuthService {
+	sessionTTL := cfg.Auth.SessionCheckTTL
+	if sessionTTL == 0 {
+		sessionTTL = defaultSessionCheckTTL
+	}
+	return &JWKSAuthService{
+		verifier: jwt.NewJWKSVerifier(cfg.Auth.JWKSURL, cfg.Auth.JWKSCacheTTL, jwt.ValidationOptions{
+			Issuer:    cfg.Auth.Issuer,
+			Audience:  cfg.Auth.Audience,
+			ClockSkew: cfg.Auth.ClockSkew,
+		}),
+		auth:       auth,
+		sessionTTL: sessionTTL,
+		confirmed:  make(map[string]time.Time),
+	}
+}
+
+func (s *JWKSAuthService) ValidateToken(ctx context.Context, token string) (*domain.UserInfo, error) {
+	claims, err := s.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if err := s.checkRevocation(ctx, claims.ID, token); err != nil {
+		return nil, err
+	}
+
+	return &domain.UserInfo{
+		UserID:        claims.UserID,
+		Role:          claims.Role,
+		WorkspaceID:   claims.WorkspaceID,
+		WorkspaceRole: domain.WorkspaceRole(claims.WorkspaceRole),
+		EmailVerified: claims.EmailVerified,
+		Permissions:   claims.Permissions(),
+	}, nil
+}
+
+// checkRevocation подтверждает в auth, что токен не отозван. Подтверждение
+// хранится по jti, отказ не запоминается: токен проверяется заново.
+func (s *JWKSAuthService) checkRevocation(ctx context.Context, jti, token string) error {
+	if s.sessionTTL < 0 {
+		return nil
+	}
+	if jti == "" {
+		jti = token
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	until, ok := s.confirmed[jti]
+	s.mu.Unlock()
+	if ok && now.Before(until) {
+		return nil
+	}
+
+	if _, err := s.auth.ValidateToken(ctx, token); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.confirmed[jti] = now.Add(s.sessionTTL)
+	if now.Sub(s.sweptAt) >= s.sessionTTL {
+		for id, until := range s.confirmed {
+			if !now.Before(until) {
+				delete(s.confirmed, id)
+			}
+		}
+		s.sweptAt = now
+	}
+	return nil
+}
+
+func (s *JWKSAuthService) ValidateAPIKey(ctx context.Context, key string) (*domain.UserInfo, error) {
+	return s.auth.ValidateAPIKey(ctx, key)
+}
+
+// NewAuthService выбирает способ проверки токенов: локально по JWKS, если
+// задан auth.jwks_url, иначе запросом в auth сервис по gRPC
+func NewAuthService(cfg *config.Config, grpcService *AuthGRPCService) domain.AuthService {
+	if cfg.Auth.JWKSURL != "" {
+		return NewJWKSAuthService(cfg, grpcService)
+	}
+	return grpcService
+}
@@ -8,6 +8,7 @@ import (
 	"marketai/cards/internal/config"
 	"marketai/cards/internal/domain"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -27,7 +28,12 @@ func NewOpenAIService(cfg *config.Config) *OpenAIService {
 	}
 }
 
-func (s *OpenAIService) GenerateCardContent(ctx context.Context, photoURL, description string) (*domain.GeneratedCard, error) {
+func (s *OpenAIService) GenerateCardContent(ctx context.Context, input domain.GenerateCardInput) (*domain.GeneratedCard, error) {
+	emojiRule := "Используй эмодзи для привлекательности"
+	if input.BrandProfile != nil && input.BrandProfile.NoEmoji {
+		emojiRule = "Не используй эмодзи"
+	}
+
 	prompt := fmt.Sprintf(`
 		Создай карточку товара для маркетплейса на основе описания: "%s"
 		
@@ -35,7 +41,7 @@ func (s *OpenAIService) GenerateCardContent(ctx context.Context, photoURL, descr
 		1. Заголовок должен быть кратким и привлекательным (до 60 символов)
 		2. Описание должно быть подробным и продающим (150-300 слов)
 		3. Теги должны быть релевантными для поиска (5-10 тегов)
-		4. Используй эмодзи для привлекательности
+		4. %s
 		
 		Ответь строго в формате JSON без пояснений, текста или Markdown.
 		{
@@ -43,7 +49,10 @@ func (s *OpenAIService) GenerateCardContent(ctx context.Context, photoURL, descr
 		  "description": "подробное описание товара",
 		  "tags": ["тег1", "тег2", "тег3"]
 		}
-`, description)
+`, input.Description, emojiRule)
+
+	prompt += keywordsPrompt(input.Keywords)
+	prompt += brandProfilePrompt(input.BrandProfile)
 
 	requestBody := map[string]interface{}{
 		"model": s.model,
@@ -107,7 +116,43 @@ func (s *OpenAIService) GenerateCardContent(ctx context.Context, photoURL, descr
 	}
 
 	// Добавляем URL изображения (в реальном проекте здесь была бы генерация через DALL-E)
-	generatedCard.Image = photoURL
+	generatedCard.Image = input.PhotoURL
 
 	return &generatedCard, nil
 }
+
+// keywordsPrompt добавляет в промпт поисковые запросы из SEO словаря
+func keywordsPrompt(keywords []string) string {
+	if len(keywords) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+		Используй в заголовке и описании следующие поисковые запросы (в порядке важности),
+		самые важные постарайся вписать в заголовок, текст должен оставаться естественным:
+		%s
+
+		Эти же запросы используй как основу для тегов.
+`, strings.Join(keywords, ", "))
+}
+
+// brandProfilePrompt добавляет в промпт требования фирменного стиля.
+// Вступление, заключение и гарантия добавляются после генерации, поэтому
+// модель только предупреждаем, чтобы она их не дублировала.
+func brandProfilePrompt(profile *domain.BrandProfile) string {
+	if profile == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	if profile.Tone != "" {
+		fmt.Fprintf(&b, "\n\t\tСтиль и тон текста: %s\n", profile.Tone)
+	}
+	if len(profile.BannedWords) > 0 {
+		fmt.Fprintf(&b, "\t\tНе используй слова: %s\n", strings.Join(profile.BannedWords, ", "))
+	}
+	if profile.Intro != "" || profile.Outro != "" || profile.WarrantyText != "" {
+		b.WriteString("\t\tНе пиши вступление, заключение и условия гарантии - они будут добавлены отдельно\n")
+	}
+	return b.String()
+}
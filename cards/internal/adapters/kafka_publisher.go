@@ -0,0 +1,137 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"marketai/cards/internal/config"
+	"marketai/cards/internal/domain"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const defaultEventsTopic = "cards.events"
+
+// Таймауты продюсера ограничивают отправку одного сообщения: попытка с ретраем
+// (до 2 * (3s + 3s + 5s) плюс пауза) укладывается в 30 секунд lease relay,
+// поэтому зависший брокер не держит отправку после истечения права публикации
+const (
+	kafkaDialTimeout  = 3 * time.Second
+	kafkaWriteTimeout = 3 * time.Second
+	kafkaReadTimeout  = 5 * time.Second
+	kafkaAckTimeout   = 4 * time.Second
+	kafkaRetryMax     = 1
+	kafkaRetryBackoff = 250 * time.Millisecond
+)
+
+// KafkaEventPublisher публикует события синхронно: событие считается
+// опубликованным только после подтверждения всеми репликами.
+// Ключ сообщения - ID карточки, поэтому события одной карточки попадают
+// в одну партицию и читаются в порядке записи.
+type KafkaEventPublisher struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func newKafkaEventPublisher(cfg *config.Config) (*KafkaEventPublisher, error) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.Return.Errors = true
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Partitioner = sarama.NewHashPartitioner
+	// Идемпотентный продюсер с одним запросом в полете не переставляет сообщения при ретраях
+	saramaConfig.Producer.Idempotent = true
+	saramaConfig.Net.MaxOpenRequests = 1
+	saramaConfig.Net.DialTimeout = kafkaDialTimeout
+	saramaConfig.Net.WriteTimeout = kafkaWriteTimeout
+	saramaConfig.Net.ReadTimeout = kafkaReadTimeout
+	saramaConfig.Producer.Timeout = kafkaAckTimeout
+	saramaConfig.Producer.Retry.Max = kafkaRetryMax
+	saramaConfig.Producer.Retry.Backoff = kafkaRetryBackoff
+	saramaConfig.Version = sarama.V2_7_2_0
+
+	if cfg.Events.Version != "" {
+		version, err := sarama.ParseKafkaVersion(cfg.Events.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kafka version: %w", err)
+		}
+		saramaConfig.Version = version
+	}
+
+	if err := saramaConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	producer, err := sarama.NewSyncProducer(cfg.Events.Brokers, saramaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return &KafkaEventPublisher{
+		producer: producer,
+		topic:    eventsTopic(cfg),
+	}, nil
+}
+
+// Publish ждет подтверждения брокера не дольше ctx. После отмены сообщение
+// еще может дойти до брокера и будет опубликовано повторно: доставка at-least-once
+func (p *KafkaEventPublisher) Publish(ctx context.Context, event *domain.CardEvent) error {
+	msg := &sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(event.CardID),
+		Value: sarama.ByteEncoder(event.Payload),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("event-id"), Value: []byte(event.ID)},
+			{Key: []byte("event-name"), Value: []byte(event.Type.DomainEvent())},
+			{Key: []byte("event-type"), Value: []byte(event.Type)},
+			{Key: []byte("workspace-id"), Value: []byte(event.WorkspaceID)},
+		},
+		Timestamp: event.CreatedAt,
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		_, _, err := p.producer.SendMessage(msg)
+		sent <- err
+	}()
+
+	select {
+	case err := <-sent:
+		if err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.ID, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to publish event %s: %w", event.ID, ctx.Err())
+	}
+}
+
+func (p *KafkaEventPublisher) Close() error {
+	return p.producer.Close()
+}
+
+// NewEventPublisher возвращает Kafka продюсер, если заданы брокеры,
+// иначе in-memory брокер для локального запуска и тестов
+func NewEventPublisher(lc fx.Lifecycle, cfg *config.Config, logger *zap.Logger) (domain.EventPublisher, error) {
+	if len(cfg.Events.Brokers) == 0 {
+		logger.Warn("kafka brokers are not configured, card events go to in-memory broker")
+		return NewInMemoryBroker(), nil
+	}
+
+	publisher, err := newKafkaEventPublisher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(fx.StopHook(publisher.Close))
+
+	return publisher, nil
+}
+
+func eventsTopic(cfg *config.Config) string {
+	if cfg.Events.Topic != "" {
+		return cfg.Events.Topic
+	}
+	return defaultEventsTopic
+}
@@ -0,0 +1,65 @@
+package adapters
+
+import (
+	"context"
+	"marketai/cards/internal/domain"
+	"sync"
+)
+
+// BrokerMessage - сообщение, принятое in-memory брокером
+type BrokerMessage struct {
+	Key     string
+	Name    string
+	Type    domain.CardEventType
+	EventID string
+	Payload []byte
+}
+
+// InMemoryBroker хранит опубликованные события в памяти процесса.
+// Используется вместо Kafka, когда брокеры не настроены.
+type InMemoryBroker struct {
+	mu       sync.Mutex
+	messages []BrokerMessage
+}
+
+func NewInMemoryBroker() *InMemoryBroker {
+	return &InMemoryBroker{}
+}
+
+func (b *InMemoryBroker) Publish(ctx context.Context, event *domain.CardEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.messages = append(b.messages, BrokerMessage{
+		Key:     event.CardID,
+		Name:    event.Type.DomainEvent(),
+		Type:    event.Type,
+		EventID: event.ID,
+		Payload: event.Payload,
+	})
+	return nil
+}
+
+// Messages возвращает копию принятых сообщений в порядке публикации
+func (b *InMemoryBroker) Messages() []BrokerMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := make([]BrokerMessage, len(b.messages))
+	copy(res, b.messages)
+	return res
+}
+
+// MessagesByKey возвращает сообщения одной карточки в порядке публикации
+func (b *InMemoryBroker) MessagesByKey(key string) []BrokerMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var res []BrokerMessage
+	for _, m := range b.messages {
+		if m.Key == key {
+			res = append(res, m)
+		}
+	}
+	return res
+}
@@ -2,6 +2,24 @@
 // sources:
 // 1_cards_migration.down.sql (28B)
 // 1_cards_migration.up.sql (536B)
+// 10_keywords_trgm_migration.down.sql (47B)
+// 10_keywords_trgm_migration.up.sql (362B)
+// 2_keywords_migration.down.sql (82B)
+// 2_keywords_migration.up.sql (408B)
+// 3_brand_profiles_migration.down.sql (96B)
+// 3_brand_profiles_migration.up.sql (699B)
+// 4_workspaces_migration.down.sql (100B)
+// 4_workspaces_migration.up.sql (636B)
+// 5_card_review_migration.down.sql (175B)
+// 5_card_review_migration.up.sql (1.07kB)
+// 6_webhooks_migration.down.sql (121B)
+// 6_webhooks_migration.up.sql (1.829kB)
+// 7_event_relay_migration.down.sql (148B)
+// 7_event_relay_migration.up.sql (898B)
+// 8_rate_limits_migration.down.sql (34B)
+// 8_rate_limits_migration.up.sql (331B)
+// 9_card_events_retention_migration.down.sql (103B)
+// 9_card_events_retention_migration.up.sql (372B)
 
 package migrations
 
@@ -109,6 +127,366 @@ func _1_cards_migrationUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __10_keywords_trgm_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x4c\xa9\x88\xcf\x4e\xad\x2c\xcf\x2f\x4a\x29\x8e\x2f\xc8\x28\x4a\x2c\x4e\x8d\x2f\x29\x4a\xcf\xb5\xe6\x02\x00\xf5\xcc\x82\xe2\x2f\x00\x00\x00")
+
+func _10_keywords_trgm_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__10_keywords_trgm_migrationDownSql,
+		"10_keywords_trgm_migration.down.sql",
+	)
+}
+
+func _10_keywords_trgm_migrationDownSql() (*asset, error) {
+	bytes, err := _10_keywords_trgm_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "10_keywords_trgm_migration.down.sql", size: 47, mode: os.FileMode(0644), modTime: time.Unix(1792397605, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x14, 0xeb, 0xea, 0x31, 0x4c, 0x66, 0xe9, 0x9b, 0x61, 0xfc, 0x32, 0x83, 0x81, 0xd0, 0x26, 0xde, 0x54, 0xc3, 0xab, 0xd1, 0xfc, 0xc4, 0xe7, 0x5c, 0xf2, 0x13, 0x4e, 0x1d, 0xfd, 0x87, 0x9, 0xa4}}
+	return a, nil
+}
+
+var __10_keywords_trgm_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5d\x8f\xc1\x4a\xc3\x40\x10\x86\xef\x79\x8a\xff\x52\xda\x82\x79\x01\x7b\x12\x5d\xcb\xa2\x6c\xc1\x44\xe8\x2d\x28\x0d\xb1\x88\x36\x24\x05\xf5\xd6\x54\x45\x24\xa0\x8f\xe0\x2b\xc4\x9a\x60\x4c\x6d\x7d\x85\x99\x37\x72\x92\x52\x04\xd9\xc3\xee\xfc\xff\xf7\xcf\xcc\xda\x36\xe8\x8d\xd6\x94\xd3\x3b\xad\x79\x06\xaa\x68\xc9\x2f\xfc\x44\x05\x2d\x38\xe5\x47\x70\x42\x4b\xf1\x17\xa0\x92\x9f\xa9\xe0\x39\x84\x4b\x68\x55\x6b\x9c\x42\x0c\xa9\x0a\x9e\x49\x20\xa7\x52\xf4\x02\xfc\x20\x65\x46\x9f\x62\x77\x8e\xf5\x91\x42\xbb\xf5\x97\xa1\xac\xd5\xee\xee\x5a\xb6\x8d\xf3\x69\xe4\xfb\xe0\xb9\xa0\x95\x38\x5f\xa0\x1f\xb9\x4a\x01\x2b\x6c\xfa\xdc\xd7\xef\x7a\x2d\x7e\xad\x27\xef\x80\x32\xe1\xa5\x79\x49\x1f\xcd\x88\x6f\x39\x2b\x4e\x25\xdb\xd7\x06\xcd\xf8\x5c\x16\xa9\x38\x81\xfc\x2b\xa7\xcc\xda\x3f\x51\x7b\xae\x82\x1a\xba\xca\x38\x7a\x60\xa0\x0f\x61\x06\xae\x08\xda\x71\x1d\x84\x81\x37\x8d\x82\xab\x9e\xb5\x05\xb5\x39\x50\xc3\x7f\xd0\x78\x74\xeb\x5d\xfa\x77\x37\x93\x68\x14\x7b\xe1\x45\x74\x16\xfb\x4d\x0a\xd2\x6e\xab\xe3\xd4\xd1\xa6\xdf\xac\xd1\xd9\x20\x08\xc6\xd7\x0d\xe6\x4d\xc2\xb8\xdb\xb3\x7e\x01\x46\x2a\xf4\x4a\x6a\x01\x00\x00")
+
+func _10_keywords_trgm_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__10_keywords_trgm_migrationUpSql,
+		"10_keywords_trgm_migration.up.sql",
+	)
+}
+
+func _10_keywords_trgm_migrationUpSql() (*asset, error) {
+	bytes, err := _10_keywords_trgm_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "10_keywords_trgm_migration.up.sql", size: 362, mode: os.FileMode(0644), modTime: time.Unix(1792397605, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa8, 0xad, 0xd5, 0xab, 0x42, 0x82, 0xe1, 0x1c, 0x34, 0x51, 0x33, 0x6b, 0x42, 0x4a, 0xd6, 0xdf, 0x70, 0xce, 0x4, 0xc1, 0xf4, 0xa, 0x12, 0x97, 0x68, 0x2c, 0x38, 0xbd, 0xc, 0xcc, 0xb8, 0x9f}}
+	return a, nil
+}
+
+var __2_keywords_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x52\x00\xad\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x72\x64\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6b\x65\x79\x77\x6f\x72\x64\x73\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6b\x65\x79\x77\x6f\x72\x64\x73\x3b\x0a\x03\x00\xb4\x4d\xd0\xcb\x52\x00\x00\x00")
+
+func _2_keywords_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2_keywords_migrationDownSql,
+		"2_keywords_migration.down.sql",
+	)
+}
+
+func _2_keywords_migrationDownSql() (*asset, error) {
+	bytes, err := _2_keywords_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2_keywords_migration.down.sql", size: 82, mode: os.FileMode(0644), modTime: time.Unix(1792386475, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfc, 0x3d, 0x10, 0x3e, 0xb8, 0x1c, 0xc7, 0xf8, 0x89, 0xc1, 0xe2, 0xc3, 0x3, 0xa8, 0x75, 0x91, 0x8b, 0x61, 0xac, 0xdb, 0x43, 0x54, 0x36, 0xdd, 0x9b, 0x31, 0xc4, 0x90, 0x5f, 0xa9, 0x60, 0x18}}
+	return a, nil
+}
+
+var __2_keywords_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\x41\x4b\xc3\x40\x14\x84\xef\xf9\x15\x73\x6b\x0a\x1e\xbc\xf7\xb4\x4d\x5e\xf5\xe1\x66\x53\xb3\x6f\x69\xaa\x48\x08\xd9\x15\x8b\xa0\x35\x6d\xd1\x22\xfe\x77\x49\x52\x53\x10\x3c\x78\xdc\x7d\x33\xc3\xcc\x97\x14\xa4\x84\x20\x6a\xae\x09\xbc\x80\xc9\x05\x54\xb2\x15\x8b\xe7\x70\x7c\x7f\x6d\xfd\x0e\x71\x04\x00\x1b\x8f\x39\x5f\x59\x2a\x58\x69\x2c\x0b\xce\x54\xb1\xc6\x0d\xad\x2f\xfa\xeb\xf6\xa9\xad\x77\x01\x42\xa5\xc0\x19\xbe\x75\xd4\x47\x19\xa7\xf5\x20\x78\x6c\xc3\xdb\x21\xbc\x34\xc7\x2e\x85\x8d\x8c\x67\xa4\xb4\x50\x4e\x0b\x2e\x07\x61\xd3\x86\x7a\x1f\x7c\x55\xef\x21\x9c\x91\x15\x95\x2d\xb1\x62\xb9\xee\x9f\xb8\xcb\x0d\x8d\x16\x93\xaf\xe2\xe9\x60\x3b\x6c\xfd\xff\x6c\xd1\x74\x16\x45\xa7\xf9\x6c\x52\x2a\x7f\xcd\xdf\xf8\x8f\xea\x07\x41\x75\x6e\x9f\x9b\x11\x4c\x7c\xfe\x4d\xc9\x26\x5d\x9e\xd2\x42\xc5\x89\x66\x53\x77\xf0\x54\x9a\x22\xc9\xb5\xcb\xcc\x5f\x78\x3b\x66\xf7\x0f\x63\xb9\xc9\xe7\xd7\x64\x16\x7d\x0f\x00\xd3\x30\x25\xba\x98\x01\x00\x00")
+
+func _2_keywords_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2_keywords_migrationUpSql,
+		"2_keywords_migration.up.sql",
+	)
+}
+
+func _2_keywords_migrationUpSql() (*asset, error) {
+	bytes, err := _2_keywords_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2_keywords_migration.up.sql", size: 408, mode: os.FileMode(0644), modTime: time.Unix(1792386475, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8d, 0x95, 0x3a, 0x83, 0x6b, 0x50, 0x15, 0x11, 0xa4, 0xee, 0xfc, 0x8d, 0x17, 0x86, 0x52, 0x31, 0xb6, 0x52, 0x35, 0xb9, 0x5a, 0xfe, 0xb1, 0xbc, 0x9, 0xd0, 0xf9, 0x16, 0x4d, 0x7b, 0x32, 0x35}}
+	return a, nil
+}
+
+var __3_brand_profiles_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x60\x00\x9f\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x72\x64\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x62\x72\x61\x6e\x64\x5f\x70\x72\x6f\x66\x69\x6c\x65\x5f\x69\x64\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x62\x72\x61\x6e\x64\x5f\x70\x72\x6f\x66\x69\x6c\x65\x73\x3b\x0a\x03\x00\xa6\x79\xd8\xce\x60\x00\x00\x00")
+
+func _3_brand_profiles_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__3_brand_profiles_migrationDownSql,
+		"3_brand_profiles_migration.down.sql",
+	)
+}
+
+func _3_brand_profiles_migrationDownSql() (*asset, error) {
+	bytes, err := _3_brand_profiles_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "3_brand_profiles_migration.down.sql", size: 96, mode: os.FileMode(0644), modTime: time.Unix(1792386583, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1d, 0xc6, 0x89, 0x27, 0x1, 0x5f, 0x79, 0x3e, 0xdb, 0x2c, 0xd9, 0xcc, 0xec, 0x76, 0xb, 0xf5, 0xde, 0x88, 0x21, 0x14, 0x4c, 0x8e, 0x14, 0x5e, 0x3e, 0x24, 0xd0, 0x42, 0x65, 0xba, 0x35, 0x8e}}
+	return a, nil
+}
+
+var __3_brand_profiles_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\x51\x0b\xda\x30\x14\x85\xdf\xfb\x2b\xee\x9b\x2d\xec\x69\xe0\x93\x4f\xb1\xbd\xc5\xb2\x34\x91\x34\x9d\xba\x31\x42\x34\xd9\xc8\x98\x89\xc4\x16\x1d\x63\xff\x7d\x58\xab\x63\x1d\x4c\xf6\x98\x9b\xef\xdc\x0b\xe7\x9c\x5c\x20\x91\x08\x92\x2c\x29\x42\x55\x02\xe3\x12\x70\x5b\x35\xb2\x81\x7d\xd4\xde\xa8\x53\x0c\x9f\xdd\x37\x7b\x86\x34\x01\x00\x70\x06\xda\xb6\x2a\x60\x2d\xaa\x9a\x88\x1d\xbc\xc3\x1d\x14\x58\x92\x96\x4a\xf8\x62\xbd\xba\x69\xc2\x51\xf5\xbd\x33\x69\xf6\x66\x90\xf4\x67\x1b\x95\x33\xf0\x9e\x88\x7c\x45\x44\xfa\x76\x3e\xcf\x86\x3b\xac\xa5\xf4\x8e\x78\x7d\xb4\x20\x71\x2b\x27\xf3\x2e\xf8\xc9\xfc\x79\x6c\x36\xbb\x4b\x9d\xef\x62\x78\xc1\x84\xfe\x35\x73\xd1\x31\x6a\xdf\x7d\x57\x9d\xbd\x76\x2f\xd8\xbd\xf6\xde\x1a\x75\x09\xd1\x9c\x07\xf4\xe3\xa7\xdf\xcc\x8f\x9f\x23\xe5\x83\xb2\xc7\xf0\xd5\xc1\x92\x73\x8a\x84\xfd\xbd\xaf\x24\xb4\xc1\x3b\x7c\x88\x56\x77\xd6\x28\xdd\x81\xac\x6a\x6c\x24\xa9\xd7\xb0\xa9\xe4\x6a\x78\xc2\x07\xce\xf0\x29\x63\x7c\xf3\xf4\xf6\x64\xfe\x4f\x96\x64\x8b\x24\x19\x43\xaf\x58\x81\xdb\x49\xe8\xce\x5c\xd5\x9f\xc1\xab\x47\x7e\x9c\x4d\x2a\x91\x8e\x3f\xb7\x95\x84\x4a\x14\x63\x8d\x0e\xfa\xe6\x0b\x29\x0a\xc8\x39\x6d\x6b\xf6\xaf\x5e\xa9\x47\xa1\x04\x96\x28\x90\xe5\x38\x2d\x5e\xea\x4c\x06\x9c\x41\x81\x14\x25\x42\x83\x12\x58\x4b\xe9\x22\xf9\x35\x00\xdd\xbe\xa8\xda\xbb\x02\x00\x00")
+
+func _3_brand_profiles_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__3_brand_profiles_migrationUpSql,
+		"3_brand_profiles_migration.up.sql",
+	)
+}
+
+func _3_brand_profiles_migrationUpSql() (*asset, error) {
+	bytes, err := _3_brand_profiles_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "3_brand_profiles_migration.up.sql", size: 699, mode: os.FileMode(0644), modTime: time.Unix(1792386583, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3b, 0x15, 0x31, 0xa4, 0xa8, 0xc3, 0x7, 0x4b, 0xdc, 0x52, 0x74, 0xbc, 0x38, 0x57, 0x47, 0x7b, 0x66, 0xed, 0x19, 0x96, 0xcc, 0x7a, 0x0, 0x73, 0x36, 0xc9, 0xae, 0x4c, 0xb4, 0x71, 0x8c, 0x7d}}
+	return a, nil
+}
+
+var __4_workspaces_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x64\x00\x9b\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x69\x64\x78\x5f\x63\x61\x72\x64\x73\x5f\x77\x6f\x72\x6b\x73\x70\x61\x63\x65\x5f\x69\x64\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x72\x64\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x77\x6f\x72\x6b\x73\x70\x61\x63\x65\x5f\x69\x64\x3b\x0a\x03\x00\xc4\x4f\x29\xa1\x64\x00\x00\x00")
+
+func _4_workspaces_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__4_workspaces_migrationDownSql,
+		"4_workspaces_migration.down.sql",
+	)
+}
+
+func _4_workspaces_migrationDownSql() (*asset, error) {
+	bytes, err := _4_workspaces_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "4_workspaces_migration.down.sql", size: 100, mode: os.FileMode(0644), modTime: time.Unix(1792387108, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5f, 0xbd, 0x8d, 0x84, 0xe4, 0xfe, 0x5f, 0x78, 0xc7, 0xa8, 0xf5, 0xdb, 0xa1, 0x81, 0xf9, 0x6a, 0x2, 0x68, 0x29, 0x4, 0x5d, 0xf6, 0x7a, 0xf1, 0xee, 0xa, 0xc8, 0xca, 0x0, 0x32, 0x7d, 0x83}}
+	return a, nil
+}
+
+var __4_workspaces_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x92\xc1\x4e\xc2\x40\x10\x86\xef\x7d\x8a\xb9\x01\x09\x5c\x8c\x5c\x24\x1e\x2a\x5d\x42\x93\x5a\x4c\x69\x95\x1b\x21\x40\x22\xf1\xa0\x01\x89\x1e\x01\x83\x1e\x34\xe1\xee\x53\xd4\xc2\x86\x2a\xb6\xbe\xc2\xcc\x1b\x39\xbb\x8d\x09\x10\xbc\x6d\x67\xfe\xf9\xe7\x9b\x99\x9a\x8e\x2f\x3c\xf0\xcd\x33\x47\x40\xb7\x33\xec\x8d\xc0\xb4\x2c\xa8\x36\x9c\xe0\xdc\x05\xbb\x06\x6e\xc3\x07\xd1\xb2\x9b\x7e\x13\x1e\x6e\x87\x37\xa3\xbb\x4e\xb7\xdf\x1e\xf4\xe0\xd2\xf4\xaa\x75\xd3\xcb\x1f\x95\xcb\x05\x2d\x72\x03\xc7\x01\x4b\xd4\xcc\xc0\xf1\x21\x97\xab\x18\x46\xa9\x04\xf8\x8e\x21\x4d\x68\x86\x29\xbd\xe0\x17\xc6\x45\xa0\x29\xa6\xb8\xc6\x15\x86\x98\x60\x42\xaf\x28\x81\x3f\x52\xc0\x1f\xd6\x2c\x30\xc2\x0d\x4a\xce\xc4\xb4\x00\x9a\xb0\xe8\x43\x97\xc6\x34\x67\x05\x07\x52\x9a\xd2\x4c\x27\x12\xf5\xc2\xa8\xa8\x2a\x25\x47\x24\xcd\xd9\x79\x45\x0b\x9a\x01\x46\xc0\x3e\x31\x17\x26\x1c\x93\x9a\xe4\x60\xb5\x6a\xac\x5a\x86\xcc\x20\x71\x43\x6f\xf4\x8c\xe1\x09\xd0\x93\xe6\x51\x01\x66\x4d\x59\x12\xb2\x5a\x6a\xb6\x4f\xee\xb8\x56\x53\xf1\x7b\xc9\x3d\x32\xc7\x58\xb9\x2b\x5d\x36\x95\xc2\xcd\xa6\x3a\xc8\xac\x80\x6c\x6b\x9b\x71\xf9\xaf\x18\xc3\x6c\x67\x11\x13\x85\x7a\x6f\x92\x27\xa4\xa9\x36\x38\x08\xc9\xab\xcb\xe3\x37\xc6\xb8\x54\x3e\x3c\x91\x5a\xe6\x31\x74\xc6\xf7\xd7\x05\x23\xb8\xb0\x4c\xff\xef\xd4\x4d\xe1\xef\x5e\xf5\x14\xc6\xa3\xfe\x50\xbd\xae\xea\xc2\x13\xfb\x49\x7d\xd6\xaa\x27\x94\x83\xed\x5a\xa2\xb5\xf7\x87\x0c\x7a\x8f\x6d\xed\xdc\xde\x29\x6c\xb8\x59\xbf\xfc\x76\xb4\x50\x31\x7e\x01\xe8\xfe\xbb\x80\x7c\x02\x00\x00")
+
+func _4_workspaces_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__4_workspaces_migrationUpSql,
+		"4_workspaces_migration.up.sql",
+	)
+}
+
+func _4_workspaces_migrationUpSql() (*asset, error) {
+	bytes, err := _4_workspaces_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "4_workspaces_migration.up.sql", size: 636, mode: os.FileMode(0644), modTime: time.Unix(1792394032, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa7, 0x39, 0xd7, 0x65, 0xd5, 0x2b, 0x6b, 0xd9, 0xfe, 0x4c, 0xdf, 0x43, 0xd8, 0x56, 0x94, 0x3f, 0xa5, 0xbc, 0x4, 0x17, 0x2c, 0xa3, 0x44, 0x25, 0x59, 0xc9, 0x29, 0xab, 0xa1, 0x87, 0x44, 0xfa}}
+	return a, nil
+}
+
+var __5_card_review_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x4e\x2c\x4a\x89\x4f\xce\xcf\xcd\x4d\xcd\x2b\x29\xb6\xe6\xe2\xc2\xad\xa8\xa4\x28\x31\xaf\x38\xb3\x24\x33\x3f\x0f\xae\xce\xd3\xcf\xc5\x35\x02\x49\x5d\x66\x4a\x45\x3c\xc8\xc0\xe2\xf8\xf2\xfc\xa2\xec\xe2\x82\xc4\xe4\xd4\xf8\xe2\x92\xc4\x92\x52\x90\x0e\x47\x9f\x10\xd7\x20\xa8\xd1\x60\x45\x0a\x60\x33\x9c\xfd\x7d\x42\x7d\xfd\x90\x0c\x29\x2e\x49\x2c\x29\x2d\xb6\xe6\x02\x0c\x00\x09\x67\x84\x8e\xaf\x00\x00\x00")
+
+func _5_card_review_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__5_card_review_migrationDownSql,
+		"5_card_review_migration.down.sql",
+	)
+}
+
+func _5_card_review_migrationDownSql() (*asset, error) {
+	bytes, err := _5_card_review_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "5_card_review_migration.down.sql", size: 175, mode: os.FileMode(0644), modTime: time.Unix(1792387226, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6b, 0x10, 0x76, 0xd9, 0xae, 0x54, 0xf2, 0xc4, 0x69, 0x3e, 0x5f, 0x37, 0xf, 0xd1, 0xf3, 0xe6, 0x6e, 0xe3, 0xfd, 0xef, 0x22, 0xd4, 0xdd, 0x93, 0x92, 0x87, 0xc3, 0xc0, 0x26, 0xb5, 0x9, 0xaa}}
+	return a, nil
+}
+
+var __5_card_review_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x91\x41\x6f\x9c\x30\x10\x85\xef\xfc\x8a\xb9\x61\xa4\x3d\xa5\xca\x29\x27\x17\x66\xb5\x28\xac\x89\x8c\x69\x36\xbd\x58\x2e\x76\x5a\xb7\x5d\x58\xd9\x26\xc9\xcf\xaf\x02\x5e\x9a\xee\x56\xed\x5e\xaa\xde\xb0\xde\x7b\xc3\xcc\xf7\x68\x25\x90\x83\xa0\xef\x2b\x84\x4e\x39\xed\x81\x16\x05\xe4\x75\xd5\x6e\x19\x94\x6b\x60\xb5\x00\xdc\x95\x8d\x68\xc0\x07\x15\x46\x0f\x1f\x28\xcf\x37\x94\x93\x77\x57\xd9\xa4\xb2\xb6\xaa\xa0\xc0\x35\x6d\x2b\x01\xa9\x76\xea\x31\xa4\x09\x00\x40\xbe\xc1\xfc\x16\x48\x8c\x95\x0c\x48\x54\x57\x90\xda\x5e\x3a\xf3\x64\xcd\x73\xba\x82\x54\x1d\x0e\x6e\x78\x32\xfa\xf5\xfb\x30\x7e\xfa\x6e\xfd\x97\xf9\xe1\xcc\x57\xd3\x05\xa3\xd3\x2c\xbb\x49\x92\x9c\x23\x15\x08\x25\x2b\x70\x77\xb2\x9a\xd5\x2f\x72\xda\x5e\x3e\x0f\xee\x9b\x3f\xa8\xce\xc8\xf8\xdf\x9a\xcd\x77\x91\x9f\x8a\xd5\xab\x78\xcc\x9b\xb1\x33\x82\x5f\xc7\xbe\x06\x65\x70\xaa\xf7\x36\xd8\xa1\xf7\x40\xa6\xc3\xac\x86\xb6\x2d\x0b\xb8\xe3\xe5\x96\xf2\x07\xb8\xc5\x87\x05\xc0\x67\xd3\x4b\xa7\x7a\x3d\xec\xe5\x38\x5a\x4d\xb2\xd5\x14\x99\x26\x1d\x73\x0b\x35\x8e\x6b\xe4\xc8\x72\x6c\xe2\x8e\x56\x67\x50\x33\x28\xb0\x42\x81\x90\xd3\x26\xa7\x05\xce\x13\x1e\xdd\xb0\x97\x7f\xa8\x60\x76\x85\xe1\xef\x9e\xd1\x1b\x27\xad\x5e\x1c\x57\xd7\xd7\xa7\x96\x6e\xd8\xef\x4d\x1f\x40\xe0\x4e\xfc\xa6\xe4\x34\xba\x9c\x51\xc1\x68\xa9\x02\x88\x72\x8b\x8d\xa0\xdb\x3b\xb8\x2f\xc5\x66\x7a\xc2\xc7\x9a\xe1\x92\x61\xf5\x3d\xc9\x92\x0b\x6b\x7c\xcb\x5c\x1e\xd1\xd5\xec\xac\x0f\x12\xb5\x4b\x6a\x8c\x27\xfd\xd7\x0e\x2f\x20\x1f\xcc\xcb\x09\xf6\x7f\xcd\xfa\x08\xe6\x0c\xf4\x51\x20\x9d\x72\x5a\x5a\x9d\xdd\x24\x3f\x06\x00\x78\xdf\x09\x28\x2e\x04\x00\x00")
+
+func _5_card_review_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__5_card_review_migrationUpSql,
+		"5_card_review_migration.up.sql",
+	)
+}
+
+func _5_card_review_migrationUpSql() (*asset, error) {
+	bytes, err := _5_card_review_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "5_card_review_migration.up.sql", size: 1070, mode: os.FileMode(0644), modTime: time.Unix(1792387245, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x13, 0xad, 0xa9, 0xa4, 0x6e, 0x5f, 0x3b, 0xcd, 0x91, 0x9e, 0x66, 0xdc, 0xa4, 0x3c, 0xef, 0xf5, 0x97, 0x22, 0xf, 0x9d, 0x9e, 0x33, 0x8, 0x49, 0xc2, 0xf3, 0xb1, 0xa, 0xf1, 0x74, 0x59, 0xf0}}
+	return a, nil
+}
+
+var __6_webhooks_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x79\x00\x86\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x77\x65\x62\x68\x6f\x6f\x6b\x5f\x64\x65\x6c\x69\x76\x65\x72\x69\x65\x73\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x77\x65\x62\x68\x6f\x6f\x6b\x5f\x73\x75\x62\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x72\x64\x5f\x65\x76\x65\x6e\x74\x73\x3b\x0a\x03\x00\xa9\x3d\xfc\x7d\x79\x00\x00\x00")
+
+func _6_webhooks_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__6_webhooks_migrationDownSql,
+		"6_webhooks_migration.down.sql",
+	)
+}
+
+func _6_webhooks_migrationDownSql() (*asset, error) {
+	bytes, err := _6_webhooks_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "6_webhooks_migration.down.sql", size: 121, mode: os.FileMode(0644), modTime: time.Unix(1792387360, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x35, 0xe7, 0x18, 0x99, 0xe9, 0x13, 0x80, 0x6d, 0x19, 0x19, 0x21, 0x72, 0xa0, 0xae, 0xce, 0x39, 0x2d, 0x7f, 0x6a, 0xac, 0x7e, 0x93, 0x32, 0xd7, 0x2f, 0x7e, 0x9f, 0x7d, 0x51, 0xb1, 0x14, 0x7f}}
+	return a, nil
+}
+
+var __6_webhooks_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x54\x41\x4f\xdb\x4c\x10\xbd\xe7\x57\xcc\xcd\xb6\x14\xa4\xef\xab\x0a\x17\xd4\x83\x93\x2c\x8d\x4b\xb0\xa9\xe3\x14\x68\x55\xad\x8c\x77\x45\x2d\x82\x1d\xbc\x1b\x0a\x37\xc8\xa1\xad\xd4\x43\xff\x0a\xad\x1a\x61\x22\x48\xff\xc2\xf8\x1f\x55\x38\x8e\x71\x0c\x58\x40\x0f\x39\x38\x6f\xe6\xed\xec\x7b\x6f\x76\x69\x09\xac\xa1\xdc\x0d\x8f\x21\x39\xc3\x29\xfe\x4c\xbe\x27\x23\x8c\xf1\x12\x70\x82\xe7\xc9\x69\x32\xc2\x69\xf2\x15\xc7\x38\xa9\x03\xfe\xc1\x38\xf9\x86\xe3\x64\x94\x9c\x25\x3f\x00\x7f\x01\x4e\xf1\x37\x5e\xe3\x14\x2f\x21\x19\x25\xa7\x78\x8e\xd7\x78\x81\xe7\x38\x49\xbe\x60\x8c\x31\x24\x67\x80\x31\x5e\xe0\x15\x8e\xf1\x3a\xfd\xc5\x38\xc6\xab\x45\xee\x09\xc6\xb5\xa6\x4d\x74\x87\x80\xa3\x37\x3a\x04\x8c\x35\x30\x2d\x07\xc8\xb6\xd1\x75\xba\xe0\xb9\x11\xa3\xfc\x88\x07\x52\x80\x5a\x03\x00\xf0\x19\xf4\x7a\x46\x0b\x36\x6d\x63\x43\xb7\x77\x60\x9d\xec\xd4\x53\x40\xf0\x43\x68\x18\xaf\xbb\xc4\x36\xf4\x4e\xca\x61\xf6\x3a\x9d\x19\x26\x4f\x06\x1c\xde\xe9\x76\xb3\xad\xdb\xea\xca\x4b\xad\x04\xa7\xa7\xcc\x89\x17\xa1\xcf\x61\xb4\x2f\x06\xae\xc7\xa9\xcf\x72\x86\x17\xcb\xcb\x65\x8a\x81\x7b\xd2\x0f\x5d\x06\x6f\xba\x96\xd9\x28\x61\x5e\xc4\x5d\xc9\x19\x75\x25\x38\xc6\x06\xe9\x3a\xfa\xc6\x26\x6c\x19\x4e\x3b\xfd\x84\xf7\x96\x49\xa0\x45\xd6\xf4\x5e\xc7\x01\xd3\xda\x52\xb5\xec\x68\xbe\xfb\x29\x0c\xf7\x05\x1d\x44\xa1\xc7\x85\xa8\x66\xa8\x69\xab\xb5\xb9\x92\x86\xd9\x22\xdb\x25\x25\x7d\x76\x4c\x0b\x6a\xd2\x5b\x76\x1e\x30\x3f\xd8\x03\xcb\x2c\xaa\xad\x0a\x7e\xa8\xc1\x56\x9b\xd8\xe4\x81\x41\x8c\x6e\x7a\xc5\xd5\x5a\x95\x7f\x59\x2b\x15\xc3\x5d\xe1\x45\xfe\x40\xfa\x61\x50\xe1\x64\x2e\xc3\x1e\x0f\x68\xe4\x06\x2c\x3c\xa0\xc3\xa1\xcf\x54\xed\x69\x6e\x0c\xa3\x3e\x38\x64\xdb\x29\xfd\x9d\x5e\x8d\xde\x84\x41\xa4\xf0\x87\x8f\xa5\x02\xc1\xbd\x88\xcb\xfb\x5a\x5d\x4f\xfa\x47\x1c\x1a\x96\xd5\x21\xba\x99\x83\xf9\xc0\x8e\xdd\x23\xcf\x74\xfb\x31\xce\xdd\xab\x23\x5d\x90\xc3\x32\xef\x57\x5b\x2d\x56\x69\x8f\xb3\x8b\xf1\xbe\x7f\xc4\x23\x9f\xff\x83\x57\xc5\x21\xe8\xbc\x3f\xd7\xcd\x26\x6b\xc4\x26\x66\x93\x3c\x90\x11\xd5\x67\xda\x4d\x24\x5b\xa4\x43\x1c\x02\x4d\xbd\xdb\xd4\x5b\x99\xc2\x33\x1b\xab\x28\x8b\x41\xae\x20\x12\xd2\x95\x43\x91\x07\xe9\xff\x95\xdb\x1c\xe5\xb7\x53\xb2\xfd\x50\xa0\xd9\x26\xcd\x75\x50\xb3\x26\xc3\x04\x35\xc7\xea\xa0\x64\x92\x71\x36\xfb\x70\x99\xa2\x65\x42\xb8\x52\xf2\x83\x81\x14\x60\x98\xce\x5d\xfe\xff\x66\x45\x01\x3f\x96\x34\xab\xac\x8c\xce\x1d\x82\xc2\x8b\xd1\x77\x85\xa4\x3c\x8a\xc2\x68\x31\xc2\x79\xad\xa2\x14\x0a\x67\x17\xa1\x5e\xc8\x78\xe5\x68\xcf\x7c\xbe\x72\x41\xaa\x1a\x67\xe3\xf4\x4c\xe3\x6d\x8f\x80\x5a\x8a\x4c\x3d\x5b\x58\x9f\x3d\x69\x47\xb2\x83\x7d\x2e\x28\x1b\xf2\xe2\x5e\xdc\x22\x6a\x49\xef\xf9\x53\x97\x99\xfb\x0a\x94\x01\x0f\x98\x1f\xec\x29\xab\xb5\xbf\x03\x00\x21\xb7\xb8\xfb\x25\x07\x00\x00")
+
+func _6_webhooks_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__6_webhooks_migrationUpSql,
+		"6_webhooks_migration.up.sql",
+	)
+}
+
+func _6_webhooks_migrationUpSql() (*asset, error) {
+	bytes, err := _6_webhooks_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "6_webhooks_migration.up.sql", size: 1829, mode: os.FileMode(0644), modTime: time.Unix(1792387360, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7a, 0x48, 0x1, 0x5f, 0xcd, 0x64, 0x68, 0x5e, 0xe7, 0x5f, 0xb7, 0x12, 0x30, 0xfa, 0x30, 0x99, 0xb2, 0xd7, 0x7c, 0x24, 0x23, 0x63, 0x39, 0x81, 0xdd, 0x93, 0xbc, 0xb8, 0x7f, 0x79, 0xff, 0xb8}}
+	return a, nil
+}
+
+var __7_event_relay_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x2f\x2d\x49\xca\xaf\x88\xcf\x49\x4d\x2c\x4e\x2d\xb6\xe6\xe2\x72\x01\x29\xf2\xf4\x73\x71\x8d\x40\x52\x94\x99\x52\x11\x9f\x9c\x58\x94\x12\x9f\x5a\x96\x9a\x57\x52\x1c\x5f\x9a\x57\x50\x9a\x94\x93\x59\x9c\x91\x9a\x02\xd4\xe2\xe8\x13\xe2\x1a\x04\x35\x18\x49\x95\x02\xd8\x28\x67\x7f\x9f\x50\x5f\x3f\x24\xb3\xe0\x3a\xe3\x13\x4b\xac\xb9\x00\x3d\x81\x30\x9e\x94\x00\x00\x00")
+
+func _7_event_relay_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__7_event_relay_migrationDownSql,
+		"7_event_relay_migration.down.sql",
+	)
+}
+
+func _7_event_relay_migrationDownSql() (*asset, error) {
+	bytes, err := _7_event_relay_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "7_event_relay_migration.down.sql", size: 148, mode: os.FileMode(0644), modTime: time.Unix(1792394206, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1e, 0x3b, 0x6e, 0x70, 0x3, 0xcf, 0x11, 0x37, 0x28, 0x74, 0xae, 0x6d, 0x15, 0x8c, 0xd7, 0xee, 0x70, 0x11, 0x46, 0xec, 0x37, 0x5a, 0x8, 0x14, 0x50, 0x2a, 0xbb, 0x6f, 0x84, 0x54, 0x9, 0x5a}}
+	return a, nil
+}
+
+var __7_event_relay_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x53\xc1\x6e\xda\x40\x10\xbd\xfb\x2b\xe6\x08\x12\xb9\x55\x3d\x34\xea\xc1\x85\x8d\xb0\x02\x06\xd9\xa6\x49\x7a\x41\x4e\xd8\x2a\xa8\x14\x52\x1b\x2a\x7a\x23\x54\x2d\x87\x24\xf0\x09\x55\xfe\x80\x20\xac\xb8\xa5\xc0\x2f\xcc\xfe\x51\xdf\xda\x0d\xc2\xad\x14\x5f\x76\x66\xf7\xcd\x9b\xd9\xf7\xd6\x66\xc5\x13\x0e\x79\xe6\x9b\x8a\xa0\x0b\x3f\x68\x35\xe5\x67\xd9\xed\x87\x64\x96\x4a\x54\xac\x55\x1a\x55\x9b\xac\x23\xb2\x6b\x1e\x89\x53\xcb\xf5\x5c\xba\x1a\x9c\x77\xda\xe1\xa5\x6c\x35\xfd\x3e\x79\x56\x55\xb8\x9e\x59\xad\xd3\x89\xe5\x95\x93\x94\xde\xd5\x6c\x71\x68\x18\x07\x07\xc4\xf7\xbc\xe1\x07\x75\xa3\xc6\x1c\xab\x59\x81\xf8\x91\xe7\xbc\x45\x7c\x8d\x75\xcd\x6b\x75\xc3\x11\xf1\x92\x37\x84\xdd\x8d\x9a\xf1\x82\x57\x1c\xe1\x04\x68\x0a\x64\xc7\xff\x52\x20\x75\xad\x26\xc8\xc7\x3c\x57\x53\x35\x46\x36\x23\x90\x6e\xd5\x57\x7e\x00\x38\xe6\x5f\xc8\x16\x3b\xba\xdf\x1c\xa3\x4d\x8c\x6c\x8e\xb2\x28\x19\x62\xcb\x91\x1a\xf1\x02\xa7\x3f\x91\x20\xdc\xa8\x6f\x28\x5a\xa6\x1d\xc0\x06\x72\xbd\x3d\x07\x4f\xcc\x2b\xd2\x13\x13\x2f\xe8\xd8\x7f\xff\xc1\x47\x80\x9e\x53\xd2\x43\x03\xb7\x01\x2e\x56\x53\xa3\x51\x2f\x99\x5e\x56\x30\x57\x78\x59\x6d\x5e\xd3\x45\x20\xfd\x7e\x9a\x9c\x94\x85\x23\xb2\xe7\x96\x4b\x76\xa3\x52\x81\x54\x45\x47\x68\x36\xcb\x2e\x89\xd3\x7f\xd4\x6e\xb7\x86\xcd\xbd\x2e\xcd\x41\x77\xc7\x41\x35\x7b\x7f\x80\x5c\x28\x3f\xe5\x9f\xef\xa3\xd5\xf8\xf1\xf7\xa6\x5a\xf3\x3d\x11\xe7\xea\x3b\xd6\x98\x7a\x83\xfe\x79\x6f\xf8\x4a\x6b\xbc\x84\x8a\xd0\x16\xf8\x08\xba\x6a\x5b\x90\xc3\x8f\x7d\x4f\x33\x24\x88\x22\x35\x26\x2d\x13\xaf\xd4\xad\xb6\x26\xe5\x81\x1f\xba\xb7\xba\xc3\xd6\x63\xc2\xb6\x05\x60\xa6\x46\x9a\x2d\x31\x27\x7d\x13\x70\x7b\x92\x54\x27\x0e\xac\xf5\xe3\x48\xcd\x8b\x12\xf1\x13\x83\xf0\x06\x6e\xff\x1b\xe2\x69\x58\x18\xac\xaf\x32\x4a\x9c\x9a\x20\x8e\x9f\xb4\x4d\x1f\x78\x56\xdb\xf4\xae\xcd\x8e\xf4\x43\x19\x52\xce\x20\x7c\x5d\xff\xa3\xa4\xb7\xa6\x53\x2c\x9b\x4e\xee\xe5\x8b\x3c\xd5\x1d\xab\x6a\x3a\x67\x74\x2c\xce\x0a\x09\xe2\xb2\xd7\x69\xc9\x20\x83\xd1\x9c\x5a\xe2\x14\x20\x87\x57\xed\x40\x86\xcf\xfd\x1e\xbb\x0a\x23\x7f\x68\xfc\x01\xc1\x0d\x33\xda\x82\x03\x00\x00")
+
+func _7_event_relay_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__7_event_relay_migrationUpSql,
+		"7_event_relay_migration.up.sql",
+	)
+}
+
+func _7_event_relay_migrationUpSql() (*asset, error) {
+	bytes, err := _7_event_relay_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "7_event_relay_migration.up.sql", size: 898, mode: os.FileMode(0644), modTime: time.Unix(1792394206, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa9, 0x90, 0x4d, 0x3, 0x3e, 0xff, 0x78, 0xc5, 0x1a, 0x1e, 0x8e, 0xd4, 0x52, 0x82, 0x24, 0xa, 0x92, 0xb6, 0xa1, 0x14, 0x75, 0x25, 0x11, 0x72, 0x52, 0x2, 0x3, 0xde, 0xe6, 0x9, 0x85, 0xa1}}
+	return a, nil
+}
+
+var __8_rate_limits_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x2c\x49\x8d\xcf\xc9\xcc\xcd\x2c\x29\xb6\xe6\x02\x00\xfa\x9b\xfd\x9d\x22\x00\x00\x00")
+
+func _8_rate_limits_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__8_rate_limits_migrationDownSql,
+		"8_rate_limits_migration.down.sql",
+	)
+}
+
+func _8_rate_limits_migrationDownSql() (*asset, error) {
+	bytes, err := _8_rate_limits_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "8_rate_limits_migration.down.sql", size: 34, mode: os.FileMode(0644), modTime: time.Unix(1792393240, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe, 0xf3, 0x7c, 0xcf, 0x89, 0x1c, 0xcf, 0x1e, 0xe, 0x1a, 0x8b, 0xbd, 0x1, 0x49, 0x7e, 0x50, 0x42, 0xd4, 0xf, 0x9d, 0xa2, 0x8b, 0x2b, 0xb8, 0x8f, 0x1a, 0xb8, 0xe, 0x85, 0xf5, 0x2f, 0xb0}}
+	return a, nil
+}
+
+var __8_rate_limits_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\x8f\x41\x0b\x82\x40\x10\x85\xef\xfe\x8a\x39\x16\x74\xe8\xde\x69\xd3\x91\x86\xd6\x5d\xd1\x91\xb4\x8b\x48\xed\x41\xca\x12\xdd\xc2\xfe\x7d\xa6\x50\x12\x04\xcd\xed\xf1\x3e\x3e\xe6\xb9\x11\x0a\x46\x60\xb1\x96\x08\xe4\x83\xd2\x0c\x98\x52\xcc\x31\x34\x85\x35\xf9\xb9\xac\x4a\xdb\xc2\xcc\x81\xfe\x4e\xe6\x01\x8c\x29\x43\x18\x51\x20\xa2\x0c\xb6\x98\x2d\x86\xe6\x70\xbd\x5d\x2c\x78\x3a\x79\x69\xc2\x08\x5d\x8a\x49\xab\xc1\xa6\x12\x29\xc1\x43\x5f\x24\x92\x61\x39\xe2\x75\x63\xee\xff\xd3\xad\x2d\xaa\x1a\x98\x02\x8c\x59\x04\x21\xec\x88\x37\x43\x84\xbd\x56\x38\x32\xa6\xab\xcb\xc6\xb4\x79\x61\x7f\x82\x6f\xbf\x33\x5f\x39\x8e\x3b\x0e\x27\xe5\x61\xfa\x35\xbc\x3c\x76\xf9\x64\x7c\x3e\x71\xf7\x5f\x4e\x9a\xd9\xa7\xe9\x95\x4f\x8f\xbe\x84\x41\x4b\x01\x00\x00")
+
+func _8_rate_limits_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__8_rate_limits_migrationUpSql,
+		"8_rate_limits_migration.up.sql",
+	)
+}
+
+func _8_rate_limits_migrationUpSql() (*asset, error) {
+	bytes, err := _8_rate_limits_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "8_rate_limits_migration.up.sql", size: 331, mode: os.FileMode(0644), modTime: time.Unix(1792393240, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8b, 0xa6, 0xde, 0x4e, 0x2b, 0x70, 0xa7, 0x1a, 0xc2, 0x2c, 0x42, 0x3a, 0x7b, 0xc2, 0x59, 0x5e, 0xbe, 0xd0, 0xf0, 0x77, 0xf4, 0x5b, 0x5b, 0x32, 0xc7, 0x29, 0x2d, 0x54, 0xca, 0xcf, 0xd5, 0x9c}}
+	return a, nil
+}
+
+var __9_card_events_retention_migrationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x4c\xa9\x88\x2f\x4f\x4d\xca\xc8\xcf\xcf\x8e\x4f\x49\xcd\xc9\x2c\x4b\x2d\xca\x4c\x2d\x8e\x4f\x2d\x4b\xcd\x2b\x89\xcf\x4c\xb1\xe6\x72\xc1\xa5\x2b\x39\xb1\x28\x05\xa2\xae\x38\x3e\xb9\x28\x35\xb1\x24\x35\x25\x3e\xb1\xc4\x9a\x0b\x00\x46\xda\x02\x56\x67\x00\x00\x00")
+
+func _9_card_events_retention_migrationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__9_card_events_retention_migrationDownSql,
+		"9_card_events_retention_migration.down.sql",
+	)
+}
+
+func _9_card_events_retention_migrationDownSql() (*asset, error) {
+	bytes, err := _9_card_events_retention_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "9_card_events_retention_migration.down.sql", size: 103, mode: os.FileMode(0644), modTime: time.Unix(1792397580, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xcf, 0x76, 0x98, 0xca, 0x0, 0x25, 0xf4, 0xaf, 0x6d, 0x5a, 0x3e, 0xb4, 0x53, 0x76, 0x49, 0x39, 0x1a, 0x1c, 0x30, 0x4, 0xda, 0xed, 0x9b, 0x56, 0x4e, 0xd2, 0x40, 0x28, 0x7a, 0x27, 0x7b, 0xf0}}
+	return a, nil
+}
+
+var __9_card_events_retention_migrationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x85\x8e\xcd\x6a\xc2\x40\x14\x85\xf7\x3e\xc5\x5d\x5a\x68\x9e\xa0\x2b\xa9\x29\x64\x13\x41\xb3\x70\x37\x44\x67\xc0\x60\x69\x20\x46\xeb\x32\xb5\x0b\x17\x2e\x7c\x83\x3e\x43\xb4\x06\x07\x7f\xe2\x2b\x9c\xfb\x46\xbd\x31\x48\x0b\x2e\xba\x98\x81\x39\x3f\xdf\x1c\xc7\x21\x7c\xf1\x12\x96\x3f\x78\x81\x03\x72\x8a\xa7\xe9\x20\x9e\x13\xb6\xbc\xc2\x46\xf4\x0c\x39\x0a\x5e\xd0\x35\x90\x73\x26\x72\x21\x0f\x94\xd8\xf0\x4a\x24\xcb\x6b\xc2\x05\x65\xd5\xc8\x50\xe0\x24\xe7\x0c\x4b\xd8\x4b\xf1\x52\x81\x61\x1f\x49\xc0\xfc\x89\x9d\x48\xc7\xda\x47\xd1\x70\x9c\x3b\x8e\x0c\x10\x49\x6e\x89\x9e\x85\x79\xeb\xf0\xfa\xba\x41\xaa\xdf\xd5\x4f\x3b\x94\xf5\x1c\x6c\x25\x6c\x1b\xcf\x5d\xb7\x15\xb8\xe4\xf9\x6d\xb7\x4f\xde\x0b\xf9\x9d\x80\xdc\xbe\xd7\x0b\x7a\x14\xe9\xb9\x1a\x86\x89\x56\x66\x66\xde\xd2\x89\x1a\x26\x26\x4c\x8d\x56\x61\x4a\x1d\x9f\xfe\x38\xcd\x5f\xe7\xe1\xe9\x3f\xe2\xbb\x19\x8c\xe2\x78\xac\xb4\x79\x8d\x66\x26\x89\xcc\xa4\xa6\xa8\x48\x57\xd8\x7b\xbb\x79\xb3\x85\xfd\x03\x90\xe5\x10\x34\x74\x01\x00\x00")
+
+func _9_card_events_retention_migrationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__9_card_events_retention_migrationUpSql,
+		"9_card_events_retention_migration.up.sql",
+	)
+}
+
+func _9_card_events_retention_migrationUpSql() (*asset, error) {
+	bytes, err := _9_card_events_retention_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "9_card_events_retention_migration.up.sql", size: 372, mode: os.FileMode(0644), modTime: time.Unix(1792397580, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xec, 0xd8, 0xc0, 0x81, 0x5e, 0x65, 0xa, 0x89, 0xf6, 0x50, 0x85, 0x34, 0x5, 0xd2, 0xdb, 0x34, 0xd6, 0x98, 0x81, 0xa1, 0xc1, 0xd4, 0xe7, 0xa3, 0x50, 0xea, 0x15, 0x34, 0x6a, 0xd, 0x13, 0x65}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -200,8 +578,26 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"1_cards_migration.down.sql": _1_cards_migrationDownSql,
-	"1_cards_migration.up.sql":   _1_cards_migrationUpSql,
+	"1_cards_migration.down.sql":                 _1_cards_migrationDownSql,
+	"1_cards_migration.up.sql":                   _1_cards_migrationUpSql,
+	"10_keywords_trgm_migration.down.sql":        _10_keywords_trgm_migrationDownSql,
+	"10_keywords_trgm_migration.up.sql":          _10_keywords_trgm_migrationUpSql,
+	"2_keywords_migration.down.sql":              _2_keywords_migrationDownSql,
+	"2_keywords_migration.up.sql":                _2_keywords_migrationUpSql,
+	"3_brand_profiles_migration.down.sql":        _3_brand_profiles_migrationDownSql,
+	"3_brand_profiles_migration.up.sql":          _3_brand_profiles_migrationUpSql,
+	"4_workspaces_migration.down.sql":            _4_workspaces_migrationDownSql,
+	"4_workspaces_migration.up.sql":              _4_workspaces_migrationUpSql,
+	"5_card_review_migration.down.sql":           _5_card_review_migrationDownSql,
+	"5_card_review_migration.up.sql":             _5_card_review_migrationUpSql,
+	"6_webhooks_migration.down.sql":              _6_webhooks_migrationDownSql,
+	"6_webhooks_migration.up.sql":                _6_webhooks_migrationUpSql,
+	"7_event_relay_migration.down.sql":           _7_event_relay_migrationDownSql,
+	"7_event_relay_migration.up.sql":             _7_event_relay_migrationUpSql,
+	"8_rate_limits_migration.down.sql":           _8_rate_limits_migrationDownSql,
+	"8_rate_limits_migration.up.sql":             _8_rate_limits_migrationUpSql,
+	"9_card_events_retention_migration.down.sql": _9_card_events_retention_migrationDownSql,
+	"9_card_events_retention_migration.up.sql":   _9_card_events_retention_migrationUpSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -250,8 +646,26 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"1_cards_migration.down.sql": {_1_cards_migrationDownSql, map[string]*bintree{}},
-	"1_cards_migration.up.sql":   {_1_cards_migrationUpSql, map[string]*bintree{}},
+	"1_cards_migration.down.sql":                 {_1_cards_migrationDownSql, map[string]*bintree{}},
+	"1_cards_migration.up.sql":                   {_1_cards_migrationUpSql, map[string]*bintree{}},
+	"10_keywords_trgm_migration.down.sql":        {_10_keywords_trgm_migrationDownSql, map[string]*bintree{}},
+	"10_keywords_trgm_migration.up.sql":          {_10_keywords_trgm_migrationUpSql, map[string]*bintree{}},
+	"2_keywords_migration.down.sql":              {_2_keywords_migrationDownSql, map[string]*bintree{}},
+	"2_keywords_migration.up.sql":                {_2_keywords_migrationUpSql, map[string]*bintree{}},
+	"3_brand_profiles_migration.down.sql":        {_3_brand_profiles_migrationDownSql, map[string]*bintree{}},
+	"3_brand_profiles_migration.up.sql":          {_3_brand_profiles_migrationUpSql, map[string]*bintree{}},
+	"4_workspaces_migration.down.sql":            {_4_workspaces_migrationDownSql, map[string]*bintree{}},
+	"4_workspaces_migration.up.sql":              {_4_workspaces_migrationUpSql, map[string]*bintree{}},
+	"5_card_review_migration.down.sql":           {_5_card_review_migrationDownSql, map[string]*bintree{}},
+	"5_card_review_migration.up.sql":             {_5_card_review_migrationUpSql, map[string]*bintree{}},
+	"6_webhooks_migration.down.sql":              {_6_webhooks_migrationDownSql, map[string]*bintree{}},
+	"6_webhooks_migration.up.sql":                {_6_webhooks_migrationUpSql, map[string]*bintree{}},
+	"7_event_relay_migration.down.sql":           {_7_event_relay_migrationDownSql, map[string]*bintree{}},
+	"7_event_relay_migration.up.sql":             {_7_event_relay_migrationUpSql, map[string]*bintree{}},
+	"8_rate_limits_migration.down.sql":           {_8_rate_limits_migrationDownSql, map[string]*bintree{}},
+	"8_rate_limits_migration.up.sql":             {_8_rate_limits_migrationUpSql, map[string]*bintree{}},
+	"9_card_events_retention_migration.down.sql": {_9_card_events_retention_migrationDownSql, map[string]*bintree{}},
+	"9_card_events_retention_migration.up.sql":   {_9_card_events_retention_migrationUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"marketai/cards/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BrandProfileRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBrandProfileRepository(db *pgxpool.Pool) *BrandProfileRepository {
+	return &BrandProfileRepository{db: db}
+}
+
+func (r *BrandProfileRepository) CreateBrandProfile(ctx context.Context, profile *domain.BrandProfile) error {
+	query := `
+		INSERT INTO brand_profiles (user_id, name, tone, intro, outro, warranty_text, banned_words, no_emoji, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(ctx, query,
+		profile.UserID,
+		profile.Name,
+		profile.Tone,
+		profile.Intro,
+		profile.Outro,
+		profile.WarrantyText,
+		profile.BannedWords,
+		profile.NoEmoji,
+		profile.CreatedAt,
+		profile.UpdatedAt,
+	).Scan(&profile.ID)
+}
+
+func (r *BrandProfileRepository) UpdateBrandProfile(ctx context.Context, profile *domain.BrandProfile) error {
+	query := `
+		UPDATE brand_profiles
+		SET name = $3, tone = $4, intro = $5, outro = $6, warranty_text = $7, banned_words = $8, no_emoji = $9, updated_at = $10
+		WHERE id = $1 AND user_id = $2
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		profile.ID,
+		profile.UserID,
+		profile.Name,
+		profile.Tone,
+		profile.Intro,
+		profile.Outro,
+		profile.WarrantyText,
+		profile.BannedWords,
+		profile.NoEmoji,
+		profile.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrBrandProfileNotFound
+	}
+
+	return nil
+}
+
+func (r *BrandProfileRepository) DeleteBrandProfile(ctx context.Context, id, userID string) error {
+	query := `DELETE FROM brand_profiles WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrBrandProfileNotFound
+	}
+
+	return nil
+}
+
+func (r *BrandProfileRepository) GetBrandProfileByID(ctx context.Context, id string) (*domain.BrandProfile, error) {
+	query := `
+		SELECT id, user_id, name, tone, intro, outro, warranty_text, banned_words, no_emoji, created_at, updated_at
+		FROM brand_profiles
+		WHERE id = $1
+	`
+
+	profile := &domain.BrandProfile{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&profile.ID,
+		&profile.UserID,
+		&profile.Name,
+		&profile.Tone,
+		&profile.Intro,
+		&profile.Outro,
+		&profile.WarrantyText,
+		&profile.BannedWords,
+		&profile.NoEmoji,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrBrandProfileNotFound
+		}
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (r *BrandProfileRepository) GetBrandProfilesByUserID(ctx context.Context, userID string) ([]*domain.BrandProfile, error) {
+	query := `
+		SELECT id, user_id, name, tone, intro, outro, warranty_text, banned_words, no_emoji, created_at, updated_at
+		FROM brand_profiles
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []*domain.BrandProfile
+	for rows.Next() {
+		profile := &domain.BrandProfile{}
+		err := rows.Scan(
+			&profile.ID,
+			&profile.UserID,
+			&profile.Name,
+			&profile.Tone,
+			&profile.Intro,
+			&profile.Outro,
+			&profile.WarrantyText,
+			&profile.BannedWords,
+			&profile.NoEmoji,
+			&profile.CreatedAt,
+			&profile.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
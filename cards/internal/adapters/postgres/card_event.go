@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"marketai/cards/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// relayLeaseName - имя lease в outbox_leases, под которым работает relay событий
+const relayLeaseName = "card_events_relay"
+
+// insertCardEvent пишет событие в outbox внутри транзакции изменения карточки
+func insertCardEvent(ctx context.Context, tx pgx.Tx, event *domain.CardEvent) error {
+	query := `
+		INSERT INTO card_events (id, type, card_id, workspace_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		event.ID,
+		event.Type,
+		event.CardID,
+		event.WorkspaceID,
+		event.Payload,
+		event.CreatedAt,
+	)
+	return err
+}
+
+// OutboxRepository выдает события relay под lease: право публикации
+// хранится в outbox_leases и не держит соединение с базой, пока relay
+// ждет брокер
+type OutboxRepository struct {
+	db *pgxpool.Pool
+	// holder - экземпляр сервиса, которому принадлежит lease
+	holder string
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: db, holder: uuid.New().String()}
+}
+
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.CardEvent, bool, error) {
+	// lease продлевается текущим владельцем или переходит к другому после истечения
+	var holder string
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO outbox_leases (name, holder, expires_at)
+		VALUES ($1, $2, NOW() + $3 * INTERVAL '1 second')
+		ON CONFLICT (name) DO UPDATE SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+		WHERE outbox_leases.expires_at < NOW() OR outbox_leases.holder = EXCLUDED.holder
+		RETURNING holder
+	`, relayLeaseName, r.holder, lease.Seconds()).Scan(&holder)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, type, card_id, workspace_id, payload, created_at
+		FROM card_events
+		WHERE published_at IS NULL
+		ORDER BY seq
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, true, err
+	}
+	defer rows.Close()
+
+	var events []*domain.CardEvent
+	for rows.Next() {
+		e := &domain.CardEvent{}
+		if err := rows.Scan(&e.ID, &e.Type, &e.CardID, &e.WorkspaceID, &e.Payload, &e.CreatedAt); err != nil {
+			return nil, true, err
+		}
+		events = append(events, e)
+	}
+
+	return events, true, rows.Err()
+}
+
+// MarkPublished блокирует строку lease до конца запроса: другой экземпляр не
+// перехватит право публикации между проверкой и отметкой
+func (r *OutboxRepository) MarkPublished(ctx context.Context, ids []string) error {
+	tag, err := r.db.Exec(ctx, `
+		WITH lease AS (
+			SELECT holder FROM outbox_leases
+			WHERE name = $2 AND holder = $3 AND expires_at > NOW()
+			FOR SHARE
+		)
+		UPDATE card_events SET published_at = NOW()
+		WHERE id::text = ANY($1) AND EXISTS (SELECT 1 FROM lease)
+	`, ids, relayLeaseName, r.holder)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrOutboxLeaseLost
+	}
+	return nil
+}
+
+func (r *OutboxRepository) Release(ctx context.Context) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE outbox_leases SET expires_at = NOW() WHERE name = $1 AND holder = $2
+	`, relayLeaseName, r.holder)
+	return err
+}
+
+// DeleteProcessedEvents удаляет события вместе с их доставками: мертвые
+// доставки старше срока хранения повторить уже нельзя
+func (r *OutboxRepository) DeleteProcessedEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM card_events e
+		WHERE e.created_at < $1
+			AND e.published_at IS NOT NULL
+			AND e.webhooks_processed_at IS NOT NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM webhook_deliveries d
+				WHERE d.event_id = e.id AND d.status = 'pending'
+			)
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
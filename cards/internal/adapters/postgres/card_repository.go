@@ -2,7 +2,7 @@ package postgres
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"marketai/cards/internal/domain"
 
 	"github.com/google/uuid"
@@ -10,6 +10,8 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const cardColumns = `id, user_id, workspace_id, photo_url, short_description, title, description, tags, image, keywords, brand_profile_id, status, created_at, updated_at`
+
 type CardRepository struct {
 	db *pgxpool.Pool
 }
@@ -18,41 +20,63 @@ func NewCardRepository(db *pgxpool.Pool) *CardRepository {
 	return &CardRepository{db: db}
 }
 
-func (r *CardRepository) CreateCard(ctx context.Context, card *domain.Card) error {
+func (r *CardRepository) CreateCard(ctx context.Context, card *domain.Card, event *domain.CardEvent) error {
 	query := `
-		INSERT INTO cards (id, user_id, photo_url, short_description, title, description, tags, image, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO cards (` + cardColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
 	if card.ID == "" {
 		card.ID = uuid.New().String()
 	}
 
-	_, err := r.db.Exec(ctx, query,
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, query,
 		card.ID,
 		card.UserID,
+		card.WorkspaceID,
 		card.PhotoURL,
 		card.ShortDescription,
 		card.Title,
 		card.Description,
 		card.Tags,
 		card.Image,
+		card.Keywords,
+		card.BrandProfileID,
+		card.Status,
 		card.CreatedAt,
 		card.UpdatedAt,
 	)
+	if err != nil {
+		return err
+	}
+
+	if err := insertCardEvent(ctx, tx, event); err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit(ctx)
 }
 
-func (r *CardRepository) GetCardsByUserID(ctx context.Context, userID string) ([]*domain.Card, error) {
+func (r *CardRepository) GetCardsByWorkspaceID(ctx context.Context, workspaceID string, filter domain.CardFilter) ([]*domain.Card, error) {
 	query := `
-		SELECT id, user_id, photo_url, short_description, title, description, tags, image, created_at, updated_at
+		SELECT ` + cardColumns + `
 		FROM cards
-		WHERE user_id = $1
+		WHERE workspace_id = $1 AND (cardinality($2::text[]) = 0 OR status = ANY($2))
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, userID)
+	statuses := make([]string, 0, len(filter.Statuses))
+	for _, s := range filter.Statuses {
+		statuses = append(statuses, string(s))
+	}
+
+	rows, err := r.db.Query(ctx, query, workspaceID, statuses)
 	if err != nil {
 		return nil, err
 	}
@@ -60,55 +84,113 @@ func (r *CardRepository) GetCardsByUserID(ctx context.Context, userID string) ([
 
 	var cards []*domain.Card
 	for rows.Next() {
-		card := &domain.Card{}
-		err := rows.Scan(
-			&card.ID,
-			&card.UserID,
-			&card.PhotoURL,
-			&card.ShortDescription,
-			&card.Title,
-			&card.Description,
-			&card.Tags,
-			&card.Image,
-			&card.CreatedAt,
-			&card.UpdatedAt,
-		)
+		card, err := scanCard(rows)
 		if err != nil {
 			return nil, err
 		}
 		cards = append(cards, card)
 	}
 
-	return cards, nil
+	return cards, rows.Err()
 }
 
 func (r *CardRepository) GetCardByID(ctx context.Context, id string) (*domain.Card, error) {
 	query := `
-		SELECT id, user_id, photo_url, short_description, title, description, tags, image, created_at, updated_at
+		SELECT ` + cardColumns + `
 		FROM cards
 		WHERE id = $1
 	`
 
+	card, err := scanCard(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCardNotFound
+		}
+		return nil, err
+	}
+
+	return card, nil
+}
+
+func (r *CardRepository) UpdateCard(ctx context.Context, card *domain.Card, event *domain.CardEvent) error {
+	query := `
+		UPDATE cards
+		SET title = $3, description = $4, tags = $5, updated_at = $6
+		WHERE id = $1 AND workspace_id = $2
+	`
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query,
+		card.ID,
+		card.WorkspaceID,
+		card.Title,
+		card.Description,
+		card.Tags,
+		card.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCardNotFound
+	}
+
+	if err := insertCardEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *CardRepository) DeleteCard(ctx context.Context, id, workspaceID string, event *domain.CardEvent) error {
+	query := `DELETE FROM cards WHERE id = $1 AND workspace_id = $2`
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query, id, workspaceID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCardNotFound
+	}
+
+	if err := insertCardEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanCard(row pgx.Row) (*domain.Card, error) {
 	card := &domain.Card{}
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := row.Scan(
 		&card.ID,
 		&card.UserID,
+		&card.WorkspaceID,
 		&card.PhotoURL,
 		&card.ShortDescription,
 		&card.Title,
 		&card.Description,
 		&card.Tags,
 		&card.Image,
+		&card.Keywords,
+		&card.BrandProfileID,
+		&card.Status,
 		&card.CreatedAt,
 		&card.UpdatedAt,
 	)
-
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("card not found")
-		}
 		return nil, err
 	}
-
 	return card, nil
 }
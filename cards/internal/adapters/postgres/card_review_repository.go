@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"marketai/cards/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CardReviewRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCardReviewRepository(db *pgxpool.Pool) *CardReviewRepository {
+	return &CardReviewRepository{db: db}
+}
+
+func (r *CardReviewRepository) ChangeCardStatus(ctx context.Context, transition *domain.CardTransition, event *domain.CardEvent) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Условие на текущий статус защищает от одновременных переходов
+	tag, err := tx.Exec(ctx, `
+		UPDATE cards
+		SET status = $3, updated_at = $4
+		WHERE id = $1 AND status = $2
+	`, transition.CardID, transition.FromStatus, transition.ToStatus, transition.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCardStatusConflict
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO card_transitions (card_id, from_status, to_status, user_id, comment, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`,
+		transition.CardID,
+		transition.FromStatus,
+		transition.ToStatus,
+		transition.UserID,
+		transition.Comment,
+		transition.CreatedAt,
+	).Scan(&transition.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := insertCardEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *CardReviewRepository) GetCardTransitions(ctx context.Context, cardID string) ([]*domain.CardTransition, error) {
+	query := `
+		SELECT id, card_id, from_status, to_status, user_id, comment, created_at
+		FROM card_transitions
+		WHERE card_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, cardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []*domain.CardTransition
+	for rows.Next() {
+		t := &domain.CardTransition{}
+		err := rows.Scan(
+			&t.ID,
+			&t.CardID,
+			&t.FromStatus,
+			&t.ToStatus,
+			&t.UserID,
+			&t.Comment,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, t)
+	}
+
+	return transitions, rows.Err()
+}
+
+func (r *CardReviewRepository) AddCardComment(ctx context.Context, comment *domain.CardComment) error {
+	query := `
+		INSERT INTO card_comments (card_id, user_id, text, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(ctx, query,
+		comment.CardID,
+		comment.UserID,
+		comment.Text,
+		comment.CreatedAt,
+	).Scan(&comment.ID)
+}
+
+func (r *CardReviewRepository) GetCardComments(ctx context.Context, cardID string) ([]*domain.CardComment, error) {
+	query := `
+		SELECT id, card_id, user_id, text, created_at
+		FROM card_comments
+		WHERE card_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, cardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*domain.CardComment
+	for rows.Next() {
+		c := &domain.CardComment{}
+		if err := rows.Scan(&c.ID, &c.CardID, &c.UserID, &c.Text, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+
+	return comments, rows.Err()
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"marketai/cards/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type KeywordRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewKeywordRepository(db *pgxpool.Pool) *KeywordRepository {
+	return &KeywordRepository{db: db}
+}
+
+func (r *KeywordRepository) UpsertKeywords(ctx context.Context, keywords []*domain.Keyword) error {
+	query := `
+		INSERT INTO keywords (phrase, frequency, created_at, updated_at)
+		SELECT phrase, frequency, NOW(), NOW()
+		FROM unnest($1::text[], $2::bigint[]) AS k(phrase, frequency)
+		ON CONFLICT (phrase) DO UPDATE
+		SET frequency = EXCLUDED.frequency, updated_at = NOW()
+	`
+
+	phrases := make([]string, 0, len(keywords))
+	frequencies := make([]int64, 0, len(keywords))
+	for _, k := range keywords {
+		phrases = append(phrases, k.Phrase)
+		frequencies = append(frequencies, k.Frequency)
+	}
+
+	_, err := r.db.Exec(ctx, query, phrases, frequencies)
+	return err
+}
+
+// FindKeywordsByStems ищет основы в любом месте фразы по триграммному индексу idx_keywords_phrase_trgm
+func (r *KeywordRepository) FindKeywordsByStems(ctx context.Context, stems []string, limit int) ([]*domain.Keyword, error) {
+	query := `
+		SELECT id, phrase, frequency, created_at, updated_at
+		FROM keywords
+		WHERE phrase LIKE ANY($1)
+		ORDER BY frequency DESC
+		LIMIT $2
+	`
+
+	patterns := make([]string, 0, len(stems))
+	for _, s := range stems {
+		patterns = append(patterns, "%"+s+"%")
+	}
+
+	rows, err := r.db.Query(ctx, query, patterns, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keywords []*domain.Keyword
+	for rows.Next() {
+		k := &domain.Keyword{}
+		err := rows.Scan(
+			&k.ID,
+			&k.Phrase,
+			&k.Frequency,
+			&k.CreatedAt,
+			&k.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		keywords = append(keywords, k)
+	}
+
+	return keywords, rows.Err()
+}
@@ -0,0 +1,344 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"marketai/cards/internal/domain"
+	"marketai/pkg/encryption"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const deliveryColumns = `d.id, d.subscription_id, d.event_id, e.type, d.status, d.attempts, d.next_attempt_at, d.last_error, d.last_status_code, d.created_at, d.delivered_at`
+
+// WebhookRepository хранит секреты подписок зашифрованными
+type WebhookRepository struct {
+	db  *pgxpool.Pool
+	box *encryption.Box
+}
+
+func NewWebhookRepository(db *pgxpool.Pool, box *encryption.Box) *WebhookRepository {
+	return &WebhookRepository{db: db, box: box}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	secret, err := r.box.Seal(sub.Secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (workspace_id, url, event_types, secret, active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(ctx, query,
+		sub.WorkspaceID,
+		sub.URL,
+		eventTypesToStrings(sub.EventTypes),
+		secret,
+		sub.Active,
+		sub.CreatedAt,
+	).Scan(&sub.ID)
+}
+
+func (r *WebhookRepository) GetSubscriptions(ctx context.Context, workspaceID string) ([]*domain.WebhookSubscription, error) {
+	query := `
+		SELECT id, workspace_id, url, event_types, secret, active, created_at
+		FROM webhook_subscriptions
+		WHERE workspace_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*domain.WebhookSubscription
+	for rows.Next() {
+		sub, err := r.scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	query := `
+		SELECT id, workspace_id, url, event_types, secret, active, created_at
+		FROM webhook_subscriptions
+		WHERE id = $1
+	`
+
+	sub, err := r.scanSubscription(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id, workspaceID string) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1 AND workspace_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, id, workspaceID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) FanOutEvents(ctx context.Context, limit int) (int, error) {
+	// Один запрос: выбор событий, создание доставок и отметка об обработке атомарны
+	query := `
+		WITH events AS (
+			SELECT id, type, workspace_id
+			FROM card_events
+			WHERE webhooks_processed_at IS NULL
+			ORDER BY seq
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), deliveries AS (
+			INSERT INTO webhook_deliveries (subscription_id, event_id)
+			SELECT s.id, e.id
+			FROM events e
+			JOIN webhook_subscriptions s
+				ON s.workspace_id = e.workspace_id AND s.active AND e.type = ANY(s.event_types)
+			ON CONFLICT DO NOTHING
+		)
+		UPDATE card_events
+		SET webhooks_processed_at = NOW()
+		WHERE id IN (SELECT id FROM events)
+	`
+
+	tag, err := r.db.Exec(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+		FROM due, webhook_subscriptions s, card_events e
+		WHERE d.id = due.id AND s.id = d.subscription_id AND e.id = d.event_id
+		RETURNING ` + deliveryColumns + `, s.url, s.secret, e.payload
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		d := &domain.WebhookDelivery{}
+		err := rows.Scan(append(deliveryFields(d), &d.URL, &d.Secret, &d.Payload)...)
+		if err != nil {
+			return nil, err
+		}
+		if d.Secret, err = r.box.Open(d.Secret); err != nil {
+			return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) SaveDeliveryResult(ctx context.Context, d *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, last_status_code = $6, delivered_at = $7
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		d.ID,
+		d.Status,
+		d.Attempts,
+		d.NextAttemptAt,
+		d.LastError,
+		d.LastStatusCode,
+		d.DeliveredAt,
+	)
+	return err
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID string, status domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries d
+		JOIN card_events e ON e.id = d.event_id
+		WHERE d.subscription_id = $1 AND ($2 = '' OR d.status = $2)
+		ORDER BY d.created_at DESC
+		LIMIT 100
+	`
+
+	rows, err := r.db.Query(ctx, query, subscriptionID, string(status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		d := &domain.WebhookDelivery{}
+		if err := rows.Scan(deliveryFields(d)...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries d
+		JOIN card_events e ON e.id = d.event_id
+		WHERE d.id = $1
+	`
+
+	d := &domain.WebhookDelivery{}
+	if err := r.db.QueryRow(ctx, query, id).Scan(deliveryFields(d)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (r *WebhookRepository) ReplayDeliveries(ctx context.Context, subscriptionID string, deliveryIDs []string) (int, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), last_error = ''
+		WHERE subscription_id = $1 AND status = 'dead'
+			AND (cardinality($2::text[]) = 0 OR id::text = ANY($2))
+	`
+
+	if deliveryIDs == nil {
+		deliveryIDs = []string{}
+	}
+
+	tag, err := r.db.Exec(ctx, query, subscriptionID, deliveryIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *WebhookRepository) SealSecrets(ctx context.Context) (int, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, secret FROM webhook_subscriptions WHERE secret NOT LIKE 'enc:%'`)
+	if err != nil {
+		return 0, err
+	}
+	plain := map[string]string{}
+	for rows.Next() {
+		var id, secret string
+		if err := rows.Scan(&id, &secret); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		plain[id] = secret
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for id, secret := range plain {
+		sealed, err := r.box.Seal(secret)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		}
+		// условие на старое значение защищает от гонки с другим экземпляром
+		query := `UPDATE webhook_subscriptions SET secret = $2 WHERE id = $1 AND secret = $3`
+		if _, err := r.db.Exec(ctx, query, id, sealed, secret); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(plain), nil
+}
+
+func (r *WebhookRepository) scanSubscription(row pgx.Row) (*domain.WebhookSubscription, error) {
+	sub := &domain.WebhookSubscription{}
+	var eventTypes []string
+	err := row.Scan(
+		&sub.ID,
+		&sub.WorkspaceID,
+		&sub.URL,
+		&eventTypes,
+		&sub.Secret,
+		&sub.Active,
+		&sub.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Secret, err = r.box.Open(sub.Secret); err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	for _, t := range eventTypes {
+		sub.EventTypes = append(sub.EventTypes, domain.CardEventType(t))
+	}
+	return sub, nil
+}
+
+func deliveryFields(d *domain.WebhookDelivery) []any {
+	return []any{
+		&d.ID,
+		&d.SubscriptionID,
+		&d.EventID,
+		&d.EventType,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastError,
+		&d.LastStatusCode,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	}
+}
+
+func eventTypesToStrings(types []domain.CardEventType) []string {
+	res := make([]string, 0, len(types))
+	for _, t := range types {
+		res = append(res, string(t))
+	}
+	return res
+}
@@ -0,0 +1,131 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"marketai/cards/internal/config"
+	"marketai/cards/internal/domain"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// Диапазоны, не покрытые методами netip.Addr: "этот" сеть и CGNAT
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// HTTPWebhookSender отправляет события POST запросом с HMAC-SHA256 подписью.
+// Подписывается строка "<timestamp>.<body>", подпись передается в X-MarketAI-Signature.
+// Адрес подписки задает пользователь, поэтому соединения во внутреннюю сеть
+// запрещены. Адрес проверяется при подключении после разрешения имени, так что
+// проверка действует и для редиректов, и при подмене DNS записи после создания подписки.
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+func NewHTTPWebhookSender(cfg *config.Config) *HTTPWebhookSender {
+	timeout := cfg.Webhooks.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !cfg.Webhooks.AllowPrivateTargets {
+		dialer.Control = denyPrivateTargets
+	}
+
+	return &HTTPWebhookSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				// через прокси проверялся бы адрес прокси, а не получателя
+				Proxy:               nil,
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+	}
+}
+
+// denyPrivateTargets вызывается для каждого соединения с уже разрешенным IP
+func denyPrivateTargets(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !publicAddr(addr.Unmap()) {
+		return fmt.Errorf("%w: %s", domain.ErrWebhookTargetForbidden, addr)
+	}
+	return nil
+}
+
+// publicAddr отсекает loopback, частные, link-local (в том числе адрес
+// метаданных 169.254.169.254), multicast и служебные адреса
+func publicAddr(addr netip.Addr) bool {
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, delivery *domain.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MarketAI-Webhooks/1.0")
+	req.Header.Set("X-MarketAI-Event", string(delivery.EventType))
+	req.Header.Set("X-MarketAI-Delivery", delivery.ID)
+	req.Header.Set("X-MarketAI-Timestamp", timestamp)
+	req.Header.Set("X-MarketAI-Signature", "sha256="+SignWebhook(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// SignWebhook вычисляет подпись, которую получатель должен сверить со своей
+func SignWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
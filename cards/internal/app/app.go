@@ -5,15 +5,42 @@ import (
 	"marketai/cards/internal/adapters/postgres"
 	"marketai/cards/internal/app/command"
 	"marketai/cards/internal/app/query"
+	"marketai/cards/internal/config"
+	"marketai/cards/internal/domain"
 )
 
+const defaultPromptKeywords = 10
+
 type Commands struct {
-	GenerateCard command.GenerateCardHandler
+	GenerateCard       command.GenerateCardHandler
+	ImportKeywords     command.ImportKeywordsHandler
+	CreateBrandProfile command.CreateBrandProfileHandler
+	UpdateBrandProfile command.UpdateBrandProfileHandler
+	DeleteBrandProfile command.DeleteBrandProfileHandler
+	UpdateCard         command.UpdateCardHandler
+	DeleteCard         command.DeleteCardHandler
+	ChangeCardStatus   command.ChangeCardStatusHandler
+	AddCardComment     command.AddCardCommentHandler
+
+	CreateWebhook           command.CreateWebhookHandler
+	DeleteWebhook           command.DeleteWebhookHandler
+	ReplayWebhookDeliveries command.ReplayWebhookDeliveriesHandler
+	DispatchWebhooks        command.DispatchWebhooksHandler
+
+	RelayCardEvents command.RelayCardEventsHandler
+	PurgeCardEvents command.PurgeCardEventsHandler
 }
 
 type Queries struct {
-	GetCardsByUser query.GetCardsByUserHandler
-	GetCardByID    query.GetCardByIDHandler
+	GetCardsByWorkspace query.GetCardsByWorkspaceHandler
+	GetCardByID         query.GetCardByIDHandler
+	SuggestKeywords     query.SuggestKeywordsHandler
+	GetBrandProfiles    query.GetBrandProfilesHandler
+	GetBrandProfileByID query.GetBrandProfileByIDHandler
+	GetCardReview       query.GetCardReviewHandler
+
+	GetWebhooks          query.GetWebhooksHandler
+	GetWebhookDeliveries query.GetWebhookDeliveriesHandler
 }
 
 type AppCQRS struct {
@@ -23,16 +50,55 @@ type AppCQRS struct {
 
 func NewAppCQRS(
 	cardRepo *postgres.CardRepository,
+	keywordRepo *postgres.KeywordRepository,
+	profileRepo *postgres.BrandProfileRepository,
+	reviewRepo *postgres.CardReviewRepository,
+	webhookRepo *postgres.WebhookRepository,
+	webhookSender *adapters.HTTPWebhookSender,
+	outboxRepo *postgres.OutboxRepository,
+	eventPublisher domain.EventPublisher,
 	authService *adapters.AuthGRPCService,
 	aiService *adapters.OpenAIService,
+	cfg *config.Config,
 ) *AppCQRS {
+	promptKeywords := cfg.SEO.PromptKeywords
+	if promptKeywords <= 0 {
+		promptKeywords = defaultPromptKeywords
+	}
+
+	suggestKeywords := query.NewSuggestKeywordsHandler(keywordRepo)
+	getBrandProfile := query.NewGetBrandProfileByIDHandler(profileRepo)
+
 	return &AppCQRS{
 		Commands: Commands{
-			GenerateCard: command.NewGenerateCardHandler(cardRepo, aiService),
+			GenerateCard:       command.NewGenerateCardHandler(cardRepo, aiService, suggestKeywords, getBrandProfile, promptKeywords),
+			ImportKeywords:     command.NewImportKeywordsHandler(keywordRepo),
+			CreateBrandProfile: command.NewCreateBrandProfileHandler(profileRepo),
+			UpdateBrandProfile: command.NewUpdateBrandProfileHandler(profileRepo),
+			DeleteBrandProfile: command.NewDeleteBrandProfileHandler(profileRepo),
+			UpdateCard:         command.NewUpdateCardHandler(cardRepo),
+			DeleteCard:         command.NewDeleteCardHandler(cardRepo),
+			ChangeCardStatus:   command.NewChangeCardStatusHandler(cardRepo, reviewRepo),
+			AddCardComment:     command.NewAddCardCommentHandler(cardRepo, reviewRepo),
+
+			CreateWebhook:           command.NewCreateWebhookHandler(webhookRepo),
+			DeleteWebhook:           command.NewDeleteWebhookHandler(webhookRepo),
+			ReplayWebhookDeliveries: command.NewReplayWebhookDeliveriesHandler(webhookRepo),
+			DispatchWebhooks:        command.NewDispatchWebhooksHandler(webhookRepo, webhookSender),
+
+			RelayCardEvents: command.NewRelayCardEventsHandler(outboxRepo, eventPublisher),
+			PurgeCardEvents: command.NewPurgeCardEventsHandler(outboxRepo, cfg),
 		},
 		Queries: Queries{
-			GetCardsByUser: query.NewGetCardsByUserHandler(cardRepo),
-			GetCardByID:    query.NewGetCardByIDHandler(cardRepo),
+			GetCardsByWorkspace: query.NewGetCardsByWorkspaceHandler(cardRepo),
+			GetCardByID:         query.NewGetCardByIDHandler(cardRepo),
+			SuggestKeywords:     suggestKeywords,
+			GetBrandProfiles:    query.NewGetBrandProfilesHandler(profileRepo),
+			GetBrandProfileByID: getBrandProfile,
+			GetCardReview:       query.NewGetCardReviewHandler(cardRepo, reviewRepo),
+
+			GetWebhooks:          query.NewGetWebhooksHandler(webhookRepo),
+			GetWebhookDeliveries: query.NewGetWebhookDeliveriesHandler(webhookRepo),
 		},
 	}
 }
@@ -0,0 +1,128 @@
+package command
+
+import (
+	"context"
+	"marketai/cards/internal/domain"
+	"strings"
+	"time"
+)
+
+type SaveBrandProfileCommand struct {
+	// ID заполняется только при обновлении профиля
+	ID           string
+	UserID       string
+	Name         string
+	Tone         string
+	Intro        string
+	Outro        string
+	WarrantyText string
+	BannedWords  []string
+	NoEmoji      bool
+}
+
+type SaveBrandProfileResult struct {
+	Profile *domain.BrandProfile
+}
+
+type CreateBrandProfileHandler interface {
+	Handle(ctx context.Context, cmd SaveBrandProfileCommand) (*SaveBrandProfileResult, error)
+}
+
+type UpdateBrandProfileHandler interface {
+	Handle(ctx context.Context, cmd SaveBrandProfileCommand) (*SaveBrandProfileResult, error)
+}
+
+type DeleteBrandProfileCommand struct {
+	ID     string
+	UserID string
+}
+
+type DeleteBrandProfileHandler interface {
+	Handle(ctx context.Context, cmd DeleteBrandProfileCommand) error
+}
+
+type createBrandProfileHandler struct {
+	profileRepo domain.BrandProfileRepository
+}
+
+func NewCreateBrandProfileHandler(profileRepo domain.BrandProfileRepository) *createBrandProfileHandler {
+	return &createBrandProfileHandler{
+		profileRepo: profileRepo,
+	}
+}
+
+func (h *createBrandProfileHandler) Handle(ctx context.Context, cmd SaveBrandProfileCommand) (*SaveBrandProfileResult, error) {
+	profile := newBrandProfile(cmd)
+	profile.CreatedAt = profile.UpdatedAt
+
+	if err := h.profileRepo.CreateBrandProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return &SaveBrandProfileResult{Profile: profile}, nil
+}
+
+type updateBrandProfileHandler struct {
+	profileRepo domain.BrandProfileRepository
+}
+
+func NewUpdateBrandProfileHandler(profileRepo domain.BrandProfileRepository) *updateBrandProfileHandler {
+	return &updateBrandProfileHandler{
+		profileRepo: profileRepo,
+	}
+}
+
+func (h *updateBrandProfileHandler) Handle(ctx context.Context, cmd SaveBrandProfileCommand) (*SaveBrandProfileResult, error) {
+	existing, err := h.profileRepo.GetBrandProfileByID(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.UserID != cmd.UserID {
+		return nil, domain.ErrBrandProfileNotFound
+	}
+
+	profile := newBrandProfile(cmd)
+	profile.CreatedAt = existing.CreatedAt
+
+	if err := h.profileRepo.UpdateBrandProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return &SaveBrandProfileResult{Profile: profile}, nil
+}
+
+type deleteBrandProfileHandler struct {
+	profileRepo domain.BrandProfileRepository
+}
+
+func NewDeleteBrandProfileHandler(profileRepo domain.BrandProfileRepository) *deleteBrandProfileHandler {
+	return &deleteBrandProfileHandler{
+		profileRepo: profileRepo,
+	}
+}
+
+func (h *deleteBrandProfileHandler) Handle(ctx context.Context, cmd DeleteBrandProfileCommand) error {
+	return h.profileRepo.DeleteBrandProfile(ctx, cmd.ID, cmd.UserID)
+}
+
+func newBrandProfile(cmd SaveBrandProfileCommand) *domain.BrandProfile {
+	bannedWords := make([]string, 0, len(cmd.BannedWords))
+	for _, w := range cmd.BannedWords {
+		if w = strings.TrimSpace(w); w != "" {
+			bannedWords = append(bannedWords, w)
+		}
+	}
+
+	return &domain.BrandProfile{
+		ID:           cmd.ID,
+		UserID:       cmd.UserID,
+		Name:         strings.TrimSpace(cmd.Name),
+		Tone:         strings.TrimSpace(cmd.Tone),
+		Intro:        strings.TrimSpace(cmd.Intro),
+		Outro:        strings.TrimSpace(cmd.Outro),
+		WarrantyText: strings.TrimSpace(cmd.WarrantyText),
+		BannedWords:  bannedWords,
+		NoEmoji:      cmd.NoEmoji,
+		UpdatedAt:    time.Now(),
+	}
+}
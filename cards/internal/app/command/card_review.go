@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"marketai/cards/internal/domain"
+	"strings"
+	"time"
+)
+
+type ChangeCardStatusCommand struct {
+	CardID        string
+	WorkspaceID   string
+	UserID        string
+	WorkspaceRole domain.WorkspaceRole
+	Status        domain.CardStatus
+	// Comment - необязательное пояснение, например причина отклонения
+	Comment string
+}
+
+type ChangeCardStatusResult struct {
+	Card       *domain.Card
+	Transition *domain.CardTransition
+}
+
+type ChangeCardStatusHandler interface {
+	Handle(ctx context.Context, cmd ChangeCardStatusCommand) (*ChangeCardStatusResult, error)
+}
+
+type AddCardCommentCommand struct {
+	CardID      string
+	WorkspaceID string
+	UserID      string
+	Text        string
+}
+
+type AddCardCommentHandler interface {
+	Handle(ctx context.Context, cmd AddCardCommentCommand) (*domain.CardComment, error)
+}
+
+type changeCardStatusHandler struct {
+	cardRepo   domain.CardRepository
+	reviewRepo domain.CardReviewRepository
+}
+
+func NewChangeCardStatusHandler(cardRepo domain.CardRepository, reviewRepo domain.CardReviewRepository) *changeCardStatusHandler {
+	return &changeCardStatusHandler{
+		cardRepo:   cardRepo,
+		reviewRepo: reviewRepo,
+	}
+}
+
+func (h *changeCardStatusHandler) Handle(ctx context.Context, cmd ChangeCardStatusCommand) (*ChangeCardStatusResult, error) {
+	if !cmd.Status.Valid() {
+		return nil, domain.ErrInvalidCardStatus
+	}
+
+	card, err := h.cardRepo.GetCardByID(ctx, cmd.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.WorkspaceID != cmd.WorkspaceID {
+		return nil, domain.ErrCardNotFound
+	}
+
+	if !card.Status.CanTransitionTo(cmd.Status) {
+		return nil, domain.ErrInvalidTransition
+	}
+	if !cmd.WorkspaceRole.CanChangeStatus(cmd.Status) {
+		return nil, domain.ErrTransitionForbidden
+	}
+
+	transition := &domain.CardTransition{
+		CardID:     card.ID,
+		FromStatus: card.Status,
+		ToStatus:   cmd.Status,
+		UserID:     cmd.UserID,
+		Comment:    strings.TrimSpace(cmd.Comment),
+		CreatedAt:  time.Now(),
+	}
+	card.Status = transition.ToStatus
+	card.UpdatedAt = transition.CreatedAt
+
+	event, err := domain.NewCardEvent(domain.StatusEventType(card.Status), card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build card event: %w", err)
+	}
+
+	if err := h.reviewRepo.ChangeCardStatus(ctx, transition, event); err != nil {
+		return nil, err
+	}
+
+	return &ChangeCardStatusResult{
+		Card:       card,
+		Transition: transition,
+	}, nil
+}
+
+type addCardCommentHandler struct {
+	cardRepo   domain.CardRepository
+	reviewRepo domain.CardReviewRepository
+}
+
+func NewAddCardCommentHandler(cardRepo domain.CardRepository, reviewRepo domain.CardReviewRepository) *addCardCommentHandler {
+	return &addCardCommentHandler{
+		cardRepo:   cardRepo,
+		reviewRepo: reviewRepo,
+	}
+}
+
+func (h *addCardCommentHandler) Handle(ctx context.Context, cmd AddCardCommentCommand) (*domain.CardComment, error) {
+	text := strings.TrimSpace(cmd.Text)
+	if text == "" {
+		return nil, domain.ErrEmptyComment
+	}
+
+	card, err := h.cardRepo.GetCardByID(ctx, cmd.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.WorkspaceID != cmd.WorkspaceID {
+		return nil, domain.ErrCardNotFound
+	}
+
+	comment := &domain.CardComment{
+		CardID:    card.ID,
+		UserID:    cmd.UserID,
+		Text:      text,
+		CreatedAt: time.Now(),
+	}
+	if err := h.reviewRepo.AddCardComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"marketai/cards/internal/domain"
+	"time"
+)
+
+// DispatchWebhooksCommand - один проход воркера доставки
+type DispatchWebhooksCommand struct {
+	BatchSize   int
+	MaxAttempts int
+	// Lease - на сколько откладывается выбранная доставка, пока идет отправка
+	Lease time.Duration
+}
+
+type DispatchWebhooksResult struct {
+	Events    int
+	Delivered int
+	Failed    int
+}
+
+type DispatchWebhooksHandler interface {
+	Handle(ctx context.Context, cmd DispatchWebhooksCommand) (*DispatchWebhooksResult, error)
+}
+
+type dispatchWebhooksHandler struct {
+	webhookRepo domain.WebhookRepository
+	sender      domain.WebhookSender
+}
+
+func NewDispatchWebhooksHandler(webhookRepo domain.WebhookRepository, sender domain.WebhookSender) *dispatchWebhooksHandler {
+	return &dispatchWebhooksHandler{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+	}
+}
+
+func (h *dispatchWebhooksHandler) Handle(ctx context.Context, cmd DispatchWebhooksCommand) (*DispatchWebhooksResult, error) {
+	events, err := h.webhookRepo.FanOutEvents(ctx, cmd.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fan out events: %w", err)
+	}
+
+	deliveries, err := h.webhookRepo.ClaimDeliveries(ctx, cmd.BatchSize, cmd.Lease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim deliveries: %w", err)
+	}
+
+	result := &DispatchWebhooksResult{Events: events}
+	for _, d := range deliveries {
+		statusCode, sendErr := h.sender.Send(ctx, d)
+
+		now := time.Now()
+		d.Attempts++
+		d.LastStatusCode = statusCode
+
+		switch {
+		case sendErr == nil:
+			d.Status = domain.WebhookDeliveryDelivered
+			d.LastError = ""
+			d.DeliveredAt = &now
+			result.Delivered++
+		case d.Attempts >= cmd.MaxAttempts:
+			d.Status = domain.WebhookDeliveryDead
+			d.LastError = sendErr.Error()
+			result.Failed++
+		default:
+			d.LastError = sendErr.Error()
+			d.NextAttemptAt = now.Add(domain.WebhookBackoff(d.Attempts))
+			result.Failed++
+		}
+
+		if err := h.webhookRepo.SaveDeliveryResult(ctx, d); err != nil {
+			return result, fmt.Errorf("failed to save delivery %s: %w", d.ID, err)
+		}
+	}
+
+	return result, nil
+}
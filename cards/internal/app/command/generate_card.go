@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"marketai/cards/internal/app/query"
 	"marketai/cards/internal/domain"
 	"time"
 
@@ -11,12 +12,16 @@ import (
 
 type GenerateCardCommand struct {
 	UserID           string
+	WorkspaceID      string
 	PhotoURL         string
 	ShortDescription string
+	// BrandProfileID - профиль бренда, пустая строка если профиль не выбран
+	BrandProfileID string
 }
 
 type GenerateCardResult struct {
-	Card *domain.Card
+	Card          *domain.Card
+	KeywordReport []domain.KeywordUsage
 }
 
 type GenerateCardHandler interface {
@@ -24,41 +29,103 @@ type GenerateCardHandler interface {
 }
 
 type generateCardHandler struct {
-	cardRepo  domain.CardRepository
-	aiService domain.AIService
+	cardRepo        domain.CardRepository
+	aiService       domain.AIService
+	suggestKeywords query.SuggestKeywordsHandler
+	getProfile      query.GetBrandProfileByIDHandler
+	promptKeywords  int
 }
 
-func NewGenerateCardHandler(cardRepo domain.CardRepository, aiService domain.AIService) *generateCardHandler {
+func NewGenerateCardHandler(
+	cardRepo domain.CardRepository,
+	aiService domain.AIService,
+	suggestKeywords query.SuggestKeywordsHandler,
+	getProfile query.GetBrandProfileByIDHandler,
+	promptKeywords int,
+) *generateCardHandler {
 	return &generateCardHandler{
-		cardRepo:  cardRepo,
-		aiService: aiService,
+		cardRepo:        cardRepo,
+		aiService:       aiService,
+		suggestKeywords: suggestKeywords,
+		getProfile:      getProfile,
+		promptKeywords:  promptKeywords,
 	}
 }
 
 func (h *generateCardHandler) Handle(ctx context.Context, cmd GenerateCardCommand) (*GenerateCardResult, error) {
+	var profile *domain.BrandProfile
+	if cmd.BrandProfileID != "" {
+		result, err := h.getProfile.Handle(ctx, query.GetBrandProfileByIDQuery{
+			ProfileID: cmd.BrandProfileID,
+			UserID:    cmd.UserID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get brand profile: %w", err)
+		}
+		profile = result.Profile
+	}
+
+	// Подбираем самые релевантные поисковые запросы из словаря
+	suggested, err := h.suggestKeywords.Handle(ctx, query.SuggestKeywordsQuery{
+		Description: cmd.ShortDescription,
+		Limit:       h.promptKeywords,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest keywords: %w", err)
+	}
+
+	keywords := make([]string, 0, len(suggested.Keywords))
+	for _, k := range suggested.Keywords {
+		keywords = append(keywords, k.Keyword.Phrase)
+	}
+
 	// Генерируем контент карточки через AI
-	generatedContent, err := h.aiService.GenerateCardContent(ctx, cmd.PhotoURL, cmd.ShortDescription)
+	generatedContent, err := h.aiService.GenerateCardContent(ctx, domain.GenerateCardInput{
+		PhotoURL:     cmd.PhotoURL,
+		Description:  cmd.ShortDescription,
+		Keywords:     keywords,
+		BrandProfile: profile,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate card content: %w", err)
 	}
 
+	if profile != nil {
+		profile.Apply(generatedContent)
+	}
+
 	// Создаем карточку
 	card := &domain.Card{
 		ID:               uuid.New().String(),
 		UserID:           cmd.UserID,
+		WorkspaceID:      cmd.WorkspaceID,
 		PhotoURL:         cmd.PhotoURL,
 		ShortDescription: cmd.ShortDescription,
 		Title:            generatedContent.Title,
 		Description:      generatedContent.Description,
 		Tags:             generatedContent.Tags,
 		Image:            generatedContent.Image,
+		Keywords:         keywords,
+		Status:           domain.CardStatusDraft,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
 
-	if err := h.cardRepo.CreateCard(ctx, card); err != nil {
+	if profile != nil {
+		card.BrandProfileID = &profile.ID
+	}
+
+	event, err := domain.NewCardEvent(domain.CardEventGenerated, card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build card event: %w", err)
+	}
+
+	if err := h.cardRepo.CreateCard(ctx, card, event); err != nil {
 		return nil, fmt.Errorf("failed to save card: %w", err)
 	}
 
-	return &GenerateCardResult{Card: card}, nil
+	return &GenerateCardResult{
+		Card:          card,
+		KeywordReport: domain.BuildKeywordReport(keywords, card.Title, card.Description),
+	}, nil
 }
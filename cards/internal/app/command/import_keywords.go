@@ -0,0 +1,116 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"marketai/cards/internal/domain"
+	"strconv"
+	"strings"
+)
+
+var ErrEmptyKeywordsFile = errors.New("keywords file is empty")
+
+type ImportKeywordsCommand struct {
+	File io.Reader
+}
+
+type ImportKeywordsResult struct {
+	Imported int
+	Skipped  int
+}
+
+type ImportKeywordsHandler interface {
+	Handle(ctx context.Context, cmd ImportKeywordsCommand) (*ImportKeywordsResult, error)
+}
+
+type importKeywordsHandler struct {
+	keywordRepo domain.KeywordRepository
+}
+
+func NewImportKeywordsHandler(keywordRepo domain.KeywordRepository) *importKeywordsHandler {
+	return &importKeywordsHandler{
+		keywordRepo: keywordRepo,
+	}
+}
+
+// Handle импортирует словарь из CSV в формате "фраза,частотность".
+// Разделителем может быть запятая или точка с запятой (выгрузки Wordstat),
+// строка заголовка и строки с некорректной частотностью пропускаются.
+func (h *importKeywordsHandler) Handle(ctx context.Context, cmd ImportKeywordsCommand) (*ImportKeywordsResult, error) {
+	reader := bufio.NewReader(cmd.File)
+
+	firstLine, err := reader.Peek(reader.Size())
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("failed to read keywords file: %w", err)
+	}
+	if len(firstLine) == 0 {
+		return nil, ErrEmptyKeywordsFile
+	}
+
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.Comma = detectComma(string(firstLine))
+
+	byPhrase := make(map[string]*domain.Keyword)
+	var keywords []*domain.Keyword
+	result := &ImportKeywordsResult{}
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse keywords file: %w", err)
+		}
+
+		if len(record) < 2 {
+			result.Skipped++
+			continue
+		}
+
+		phrase := domain.NormalizePhrase(strings.TrimPrefix(record[0], "\ufeff"))
+		frequency, err := strconv.ParseInt(strings.Join(strings.Fields(record[1]), ""), 10, 64)
+		if phrase == "" || err != nil || frequency < 0 {
+			result.Skipped++
+			continue
+		}
+
+		// Одна и та же фраза может встретиться несколько раз, оставляем последнюю
+		if k, ok := byPhrase[phrase]; ok {
+			k.Frequency = frequency
+			result.Skipped++
+			continue
+		}
+
+		k := &domain.Keyword{Phrase: phrase, Frequency: frequency}
+		byPhrase[phrase] = k
+		keywords = append(keywords, k)
+	}
+
+	if len(keywords) == 0 {
+		return result, nil
+	}
+
+	if err := h.keywordRepo.UpsertKeywords(ctx, keywords); err != nil {
+		return nil, fmt.Errorf("failed to save keywords: %w", err)
+	}
+
+	result.Imported = len(keywords)
+	return result, nil
+}
+
+func detectComma(sample string) rune {
+	if i := strings.IndexByte(sample, '\n'); i >= 0 {
+		sample = sample[:i]
+	}
+	if strings.Count(sample, ";") > strings.Count(sample, ",") {
+		return ';'
+	}
+	return ','
+}
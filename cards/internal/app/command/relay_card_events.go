@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"marketai/cards/internal/config"
+	"marketai/cards/internal/domain"
+	"time"
+)
+
+type RelayCardEventsCommand struct {
+	BatchSize int
+	// Lease - на сколько relay захватывает право публикации. Публикация
+	// пачки прерывается через три четверти lease, остаток уходит на отметку
+	// опубликованных, пока relay другого экземпляра не может взять те же события.
+	Lease time.Duration
+}
+
+type RelayCardEventsHandler interface {
+	// Handle возвращает количество опубликованных событий
+	Handle(ctx context.Context, cmd RelayCardEventsCommand) (int, error)
+}
+
+type relayCardEventsHandler struct {
+	outbox    domain.CardEventOutbox
+	publisher domain.EventPublisher
+}
+
+func NewRelayCardEventsHandler(outbox domain.CardEventOutbox, publisher domain.EventPublisher) *relayCardEventsHandler {
+	return &relayCardEventsHandler{
+		outbox:    outbox,
+		publisher: publisher,
+	}
+}
+
+// Handle публикует события вне транзакции и отмечает только подтвержденные
+// брокером. На первой ошибке публикация останавливается, чтобы не нарушить
+// порядок событий карточки.
+func (h *relayCardEventsHandler) Handle(ctx context.Context, cmd RelayCardEventsCommand) (published int, err error) {
+	events, ok, err := h.outbox.ClaimPending(ctx, cmd.BatchSize, cmd.Lease)
+	if err != nil || !ok {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, h.outbox.Release(context.WithoutCancel(ctx)))
+	}()
+
+	publishCtx, cancel := context.WithTimeout(ctx, cmd.Lease*3/4)
+	defer cancel()
+
+	ids := make([]string, 0, len(events))
+	var publishErr error
+	for _, e := range events {
+		if publishErr = h.publisher.Publish(publishCtx, e); publishErr != nil {
+			break
+		}
+		ids = append(ids, e.ID)
+	}
+
+	if len(ids) > 0 {
+		if err := h.outbox.MarkPublished(ctx, ids); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), publishErr
+}
+
+type PurgeCardEventsHandler interface {
+	// Handle удаляет обработанные события старше срока хранения и возвращает их число
+	Handle(ctx context.Context) (int64, error)
+}
+
+type purgeCardEventsHandler struct {
+	purger    domain.CardEventPurger
+	retention time.Duration
+}
+
+func NewPurgeCardEventsHandler(purger domain.CardEventPurger, cfg *config.Config) *purgeCardEventsHandler {
+	return &purgeCardEventsHandler{purger: purger, retention: cfg.Events.Retention}
+}
+
+func (h *purgeCardEventsHandler) Handle(ctx context.Context) (int64, error) {
+	if h.retention <= 0 {
+		return 0, nil
+	}
+	return h.purger.DeleteProcessedEvents(ctx, time.Now().Add(-h.retention))
+}
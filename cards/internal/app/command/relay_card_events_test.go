@@ -0,0 +1,176 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"marketai/cards/internal/adapters"
+	"marketai/cards/internal/domain"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fakeOutbox struct {
+	events    []*domain.CardEvent
+	busy      bool
+	claimErr  error
+	published []string
+	released  bool
+}
+
+func (o *fakeOutbox) ClaimPending(_ context.Context, limit int, _ time.Duration) ([]*domain.CardEvent, bool, error) {
+	if o.claimErr != nil {
+		return nil, false, o.claimErr
+	}
+	if o.busy {
+		return nil, false, nil
+	}
+	return o.events[:min(limit, len(o.events))], true, nil
+}
+
+func (o *fakeOutbox) MarkPublished(_ context.Context, ids []string) error {
+	o.published = append(o.published, ids...)
+	return nil
+}
+
+func (o *fakeOutbox) Release(context.Context) error {
+	o.released = true
+	return nil
+}
+
+// failingPublisher отклоняет событие с идентификатором failID
+type failingPublisher struct {
+	*adapters.InMemoryBroker
+	failID string
+}
+
+var errBrokerDown = errors.New("broker is down")
+
+func (p failingPublisher) Publish(ctx context.Context, event *domain.CardEvent) error {
+	if event.ID == p.failID {
+		return errBrokerDown
+	}
+	return p.InMemoryBroker.Publish(ctx, event)
+}
+
+func TestRelayCardEvents(t *testing.T) {
+	events := []*domain.CardEvent{
+		{ID: "e1", Type: domain.CardEventGenerated, CardID: "c1"},
+		{ID: "e2", Type: domain.CardEventGenerated, CardID: "c2"},
+		{ID: "e3", Type: domain.CardEventStatusChanged, CardID: "c1"},
+		{ID: "e4", Type: domain.CardEventDeleted, CardID: "c1"},
+	}
+
+	tests := []struct {
+		name         string
+		outbox       *fakeOutbox
+		batch        int
+		failID       string
+		wantErr      error
+		wantIDs      []string
+		wantC1       []string
+		wantReleased bool
+	}{
+		{
+			name:         "publishes batch in order",
+			outbox:       &fakeOutbox{events: events},
+			batch:        10,
+			wantIDs:      []string{"e1", "e2", "e3", "e4"},
+			wantC1:       []string{"e1", "e3", "e4"},
+			wantReleased: true,
+		},
+		{
+			name:         "respects batch size",
+			outbox:       &fakeOutbox{events: events},
+			batch:        2,
+			wantIDs:      []string{"e1", "e2"},
+			wantC1:       []string{"e1"},
+			wantReleased: true,
+		},
+		{
+			name:         "stops at first failure",
+			outbox:       &fakeOutbox{events: events},
+			batch:        10,
+			failID:       "e3",
+			wantErr:      errBrokerDown,
+			wantIDs:      []string{"e1", "e2"},
+			wantC1:       []string{"e1"},
+			wantReleased: true,
+		},
+		{
+			name:   "lease held by another instance",
+			outbox: &fakeOutbox{events: events, busy: true},
+			batch:  10,
+		},
+		{
+			name:    "claim error",
+			outbox:  &fakeOutbox{events: events, claimErr: errBrokerDown},
+			batch:   10,
+			wantErr: errBrokerDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := adapters.NewInMemoryBroker()
+			h := NewRelayCardEventsHandler(tt.outbox, failingPublisher{InMemoryBroker: broker, failID: tt.failID})
+
+			n, err := h.Handle(context.Background(), RelayCardEventsCommand{BatchSize: tt.batch, Lease: time.Second})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if n != len(tt.wantIDs) {
+				t.Errorf("published = %d, want %d", n, len(tt.wantIDs))
+			}
+			if !slices.Equal(tt.outbox.published, tt.wantIDs) {
+				t.Errorf("marked = %v, want %v", tt.outbox.published, tt.wantIDs)
+			}
+			if got := messageIDs(broker.Messages()); !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("broker messages = %v, want %v", got, tt.wantIDs)
+			}
+			if got := messageIDs(broker.MessagesByKey("c1")); !slices.Equal(got, tt.wantC1) {
+				t.Errorf("card c1 messages = %v, want %v", got, tt.wantC1)
+			}
+			if tt.outbox.released != tt.wantReleased {
+				t.Errorf("released = %v, want %v", tt.outbox.released, tt.wantReleased)
+			}
+		})
+	}
+}
+
+// stuckPublisher не получает подтверждения брокера и ждет отмены ctx
+type stuckPublisher struct{}
+
+func (stuckPublisher) Publish(ctx context.Context, _ *domain.CardEvent) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRelayCardEventsStopsBeforeLeaseExpires(t *testing.T) {
+	outbox := &fakeOutbox{events: []*domain.CardEvent{{ID: "e1", Type: domain.CardEventGenerated, CardID: "c1"}}}
+	h := NewRelayCardEventsHandler(outbox, stuckPublisher{})
+
+	lease := 200 * time.Millisecond
+	started := time.Now()
+	n, err := h.Handle(context.Background(), RelayCardEventsCommand{BatchSize: 10, Lease: lease})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(started); elapsed >= lease {
+		t.Errorf("relay ran %s, want less than lease %s", elapsed, lease)
+	}
+	if n != 0 || len(outbox.published) != 0 {
+		t.Errorf("published = %d, marked = %v, want none", n, outbox.published)
+	}
+	if !outbox.released {
+		t.Errorf("lease was not released")
+	}
+}
+
+func messageIDs(messages []adapters.BrokerMessage) []string {
+	var ids []string
+	for _, m := range messages {
+		ids = append(ids, m.EventID)
+	}
+	return ids
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"marketai/cards/internal/domain"
+	"strings"
+	"time"
+)
+
+// UpdateCardCommand - ручная правка карточки, nil поля не меняются
+type UpdateCardCommand struct {
+	CardID      string
+	WorkspaceID string
+	Title       *string
+	Description *string
+	Tags        []string
+}
+
+type UpdateCardResult struct {
+	Card *domain.Card
+}
+
+type UpdateCardHandler interface {
+	Handle(ctx context.Context, cmd UpdateCardCommand) (*UpdateCardResult, error)
+}
+
+type DeleteCardCommand struct {
+	CardID      string
+	WorkspaceID string
+}
+
+type DeleteCardHandler interface {
+	Handle(ctx context.Context, cmd DeleteCardCommand) error
+}
+
+type updateCardHandler struct {
+	cardRepo domain.CardRepository
+}
+
+func NewUpdateCardHandler(cardRepo domain.CardRepository) *updateCardHandler {
+	return &updateCardHandler{
+		cardRepo: cardRepo,
+	}
+}
+
+func (h *updateCardHandler) Handle(ctx context.Context, cmd UpdateCardCommand) (*UpdateCardResult, error) {
+	card, err := h.cardRepo.GetCardByID(ctx, cmd.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.WorkspaceID != cmd.WorkspaceID {
+		return nil, domain.ErrCardNotFound
+	}
+	if !card.Status.Editable() {
+		return nil, domain.ErrCardNotEditable
+	}
+
+	if cmd.Title != nil {
+		card.Title = strings.TrimSpace(*cmd.Title)
+	}
+	if cmd.Description != nil {
+		card.Description = strings.TrimSpace(*cmd.Description)
+	}
+	if cmd.Tags != nil {
+		card.Tags = cmd.Tags
+	}
+	card.UpdatedAt = time.Now()
+
+	event, err := domain.NewCardEvent(domain.CardEventUpdated, card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build card event: %w", err)
+	}
+
+	if err := h.cardRepo.UpdateCard(ctx, card, event); err != nil {
+		return nil, err
+	}
+
+	return &UpdateCardResult{Card: card}, nil
+}
+
+type deleteCardHandler struct {
+	cardRepo domain.CardRepository
+}
+
+func NewDeleteCardHandler(cardRepo domain.CardRepository) *deleteCardHandler {
+	return &deleteCardHandler{
+		cardRepo: cardRepo,
+	}
+}
+
+func (h *deleteCardHandler) Handle(ctx context.Context, cmd DeleteCardCommand) error {
+	card, err := h.cardRepo.GetCardByID(ctx, cmd.CardID)
+	if err != nil {
+		return err
+	}
+	if card.WorkspaceID != cmd.WorkspaceID {
+		return domain.ErrCardNotFound
+	}
+
+	// Подписчики получают последний снимок удаленной карточки
+	event, err := domain.NewCardEvent(domain.CardEventDeleted, card)
+	if err != nil {
+		return fmt.Errorf("failed to build card event: %w", err)
+	}
+
+	return h.cardRepo.DeleteCard(ctx, card.ID, card.WorkspaceID, event)
+}
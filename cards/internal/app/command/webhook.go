@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"marketai/cards/internal/domain"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type CreateWebhookCommand struct {
+	WorkspaceID string
+	URL         string
+	EventTypes  []domain.CardEventType
+	// Secret - если не задан, генерируется случайный
+	Secret string
+}
+
+type CreateWebhookHandler interface {
+	Handle(ctx context.Context, cmd CreateWebhookCommand) (*domain.WebhookSubscription, error)
+}
+
+type DeleteWebhookCommand struct {
+	ID          string
+	WorkspaceID string
+}
+
+type DeleteWebhookHandler interface {
+	Handle(ctx context.Context, cmd DeleteWebhookCommand) error
+}
+
+type ReplayWebhookDeliveriesCommand struct {
+	SubscriptionID string
+	WorkspaceID    string
+	// DeliveryIDs - пустой список означает все мертвые доставки подписки
+	DeliveryIDs []string
+}
+
+type ReplayWebhookDeliveriesHandler interface {
+	Handle(ctx context.Context, cmd ReplayWebhookDeliveriesCommand) (int, error)
+}
+
+type createWebhookHandler struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewCreateWebhookHandler(webhookRepo domain.WebhookRepository) *createWebhookHandler {
+	return &createWebhookHandler{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (h *createWebhookHandler) Handle(ctx context.Context, cmd CreateWebhookCommand) (*domain.WebhookSubscription, error) {
+	target, err := url.Parse(strings.TrimSpace(cmd.URL))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, domain.ErrInvalidWebhookURL
+	}
+
+	eventTypes := cmd.EventTypes
+	if len(eventTypes) == 0 {
+		eventTypes = domain.CardEventTypes
+	}
+	for _, t := range eventTypes {
+		if !t.Valid() {
+			return nil, domain.ErrInvalidEventType
+		}
+	}
+
+	secret := strings.TrimSpace(cmd.Secret)
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+	}
+
+	sub := &domain.WebhookSubscription{
+		WorkspaceID: cmd.WorkspaceID,
+		URL:         target.String(),
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		Active:      true,
+		CreatedAt:   time.Now(),
+	}
+	if err := h.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+type deleteWebhookHandler struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewDeleteWebhookHandler(webhookRepo domain.WebhookRepository) *deleteWebhookHandler {
+	return &deleteWebhookHandler{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (h *deleteWebhookHandler) Handle(ctx context.Context, cmd DeleteWebhookCommand) error {
+	return h.webhookRepo.DeleteSubscription(ctx, cmd.ID, cmd.WorkspaceID)
+}
+
+type replayWebhookDeliveriesHandler struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewReplayWebhookDeliveriesHandler(webhookRepo domain.WebhookRepository) *replayWebhookDeliveriesHandler {
+	return &replayWebhookDeliveriesHandler{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (h *replayWebhookDeliveriesHandler) Handle(ctx context.Context, cmd ReplayWebhookDeliveriesCommand) (int, error) {
+	sub, err := h.webhookRepo.GetSubscription(ctx, cmd.SubscriptionID)
+	if err != nil {
+		return 0, err
+	}
+	if sub.WorkspaceID != cmd.WorkspaceID {
+		return 0, domain.ErrWebhookNotFound
+	}
+
+	return h.webhookRepo.ReplayDeliveries(ctx, sub.ID, cmd.DeliveryIDs)
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,28 @@
+package dto
+
+type BrandProfileRequest struct {
+	Name         string   `json:"name" validate:"required,max=255"`
+	Tone         string   `json:"tone"`
+	Intro        string   `json:"intro"`
+	Outro        string   `json:"outro"`
+	WarrantyText string   `json:"warranty_text"`
+	BannedWords  []string `json:"banned_words"`
+	NoEmoji      bool     `json:"no_emoji"`
+}
+
+type BrandProfileResponse struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Tone         string   `json:"tone"`
+	Intro        string   `json:"intro"`
+	Outro        string   `json:"outro"`
+	WarrantyText string   `json:"warranty_text"`
+	BannedWords  []string `json:"banned_words"`
+	NoEmoji      bool     `json:"no_emoji"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+}
+
+type BrandProfilesResponse struct {
+	Profiles []BrandProfileResponse `json:"profiles"`
+}
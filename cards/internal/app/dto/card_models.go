@@ -3,14 +3,18 @@ package dto
 type GenerateCardRequest struct {
 	PhotoURL         string `json:"photo_url" validate:"required,url"`
 	ShortDescription string `json:"short_description" validate:"required"`
+	BrandProfileID   string `json:"brand_profile_id" validate:"omitempty,uuid"`
 }
 
 type GenerateCardResponse struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
-	Image       string   `json:"image"`
+	ID             string         `json:"id"`
+	Title          string         `json:"title"`
+	Description    string         `json:"description"`
+	Tags           []string       `json:"tags"`
+	Image          string         `json:"image"`
+	KeywordReport  []KeywordUsage `json:"keyword_report"`
+	BrandProfileID *string        `json:"brand_profile_id,omitempty"`
+	Status         string         `json:"status"`
 }
 
 type CardHistoryResponse struct {
@@ -19,22 +23,35 @@ type CardHistoryResponse struct {
 
 type CardInfo struct {
 	ID               string   `json:"id"`
+	UserID           string   `json:"user_id"`
 	PhotoURL         string   `json:"photo_url"`
 	ShortDescription string   `json:"short_description"`
 	Title            string   `json:"title"`
 	Description      string   `json:"description"`
 	Tags             []string `json:"tags"`
 	Image            string   `json:"image"`
+	BrandProfileID   *string  `json:"brand_profile_id,omitempty"`
+	Status           string   `json:"status"`
 	CreatedAt        string   `json:"created_at"`
 }
 
 type CardDetailResponse struct {
-	ID               string   `json:"id"`
-	PhotoURL         string   `json:"photo_url"`
-	ShortDescription string   `json:"short_description"`
-	Title            string   `json:"title"`
-	Description      string   `json:"description"`
-	Tags             []string `json:"tags"`
-	Image            string   `json:"image"`
-	CreatedAt        string   `json:"created_at"`
+	ID               string         `json:"id"`
+	PhotoURL         string         `json:"photo_url"`
+	ShortDescription string         `json:"short_description"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	Tags             []string       `json:"tags"`
+	Image            string         `json:"image"`
+	KeywordReport    []KeywordUsage `json:"keyword_report"`
+	BrandProfileID   *string        `json:"brand_profile_id,omitempty"`
+	Status           string         `json:"status"`
+	CreatedAt        string         `json:"created_at"`
+}
+
+// UpdateCardRequest - поля, которые не переданы, остаются без изменений
+type UpdateCardRequest struct {
+	Title       *string  `json:"title" validate:"omitempty,min=1"`
+	Description *string  `json:"description"`
+	Tags        []string `json:"tags"`
 }
@@ -0,0 +1,31 @@
+package dto
+
+type ChangeCardStatusRequest struct {
+	Status  string `json:"status" validate:"required"`
+	Comment string `json:"comment"`
+}
+
+type AddCardCommentRequest struct {
+	Text string `json:"text" validate:"required"`
+}
+
+type CardTransition struct {
+	ID         string `json:"id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	UserID     string `json:"user_id"`
+	Comment    string `json:"comment"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type CardComment struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+}
+
+type CardReviewResponse struct {
+	Transitions []CardTransition `json:"transitions"`
+	Comments    []CardComment    `json:"comments"`
+}
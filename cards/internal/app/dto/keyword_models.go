@@ -0,0 +1,27 @@
+package dto
+
+type KeywordUsage struct {
+	Phrase        string `json:"phrase"`
+	InTitle       bool   `json:"in_title"`
+	InDescription bool   `json:"in_description"`
+}
+
+type ImportKeywordsResponse struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
+
+type SuggestKeywordsRequest struct {
+	Description string `query:"description" validate:"required"`
+	Limit       int    `query:"limit" validate:"gte=0,lte=100"`
+}
+
+type SuggestKeywordsResponse struct {
+	Keywords []SuggestedKeyword `json:"keywords"`
+}
+
+type SuggestedKeyword struct {
+	Phrase    string  `json:"phrase"`
+	Frequency int64   `json:"frequency"`
+	Score     float64 `json:"score"`
+}
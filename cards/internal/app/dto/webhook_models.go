@@ -0,0 +1,48 @@
+package dto
+
+type CreateWebhookRequest struct {
+	URL string `json:"url" validate:"required,url"`
+	// EventTypes - пустой список означает подписку на все события
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+type WebhookResponse struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	// Secret возвращается только при создании подписки
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type WebhooksResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+type WebhookDelivery struct {
+	ID             string  `json:"id"`
+	EventID        string  `json:"event_id"`
+	EventType      string  `json:"event_type"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	NextAttemptAt  string  `json:"next_attempt_at"`
+	LastError      string  `json:"last_error"`
+	LastStatusCode int     `json:"last_status_code"`
+	CreatedAt      string  `json:"created_at"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+type ReplayDeliveriesRequest struct {
+	// DeliveryIDs - пустой список переотправляет все мертвые доставки подписки
+	DeliveryIDs []string `json:"delivery_ids"`
+}
+
+type ReplayDeliveriesResponse struct {
+	Replayed int `json:"replayed"`
+}
@@ -0,0 +1,58 @@
+package query
+
+import (
+	"context"
+	"marketai/cards/internal/domain"
+)
+
+type GetCardReviewQuery struct {
+	CardID      string
+	WorkspaceID string
+}
+
+// GetCardReviewResult - история переходов и комментарии согласования карточки
+type GetCardReviewResult struct {
+	Transitions []*domain.CardTransition
+	Comments    []*domain.CardComment
+}
+
+type GetCardReviewHandler interface {
+	Handle(ctx context.Context, query GetCardReviewQuery) (*GetCardReviewResult, error)
+}
+
+type getCardReviewHandler struct {
+	cardRepo   domain.CardRepository
+	reviewRepo domain.CardReviewRepository
+}
+
+func NewGetCardReviewHandler(cardRepo domain.CardRepository, reviewRepo domain.CardReviewRepository) *getCardReviewHandler {
+	return &getCardReviewHandler{
+		cardRepo:   cardRepo,
+		reviewRepo: reviewRepo,
+	}
+}
+
+func (h *getCardReviewHandler) Handle(ctx context.Context, query GetCardReviewQuery) (*GetCardReviewResult, error) {
+	card, err := h.cardRepo.GetCardByID(ctx, query.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.WorkspaceID != query.WorkspaceID {
+		return nil, domain.ErrCardNotFound
+	}
+
+	transitions, err := h.reviewRepo.GetCardTransitions(ctx, card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := h.reviewRepo.GetCardComments(ctx, card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetCardReviewResult{
+		Transitions: transitions,
+		Comments:    comments,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"context"
+	"marketai/cards/internal/domain"
+)
+
+type GetBrandProfilesQuery struct {
+	UserID string
+}
+
+type GetBrandProfilesResult struct {
+	Profiles []*domain.BrandProfile
+}
+
+type GetBrandProfilesHandler interface {
+	Handle(ctx context.Context, query GetBrandProfilesQuery) (*GetBrandProfilesResult, error)
+}
+
+type getBrandProfilesHandler struct {
+	profileRepo domain.BrandProfileRepository
+}
+
+func NewGetBrandProfilesHandler(profileRepo domain.BrandProfileRepository) *getBrandProfilesHandler {
+	return &getBrandProfilesHandler{
+		profileRepo: profileRepo,
+	}
+}
+
+func (h *getBrandProfilesHandler) Handle(ctx context.Context, query GetBrandProfilesQuery) (*GetBrandProfilesResult, error) {
+	profiles, err := h.profileRepo.GetBrandProfilesByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetBrandProfilesResult{Profiles: profiles}, nil
+}
+
+type GetBrandProfileByIDQuery struct {
+	ProfileID string
+	UserID    string
+}
+
+type GetBrandProfileByIDResult struct {
+	Profile *domain.BrandProfile
+}
+
+type GetBrandProfileByIDHandler interface {
+	Handle(ctx context.Context, query GetBrandProfileByIDQuery) (*GetBrandProfileByIDResult, error)
+}
+
+type getBrandProfileByIDHandler struct {
+	profileRepo domain.BrandProfileRepository
+}
+
+func NewGetBrandProfileByIDHandler(profileRepo domain.BrandProfileRepository) *getBrandProfileByIDHandler {
+	return &getBrandProfileByIDHandler{
+		profileRepo: profileRepo,
+	}
+}
+
+func (h *getBrandProfileByIDHandler) Handle(ctx context.Context, query GetBrandProfileByIDQuery) (*GetBrandProfileByIDResult, error) {
+	profile, err := h.profileRepo.GetBrandProfileByID(ctx, query.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Чужой профиль для пользователя не существует
+	if profile.UserID != query.UserID {
+		return nil, domain.ErrBrandProfileNotFound
+	}
+
+	return &GetBrandProfileByIDResult{Profile: profile}, nil
+}
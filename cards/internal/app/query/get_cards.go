@@ -5,39 +5,49 @@ import (
 	"marketai/cards/internal/domain"
 )
 
-type GetCardsByUserQuery struct {
-	UserID string
+type GetCardsByWorkspaceQuery struct {
+	WorkspaceID string
+	Statuses    []domain.CardStatus
 }
 
-type GetCardsByUserResult struct {
+type GetCardsByWorkspaceResult struct {
 	Cards []*domain.Card
 }
 
-type GetCardsByUserHandler interface {
-	Handle(ctx context.Context, query GetCardsByUserQuery) (*GetCardsByUserResult, error)
+type GetCardsByWorkspaceHandler interface {
+	Handle(ctx context.Context, query GetCardsByWorkspaceQuery) (*GetCardsByWorkspaceResult, error)
 }
 
-type getCardsByUserHandler struct {
+type getCardsByWorkspaceHandler struct {
 	cardRepo domain.CardRepository
 }
 
-func NewGetCardsByUserHandler(cardRepo domain.CardRepository) *getCardsByUserHandler {
-	return &getCardsByUserHandler{
+func NewGetCardsByWorkspaceHandler(cardRepo domain.CardRepository) *getCardsByWorkspaceHandler {
+	return &getCardsByWorkspaceHandler{
 		cardRepo: cardRepo,
 	}
 }
 
-func (h *getCardsByUserHandler) Handle(ctx context.Context, query GetCardsByUserQuery) (*GetCardsByUserResult, error) {
-	cards, err := h.cardRepo.GetCardsByUserID(ctx, query.UserID)
+func (h *getCardsByWorkspaceHandler) Handle(ctx context.Context, query GetCardsByWorkspaceQuery) (*GetCardsByWorkspaceResult, error) {
+	for _, s := range query.Statuses {
+		if !s.Valid() {
+			return nil, domain.ErrInvalidCardStatus
+		}
+	}
+
+	cards, err := h.cardRepo.GetCardsByWorkspaceID(ctx, query.WorkspaceID, domain.CardFilter{
+		Statuses: query.Statuses,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetCardsByUserResult{Cards: cards}, nil
+	return &GetCardsByWorkspaceResult{Cards: cards}, nil
 }
 
 type GetCardByIDQuery struct {
-	CardID string
+	CardID      string
+	WorkspaceID string
 }
 
 type GetCardByIDResult struct {
@@ -64,5 +74,10 @@ func (h *getCardByIDHandler) Handle(ctx context.Context, query GetCardByIDQuery)
 		return nil, err
 	}
 
+	// Карточка чужого пространства для пользователя не существует
+	if card.WorkspaceID != query.WorkspaceID {
+		return nil, domain.ErrCardNotFound
+	}
+
 	return &GetCardByIDResult{Card: card}, nil
 }
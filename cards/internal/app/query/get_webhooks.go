@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+	"marketai/cards/internal/domain"
+)
+
+type GetWebhooksQuery struct {
+	WorkspaceID string
+}
+
+type GetWebhooksHandler interface {
+	Handle(ctx context.Context, query GetWebhooksQuery) ([]*domain.WebhookSubscription, error)
+}
+
+type getWebhooksHandler struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewGetWebhooksHandler(webhookRepo domain.WebhookRepository) *getWebhooksHandler {
+	return &getWebhooksHandler{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (h *getWebhooksHandler) Handle(ctx context.Context, query GetWebhooksQuery) ([]*domain.WebhookSubscription, error) {
+	return h.webhookRepo.GetSubscriptions(ctx, query.WorkspaceID)
+}
+
+type GetWebhookDeliveriesQuery struct {
+	SubscriptionID string
+	WorkspaceID    string
+	// Status - пустая строка означает доставки в любом статусе
+	Status domain.WebhookDeliveryStatus
+}
+
+type GetWebhookDeliveriesHandler interface {
+	Handle(ctx context.Context, query GetWebhookDeliveriesQuery) ([]*domain.WebhookDelivery, error)
+}
+
+type getWebhookDeliveriesHandler struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewGetWebhookDeliveriesHandler(webhookRepo domain.WebhookRepository) *getWebhookDeliveriesHandler {
+	return &getWebhookDeliveriesHandler{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (h *getWebhookDeliveriesHandler) Handle(ctx context.Context, query GetWebhookDeliveriesQuery) ([]*domain.WebhookDelivery, error) {
+	sub, err := h.webhookRepo.GetSubscription(ctx, query.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.WorkspaceID != query.WorkspaceID {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	return h.webhookRepo.GetDeliveries(ctx, sub.ID, query.Status)
+}
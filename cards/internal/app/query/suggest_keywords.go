@@ -0,0 +1,49 @@
+package query
+
+import (
+	"context"
+	"marketai/cards/internal/domain"
+)
+
+// candidatesLimit - сколько самых частотных совпадений достаем из словаря
+// перед ранжированием
+const candidatesLimit = 500
+
+type SuggestKeywordsQuery struct {
+	Description string
+	Limit       int
+}
+
+type SuggestKeywordsResult struct {
+	Keywords []domain.RankedKeyword
+}
+
+type SuggestKeywordsHandler interface {
+	Handle(ctx context.Context, query SuggestKeywordsQuery) (*SuggestKeywordsResult, error)
+}
+
+type suggestKeywordsHandler struct {
+	keywordRepo domain.KeywordRepository
+}
+
+func NewSuggestKeywordsHandler(keywordRepo domain.KeywordRepository) *suggestKeywordsHandler {
+	return &suggestKeywordsHandler{
+		keywordRepo: keywordRepo,
+	}
+}
+
+func (h *suggestKeywordsHandler) Handle(ctx context.Context, query SuggestKeywordsQuery) (*SuggestKeywordsResult, error) {
+	stems := domain.Stems(query.Description)
+	if len(stems) == 0 {
+		return &SuggestKeywordsResult{}, nil
+	}
+
+	candidates, err := h.keywordRepo.FindKeywordsByStems(ctx, stems, candidatesLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SuggestKeywordsResult{
+		Keywords: domain.RankKeywords(query.Description, candidates, query.Limit),
+	}, nil
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"marketai/pkg/bootstrap"
 	"marketai/pkg/grpc"
 	"marketai/pkg/logger"
@@ -19,12 +21,55 @@ type (
 
 		Auth struct {
 			GRPCEndpoint string `mapstructure:"grpc_endpoint"`
+			// JWKSURL - адрес JWKS auth сервиса. Если задан, токены проверяются
+			// локально по открытым ключам, без запроса в auth по gRPC
+			JWKSURL      string        `mapstructure:"jwks_url"`
+			JWKSCacheTTL time.Duration `mapstructure:"jwks_cache_ttl"`
+			// Issuer, Audience и ClockSkew - проверки claims при локальной проверке токена
+			Issuer    string        `mapstructure:"issuer"`
+			Audience  string        `mapstructure:"audience"`
+			ClockSkew time.Duration `mapstructure:"clock_skew"`
+			// SessionCheckTTL - сколько помнить подтверждение auth, что токен, проверенный
+			// по JWKS, не отозван (по умолчанию 30s, отрицательное значение отключает проверку)
+			SessionCheckTTL time.Duration `mapstructure:"session_check_ttl"`
+			// RequireVerifiedEmail запрещает генерацию карточек пользователям с неподтвержденным email
+			RequireVerifiedEmail bool `mapstructure:"require_verified_email"`
 		} `mapstructure:"auth"`
 
 		AI struct {
 			DeepseekAPIKey string `mapstructure:"deepseek_api"`
 			Model          string `mapstructure:"model"`
 		} `mapstructure:"ai"`
+
+		SEO struct {
+			// PromptKeywords - сколько ключевых слов из словаря передавать в промпт
+			PromptKeywords int `mapstructure:"prompt_keywords"`
+		} `mapstructure:"seo"`
+
+		Webhooks struct {
+			// DispatchInterval - пауза между проходами воркера доставки, 0 отключает воркер
+			DispatchInterval time.Duration `mapstructure:"dispatch_interval"`
+			BatchSize        int           `mapstructure:"batch_size"`
+			MaxAttempts      int           `mapstructure:"max_attempts"`
+			Timeout          time.Duration `mapstructure:"timeout"`
+			// EncryptionKey - ключ шифрования секретов подписей в базе, переопределяется WEBHOOK_ENCRYPTION_KEY
+			EncryptionKey string `mapstructure:"encryption_key"`
+			// AllowPrivateTargets разрешает доставку во внутреннюю сеть, только для локальной разработки
+			AllowPrivateTargets bool `mapstructure:"allow_private_targets"`
+		} `mapstructure:"webhooks"`
+
+		// Events - публикация событий карточек в Kafka, без брокеров используется in-memory брокер
+		Events struct {
+			Brokers       []string      `mapstructure:"brokers"`
+			Topic         string        `mapstructure:"topic"`
+			Version       string        `mapstructure:"version"`
+			RelayInterval time.Duration `mapstructure:"relay_interval"`
+			BatchSize     int           `mapstructure:"batch_size"`
+			// Retention - сколько хранить опубликованные и разосланные события outbox,
+			// CleanupInterval - пауза между проходами очистки; 0 отключает очистку
+			Retention       time.Duration `mapstructure:"retention"`
+			CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+		} `mapstructure:"events"`
 	}
 
 	ServerConfig struct {
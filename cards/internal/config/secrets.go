@@ -30,6 +30,7 @@ func mapEnv(config *Config, secrets *Secrets) {
 	postgresDbPassword := os.Getenv("CARDS_POSTGRES_DB_PASSWORD")
 
 	deepseekApiKey := os.Getenv("DEEPSEEK_API_KEY")
+	webhookEncryptionKey := os.Getenv("WEBHOOK_ENCRYPTION_KEY")
 
 	config.Http.Port = serverPort
 	config.Postgres.Host = postgresHost
@@ -39,4 +40,7 @@ func mapEnv(config *Config, secrets *Secrets) {
 	secrets.Postgres.Password = postgresDbPassword
 
 	config.AI.DeepseekAPIKey = deepseekApiKey
+	if webhookEncryptionKey != "" {
+		config.Webhooks.EncryptionKey = webhookEncryptionKey
+	}
 }
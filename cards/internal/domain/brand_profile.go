@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var ErrBrandProfileNotFound = errors.New("brand profile not found")
+
+// BrandProfile - фирменный стиль продавца: тон текста, постоянные блоки
+// и запрещенные слова, которые применяются к каждой карточке
+type BrandProfile struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	Name         string    `json:"name"`
+	Tone         string    `json:"tone"`
+	Intro        string    `json:"intro"`
+	Outro        string    `json:"outro"`
+	WarrantyText string    `json:"warranty_text"`
+	BannedWords  []string  `json:"banned_words"`
+	NoEmoji      bool      `json:"no_emoji"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type BrandProfileRepository interface {
+	CreateBrandProfile(ctx context.Context, profile *BrandProfile) error
+	UpdateBrandProfile(ctx context.Context, profile *BrandProfile) error
+	DeleteBrandProfile(ctx context.Context, id, userID string) error
+	GetBrandProfileByID(ctx context.Context, id string) (*BrandProfile, error)
+	GetBrandProfilesByUserID(ctx context.Context, userID string) ([]*BrandProfile, error)
+}
+
+var multiSpace = regexp.MustCompile(`[ \t]{2,}`)
+
+// Apply дорабатывает сгенерированный текст под профиль: вырезает запрещенные
+// слова и эмодзи, добавляет вступление, заключение и текст гарантии.
+// Модель получает те же требования в промпте, но не всегда их соблюдает.
+func (p *BrandProfile) Apply(card *GeneratedCard) {
+	card.Title = p.clean(card.Title)
+	card.Description = p.clean(card.Description)
+
+	tags := make([]string, 0, len(card.Tags))
+	for _, tag := range card.Tags {
+		if tag = p.clean(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	card.Tags = tags
+
+	blocks := make([]string, 0, 4)
+	for _, block := range []string{p.Intro, card.Description, p.Outro, p.WarrantyText} {
+		if block = strings.TrimSpace(block); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	card.Description = strings.Join(blocks, "\n\n")
+}
+
+func (p *BrandProfile) clean(text string) string {
+	for _, word := range p.BannedWords {
+		text = removeWord(text, word)
+	}
+	if p.NoEmoji {
+		text = RemoveEmoji(text)
+	}
+	return strings.TrimSpace(multiSpace.ReplaceAllString(text, " "))
+}
+
+// removeWord вырезает слово целиком без учета регистра. Слова профиля
+// задает пользователь, поэтому вместо regexp на каждое слово текст
+// сравнивается посимвольно; границы слова - любые символы, кроме букв и цифр.
+func removeWord(text, word string) string {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return text
+	}
+
+	var b strings.Builder
+	prev := rune(-1)
+	for i := 0; i < len(text); {
+		if n := prefixFold(text[i:], word); n > 0 && !isWordRune(prev) {
+			next, _ := utf8.DecodeRuneInString(text[i+n:])
+			if i+n == len(text) || !isWordRune(next) {
+				i += n
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		b.WriteString(text[i : i+size])
+		prev = r
+		i += size
+	}
+	return b.String()
+}
+
+// prefixFold возвращает длину начала text в байтах, совпадающего с word без
+// учета регистра, или 0
+func prefixFold(text, word string) int {
+	n := 0
+	for _, w := range word {
+		r, size := utf8.DecodeRuneInString(text[n:])
+		if size == 0 || unicode.ToLower(r) != unicode.ToLower(w) {
+			return 0
+		}
+		n += size
+	}
+	return n
+}
+
+func isWordRune(r rune) bool {
+	return r >= 0 && (unicode.IsLetter(r) || unicode.IsNumber(r))
+}
+
+// RemoveEmoji удаляет эмодзи и связанные с ними служебные символы
+func RemoveEmoji(text string) string {
+	return strings.Map(func(r rune) rune {
+		if isEmoji(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // пиктограммы, смайлы, флаги
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // разные символы и dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // стрелки и звезды
+		return true
+	case r == 0x200D || r == 0xFE0F || r == 0x20E3: // ZWJ, вариации, keycap
+		return true
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrOutboxLeaseLost - право публикации истекло или перешло к relay другого экземпляра
+var ErrOutboxLeaseLost = errors.New("outbox lease is lost")
+
+// CardEventType - событие жизненного цикла карточки
+type CardEventType string
+
+const (
+	CardEventGenerated     CardEventType = "card.generated"
+	CardEventUpdated       CardEventType = "card.updated"
+	CardEventStatusChanged CardEventType = "card.status_changed"
+	CardEventApproved      CardEventType = "card.approved"
+	CardEventDeleted       CardEventType = "card.deleted"
+)
+
+var CardEventTypes = []CardEventType{
+	CardEventGenerated,
+	CardEventUpdated,
+	CardEventStatusChanged,
+	CardEventApproved,
+	CardEventDeleted,
+}
+
+func (t CardEventType) Valid() bool {
+	for _, known := range CardEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DomainEvent - имя события для шины: изменения статуса публикуются как обновление карточки
+func (t CardEventType) DomainEvent() string {
+	switch t {
+	case CardEventGenerated:
+		return "CardGenerated"
+	case CardEventDeleted:
+		return "CardDeleted"
+	}
+	return "CardUpdated"
+}
+
+// CardEvent - запись outbox, сохраняется в той же транзакции, что и изменение карточки
+type CardEvent struct {
+	ID          string
+	Type        CardEventType
+	CardID      string
+	WorkspaceID string
+	// Payload - тело события в том виде, в котором его получат подписчики
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+type cardEventPayload struct {
+	ID          string        `json:"id"`
+	Type        CardEventType `json:"type"`
+	OccurredAt  time.Time     `json:"occurred_at"`
+	WorkspaceID string        `json:"workspace_id"`
+	Card        *Card         `json:"card"`
+}
+
+// NewCardEvent фиксирует снимок карточки на момент события
+func NewCardEvent(eventType CardEventType, card *Card) (*CardEvent, error) {
+	event := &CardEvent{
+		ID:          uuid.New().String(),
+		Type:        eventType,
+		CardID:      card.ID,
+		WorkspaceID: card.WorkspaceID,
+		CreatedAt:   time.Now(),
+	}
+
+	payload, err := json.Marshal(cardEventPayload{
+		ID:          event.ID,
+		Type:        event.Type,
+		OccurredAt:  event.CreatedAt,
+		WorkspaceID: event.WorkspaceID,
+		Card:        card,
+	})
+	if err != nil {
+		return nil, err
+	}
+	event.Payload = payload
+
+	return event, nil
+}
+
+// StatusEventType - одобрение публикуется отдельным событием, остальные переходы общим
+func StatusEventType(to CardStatus) CardEventType {
+	if to == CardStatusApproved {
+		return CardEventApproved
+	}
+	return CardEventStatusChanged
+}
+
+// EventPublisher публикует события карточек во внешнюю шину
+type EventPublisher interface {
+	Publish(ctx context.Context, event *CardEvent) error
+}
+
+type CardEventOutbox interface {
+	// ClaimPending захватывает право публикации на lease и возвращает до limit
+	// неопубликованных событий в порядке записи. ok == false, если право у relay
+	// другого экземпляра: одновременно работает только один relay.
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) (events []*CardEvent, ok bool, err error)
+	// MarkPublished отмечает события опубликованными, пока право публикации
+	// принадлежит этому экземпляру, иначе ErrOutboxLeaseLost
+	MarkPublished(ctx context.Context, ids []string) error
+	// Release досрочно освобождает право публикации
+	Release(ctx context.Context) error
+}
+
+// CardEventPurger удаляет из outbox обработанные события
+type CardEventPurger interface {
+	// DeleteProcessedEvents удаляет записанные до before события, которые уже
+	// опубликованы и разосланы по подпискам без ожидающих доставок
+	DeleteProcessedEvents(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidCardStatus   = errors.New("unknown card status")
+	ErrInvalidTransition   = errors.New("card status transition is not allowed")
+	ErrTransitionForbidden = errors.New("not enough rights for card status transition")
+	ErrCardStatusConflict  = errors.New("card status was changed concurrently")
+	ErrCardNotEditable     = errors.New("card can be edited only in draft or rejected status")
+	ErrEmptyComment        = errors.New("comment text is empty")
+)
+
+// CardStatus - этап согласования карточки перед публикацией
+type CardStatus string
+
+const (
+	CardStatusDraft     CardStatus = "draft"
+	CardStatusInReview  CardStatus = "in_review"
+	CardStatusApproved  CardStatus = "approved"
+	CardStatusPublished CardStatus = "published"
+	CardStatusRejected  CardStatus = "rejected"
+)
+
+// cardTransitions - разрешенные переходы между статусами.
+// Опубликованная карточка больше не меняет статус.
+var cardTransitions = map[CardStatus][]CardStatus{
+	CardStatusDraft:    {CardStatusInReview},
+	CardStatusInReview: {CardStatusApproved, CardStatusRejected, CardStatusDraft},
+	CardStatusApproved: {CardStatusPublished, CardStatusDraft},
+	CardStatusRejected: {CardStatusDraft},
+}
+
+func (s CardStatus) Valid() bool {
+	switch s {
+	case CardStatusDraft, CardStatusInReview, CardStatusApproved, CardStatusPublished, CardStatusRejected:
+		return true
+	}
+	return false
+}
+
+func (s CardStatus) CanTransitionTo(to CardStatus) bool {
+	for _, allowed := range cardTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Editable - текст карточки можно менять только до отправки на согласование
+func (s CardStatus) Editable() bool {
+	return s == CardStatusDraft || s == CardStatusRejected
+}
+
+// Exportable - выгружать можно только согласованные карточки
+func (s CardStatus) Exportable() bool {
+	return s == CardStatusApproved || s == CardStatusPublished
+}
+
+// CanChangeStatus проверяет права роли на переход в статус to:
+// одобрять и отклонять карточки может только владелец пространства,
+// остальные переходы доступны всем, кто может редактировать карточки
+func (r WorkspaceRole) CanChangeStatus(to CardStatus) bool {
+	if to == CardStatusApproved || to == CardStatusRejected {
+		return r == WorkspaceRoleOwner
+	}
+	return r.CanEdit()
+}
+
+// CardTransition - запись аудита: кто и когда перевел карточку в новый статус
+type CardTransition struct {
+	ID         string     `json:"id"`
+	CardID     string     `json:"card_id"`
+	FromStatus CardStatus `json:"from_status"`
+	ToStatus   CardStatus `json:"to_status"`
+	UserID     string     `json:"user_id"`
+	Comment    string     `json:"comment"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CardComment struct {
+	ID        string    `json:"id"`
+	CardID    string    `json:"card_id"`
+	UserID    string    `json:"user_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CardFilter - условия выборки карточек пространства, пустой список статусов - все карточки
+type CardFilter struct {
+	Statuses []CardStatus
+}
+
+type CardReviewRepository interface {
+	// ChangeCardStatus меняет статус карточки, если он не изменился с момента чтения,
+	// и в той же транзакции записывает переход
+	ChangeCardStatus(ctx context.Context, transition *CardTransition, event *CardEvent) error
+	GetCardTransitions(ctx context.Context, cardID string) ([]*CardTransition, error)
+	AddCardComment(ctx context.Context, comment *CardComment) error
+	GetCardComments(ctx context.Context, cardID string) ([]*CardComment, error)
+}
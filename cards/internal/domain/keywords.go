@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// minStemLen - минимальная длина основы слова, короче не обрезаем
+	minStemLen = 4
+	// minTokenLen - слова короче (предлоги, союзы) не участвуют в сравнении
+	minTokenLen = 3
+)
+
+type Keyword struct {
+	ID        int64     `json:"id"`
+	Phrase    string    `json:"phrase"`
+	Frequency int64     `json:"frequency"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RankedKeyword struct {
+	Keyword *Keyword
+	Score   float64
+}
+
+type KeywordUsage struct {
+	Phrase        string `json:"phrase"`
+	InTitle       bool   `json:"in_title"`
+	InDescription bool   `json:"in_description"`
+}
+
+type KeywordRepository interface {
+	UpsertKeywords(ctx context.Context, keywords []*Keyword) error
+	FindKeywordsByStems(ctx context.Context, stems []string, limit int) ([]*Keyword, error)
+}
+
+// NormalizePhrase приводит поисковую фразу к единому виду: нижний регистр
+// и одиночные пробелы между словами.
+func NormalizePhrase(phrase string) string {
+	return strings.Join(strings.Fields(strings.ToLower(phrase)), " ")
+}
+
+// Stems разбивает текст на слова и возвращает их упрощенные основы.
+// Вместо полноценного морфологического анализа отрезаем окончание,
+// этого хватает, чтобы "кроссовки" и "кроссовок" считались одним словом.
+func Stems(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	stems := make([]string, 0, len(words))
+	for _, w := range words {
+		if utf8.RuneCountInString(w) < minTokenLen {
+			continue
+		}
+		stems = append(stems, stem(w))
+	}
+	return stems
+}
+
+func stem(word string) string {
+	runes := []rune(word)
+	n := len(runes) - 2
+	if n < minStemLen {
+		n = minStemLen
+	}
+	if n > len(runes) {
+		n = len(runes)
+	}
+	return string(runes[:n])
+}
+
+func stemSet(text string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, s := range Stems(text) {
+		set[s] = struct{}{}
+	}
+	return set
+}
+
+// containsAll проверяет, что все слова фразы встречаются в тексте
+func containsAll(set map[string]struct{}, stems []string) bool {
+	if len(stems) == 0 {
+		return false
+	}
+	for _, s := range stems {
+		if _, ok := set[s]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// RankKeywords сортирует ключевые слова по релевантности описанию товара.
+// Релевантность - доля слов фразы, встречающихся в описании, умноженная на
+// логарифм частотности, чтобы частотные запросы поднимались выше.
+func RankKeywords(description string, keywords []*Keyword, limit int) []RankedKeyword {
+	descStems := stemSet(description)
+
+	ranked := make([]RankedKeyword, 0, len(keywords))
+	for _, k := range keywords {
+		phraseStems := Stems(k.Phrase)
+		if len(phraseStems) == 0 {
+			continue
+		}
+
+		matched := 0
+		for _, s := range phraseStems {
+			if _, ok := descStems[s]; ok {
+				matched++
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+
+		coverage := float64(matched) / float64(len(phraseStems))
+		ranked = append(ranked, RankedKeyword{
+			Keyword: k,
+			Score:   coverage * (1 + math.Log10(1+float64(k.Frequency))),
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Keyword.Frequency > ranked[j].Keyword.Frequency
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// BuildKeywordReport показывает, какие из переданных в промпт ключевых слов
+// попали в заголовок и описание карточки.
+func BuildKeywordReport(keywords []string, title, description string) []KeywordUsage {
+	titleStems := stemSet(title)
+	descStems := stemSet(description)
+
+	report := make([]KeywordUsage, 0, len(keywords))
+	for _, k := range keywords {
+		phraseStems := Stems(k)
+		report = append(report, KeywordUsage{
+			Phrase:        k,
+			InTitle:       containsAll(titleStems, phraseStems),
+			InDescription: containsAll(descStems, phraseStems),
+		})
+	}
+	return report
+}
@@ -2,39 +2,89 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var ErrCardNotFound = errors.New("card not found")
+
 type Card struct {
-	ID               string    `json:"id"`
-	UserID           string    `json:"user_id"`
-	PhotoURL         string    `json:"photo_url"`
-	ShortDescription string    `json:"short_description"`
-	Title            string    `json:"title"`
-	Description      string    `json:"description"`
-	Tags             []string  `json:"tags"`
-	Image            string    `json:"image"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	// WorkspaceID - рабочее пространство, участники которого видят карточку
+	WorkspaceID      string     `json:"workspace_id"`
+	PhotoURL         string     `json:"photo_url"`
+	ShortDescription string     `json:"short_description"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	Tags             []string   `json:"tags"`
+	Image            string     `json:"image"`
+	Keywords         []string   `json:"keywords"`
+	BrandProfileID   *string    `json:"brand_profile_id,omitempty"`
+	Status           CardStatus `json:"status"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
+// CardRepository - изменения карточек сохраняются вместе с событием outbox в одной транзакции
 type CardRepository interface {
-	CreateCard(ctx context.Context, card *Card) error
-	GetCardsByUserID(ctx context.Context, userID string) ([]*Card, error)
+	CreateCard(ctx context.Context, card *Card, event *CardEvent) error
+	GetCardsByWorkspaceID(ctx context.Context, workspaceID string, filter CardFilter) ([]*Card, error)
 	GetCardByID(ctx context.Context, id string) (*Card, error)
+	UpdateCard(ctx context.Context, card *Card, event *CardEvent) error
+	DeleteCard(ctx context.Context, id, workspaceID string, event *CardEvent) error
 }
 
 type AuthService interface {
 	ValidateToken(ctx context.Context, token string) (*UserInfo, error)
+	// ValidateAPIKey проверяет персональный API ключ из заголовка X-API-Key
+	ValidateAPIKey(ctx context.Context, key string) (*UserInfo, error)
 }
 
+// Права auth сервиса, которые проверяет cards
+const (
+	// PermissionCardsRead - просмотр карточек, истории, экспорт, подбор ключевых слов и профили бренда
+	PermissionCardsRead = "cards:read"
+	// PermissionCardsWrite - генерация, редактирование и удаление карточек и профилей бренда
+	PermissionCardsWrite = "cards:write"
+	// PermissionKeywordsManage - замена SEO словаря, общего для всех пространств
+	PermissionKeywordsManage = "keywords:manage"
+	// PermissionIntegrationsManage - подписки на вебхуки пространства
+	PermissionIntegrationsManage = "integrations:manage"
+)
+
 type UserInfo struct {
-	UserID string
-	Role   string
+	UserID        string
+	Role          string
+	WorkspaceID   string
+	WorkspaceRole WorkspaceRole
+	EmailVerified bool
+	// Permissions - права пользователя из scope токена или права API ключа
+	Permissions []string
+	// APIKeyID - ключ, по которому выполнен запрос, пустой для запросов с JWT
+	APIKeyID string
+}
+
+func (u *UserInfo) HasPermission(permission string) bool {
+	for _, p := range u.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
 }
 
 type AIService interface {
-	GenerateCardContent(ctx context.Context, photoURL, description string) (*GeneratedCard, error)
+	GenerateCardContent(ctx context.Context, input GenerateCardInput) (*GeneratedCard, error)
+}
+
+type GenerateCardInput struct {
+	PhotoURL    string
+	Description string
+	// Keywords - поисковые запросы, которые модель должна использовать в тексте
+	Keywords []string
+	// BrandProfile - фирменный стиль продавца, может отсутствовать
+	BrandProfile *BrandProfile
 }
 
 type GeneratedCard struct {
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL       = errors.New("webhook url must be an absolute http(s) url")
+	ErrInvalidEventType        = errors.New("unknown event type")
+	ErrDeliveryNotDead         = errors.New("only dead deliveries can be replayed")
+	// ErrWebhookTargetForbidden - адрес получателя во внутренней сети: loopback,
+	// частные, link-local диапазоны и адреса метаданных облака
+	ErrWebhookTargetForbidden = errors.New("webhook target address is not allowed")
+)
+
+// WebhookSubscription - подписка внешней системы на события карточек пространства
+type WebhookSubscription struct {
+	ID          string
+	WorkspaceID string
+	URL         string
+	EventTypes  []CardEventType
+	// Secret - ключ для HMAC подписи тела запроса
+	Secret    string
+	Active    bool
+	CreatedAt time.Time
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead - попытки исчерпаны, доставку можно только переотправить вручную
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery - доставка одного события одному подписчику
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      CardEventType
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      string
+	LastStatusCode int
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+
+	// Заполняются при выборке доставок на отправку
+	URL     string
+	Secret  string
+	Payload []byte
+}
+
+// WebhookBackoff - задержка перед следующей попыткой: 30s, 1m, 2m, ... но не больше часа
+func WebhookBackoff(attempts int) time.Duration {
+	const (
+		base = 30 * time.Second
+		max  = time.Hour
+	)
+
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *WebhookSubscription) error
+	GetSubscriptions(ctx context.Context, workspaceID string) ([]*WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id string) (*WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id, workspaceID string) error
+
+	// FanOutEvents создает доставки для новых событий outbox по активным подпискам
+	// и возвращает количество обработанных событий
+	FanOutEvents(ctx context.Context, limit int) (int, error)
+	// ClaimDeliveries выбирает доставки, время которых пришло, и откладывает их на lease,
+	// чтобы другой экземпляр сервиса не отправил их одновременно
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDelivery, error)
+	SaveDeliveryResult(ctx context.Context, delivery *WebhookDelivery) error
+
+	GetDeliveries(ctx context.Context, subscriptionID string, status WebhookDeliveryStatus) ([]*WebhookDelivery, error)
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+	// ReplayDeliveries возвращает мертвые доставки в очередь
+	ReplayDeliveries(ctx context.Context, subscriptionID string, deliveryIDs []string) (int, error)
+	// SealSecrets шифрует секреты подписок, сохраненные до включения шифрования
+	SealSecrets(ctx context.Context) (int, error)
+}
+
+// WebhookSender отправляет событие подписчику
+type WebhookSender interface {
+	// Send возвращает HTTP статус ответа; ошибка означает, что доставка не удалась
+	Send(ctx context.Context, delivery *WebhookDelivery) (int, error)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "errors"
+
+var ErrWorkspaceRequired = errors.New("workspace is not selected")
+
+// WorkspaceRole - роль пользователя в рабочем пространстве, приходит в claims токена
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+	WorkspaceRoleEditor WorkspaceRole = "editor"
+	WorkspaceRoleViewer WorkspaceRole = "viewer"
+)
+
+// CanRead - история и экспорт карточек доступны всем участникам
+func (r WorkspaceRole) CanRead() bool {
+	return r == WorkspaceRoleOwner || r == WorkspaceRoleEditor || r == WorkspaceRoleViewer
+}
+
+// CanEdit - генерировать, редактировать и удалять карточки могут владелец и редактор
+func (r WorkspaceRole) CanEdit() bool {
+	return r == WorkspaceRoleOwner || r == WorkspaceRoleEditor
+}
+
+// CanManageIntegrations - вебхуки и другие интеграции настраивает только владелец
+func (r WorkspaceRole) CanManageIntegrations() bool {
+	return r == WorkspaceRoleOwner
+}
@@ -0,0 +1,70 @@
+package ports
+
+import (
+	"marketai/cards/internal/domain"
+	"marketai/pkg/http/problem"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// Ошибки API сервиса карточек, не связанные с доменными ошибками
+var (
+	errAPIKeyInvalid       = problem.New(http.StatusUnauthorized, "api_key_invalid", "Недействительный API ключ")
+	errWorkspaceRequired   = problem.New(http.StatusForbidden, "workspace_required", "Рабочее пространство не выбрано")
+	errWorkspaceForbidden  = problem.New(http.StatusForbidden, "workspace_forbidden", "Недостаточно прав в рабочем пространстве")
+	errEmailNotVerified    = problem.New(http.StatusForbidden, "email_not_verified", "Подтвердите email, чтобы генерировать карточки")
+	errFileRequired        = problem.New(http.StatusBadRequest, "file_required", "Файл не передан")
+	errFileUnreadable      = problem.New(http.StatusBadRequest, "file_unreadable", "Не удалось прочитать файл")
+	errKeywordsFileInvalid = problem.New(http.StatusBadRequest, "keywords_file_invalid", "Неверный формат файла")
+)
+
+// domainErrors сопоставляет доменные ошибки с ответами API. Тексты доменных
+// ошибок сервиса на английском, поэтому русский текст задан явно.
+var domainErrors = problem.Mapping{
+	{Target: domain.ErrCardNotFound, Status: http.StatusNotFound, Code: "card_not_found", Message: "Карточка не найдена"},
+	{Target: domain.ErrInvalidCardStatus, Status: http.StatusBadRequest, Code: "card_status_invalid", Message: "Неизвестный статус карточки"},
+	{Target: domain.ErrEmptyComment, Status: http.StatusBadRequest, Code: "comment_empty", Message: "Комментарий не может быть пустым"},
+	{Target: domain.ErrTransitionForbidden, Status: http.StatusForbidden, Code: "card_transition_forbidden", Message: "Недостаточно прав для смены статуса"},
+	{Target: domain.ErrInvalidTransition, Status: http.StatusConflict, Code: "card_transition_invalid", Message: "Переход в этот статус недоступен"},
+	{Target: domain.ErrCardStatusConflict, Status: http.StatusConflict, Code: "card_status_conflict", Message: "Статус карточки уже изменен"},
+	{Target: domain.ErrCardNotEditable, Status: http.StatusConflict, Code: "card_not_editable", Message: "Карточку можно редактировать только в черновике или после отклонения"},
+	{Target: domain.ErrBrandProfileNotFound, Status: http.StatusNotFound, Code: "brand_profile_not_found", Message: "Профиль бренда не найден"},
+	{Target: domain.ErrWorkspaceRequired, Status: http.StatusForbidden, Code: "workspace_required", Message: "Рабочее пространство не выбрано"},
+	{Target: domain.ErrWebhookNotFound, Status: http.StatusNotFound, Code: "webhook_not_found", Message: "Подписка не найдена"},
+	{Target: domain.ErrWebhookDeliveryNotFound, Status: http.StatusNotFound, Code: "webhook_delivery_not_found", Message: "Доставка не найдена"},
+	{Target: domain.ErrInvalidWebhookURL, Status: http.StatusBadRequest, Code: "webhook_url_invalid", Message: "URL должен быть абсолютным http(s) адресом"},
+	{Target: domain.ErrInvalidEventType, Status: http.StatusBadRequest, Code: "event_type_invalid", Message: "Неизвестный тип события"},
+	{Target: domain.ErrDeliveryNotDead, Status: http.StatusConflict, Code: "webhook_delivery_not_dead", Message: "Повторно отправить можно только недоставленные события"},
+}
+
+func init() {
+	problem.RegisterMessages(language.English, map[string]string{
+		errAPIKeyInvalid.Code:        "Invalid API key",
+		errWorkspaceRequired.Code:    "Workspace is not selected",
+		errWorkspaceForbidden.Code:   "Insufficient permissions in the workspace",
+		errEmailNotVerified.Code:     "Verify your email to generate cards",
+		errFileRequired.Code:         "File is missing",
+		errFileUnreadable.Code:       "Failed to read the file",
+		errKeywordsFileInvalid.Code:  "Invalid file format",
+		"card_not_found":             "Card not found",
+		"card_status_invalid":        "Unknown card status",
+		"comment_empty":              "Comment must not be empty",
+		"card_transition_forbidden":  "Insufficient permissions to change the status",
+		"card_transition_invalid":    "This status transition is not allowed",
+		"card_status_conflict":       "Card status has already been changed",
+		"card_not_editable":          "Card can be edited only in draft or rejected status",
+		"brand_profile_not_found":    "Brand profile not found",
+		"webhook_not_found":          "Subscription not found",
+		"webhook_delivery_not_found": "Delivery not found",
+		"webhook_url_invalid":        "URL must be an absolute http(s) address",
+		"event_type_invalid":         "Unknown event type",
+		"webhook_delivery_not_dead":  "Only dead deliveries can be replayed",
+	})
+}
+
+// domainError переводит ошибку приложения в ошибку API. Непредвиденные
+// ошибки становятся 500, их текст попадает только в лог.
+func domainError(err error) error {
+	return domainErrors.Map(err)
+}
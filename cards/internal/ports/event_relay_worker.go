@@ -0,0 +1,65 @@
+package ports
+
+import (
+	"context"
+	"marketai/cards/internal/app"
+	"marketai/cards/internal/app/command"
+	"marketai/cards/internal/config"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRelayBatchSize = 100
+	relayLease            = 30 * time.Second
+)
+
+// runEventRelay периодически публикует события из outbox в шину
+func runEventRelay(lc fx.Lifecycle, cfg *config.Config, a *app.AppCQRS, logger *zap.Logger) {
+	interval := cfg.Events.RelayInterval
+	if interval <= 0 {
+		logger.Info("card events relay disabled")
+		return
+	}
+
+	cmd := command.RelayCardEventsCommand{
+		BatchSize: cfg.Events.BatchSize,
+		Lease:     relayLease,
+	}
+	if cmd.BatchSize <= 0 {
+		cmd.BatchSize = defaultRelayBatchSize
+	}
+
+	runPeriodically(lc, interval, func(ctx context.Context) {
+		published, err := a.Commands.RelayCardEvents.Handle(ctx, cmd)
+		if err != nil {
+			logger.Error("card events relay failed", zap.Int("published", published), zap.Error(err))
+			return
+		}
+		if published > 0 {
+			logger.Debug("card events published", zap.Int("published", published))
+		}
+	})
+}
+
+// runCardEventsRetention удаляет из outbox обработанные события старше срока хранения
+func runCardEventsRetention(lc fx.Lifecycle, cfg *config.Config, a *app.AppCQRS, logger *zap.Logger) {
+	interval := cfg.Events.CleanupInterval
+	if interval <= 0 || cfg.Events.Retention <= 0 {
+		logger.Info("card events retention disabled")
+		return
+	}
+
+	runPeriodically(lc, interval, func(ctx context.Context) {
+		deleted, err := a.Commands.PurgeCardEvents.Handle(ctx)
+		if err != nil {
+			logger.Error("card events cleanup failed", zap.Error(err))
+			return
+		}
+		if deleted > 0 {
+			logger.Info("processed card events purged", zap.Int64("deleted", deleted))
+		}
+	})
+}
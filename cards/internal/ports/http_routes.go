@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"log"
 	"marketai/cards/internal/app"
 	"marketai/cards/internal/app/command"
@@ -9,11 +11,15 @@ import (
 	"marketai/cards/internal/app/query"
 	"marketai/cards/internal/config"
 	"marketai/cards/internal/domain"
+	"marketai/pkg/http/problem"
+	"marketai/pkg/http/ratelimit"
 	"marketai/pkg/logger"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"go.uber.org/fx"
@@ -28,6 +34,7 @@ type httpServer struct {
 	Validator   *validator.Validate
 	App         *app.AppCQRS
 	AuthService domain.AuthService
+	RateLimiter *ratelimit.Limiter `optional:"true"`
 }
 
 type HTTPServer struct {
@@ -52,37 +59,30 @@ func registerRoutes(s httpServer, a *app.AppCQRS, authService domain.AuthService
 	s.Echo.Use(middleware.Logger())
 	s.Echo.Use(middleware.Recover())
 
-	// Middleware для проверки JWT токена
-	//authMiddleware := func(next echo.HandlerFunc) echo.HandlerFunc {
-	//	return func(c echo.Context) error {
-	//		authHeader := c.Request().Header.Get("Authorization")
-	//		if authHeader == "" {
-	//			return echo.NewHTTPError(http.StatusUnauthorized, "Authorization header required")
-	//		}
-	//
-	//		token := strings.TrimPrefix(authHeader, "Bearer ")
-	//		if token == authHeader {
-	//			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authorization header format")
-	//		}
-	//
-	//		userInfo, err := authService.ValidateToken(c.Request().Context(), token)
-	//		if err != nil {
-	//			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
-	//		}
-	//
-	//		c.Set("user_id", userInfo.UserID)
-	//		c.Set("user_role", userInfo.Role)
-	//
-	//		return next(c)
-	//	}
-	//}
-
-	api := s.Echo.Group(s.Config.Http.ApiBasePath)
-	//api.Use(authMiddleware) // Временно отключено для тестирования
-	//s.Echo.POST("/generate", s.generateCardHandler(a))
-	api.POST("/generate", s.generateCardHandler(a))
-	api.GET("/history", s.getCardsHistoryHandler(a))
-	api.GET("/:id", s.getCardByIDHandler(a))
+	api := s.Echo.Group(s.Config.Http.ApiBasePath, authMiddleware(authService), s.RateLimiter.Authenticated(rateLimitSubject))
+	api.POST("/generate", s.generateCardHandler(a), requirePermission(domain.PermissionCardsWrite), canEdit(), requireVerifiedEmail(s.Config.Auth.RequireVerifiedEmail))
+	api.GET("/history", s.getCardsHistoryHandler(a), requirePermission(domain.PermissionCardsRead), canRead())
+	api.GET("/export", s.exportCardsHandler(a), requirePermission(domain.PermissionCardsRead), canRead())
+	api.POST("/keywords/import", s.importKeywordsHandler(a), requirePermission(domain.PermissionKeywordsManage))
+	api.GET("/keywords/suggest", s.suggestKeywordsHandler(a), requirePermission(domain.PermissionCardsRead))
+	api.POST("/profiles", s.createBrandProfileHandler(a), requirePermission(domain.PermissionCardsWrite))
+	api.GET("/profiles", s.getBrandProfilesHandler(a), requirePermission(domain.PermissionCardsRead))
+	api.GET("/profiles/:id", s.getBrandProfileHandler(a), requirePermission(domain.PermissionCardsRead))
+	api.PUT("/profiles/:id", s.updateBrandProfileHandler(a), requirePermission(domain.PermissionCardsWrite))
+	api.DELETE("/profiles/:id", s.deleteBrandProfileHandler(a), requirePermission(domain.PermissionCardsWrite))
+	api.GET("/:id", s.getCardByIDHandler(a), requirePermission(domain.PermissionCardsRead), canRead())
+	api.PATCH("/:id", s.updateCardHandler(a), requirePermission(domain.PermissionCardsWrite), canEdit())
+	api.DELETE("/:id", s.deleteCardHandler(a), requirePermission(domain.PermissionCardsWrite), canEdit())
+	api.POST("/:id/status", s.changeCardStatusHandler(a), requirePermission(domain.PermissionCardsWrite), canRead())
+	api.GET("/:id/review", s.getCardReviewHandler(a), requirePermission(domain.PermissionCardsRead), canRead())
+	api.POST("/:id/comments", s.addCardCommentHandler(a), requirePermission(domain.PermissionCardsWrite), canRead())
+
+	webhooks := api.Group("/webhooks", requirePermission(domain.PermissionIntegrationsManage), canManageIntegrations())
+	webhooks.POST("", s.createWebhookHandler(a))
+	webhooks.GET("", s.getWebhooksHandler(a))
+	webhooks.DELETE("/:id", s.deleteWebhookHandler(a))
+	webhooks.GET("/:id/deliveries", s.getWebhookDeliveriesHandler(a))
+	webhooks.POST("/:id/replay", s.replayWebhookDeliveriesHandler(a))
 }
 
 // @Summary		Генерация карточки товара
@@ -92,8 +92,8 @@ func registerRoutes(s httpServer, a *app.AppCQRS, authService domain.AuthService
 // @Produce		json
 // @Param			input	body		dto.GenerateCardRequest	true	"Данные для генерации карточки"
 // @Success		200		{object}	dto.GenerateCardResponse	"Карточка успешно сгенерирована"
-// @Failure		400		{string}	string					"Неверный формат запроса"
-// @Failure		401		{string}	string					"Неавторизованный доступ"
+// @Failure		400		{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		401		{object}	problem.Problem	"Неавторизованный доступ"
 // @Router			/generate [post]
 func (rc *httpServer) generateCardHandler(a *app.AppCQRS) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -101,67 +101,86 @@ func (rc *httpServer) generateCardHandler(a *app.AppCQRS) echo.HandlerFunc {
 		var req dto.GenerateCardRequest
 
 		if err := c.Bind(&req); err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Неверный формат запроса")
+			return problem.ErrBadRequest
 		}
 
 		if err := rc.Validator.Struct(req); err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Неверные данные запроса")
+			return problem.ErrValidation
 		}
 
-		userID := "test-user" // Временно для тестирования
+		user := userFromContext(c)
+		userID := user.UserID
 
 		result, err := a.Commands.GenerateCard.Handle(ctx, command.GenerateCardCommand{
 			UserID:           userID,
+			WorkspaceID:      user.WorkspaceID,
 			PhotoURL:         req.PhotoURL,
 			ShortDescription: req.ShortDescription,
+			BrandProfileID:   req.BrandProfileID,
 		})
+		if errors.Is(err, domain.ErrBrandProfileNotFound) {
+			return domainError(err)
+		}
 		if err != nil {
 			log.Printf("Ошибка при генерации карточки для пользователя %s: %v", userID, err)
-			return echo.NewHTTPError(http.StatusInternalServerError, "Ошибка при генерации карточки")
+			return problem.ErrInternal
 		}
 
 		response := dto.GenerateCardResponse{
-			ID:          result.Card.ID,
-			Title:       result.Card.Title,
-			Description: result.Card.Description,
-			Tags:        result.Card.Tags,
-			Image:       result.Card.Image,
+			ID:             result.Card.ID,
+			Title:          result.Card.Title,
+			Description:    result.Card.Description,
+			Tags:           result.Card.Tags,
+			Image:          result.Card.Image,
+			KeywordReport:  keywordReportResponse(result.KeywordReport),
+			BrandProfileID: result.Card.BrandProfileID,
+			Status:         string(result.Card.Status),
 		}
 
 		return c.JSON(http.StatusOK, response)
 	}
 }
 
-// @Summary		История карточек пользователя
-// @Description	Возвращает список всех карточек пользователя
+// @Summary		История карточек рабочего пространства
+// @Description	Возвращает список карточек текущего рабочего пространства, можно отфильтровать по статусам
 // @Tags			cards
 // @Produce		json
+// @Param			status	query		string					false	"Статусы через запятую: draft, in_review, approved, published, rejected"
 // @Success		200		{object}	dto.CardHistoryResponse	"Список карточек"
-// @Failure		401		{string}	string					"Неавторизованный доступ"
+// @Failure		400		{object}	problem.Problem	"Неизвестный статус"
+// @Failure		401		{object}	problem.Problem	"Неавторизованный доступ"
+// @Failure		403		{object}	problem.Problem	"Нет права cards:read"
 // @Router			/history [get]
 func (rc *httpServer) getCardsHistoryHandler(a *app.AppCQRS) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-		userID := "test-user" // Временно для тестирования
+		workspaceID := userFromContext(c).WorkspaceID
 
-		result, err := a.Queries.GetCardsByUser.Handle(ctx, query.GetCardsByUserQuery{
-			UserID: userID,
+		result, err := a.Queries.GetCardsByWorkspace.Handle(ctx, query.GetCardsByWorkspaceQuery{
+			WorkspaceID: workspaceID,
+			Statuses:    parseStatuses(c.QueryParam("status")),
 		})
+		if errors.Is(err, domain.ErrInvalidCardStatus) {
+			return domainError(err)
+		}
 		if err != nil {
-			log.Printf("Ошибка при получении истории карточек для пользователя %s: %v", userID, err)
-			return echo.NewHTTPError(http.StatusInternalServerError, "Ошибка при получении истории")
+			log.Printf("Ошибка при получении истории карточек пространства %s: %v", workspaceID, err)
+			return problem.ErrInternal
 		}
 
 		var cards []dto.CardInfo
 		for _, card := range result.Cards {
 			cards = append(cards, dto.CardInfo{
 				ID:               card.ID,
+				UserID:           card.UserID,
 				PhotoURL:         card.PhotoURL,
 				ShortDescription: card.ShortDescription,
 				Title:            card.Title,
 				Description:      card.Description,
 				Tags:             card.Tags,
 				Image:            card.Image,
+				BrandProfileID:   card.BrandProfileID,
+				Status:           string(card.Status),
 				CreatedAt:        card.CreatedAt.Format(time.RFC3339),
 			})
 		}
@@ -177,33 +196,623 @@ func (rc *httpServer) getCardsHistoryHandler(a *app.AppCQRS) echo.HandlerFunc {
 // @Produce		json
 // @Param			id	path		string	true	"ID карточки"
 // @Success		200	{object}	dto.CardDetailResponse	"Детали карточки"
-// @Failure		401	{string}	string					"Неавторизованный доступ"
-// @Failure		404	{string}	string					"Карточка не найдена"
+// @Failure		401	{object}	problem.Problem	"Неавторизованный доступ"
+// @Failure		404	{object}	problem.Problem	"Карточка не найдена"
 // @Router			/{id} [get]
 func (rc *httpServer) getCardByIDHandler(a *app.AppCQRS) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-		cardID := c.Param("id")
+		cardID, err := pathID(c, domain.ErrCardNotFound)
+		if err != nil {
+			return err
+		}
 
 		result, err := a.Queries.GetCardByID.Handle(ctx, query.GetCardByIDQuery{
-			CardID: cardID,
+			CardID:      cardID,
+			WorkspaceID: userFromContext(c).WorkspaceID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, cardDetailResponse(result.Card))
+	}
+}
+
+// @Summary		Редактирование карточки
+// @Description	Изменяет заголовок, описание и теги карточки в статусе draft или rejected. Доступно владельцу и редактору пространства
+// @Tags			cards
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string					true	"ID карточки"
+// @Param			input	body		dto.UpdateCardRequest	true	"Изменяемые поля"
+// @Success		200		{object}	dto.CardDetailResponse	"Карточка обновлена"
+// @Failure		400		{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		403		{object}	problem.Problem	"Недостаточно прав"
+// @Failure		404		{object}	problem.Problem	"Карточка не найдена"
+// @Failure		409		{object}	problem.Problem	"Карточка на согласовании или уже согласована"
+// @Router			/{id} [patch]
+func (rc *httpServer) updateCardHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		cardID, err := pathID(c, domain.ErrCardNotFound)
+		if err != nil {
+			return err
+		}
+
+		var req dto.UpdateCardRequest
+
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		if err := rc.Validator.Struct(req); err != nil {
+			return problem.ErrValidation
+		}
+
+		result, err := a.Commands.UpdateCard.Handle(ctx, command.UpdateCardCommand{
+			CardID:      cardID,
+			WorkspaceID: userFromContext(c).WorkspaceID,
+			Title:       req.Title,
+			Description: req.Description,
+			Tags:        req.Tags,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, cardDetailResponse(result.Card))
+	}
+}
+
+// @Summary		Удаление карточки
+// @Description	Удаляет карточку. Доступно владельцу и редактору пространства
+// @Tags			cards
+// @Param			id	path	string	true	"ID карточки"
+// @Success		204
+// @Failure		403	{object}	problem.Problem	"Недостаточно прав"
+// @Failure		404	{object}	problem.Problem	"Карточка не найдена"
+// @Router			/{id} [delete]
+func (rc *httpServer) deleteCardHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		cardID, err := pathID(c, domain.ErrCardNotFound)
+		if err != nil {
+			return err
+		}
+
+		err = a.Commands.DeleteCard.Handle(ctx, command.DeleteCardCommand{
+			CardID:      cardID,
+			WorkspaceID: userFromContext(c).WorkspaceID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Смена статуса карточки
+// @Description	Переводит карточку по этапам согласования: draft → in_review → approved → published.
+// @Description	Одобрять и отклонять (rejected) может только владелец пространства
+// @Tags			review
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"ID карточки"
+// @Param			input	body		dto.ChangeCardStatusRequest	true	"Новый статус и комментарий"
+// @Success		200		{object}	dto.CardDetailResponse		"Статус изменен"
+// @Failure		400		{object}	problem.Problem	"Неизвестный статус"
+// @Failure		403		{object}	problem.Problem	"Недостаточно прав"
+// @Failure		404		{object}	problem.Problem	"Карточка не найдена"
+// @Failure		409		{object}	problem.Problem	"Переход недоступен"
+// @Router			/{id}/status [post]
+func (rc *httpServer) changeCardStatusHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		cardID, err := pathID(c, domain.ErrCardNotFound)
+		if err != nil {
+			return err
+		}
+
+		var req dto.ChangeCardStatusRequest
+
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		if err := rc.Validator.Struct(req); err != nil {
+			return problem.ErrValidation
+		}
+
+		user := userFromContext(c)
+		result, err := a.Commands.ChangeCardStatus.Handle(ctx, command.ChangeCardStatusCommand{
+			CardID:        cardID,
+			WorkspaceID:   user.WorkspaceID,
+			UserID:        user.UserID,
+			WorkspaceRole: user.WorkspaceRole,
+			Status:        domain.CardStatus(req.Status),
+			Comment:       req.Comment,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, cardDetailResponse(result.Card))
+	}
+}
+
+// @Summary		История согласования карточки
+// @Description	Возвращает переходы между статусами с автором и временем, а также комментарии рецензентов
+// @Tags			review
+// @Produce		json
+// @Param			id	path		string					true	"ID карточки"
+// @Success		200	{object}	dto.CardReviewResponse	"История согласования"
+// @Failure		404	{object}	problem.Problem	"Карточка не найдена"
+// @Router			/{id}/review [get]
+func (rc *httpServer) getCardReviewHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		cardID, err := pathID(c, domain.ErrCardNotFound)
+		if err != nil {
+			return err
+		}
+
+		result, err := a.Queries.GetCardReview.Handle(ctx, query.GetCardReviewQuery{
+			CardID:      cardID,
+			WorkspaceID: userFromContext(c).WorkspaceID,
 		})
 		if err != nil {
-			log.Printf("Ошибка при получении карточки %s: %v", cardID, err)
-			return echo.NewHTTPError(http.StatusNotFound, "Карточка не найдена")
+			return domainError(err)
 		}
 
-		response := dto.CardDetailResponse{
-			ID:               result.Card.ID,
-			PhotoURL:         result.Card.PhotoURL,
-			ShortDescription: result.Card.ShortDescription,
-			Title:            result.Card.Title,
-			Description:      result.Card.Description,
-			Tags:             result.Card.Tags,
-			Image:            result.Card.Image,
-			CreatedAt:        result.Card.CreatedAt.Format(time.RFC3339),
+		response := dto.CardReviewResponse{
+			Transitions: make([]dto.CardTransition, 0, len(result.Transitions)),
+			Comments:    make([]dto.CardComment, 0, len(result.Comments)),
+		}
+		for _, t := range result.Transitions {
+			response.Transitions = append(response.Transitions, dto.CardTransition{
+				ID:         t.ID,
+				FromStatus: string(t.FromStatus),
+				ToStatus:   string(t.ToStatus),
+				UserID:     t.UserID,
+				Comment:    t.Comment,
+				CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+			})
+		}
+		for _, cm := range result.Comments {
+			response.Comments = append(response.Comments, cardCommentResponse(cm))
 		}
 
 		return c.JSON(http.StatusOK, response)
 	}
 }
+
+// @Summary		Комментарий к карточке
+// @Description	Добавляет комментарий рецензента к карточке
+// @Tags			review
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"ID карточки"
+// @Param			input	body		dto.AddCardCommentRequest	true	"Текст комментария"
+// @Success		201		{object}	dto.CardComment				"Комментарий добавлен"
+// @Failure		400		{object}	problem.Problem	"Пустой комментарий"
+// @Failure		404		{object}	problem.Problem	"Карточка не найдена"
+// @Router			/{id}/comments [post]
+func (rc *httpServer) addCardCommentHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		cardID, err := pathID(c, domain.ErrCardNotFound)
+		if err != nil {
+			return err
+		}
+
+		var req dto.AddCardCommentRequest
+
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		if err := rc.Validator.Struct(req); err != nil {
+			return problem.ErrValidation
+		}
+
+		user := userFromContext(c)
+		comment, err := a.Commands.AddCardComment.Handle(ctx, command.AddCardCommentCommand{
+			CardID:      cardID,
+			WorkspaceID: user.WorkspaceID,
+			UserID:      user.UserID,
+			Text:        req.Text,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusCreated, cardCommentResponse(comment))
+	}
+}
+
+// @Summary		Экспорт карточек
+// @Description	Выгружает согласованные и опубликованные карточки текущего рабочего пространства в CSV
+// @Tags			cards
+// @Produce		text/csv
+// @Success		200	{file}		file	"CSV файл"
+// @Failure		403	{object}	problem.Problem	"Нет права cards:read или недостаточно прав в пространстве"
+// @Router			/export [get]
+func (rc *httpServer) exportCardsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		workspaceID := userFromContext(c).WorkspaceID
+
+		// Несогласованные карточки не выгружаются
+		result, err := a.Queries.GetCardsByWorkspace.Handle(ctx, query.GetCardsByWorkspaceQuery{
+			WorkspaceID: workspaceID,
+			Statuses:    []domain.CardStatus{domain.CardStatusApproved, domain.CardStatusPublished},
+		})
+		if err != nil {
+			log.Printf("Ошибка при экспорте карточек пространства %s: %v", workspaceID, err)
+			return problem.ErrInternal
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="cards.csv"`)
+		c.Response().WriteHeader(http.StatusOK)
+
+		w := csv.NewWriter(c.Response())
+		w.Write([]string{"id", "status", "title", "description", "tags", "photo_url", "created_at"})
+		for _, card := range result.Cards {
+			w.Write([]string{
+				card.ID,
+				string(card.Status),
+				card.Title,
+				card.Description,
+				strings.Join(card.Tags, ", "),
+				card.PhotoURL,
+				card.CreatedAt.Format(time.RFC3339),
+			})
+		}
+		w.Flush()
+
+		return w.Error()
+	}
+}
+
+// @Summary		Импорт SEO словаря
+// @Description	Загружает поисковые запросы и их частотность из CSV файла (фраза, частотность). Словарь общий для всех пространств, требуется право keywords:manage.
+// @Tags			keywords
+// @Accept			multipart/form-data
+// @Produce		json
+// @Param			file	formData	file						true	"CSV файл"
+// @Success		200		{object}	dto.ImportKeywordsResponse	"Результат импорта"
+// @Failure		400		{object}	problem.Problem	"Неверный формат файла"
+// @Router			/keywords/import [post]
+func (rc *httpServer) importKeywordsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return errFileRequired
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return errFileUnreadable
+		}
+		defer file.Close()
+
+		result, err := a.Commands.ImportKeywords.Handle(ctx, command.ImportKeywordsCommand{
+			File: file,
+		})
+		if err != nil {
+			log.Printf("Ошибка при импорте ключевых слов: %v", err)
+			return errKeywordsFileInvalid
+		}
+
+		return c.JSON(http.StatusOK, dto.ImportKeywordsResponse{
+			Imported: result.Imported,
+			Skipped:  result.Skipped,
+		})
+	}
+}
+
+// @Summary		Подбор ключевых слов
+// @Description	Возвращает поисковые запросы из словаря, отсортированные по релевантности описанию товара
+// @Tags			keywords
+// @Produce		json
+// @Param			description	query		string						true	"Краткое описание товара"
+// @Param			limit		query		int							false	"Количество запросов"
+// @Success		200			{object}	dto.SuggestKeywordsResponse	"Ключевые слова"
+// @Failure		400			{object}	problem.Problem	"Неверные данные запроса"
+// @Router			/keywords/suggest [get]
+func (rc *httpServer) suggestKeywordsHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var req dto.SuggestKeywordsRequest
+
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		if err := rc.Validator.Struct(req); err != nil {
+			return problem.ErrValidation
+		}
+
+		result, err := a.Queries.SuggestKeywords.Handle(ctx, query.SuggestKeywordsQuery{
+			Description: req.Description,
+			Limit:       req.Limit,
+		})
+		if err != nil {
+			log.Printf("Ошибка при подборе ключевых слов: %v", err)
+			return problem.ErrInternal
+		}
+
+		keywords := make([]dto.SuggestedKeyword, 0, len(result.Keywords))
+		for _, k := range result.Keywords {
+			keywords = append(keywords, dto.SuggestedKeyword{
+				Phrase:    k.Keyword.Phrase,
+				Frequency: k.Keyword.Frequency,
+				Score:     k.Score,
+			})
+		}
+
+		return c.JSON(http.StatusOK, dto.SuggestKeywordsResponse{Keywords: keywords})
+	}
+}
+
+// @Summary		Создание профиля бренда
+// @Description	Создает профиль с тоном текста, постоянными блоками и запрещенными словами
+// @Tags			profiles
+// @Accept			json
+// @Produce		json
+// @Param			input	body		dto.BrandProfileRequest		true	"Профиль бренда"
+// @Success		200		{object}	dto.BrandProfileResponse	"Профиль создан"
+// @Failure		400		{object}	problem.Problem	"Неверный формат запроса"
+// @Router			/profiles [post]
+func (rc *httpServer) createBrandProfileHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var req dto.BrandProfileRequest
+
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		if err := rc.Validator.Struct(req); err != nil {
+			return problem.ErrValidation
+		}
+
+		userID := userFromContext(c).UserID
+
+		result, err := a.Commands.CreateBrandProfile.Handle(ctx, brandProfileCommand(req, "", userID))
+		if err != nil {
+			log.Printf("Ошибка при создании профиля бренда для пользователя %s: %v", userID, err)
+			return problem.ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, brandProfileResponse(result.Profile))
+	}
+}
+
+// @Summary		Профили бренда пользователя
+// @Description	Возвращает список профилей бренда пользователя
+// @Tags			profiles
+// @Produce		json
+// @Success		200	{object}	dto.BrandProfilesResponse	"Список профилей"
+// @Router			/profiles [get]
+func (rc *httpServer) getBrandProfilesHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userID := userFromContext(c).UserID
+
+		result, err := a.Queries.GetBrandProfiles.Handle(ctx, query.GetBrandProfilesQuery{
+			UserID: userID,
+		})
+		if err != nil {
+			log.Printf("Ошибка при получении профилей бренда для пользователя %s: %v", userID, err)
+			return problem.ErrInternal
+		}
+
+		profiles := make([]dto.BrandProfileResponse, 0, len(result.Profiles))
+		for _, p := range result.Profiles {
+			profiles = append(profiles, brandProfileResponse(p))
+		}
+
+		return c.JSON(http.StatusOK, dto.BrandProfilesResponse{Profiles: profiles})
+	}
+}
+
+// @Summary		Получение профиля бренда
+// @Description	Возвращает профиль бренда по ID
+// @Tags			profiles
+// @Produce		json
+// @Param			id	path		string						true	"ID профиля"
+// @Success		200	{object}	dto.BrandProfileResponse	"Профиль бренда"
+// @Failure		404	{object}	problem.Problem	"Профиль не найден"
+// @Router			/profiles/{id} [get]
+func (rc *httpServer) getBrandProfileHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userID := userFromContext(c).UserID
+
+		profileID, err := pathID(c, domain.ErrBrandProfileNotFound)
+		if err != nil {
+			return err
+		}
+
+		result, err := a.Queries.GetBrandProfileByID.Handle(ctx, query.GetBrandProfileByIDQuery{
+			ProfileID: profileID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, brandProfileResponse(result.Profile))
+	}
+}
+
+// @Summary		Обновление профиля бренда
+// @Description	Полностью заменяет настройки профиля бренда
+// @Tags			profiles
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"ID профиля"
+// @Param			input	body		dto.BrandProfileRequest		true	"Профиль бренда"
+// @Success		200		{object}	dto.BrandProfileResponse	"Профиль обновлен"
+// @Failure		400		{object}	problem.Problem	"Неверный формат запроса"
+// @Failure		404		{object}	problem.Problem	"Профиль не найден"
+// @Router			/profiles/{id} [put]
+func (rc *httpServer) updateBrandProfileHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var req dto.BrandProfileRequest
+
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		if err := rc.Validator.Struct(req); err != nil {
+			return problem.ErrValidation
+		}
+
+		profileID, err := pathID(c, domain.ErrBrandProfileNotFound)
+		if err != nil {
+			return err
+		}
+
+		userID := userFromContext(c).UserID
+
+		result, err := a.Commands.UpdateBrandProfile.Handle(ctx, brandProfileCommand(req, profileID, userID))
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, brandProfileResponse(result.Profile))
+	}
+}
+
+// @Summary		Удаление профиля бренда
+// @Description	Удаляет профиль бренда, у созданных с ним карточек ссылка на профиль обнуляется
+// @Tags			profiles
+// @Param			id	path	string	true	"ID профиля"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"Профиль не найден"
+// @Router			/profiles/{id} [delete]
+func (rc *httpServer) deleteBrandProfileHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userID := userFromContext(c).UserID
+
+		profileID, err := pathID(c, domain.ErrBrandProfileNotFound)
+		if err != nil {
+			return err
+		}
+
+		err = a.Commands.DeleteBrandProfile.Handle(ctx, command.DeleteBrandProfileCommand{
+			ID:     profileID,
+			UserID: userID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// pathID возвращает ID из пути запроса. ID в базе - UUID, поэтому
+// строка другого формата сразу означает, что объекта нет.
+func pathID(c echo.Context, notFound error) (string, error) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", domainError(notFound)
+	}
+	return id, nil
+}
+
+func cardDetailResponse(card *domain.Card) dto.CardDetailResponse {
+	return dto.CardDetailResponse{
+		ID:               card.ID,
+		PhotoURL:         card.PhotoURL,
+		ShortDescription: card.ShortDescription,
+		Title:            card.Title,
+		Description:      card.Description,
+		Tags:             card.Tags,
+		Image:            card.Image,
+		KeywordReport: keywordReportResponse(domain.BuildKeywordReport(
+			card.Keywords,
+			card.Title,
+			card.Description,
+		)),
+		BrandProfileID: card.BrandProfileID,
+		Status:         string(card.Status),
+		CreatedAt:      card.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func cardCommentResponse(comment *domain.CardComment) dto.CardComment {
+	return dto.CardComment{
+		ID:        comment.ID,
+		UserID:    comment.UserID,
+		Text:      comment.Text,
+		CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// parseStatuses разбирает список статусов из query параметра вида "draft,in_review"
+func parseStatuses(raw string) []domain.CardStatus {
+	var statuses []domain.CardStatus
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			statuses = append(statuses, domain.CardStatus(s))
+		}
+	}
+	return statuses
+}
+
+func brandProfileCommand(req dto.BrandProfileRequest, id, userID string) command.SaveBrandProfileCommand {
+	return command.SaveBrandProfileCommand{
+		ID:           id,
+		UserID:       userID,
+		Name:         req.Name,
+		Tone:         req.Tone,
+		Intro:        req.Intro,
+		Outro:        req.Outro,
+		WarrantyText: req.WarrantyText,
+		BannedWords:  req.BannedWords,
+		NoEmoji:      req.NoEmoji,
+	}
+}
+
+func brandProfileResponse(p *domain.BrandProfile) dto.BrandProfileResponse {
+	bannedWords := p.BannedWords
+	if bannedWords == nil {
+		bannedWords = []string{}
+	}
+
+	return dto.BrandProfileResponse{
+		ID:           p.ID,
+		Name:         p.Name,
+		Tone:         p.Tone,
+		Intro:        p.Intro,
+		Outro:        p.Outro,
+		WarrantyText: p.WarrantyText,
+		BannedWords:  bannedWords,
+		NoEmoji:      p.NoEmoji,
+		CreatedAt:    p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func keywordReportResponse(report []domain.KeywordUsage) []dto.KeywordUsage {
+	res := make([]dto.KeywordUsage, 0, len(report))
+	for _, k := range report {
+		res = append(res, dto.KeywordUsage{
+			Phrase:        k.Phrase,
+			InTitle:       k.InTitle,
+			InDescription: k.InDescription,
+		})
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+package ports
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"marketai/cards/internal/app"
+	"marketai/cards/internal/config"
+	"marketai/cards/internal/domain"
+	"marketai/pkg/bootstrap"
+	pkghttp "marketai/pkg/http"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// keyAuthService принимает любой API ключ как ключ владельца пространства с правами permissions
+type keyAuthService struct {
+	permissions []string
+}
+
+func (s keyAuthService) ValidateToken(context.Context, string) (*domain.UserInfo, error) {
+	return nil, domain.ErrCardNotFound
+}
+
+func (s keyAuthService) ValidateAPIKey(context.Context, string) (*domain.UserInfo, error) {
+	return &domain.UserInfo{
+		UserID:        "6f1c3c1e-5d0a-4a55-9b0e-0c8f7f7b2a10",
+		WorkspaceID:   "a4b7f1d2-3c9e-4f60-8a21-5e7d9c0b1f33",
+		WorkspaceRole: domain.WorkspaceRoleOwner,
+		EmailVerified: true,
+		Permissions:   s.permissions,
+		APIKeyID:      "key-1",
+	}, nil
+}
+
+func TestRoutesRequireKeyScopes(t *testing.T) {
+	const cardPath = "/api/v1/cards/0b6f7c1a-2e4d-4c8b-9a3f-1d5e6f7a8b9c"
+
+	tests := []struct {
+		name        string
+		permissions []string
+		method      string
+		path        string
+	}{
+		{"read-only key edits card", []string{domain.PermissionCardsRead}, http.MethodPatch, cardPath},
+		{"read-only key deletes card", []string{domain.PermissionCardsRead}, http.MethodDelete, cardPath},
+		{"read-only key changes status", []string{domain.PermissionCardsRead}, http.MethodPost, cardPath + "/status"},
+		{"read-only key comments", []string{domain.PermissionCardsRead}, http.MethodPost, cardPath + "/comments"},
+		{"read-only key creates profile", []string{domain.PermissionCardsRead}, http.MethodPost, "/api/v1/cards/profiles"},
+		{"read-only key deletes profile", []string{domain.PermissionCardsRead}, http.MethodDelete, "/api/v1/cards/profiles/0b6f7c1a-2e4d-4c8b-9a3f-1d5e6f7a8b9c"},
+		{"cards key registers webhook", []string{domain.PermissionCardsRead, domain.PermissionCardsWrite}, http.MethodPost, "/api/v1/cards/webhooks"},
+		{"write-only key reads card", []string{domain.PermissionCardsWrite}, http.MethodGet, cardPath},
+		{"write-only key reads review", []string{domain.PermissionCardsWrite}, http.MethodGet, cardPath + "/review"},
+		{"write-only key lists profiles", []string{domain.PermissionCardsWrite}, http.MethodGet, "/api/v1/cards/profiles"},
+		{"write-only key suggests keywords", []string{domain.PermissionCardsWrite}, http.MethodGet, "/api/v1/cards/keywords/suggest"},
+		{"cards key lists webhooks", []string{domain.PermissionCardsRead, domain.PermissionCardsWrite}, http.MethodGet, "/api/v1/cards/webhooks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = pkghttp.GetErrorHandler(zap.NewNop())
+			registerRoutes(httpServer{
+				Config:    &config.Config{Http: &bootstrap.HttpConfig{ApiBasePath: "/api/v1/cards"}},
+				Echo:      e,
+				Validator: validator.New(),
+				App:       &app.AppCQRS{},
+			}, &app.AppCQRS{}, keyAuthService{permissions: tt.permissions})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(apiKeyHeader, "mai_test")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusForbidden {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusForbidden, rec.Body.String())
+			}
+		})
+	}
+}
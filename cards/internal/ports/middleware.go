@@ -0,0 +1,132 @@
+package ports
+
+import (
+	"errors"
+	"strings"
+
+	"marketai/cards/internal/domain"
+	"marketai/pkg/http/problem"
+	"marketai/pkg/http/ratelimit"
+	"marketai/pkgAuth/jwt"
+
+	"github.com/labstack/echo/v4"
+)
+
+const userContextKey = "user"
+
+const apiKeyHeader = "X-API-Key"
+
+// authMiddleware проверяет Bearer токен или API ключ из X-API-Key в auth сервисе
+// и сохраняет данные пользователя в контексте
+func authMiddleware(authService domain.AuthService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if apiKey := c.Request().Header.Get(apiKeyHeader); apiKey != "" {
+				userInfo, err := authService.ValidateAPIKey(c.Request().Context(), apiKey)
+				if err != nil {
+					return authFailure(err, errAPIKeyInvalid.Wrap)
+				}
+
+				c.Set(userContextKey, userInfo)
+				return next(c)
+			}
+
+			authHeader := c.Request().Header.Get("Authorization")
+			if authHeader == "" {
+				return jwt.ErrAuthRequired
+			}
+
+			token := strings.TrimPrefix(authHeader, "Bearer ")
+			if token == authHeader {
+				return jwt.ErrAuthMalformed
+			}
+
+			userInfo, err := authService.ValidateToken(c.Request().Context(), token)
+			if err != nil {
+				return authFailure(err, jwt.AuthError)
+			}
+
+			c.Set(userContextKey, userInfo)
+			return next(c)
+		}
+	}
+}
+
+// rateLimitSubject - пользователь и API ключ, проверенные authMiddleware, для лимитов по ним
+func rateLimitSubject(c echo.Context) ratelimit.Subject {
+	user := userFromContext(c)
+	return ratelimit.Subject{UserID: user.UserID, APIKeyID: user.APIKeyID}
+}
+
+// authFailure возвращает ошибку API от auth сервиса как есть (503, 429 и т.д.),
+// а отказ в проверке токена или ключа превращает в 401 через unauthorized
+func authFailure(err error, unauthorized func(error) *problem.Error) error {
+	var e *problem.Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return unauthorized(err)
+}
+
+// requireWorkspaceRole пропускает запрос, если роль пользователя в текущем
+// пространстве удовлетворяет проверке allowed
+func requireWorkspaceRole(allowed func(domain.WorkspaceRole) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := userFromContext(c)
+			if user.WorkspaceID == "" {
+				return errWorkspaceRequired
+			}
+			if !allowed(user.WorkspaceRole) {
+				return errWorkspaceForbidden
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func canRead() echo.MiddlewareFunc {
+	return requireWorkspaceRole(domain.WorkspaceRole.CanRead)
+}
+
+func canEdit() echo.MiddlewareFunc {
+	return requireWorkspaceRole(domain.WorkspaceRole.CanEdit)
+}
+
+func canManageIntegrations() echo.MiddlewareFunc {
+	return requireWorkspaceRole(domain.WorkspaceRole.CanManageIntegrations)
+}
+
+// requirePermission пропускает запрос, если в токене есть право permission
+func requirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !userFromContext(c).HasPermission(permission) {
+				return problem.ErrForbidden
+			}
+			return next(c)
+		}
+	}
+}
+
+// requireVerifiedEmail не пускает пользователей с неподтвержденным email,
+// если это включено настройкой auth.require_verified_email
+func requireVerifiedEmail(required bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if required && !userFromContext(c).EmailVerified {
+				return errEmailNotVerified
+			}
+			return next(c)
+		}
+	}
+}
+
+func userFromContext(c echo.Context) *domain.UserInfo {
+	user, ok := c.Get(userContextKey).(*domain.UserInfo)
+	if !ok {
+		return &domain.UserInfo{}
+	}
+	return user
+}
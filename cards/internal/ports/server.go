@@ -6,7 +6,6 @@ import (
 	"marketai/cards/internal/adapters/postgres"
 	"marketai/cards/internal/app"
 	"marketai/cards/internal/config"
-	"marketai/cards/internal/domain"
 	"marketai/pkg/bootstrap"
 	"marketai/pkg/postgresql"
 
@@ -27,15 +26,19 @@ func App() fx.Option {
 			fx.Provide(
 				app.NewAppCQRS,
 				postgres.NewCardRepository,
+				postgres.NewKeywordRepository,
+				postgres.NewBrandProfileRepository,
+				postgres.NewCardReviewRepository,
+				postgres.NewWebhookRepository,
+				postgres.NewOutboxRepository,
 				adapters.NewAuthGRPCService,
 				adapters.NewOpenAIService,
-				fx.Annotate(
-					func(authService *adapters.AuthGRPCService) domain.AuthService {
-						return authService
-					},
-					fx.As(new(domain.AuthService)),
-				),
+				adapters.NewHTTPWebhookSender,
+				adapters.NewEventPublisher,
+				adapters.NewAuthService,
+				newWebhookSecretBox,
 			),
+			fx.Invoke(sealWebhookSecrets, runWebhookDispatcher, runEventRelay, runCardEventsRetention),
 		),
 	)
 }
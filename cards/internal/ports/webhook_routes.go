@@ -0,0 +1,219 @@
+package ports
+
+import (
+	"marketai/cards/internal/app"
+	"marketai/cards/internal/app/command"
+	"marketai/cards/internal/app/dto"
+	"marketai/cards/internal/app/query"
+	"marketai/cards/internal/domain"
+	"marketai/pkg/http/problem"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary		Создание подписки на вебхуки
+// @Description	Подписывает URL на события карточек пространства. Секрет для проверки подписи возвращается только в ответе на создание
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Param			input	body		dto.CreateWebhookRequest	true	"URL, типы событий и секрет"
+// @Success		201		{object}	dto.WebhookResponse			"Подписка создана"
+// @Failure		400		{object}	problem.Problem	"Неверные данные запроса"
+// @Failure		403		{object}	problem.Problem	"Недостаточно прав"
+// @Router			/webhooks [post]
+func (rc *httpServer) createWebhookHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var req dto.CreateWebhookRequest
+
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		if err := rc.Validator.Struct(req); err != nil {
+			return problem.ErrValidation
+		}
+
+		eventTypes := make([]domain.CardEventType, 0, len(req.EventTypes))
+		for _, t := range req.EventTypes {
+			eventTypes = append(eventTypes, domain.CardEventType(t))
+		}
+
+		sub, err := a.Commands.CreateWebhook.Handle(ctx, command.CreateWebhookCommand{
+			WorkspaceID: userFromContext(c).WorkspaceID,
+			URL:         req.URL,
+			EventTypes:  eventTypes,
+			Secret:      req.Secret,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		response := webhookResponse(sub)
+		response.Secret = sub.Secret
+		return c.JSON(http.StatusCreated, response)
+	}
+}
+
+// @Summary		Подписки на вебхуки
+// @Description	Возвращает подписки текущего рабочего пространства
+// @Tags			webhooks
+// @Produce		json
+// @Success		200	{object}	dto.WebhooksResponse	"Список подписок"
+// @Failure		403	{object}	problem.Problem	"Недостаточно прав"
+// @Router			/webhooks [get]
+func (rc *httpServer) getWebhooksHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		subs, err := a.Queries.GetWebhooks.Handle(ctx, query.GetWebhooksQuery{
+			WorkspaceID: userFromContext(c).WorkspaceID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		webhooks := make([]dto.WebhookResponse, 0, len(subs))
+		for _, sub := range subs {
+			webhooks = append(webhooks, webhookResponse(sub))
+		}
+
+		return c.JSON(http.StatusOK, dto.WebhooksResponse{Webhooks: webhooks})
+	}
+}
+
+// @Summary		Удаление подписки на вебхуки
+// @Tags			webhooks
+// @Param			id	path	string	true	"ID подписки"
+// @Success		204
+// @Failure		404	{object}	problem.Problem	"Подписка не найдена"
+// @Router			/webhooks/{id} [delete]
+func (rc *httpServer) deleteWebhookHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		subscriptionID, err := pathID(c, domain.ErrWebhookNotFound)
+		if err != nil {
+			return err
+		}
+
+		err = a.Commands.DeleteWebhook.Handle(ctx, command.DeleteWebhookCommand{
+			ID:          subscriptionID,
+			WorkspaceID: userFromContext(c).WorkspaceID,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Доставки вебхука
+// @Description	Возвращает последние доставки подписки, можно отфильтровать по статусу: pending, delivered, dead
+// @Tags			webhooks
+// @Produce		json
+// @Param			id		path		string							true	"ID подписки"
+// @Param			status	query		string							false	"Статус доставки"
+// @Success		200		{object}	dto.WebhookDeliveriesResponse	"Доставки"
+// @Failure		404		{object}	problem.Problem	"Подписка не найдена"
+// @Router			/webhooks/{id}/deliveries [get]
+func (rc *httpServer) getWebhookDeliveriesHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		subscriptionID, err := pathID(c, domain.ErrWebhookNotFound)
+		if err != nil {
+			return err
+		}
+
+		deliveries, err := a.Queries.GetWebhookDeliveries.Handle(ctx, query.GetWebhookDeliveriesQuery{
+			SubscriptionID: subscriptionID,
+			WorkspaceID:    userFromContext(c).WorkspaceID,
+			Status:         domain.WebhookDeliveryStatus(c.QueryParam("status")),
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		response := dto.WebhookDeliveriesResponse{
+			Deliveries: make([]dto.WebhookDelivery, 0, len(deliveries)),
+		}
+		for _, d := range deliveries {
+			response.Deliveries = append(response.Deliveries, webhookDeliveryResponse(d))
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// @Summary		Повторная отправка вебхуков
+// @Description	Возвращает в очередь мертвые доставки подписки: переданные или все, если список пуст
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string							true	"ID подписки"
+// @Param			input	body		dto.ReplayDeliveriesRequest		false	"ID доставок"
+// @Success		200		{object}	dto.ReplayDeliveriesResponse	"Количество доставок в очереди"
+// @Failure		404		{object}	problem.Problem	"Подписка не найдена"
+// @Router			/webhooks/{id}/replay [post]
+func (rc *httpServer) replayWebhookDeliveriesHandler(a *app.AppCQRS) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		subscriptionID, err := pathID(c, domain.ErrWebhookNotFound)
+		if err != nil {
+			return err
+		}
+
+		var req dto.ReplayDeliveriesRequest
+
+		if err := c.Bind(&req); err != nil {
+			return problem.ErrBadRequest
+		}
+
+		replayed, err := a.Commands.ReplayWebhookDeliveries.Handle(ctx, command.ReplayWebhookDeliveriesCommand{
+			SubscriptionID: subscriptionID,
+			WorkspaceID:    userFromContext(c).WorkspaceID,
+			DeliveryIDs:    req.DeliveryIDs,
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.ReplayDeliveriesResponse{Replayed: replayed})
+	}
+}
+
+func webhookResponse(sub *domain.WebhookSubscription) dto.WebhookResponse {
+	eventTypes := make([]string, 0, len(sub.EventTypes))
+	for _, t := range sub.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	return dto.WebhookResponse{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: eventTypes,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func webhookDeliveryResponse(d *domain.WebhookDelivery) dto.WebhookDelivery {
+	res := dto.WebhookDelivery{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt.Format(time.RFC3339),
+		LastError:      d.LastError,
+		LastStatusCode: d.LastStatusCode,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+	}
+	if d.DeliveredAt != nil {
+		deliveredAt := d.DeliveredAt.Format(time.RFC3339)
+		res.DeliveredAt = &deliveredAt
+	}
+	return res
+}
@@ -0,0 +1,82 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"marketai/cards/internal/adapters/postgres"
+	"marketai/cards/internal/app"
+	"marketai/cards/internal/app/command"
+	"marketai/cards/internal/config"
+	"marketai/pkg/encryption"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWebhookBatchSize   = 50
+	defaultWebhookMaxAttempts = 8
+	webhookLease              = time.Minute
+)
+
+// runWebhookDispatcher запускает фоновую доставку вебхуков на время жизни приложения
+func runWebhookDispatcher(lc fx.Lifecycle, cfg *config.Config, a *app.AppCQRS, logger *zap.Logger) {
+	interval := cfg.Webhooks.DispatchInterval
+	if interval <= 0 {
+		logger.Info("webhook dispatcher disabled")
+		return
+	}
+
+	cmd := command.DispatchWebhooksCommand{
+		BatchSize:   cfg.Webhooks.BatchSize,
+		MaxAttempts: cfg.Webhooks.MaxAttempts,
+		Lease:       webhookLease,
+	}
+	if cmd.BatchSize <= 0 {
+		cmd.BatchSize = defaultWebhookBatchSize
+	}
+	if cmd.MaxAttempts <= 0 {
+		cmd.MaxAttempts = defaultWebhookMaxAttempts
+	}
+
+	runPeriodically(lc, interval, func(ctx context.Context) {
+		result, err := a.Commands.DispatchWebhooks.Handle(ctx, cmd)
+		if err != nil {
+			logger.Error("webhook dispatch failed", zap.Error(err))
+			return
+		}
+		if result.Events > 0 || result.Delivered > 0 || result.Failed > 0 {
+			logger.Info("webhooks dispatched",
+				zap.Int("events", result.Events),
+				zap.Int("delivered", result.Delivered),
+				zap.Int("failed", result.Failed),
+			)
+		}
+	})
+}
+
+func newWebhookSecretBox(cfg *config.Config) (*encryption.Box, error) {
+	box, err := encryption.NewBox(cfg.Webhooks.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks.encryption_key: %w", err)
+	}
+	return box, nil
+}
+
+// sealWebhookSecrets при старте шифрует секреты подписок, созданных до
+// включения шифрования
+func sealWebhookSecrets(lc fx.Lifecycle, repo *postgres.WebhookRepository, logger *zap.Logger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			sealed, err := repo.SealSecrets(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt webhook secrets: %w", err)
+			}
+			if sealed > 0 {
+				logger.Info("webhook secrets encrypted", zap.Int("count", sealed))
+			}
+			return nil
+		},
+	})
+}
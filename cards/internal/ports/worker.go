@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// runPeriodically вызывает fn каждые interval, пока приложение запущено.
+// При остановке дожидается завершения текущего прохода.
+func runPeriodically(lc fx.Lifecycle, interval time.Duration, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.StartStopHook(
+		func() {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						fn(ctx)
+					}
+				}
+			}()
+		},
+		func() {
+			cancel()
+			<-done
+		},
+	))
+}
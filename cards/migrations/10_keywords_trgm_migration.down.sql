@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_keywords_phrase_trgm;
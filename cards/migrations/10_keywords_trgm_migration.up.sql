@@ -0,0 +1,5 @@
+-- Подбор ключевых слов ищет основы в середине фразы (LIKE '%основа%'):
+-- btree такой поиск не ускоряет, а триграммный GIN индекс - да
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_keywords_phrase_trgm ON keywords USING GIN (phrase gin_trgm_ops);
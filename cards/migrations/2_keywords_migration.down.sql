@@ -0,0 +1,3 @@
+ALTER TABLE cards DROP COLUMN IF EXISTS keywords;
+
+DROP TABLE IF EXISTS keywords;
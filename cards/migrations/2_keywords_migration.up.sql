@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS keywords (
+    id BIGSERIAL PRIMARY KEY,
+    phrase TEXT UNIQUE NOT NULL,
+    frequency BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_keywords_frequency ON keywords(frequency DESC);
+
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS keywords TEXT[] DEFAULT '{}';
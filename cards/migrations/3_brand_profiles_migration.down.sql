@@ -0,0 +1,3 @@
+ALTER TABLE cards DROP COLUMN IF EXISTS brand_profile_id;
+
+DROP TABLE IF EXISTS brand_profiles;
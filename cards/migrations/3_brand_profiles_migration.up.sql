@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS brand_profiles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    name TEXT NOT NULL,
+    tone TEXT NOT NULL DEFAULT '',
+    intro TEXT NOT NULL DEFAULT '',
+    outro TEXT NOT NULL DEFAULT '',
+    warranty_text TEXT NOT NULL DEFAULT '',
+    banned_words TEXT[] DEFAULT '{}',
+    no_emoji BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_brand_profiles_user_id ON brand_profiles(user_id);
+
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS brand_profile_id UUID REFERENCES brand_profiles(id) ON DELETE SET NULL;
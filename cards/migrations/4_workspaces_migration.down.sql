@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_cards_workspace_id;
+
+ALTER TABLE cards DROP COLUMN IF EXISTS workspace_id;
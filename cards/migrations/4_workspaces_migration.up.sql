@@ -0,0 +1,8 @@
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS workspace_id VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Карточки, созданные до появления рабочих пространств, переходят в личное
+-- пространство владельца: у пользователей, зарегистрированных до пространств,
+-- ID личного пространства совпадает с ID пользователя (миграция 4 auth)
+UPDATE cards SET workspace_id = user_id WHERE workspace_id = '';
+
+CREATE INDEX IF NOT EXISTS idx_cards_workspace_id ON cards(workspace_id);
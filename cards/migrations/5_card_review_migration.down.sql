@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS card_comments;
+
+DROP TABLE IF EXISTS card_transitions;
+
+DROP INDEX IF EXISTS idx_cards_workspace_status;
+
+ALTER TABLE cards DROP COLUMN IF EXISTS status;
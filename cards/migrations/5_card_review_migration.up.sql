@@ -0,0 +1,26 @@
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT 'draft'
+    CHECK (status IN ('draft', 'in_review', 'approved', 'published', 'rejected'));
+
+CREATE INDEX IF NOT EXISTS idx_cards_workspace_status ON cards(workspace_id, status);
+
+CREATE TABLE IF NOT EXISTS card_transitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    from_status VARCHAR(32) NOT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_transitions_card_id ON card_transitions(card_id);
+
+CREATE TABLE IF NOT EXISTS card_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_comments_card_id ON card_comments(card_id);
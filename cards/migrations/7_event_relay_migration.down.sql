@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS outbox_leases;
+
+DROP INDEX IF EXISTS idx_card_events_unpublished;
+
+ALTER TABLE card_events DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,15 @@
+ALTER TABLE card_events ADD COLUMN IF NOT EXISTS published_at TIMESTAMP WITH TIME ZONE;
+
+-- События, записанные до появления relay, считаются опубликованными, иначе
+-- первый проход relay отправил бы в Kafka всю историю
+UPDATE card_events SET published_at = created_at WHERE published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_card_events_unpublished ON card_events(seq) WHERE published_at IS NULL;
+
+-- Право публикации outbox: одновременно события публикует только один
+-- экземпляр сервиса, чтобы не переставить события одной карточки
+CREATE TABLE IF NOT EXISTS outbox_leases (
+    name VARCHAR(64) PRIMARY KEY,
+    holder VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
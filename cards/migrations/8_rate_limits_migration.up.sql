@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key TEXT PRIMARY KEY,
+    count DOUBLE PRECISION NOT NULL DEFAULT 0,
+    prev DOUBLE PRECISION NOT NULL DEFAULT 0,
+    stamp TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limits_expires_at ON rate_limits(expires_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_event_id;
+DROP INDEX IF EXISTS idx_card_events_created_at;
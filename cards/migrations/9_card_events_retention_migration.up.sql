@@ -0,0 +1,4 @@
+-- Очистка outbox выбирает старые события по времени записи, а удаление
+-- события каскадно удаляет его доставки
+CREATE INDEX IF NOT EXISTS idx_card_events_created_at ON card_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries(event_id);
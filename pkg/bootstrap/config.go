@@ -20,6 +20,8 @@ var (
 	ErrEmptyConfigPath  = errors.New("config path flag value is empty")
 	ErrEmptySecretsPath = errors.New("secrets path flag value is empty")
 	ErrNoEchoRouting    = errors.New("no echo routing function defined")
+	// ErrInvalidTrustedProxy - в http.trustedProxies не CIDR
+	ErrInvalidTrustedProxy = errors.New("invalid trusted proxy cidr")
 )
 
 func adjustMaxprocs(logger *zap.Logger) {
@@ -1,7 +1,10 @@
 package bootstrap
 
 import (
+	"fmt"
 	"marketai/pkg/http"
+	"marketai/pkg/http/ratelimit"
+	"net"
 	"slices"
 	"strings"
 	"time"
@@ -22,11 +25,15 @@ const (
 
 type (
 	HttpConfig struct {
-		Port               string        `mapstructure:"port" validate:"required"`
-		ApiBasePath        string        `mapstructure:"apiBasePath"`
-		ReadTimeout        time.Duration `mapstructure:"readTimeout"`
-		WriteTimeout       time.Duration `mapstructure:"writeTimeout"`
-		BodyLimitSkipPaths []string      `mapstructure:"bodyLimitSkipPaths"`
+		Port               string           `mapstructure:"port" validate:"required"`
+		ApiBasePath        string           `mapstructure:"apiBasePath"`
+		ReadTimeout        time.Duration    `mapstructure:"readTimeout"`
+		WriteTimeout       time.Duration    `mapstructure:"writeTimeout"`
+		BodyLimitSkipPaths []string         `mapstructure:"bodyLimitSkipPaths"`
+		RateLimit          ratelimit.Config `mapstructure:"rateLimit"`
+		// TrustedProxies - подсети прокси (CIDR), которым доверяется X-Forwarded-For.
+		// Если пусто, адрес клиента берется из соединения, а заголовки игнорируются.
+		TrustedProxies []string `mapstructure:"trustedProxies"`
 	}
 
 	GetHttpConfig interface {
@@ -49,6 +56,7 @@ type (
 		Config         *HttpConfig
 		Logger         *zap.Logger
 		TracerProvider trace.TracerProvider `optional:"true"`
+		RateLimiter    *ratelimit.Limiter
 	}
 )
 
@@ -64,10 +72,13 @@ func WithEcho[Config GetHttpConfig](initRouting ...interface{}) fx.Option {
 	return fx.Options(
 		fx.Provide(
 			httpConfig[Config],
+			rateLimitStore,
+			httpRateLimiter,
 			withEcho,
 		),
 		fx.Invoke(initRouting...),
 		fx.Invoke(invokeEcho),
+		fx.Invoke(invokeRateLimitSweep),
 	)
 }
 
@@ -81,7 +92,17 @@ func DeprecatedDirectEchoNew(
 	tp trace.TracerProvider,
 ) *echo.Echo {
 
-	return newEcho(c, logger, tp)
+	limiter, err := httpRateLimiter(c, ratelimit.NewMemoryStore(), logger)
+	if err != nil {
+		logger.Error("rate limit disabled", zap.Error(err))
+	}
+
+	e, err := newEcho(c, logger, tp, limiter)
+	if err != nil {
+		logger.Error("echo configuration failed", zap.Error(err))
+	}
+
+	return e
 }
 
 // DeprecatedDirectInvokeEcho нужно для странных нетиповых сценариев где надо
@@ -108,15 +129,16 @@ func useTraceProvider(
 	e.Use(otelecho.Middleware("", otelecho.WithTracerProvider(tp)))
 }
 
-func withEcho(p withEchoParams) *echo.Echo {
-	return newEcho(p.Config, p.Logger, p.TracerProvider)
+func withEcho(p withEchoParams) (*echo.Echo, error) {
+	return newEcho(p.Config, p.Logger, p.TracerProvider, p.RateLimiter)
 }
 
 func newEcho(
 	c *HttpConfig,
 	logger *zap.Logger,
 	tp trace.TracerProvider,
-) *echo.Echo {
+	limiter *ratelimit.Limiter,
+) (*echo.Echo, error) {
 
 	e := echo.New()
 
@@ -149,12 +171,45 @@ func newEcho(
 	}
 	logger.Info("write timeout", zap.Duration("val", e.Server.WriteTimeout))
 
+	extractor, err := ipExtractor(c.TrustedProxies)
+	if err != nil {
+		return e, err
+	}
+	e.IPExtractor = extractor
+
 	e.Server.MaxHeaderBytes = maxHeaderBytes
 	e.HideBanner = true
 	e.HidePort = true
 	e.HTTPErrorHandler = http.GetErrorHandler(logger)
 
-	return e
+	// Лимиты по IP действуют до аутентификации, лимиты по пользователю и API
+	// ключу сервис ставит после нее через limiter.Authenticated
+	e.Use(limiter.Middleware())
+
+	return e, nil
+}
+
+// ipExtractor определяет адрес клиента для c.RealIP(). X-Forwarded-For
+// учитывается только от доверенных прокси, иначе клиент подставил бы любой
+// адрес в обход лимитов и блокировок входа по IP.
+func ipExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range trustedProxies {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTrustedProxy, cidr)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
 }
 
 func invokeEcho(p HttpParams) {
@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"marketai/pkg/http/ratelimit"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	rateLimitStorePostgres = "postgres"
+	rateLimitSweepInterval = time.Minute
+)
+
+var ErrRateLimitNoPostgres = errors.New("rate limit store postgres requires postgresql connection")
+
+type rateLimitStoreParams struct {
+	fx.In
+
+	Config *HttpConfig
+	Conn   *pgxpool.Pool `optional:"true"`
+}
+
+// rateLimitStore выбирает хранилище счетчиков по http.rateLimit.store. Его же
+// использует gRPC сервер сервиса.
+func rateLimitStore(p rateLimitStoreParams) (ratelimit.Store, error) {
+	if p.Config.RateLimit.Store != rateLimitStorePostgres {
+		return ratelimit.NewMemoryStore(), nil
+	}
+	if p.Conn == nil {
+		return nil, ErrRateLimitNoPostgres
+	}
+	return ratelimit.NewPostgresStore(p.Conn), nil
+}
+
+// invokeRateLimitSweep раз в минуту удаляет истекшие счетчики
+func invokeRateLimitSweep(
+	lc fx.Lifecycle,
+	store ratelimit.Store,
+	logger *zap.Logger,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lc.Append(fx.StartStopHook(
+		func() {
+			go func() {
+				ticker := time.NewTicker(rateLimitSweepInterval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case now := <-ticker.C:
+						if err := store.Sweep(ctx, now); err != nil {
+							logger.Error("rate limit sweep failed", zap.Error(err))
+						}
+					}
+				}
+			}()
+		},
+		cancel,
+	))
+}
+
+// httpRateLimiter создает ограничитель по правилам http.rateLimit. Без правил
+// возвращает nil, его middleware тогда ничего не ограничивают.
+func httpRateLimiter(
+	c *HttpConfig,
+	store ratelimit.Store,
+	logger *zap.Logger,
+) (*ratelimit.Limiter, error) {
+
+	if len(c.RateLimit.Rules) == 0 {
+		return nil, nil
+	}
+
+	limiter, err := ratelimit.NewLimiter(c.RateLimit.Rules, store, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("rate limit enabled", zap.Int("rules", len(c.RateLimit.Rules)))
+
+	return limiter, nil
+}
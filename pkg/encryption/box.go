@@ -0,0 +1,84 @@
+// Package encryption шифрует секреты, которые сервисы хранят в базе:
+// секреты подписи вебхуков, секреты TOTP и т.п.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// prefix отмечает зашифрованное значение и версию формата
+const prefix = "enc:v1:"
+
+var (
+	ErrEmptyKey  = errors.New("encryption key is empty")
+	ErrMalformed = errors.New("malformed encrypted value")
+)
+
+// Box шифрует строки AES-256-GCM. Ключ шифрования - SHA-256 от ключа из
+// конфига, поэтому в конфиге достаточно длинной случайной строки.
+type Box struct {
+	aead cipher.AEAD
+}
+
+func NewBox(key string) (*Box, error) {
+	if key == "" {
+		return nil, ErrEmptyKey
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Box{aead: aead}, nil
+}
+
+// Seal шифрует значение, результат - "enc:v1:" и base64 от nonce и шифротекста
+func (b *Box) Seal(plain string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := b.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return prefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open расшифровывает значение от Seal. Значения без префикса записаны до
+// включения шифрования и возвращаются как есть.
+func (b *Box) Open(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return value, nil
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < b.aead.NonceSize() {
+		return "", ErrMalformed
+	}
+
+	nonce, ciphertext := sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():]
+	plain, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	return string(plain), nil
+}
+
+// Sealed сообщает, зашифровано ли значение
+func Sealed(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
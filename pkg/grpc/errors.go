@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"marketai/pkg/http/problem"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const acceptLanguageMetadata = "accept-language"
+
+// errorsUnaryServerInterceptor переводит ошибки API в gRPC status с текстом на
+// языке из метаданных accept-language и кодом в ErrorInfo. Ошибки без кода
+// превращаются в Internal, их текст пишется в лог и клиенту не отдается.
+func errorsUnaryServerInterceptor(l *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		var pe *problem.Error
+		if !errors.As(err, &pe) {
+			if _, ok := status.FromError(err); ok {
+				return resp, err
+			}
+			l.Error("unexpected error", zap.String("method", info.FullMethod), zap.Error(err))
+			pe = problem.Internal(err)
+		}
+
+		return resp, pe.LocalizedStatus(acceptLanguage(ctx)).Err()
+	}
+}
+
+func acceptLanguage(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(acceptLanguageMetadata); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
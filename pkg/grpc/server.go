@@ -2,7 +2,10 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"marketai/pkg/http/ratelimit"
 	"net"
+	"net/netip"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/fx"
@@ -23,6 +26,19 @@ import (
 type (
 	ServerConfig struct {
 		Port string `mapstructure:"port" validate:"required"`
+		// RateLimit - лимиты вызовов, Path правила - полное имя метода
+		RateLimit []ratelimit.Rule `mapstructure:"rateLimit" validate:"dive"`
+		// RateLimitTrustedPeers - сети (CIDR) других сервисов, вызовы из которых не ограничиваются
+		RateLimitTrustedPeers []string `mapstructure:"rateLimitTrustedPeers" validate:"dive,cidr"`
+	}
+
+	serverParams struct {
+		fx.In
+
+		Config *ServerConfig
+		Logger *zap.Logger
+		// RateLimitStore - хранилище счетчиков, общее с http сервером
+		RateLimitStore ratelimit.Store `optional:"true"`
 	}
 
 	GetServerConfig interface {
@@ -31,7 +47,13 @@ type (
 )
 
 // see https://github.com/grpc-ecosystem/go-grpc-middleware/blob/71d7422112b1d7fadd4b8bf12a6f33ba6d22e98e/examples/server/main.go
-func server(l *zap.Logger) (*grpc.Server, *grpcprom.ServerMetrics) {
+func server(p serverParams) (*grpc.Server, *grpcprom.ServerMetrics, error) {
+	l := p.Logger
+
+	rateLimit, err := rateLimitInterceptor(p)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	buckets := []float64{
 		0.001, 0.01, 0.1, 0.3, 0.6, 1, 3, 6, 9, 20, 30, 60, 90, 120,
@@ -79,6 +101,8 @@ func server(l *zap.Logger) (*grpc.Server, *grpcprom.ServerMetrics) {
 				logging.WithFieldsFromContext(logTraceID),
 				logging.WithLevels(CodeToLevel),
 			),
+			errorsUnaryServerInterceptor(l),
+			rateLimit,
 			recovery.UnaryServerInterceptor(
 				recovery.WithRecoveryHandler(grpcPanicRecoveryHandler),
 			),
@@ -87,7 +111,45 @@ func server(l *zap.Logger) (*grpc.Server, *grpcprom.ServerMetrics) {
 
 	//	srvMetrics.InitializeMetrics(srv)
 
-	return srv, srvMetrics
+	return srv, srvMetrics, nil
+}
+
+// rateLimitInterceptor возвращает пустой перехватчик, если лимиты не заданы
+func rateLimitInterceptor(p serverParams) (grpc.UnaryServerInterceptor, error) {
+	if len(p.Config.RateLimit) == 0 {
+		return func(
+			ctx context.Context,
+			req any,
+			_ *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (any, error) {
+			return handler(ctx, req)
+		}, nil
+	}
+
+	store := p.RateLimitStore
+	if store == nil {
+		store = ratelimit.NewMemoryStore()
+	}
+
+	limiter, err := ratelimit.NewLimiter(p.Config.RateLimit, store, p.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	trusted := make([]netip.Prefix, 0, len(p.Config.RateLimitTrustedPeers))
+	for _, cidr := range p.Config.RateLimitTrustedPeers {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("grpc rate limit trusted peer %q: %w", cidr, err)
+		}
+		trusted = append(trusted, prefix.Masked())
+	}
+
+	p.Logger.Info("grpc rate limit enabled",
+		zap.Int("rules", len(p.Config.RateLimit)), zap.Strings("trusted_peers", p.Config.RateLimitTrustedPeers))
+
+	return limiter.UnaryServerInterceptor(trusted...), nil
 }
 
 func initMetrics(srv *grpc.Server, metrics *grpcprom.ServerMetrics) {
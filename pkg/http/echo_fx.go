@@ -1,10 +1,9 @@
 package http
 
 import (
-	"encoding/json"
 	"errors"
+	"marketai/pkg/http/problem"
 	"net/http"
-	"time"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
@@ -59,67 +58,45 @@ func (s *EchoFx) run() {
 	s.logger.Info("server stopped")
 }
 
-func httpError(err error) *echo.HTTPError {
-	he, ok := err.(*echo.HTTPError)
-
-	if ok {
-		if he.Internal != nil {
-			if herr, ok := he.Internal.(*echo.HTTPError); ok {
-				return herr
-			}
-		}
-
-		return he
+// apiError приводит ошибку обработчика к ошибке API. Ошибки echo (роутер,
+// BodyLimit, Bind) получают общий код по своему статусу.
+func apiError(err error) *problem.Error {
+	var pe *problem.Error
+	if errors.As(err, &pe) {
+		return pe
 	}
 
-	return echo.ErrInternalServerError.WithInternal(err)
-
-}
-
-func statusMessage(msg interface{}) interface{} {
-
-	switch m := msg.(type) {
-	case string:
-		return msg
-	case json.Marshaler:
-		return msg
-	case error:
-		return m.Error()
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return problem.FromStatus(he.Code).Wrap(err)
 	}
 
-	return msg
+	return problem.Internal(err)
 }
 
-// GetErrorHandler returns modified copy of echo default error handler
-// returns error message echo.Map{"message": m, "error": err.Error()} to client
+// GetErrorHandler returns echo error handler rendering errors as
+// application/problem+json (RFC 7807) with detail localized by Accept-Language.
+// Internal causes of 5xx errors are logged and never sent to client.
+// Handlers that already logged the cause return problem.ErrInternal as is.
 func GetErrorHandler(logger *zap.Logger) func(err error, c echo.Context) {
 	return func(err error, c echo.Context) {
 		if c.Response().Committed {
 			return
 		}
 
-		he := httpError(err)
+		pe := apiError(err)
+		req := c.Request()
 
-		if c.Request().Method == http.MethodHead {
-			c.NoContent(he.Code)
-			return
-		}
-
-		if he.Internal == nil {
-
-			c.JSON(he.Code, echo.Map{
-				"statusMessage": statusMessage(he.Message),
-				"timestamp":     time.Now(),
-			})
-
-			return
+		if pe.Status >= http.StatusInternalServerError && pe.Err != nil {
+			logger.Error(
+				"request failed",
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.String("code", pe.Code),
+				zap.Error(err),
+			)
 		}
 
-		c.JSON(he.Code, echo.Map{
-			"message":       he.Internal.Error(),
-			"statusMessage": statusMessage(he.Message),
-			"timestamp":     time.Now(),
-		})
-
+		problem.Write(c.Response(), req, pe)
 	}
 }
@@ -0,0 +1,89 @@
+package problem
+
+import (
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain - домен в ErrorInfo, по которому клиенты отличают коды marketai
+const ErrorDomain = "marketai"
+
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusLocked:                codes.FailedPrecondition,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusNotImplemented:        codes.Unimplemented,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+	http.StatusGatewayTimeout:        codes.DeadlineExceeded,
+}
+
+var httpStatuses = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusConflict,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+}
+
+// GRPCCode возвращает gRPC код, соответствующий HTTP статусу ошибки
+func (e *Error) GRPCCode() codes.Code {
+	if code, ok := grpcCodes[e.Status]; ok {
+		return code
+	}
+	if e.Status >= http.StatusInternalServerError {
+		return codes.Internal
+	}
+	return codes.FailedPrecondition
+}
+
+// GRPCStatus позволяет status.FromError и status.Convert понимать ошибку API
+// без перевода текста
+func (e *Error) GRPCStatus() *status.Status {
+	return e.LocalizedStatus("")
+}
+
+// LocalizedStatus собирает gRPC status с текстом на языке из acceptLanguage
+// и кодом ошибки в ErrorInfo.Reason
+func (e *Error) LocalizedStatus(acceptLanguage string) *status.Status {
+	st := status.New(e.GRPCCode(), Localize(e, acceptLanguage))
+	withInfo, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: e.Code,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return st
+	}
+	return withInfo
+}
+
+// FromGRPC восстанавливает ошибку API из ответа gRPC сервиса marketai.
+// Статусы без ErrorInfo получают общий код по HTTP статусу.
+func FromGRPC(err error) *Error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return Internal(err)
+	}
+	httpStatus, ok := httpStatuses[st.Code()]
+	if !ok {
+		httpStatus = http.StatusInternalServerError
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Domain == ErrorDomain {
+			return New(httpStatus, info.Reason, st.Message()).Wrap(err)
+		}
+	}
+	return FromStatus(httpStatus).Wrap(err)
+}
@@ -0,0 +1,95 @@
+package problem
+
+import (
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage - язык текстов по умолчанию в Error.Message
+var DefaultLanguage = language.Russian
+
+type catalog struct {
+	mu       sync.RWMutex
+	tags     []language.Tag
+	matcher  language.Matcher
+	messages map[language.Tag]map[string]string
+}
+
+var messages = &catalog{
+	tags:     []language.Tag{DefaultLanguage},
+	matcher:  language.NewMatcher([]language.Tag{DefaultLanguage}),
+	messages: map[language.Tag]map[string]string{},
+}
+
+func init() {
+	RegisterMessages(language.English, map[string]string{
+		ErrBadRequest.Code:       "Malformed request",
+		ErrValidation.Code:       "Invalid request data",
+		ErrUnauthorized.Code:     "Authorization required",
+		ErrForbidden.Code:        "Insufficient permissions",
+		ErrNotFound.Code:         "Resource not found",
+		ErrMethodNotAllowed.Code: "Method not allowed",
+		ErrConflict.Code:         "Conflict with the current state of the resource",
+		ErrTooLarge.Code:         "Request is too large",
+		ErrUnsupportedMedia.Code: "Unsupported content type",
+		ErrTooManyRequests.Code:  "Too many requests, try again later",
+		ErrInternal.Code:         "Internal server error",
+		ErrUnavailable.Code:      "Service is temporarily unavailable",
+	})
+}
+
+// RegisterMessages добавляет переводы текстов ошибок по их кодам. Вызывается
+// из init пакетов, объявляющих свои коды.
+func RegisterMessages(tag language.Tag, m map[string]string) {
+	messages.mu.Lock()
+	defer messages.mu.Unlock()
+
+	known, ok := messages.messages[tag]
+	if !ok {
+		known = make(map[string]string, len(m))
+		messages.messages[tag] = known
+		messages.tags = append(messages.tags, tag)
+		messages.matcher = language.NewMatcher(messages.tags)
+	}
+	for code, text := range m {
+		known[code] = text
+	}
+}
+
+// Language выбирает язык ответа по заголовку Accept-Language среди языков,
+// для которых зарегистрированы тексты
+func Language(acceptLanguage string) language.Tag {
+	messages.mu.RLock()
+	defer messages.mu.RUnlock()
+
+	return messages.match(acceptLanguage)
+}
+
+func (c *catalog) match(acceptLanguage string) language.Tag {
+	if acceptLanguage == "" {
+		return DefaultLanguage
+	}
+	prefs, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(prefs) == 0 {
+		return DefaultLanguage
+	}
+	_, index, confidence := c.matcher.Match(prefs...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	return c.tags[index]
+}
+
+// Localize возвращает текст ошибки на языке, выбранном по Accept-Language.
+// Если перевода нет, возвращается текст по умолчанию.
+func Localize(e *Error, acceptLanguage string) string {
+	messages.mu.RLock()
+	defer messages.mu.RUnlock()
+
+	tag := messages.match(acceptLanguage)
+	if text, ok := messages.messages[tag][e.Code]; ok {
+		return text
+	}
+	return e.Message
+}
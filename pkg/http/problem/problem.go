@@ -0,0 +1,189 @@
+// Package problem описывает ошибки API с устойчивыми машиночитаемыми кодами.
+// Одна и та же ошибка отдается HTTP клиентам как application/problem+json
+// (RFC 7807) и gRPC клиентам как status с ErrorInfo.
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ContentType - тип содержимого ответа с ошибкой по RFC 7807
+const ContentType = "application/problem+json"
+
+const typePrefix = "urn:marketai:problem:"
+
+var (
+	ErrBadRequest       = New(http.StatusBadRequest, "bad_request", "Неверный формат запроса")
+	ErrValidation       = New(http.StatusBadRequest, "validation_failed", "Неверные данные запроса")
+	ErrUnauthorized     = New(http.StatusUnauthorized, "unauthorized", "Требуется авторизация")
+	ErrForbidden        = New(http.StatusForbidden, "forbidden", "Недостаточно прав")
+	ErrNotFound         = New(http.StatusNotFound, "not_found", "Ресурс не найден")
+	ErrMethodNotAllowed = New(http.StatusMethodNotAllowed, "method_not_allowed", "Метод не поддерживается")
+	ErrConflict         = New(http.StatusConflict, "conflict", "Конфликт с текущим состоянием ресурса")
+	ErrTooLarge         = New(http.StatusRequestEntityTooLarge, "request_too_large", "Слишком большой запрос")
+	ErrUnsupportedMedia = New(http.StatusUnsupportedMediaType, "unsupported_media_type", "Неподдерживаемый тип содержимого")
+	ErrTooManyRequests  = New(http.StatusTooManyRequests, "too_many_requests", "Слишком много запросов, повторите позже")
+	ErrInternal         = New(http.StatusInternalServerError, "internal", "Внутренняя ошибка сервера")
+	ErrUnavailable      = New(http.StatusServiceUnavailable, "unavailable", "Сервис временно недоступен")
+)
+
+// byStatus - общие ошибки, которыми заменяются ответы без собственного кода,
+// например 404 и 405 роутера echo
+var byStatus = map[int]*Error{}
+
+func init() {
+	for _, e := range []*Error{
+		ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrMethodNotAllowed,
+		ErrConflict, ErrTooLarge, ErrUnsupportedMedia, ErrTooManyRequests, ErrInternal, ErrUnavailable,
+	} {
+		byStatus[e.Status] = e
+	}
+}
+
+type (
+	// Error - ошибка API. Code не меняется между версиями и по нему клиенты
+	// различают ошибки, Message - текст по умолчанию на русском, переводы
+	// регистрируются через RegisterMessages. Err - внутренняя причина, она
+	// попадает в логи, но никогда в ответ клиенту.
+	Error struct {
+		Status  int
+		Code    string
+		Message string
+		Fields  []FieldError
+		Err     error
+	}
+
+	// FieldError - ошибка проверки одного поля запроса
+	FieldError struct {
+		// Field - имя поля в JSON
+		Field string `json:"field"`
+		// Rule - нарушенное правило: required, email, e164, password_policy и т.д.
+		Rule    string `json:"rule"`
+		Message string `json:"message"`
+	}
+
+	// Problem - тело ответа с ошибкой по RFC 7807
+	Problem struct {
+		Type     string       `json:"type"`
+		Title    string       `json:"title"`
+		Status   int          `json:"status"`
+		Detail   string       `json:"detail,omitempty"`
+		Instance string       `json:"instance,omitempty"`
+		Code     string       `json:"code"`
+		Errors   []FieldError `json:"errors,omitempty"`
+	}
+)
+
+// New создает ошибку API с кодом code и текстом по умолчанию message
+func New(status int, code, message string) *Error {
+	return &Error{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// FromStatus возвращает общую ошибку для HTTP статуса, если у ответа нет
+// собственного кода
+func FromStatus(status int) *Error {
+	if e, ok := byStatus[status]; ok {
+		return e
+	}
+	if status >= http.StatusInternalServerError {
+		return New(status, ErrInternal.Code, ErrInternal.Message)
+	}
+	return New(status, ErrBadRequest.Code, ErrBadRequest.Message)
+}
+
+// Internal оборачивает непредвиденную ошибку в 500 без раскрытия ее текста
+func Internal(err error) *Error {
+	return ErrInternal.Wrap(err)
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Code, e.Err)
+	}
+	return e.Code
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is считает ошибки API равными при совпадении кода, чтобы errors.Is
+// работал с копиями, полученными через Wrap и WithFields
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap возвращает копию ошибки с внутренней причиной err
+func (e *Error) Wrap(err error) *Error {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// WithFields возвращает копию ошибки со списком неверных полей
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	c := *e
+	c.Fields = fields
+	return &c
+}
+
+// Problem собирает тело ответа на языке, выбранном по Accept-Language
+func (e *Error) Problem(acceptLanguage string) Problem {
+	return Problem{
+		Type:   typePrefix + e.Code,
+		Title:  http.StatusText(e.Status),
+		Status: e.Status,
+		Detail: Localize(e, acceptLanguage),
+		Code:   e.Code,
+		Errors: e.Fields,
+	}
+}
+
+// As достает ошибку API из цепочки err. Ошибки без кода становятся 500.
+func As(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return Internal(err)
+}
+
+type (
+	// Rule сопоставляет доменную ошибку Target с ошибкой API. Текст ответа
+	// по умолчанию - Message, а если он пуст, то текст Target, но не обернутой
+	// ошибки, чтобы подробности не уходили клиенту.
+	Rule struct {
+		Target  error
+		Status  int
+		Code    string
+		Message string
+	}
+
+	// Mapping - таблица доменных ошибок сервиса
+	Mapping []Rule
+)
+
+// Map ищет err в таблице. Не найденные ошибки становятся 500.
+func (m Mapping) Map(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	for _, r := range m {
+		if errors.Is(err, r.Target) {
+			message := r.Message
+			if message == "" {
+				message = r.Target.Error()
+			}
+			return New(r.Status, r.Code, message).Wrap(err)
+		}
+	}
+	return Internal(err)
+}
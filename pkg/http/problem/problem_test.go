@@ -0,0 +1,76 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestMappingMap(t *testing.T) {
+	errNotFound := errors.New("card not found")
+	errTaken := errors.New("email already taken")
+	errCustom := New(http.StatusLocked, "locked", "Заблокировано")
+
+	mapping := Mapping{
+		{Target: errNotFound, Status: http.StatusNotFound, Code: "card_not_found"},
+		{Target: errTaken, Status: http.StatusConflict, Code: "email_taken", Message: "Email занят"},
+	}
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name:        "domain error uses target text",
+			err:         errNotFound,
+			wantStatus:  http.StatusNotFound,
+			wantCode:    "card_not_found",
+			wantMessage: "card not found",
+		},
+		{
+			name:        "wrapped domain error hides wrapping details",
+			err:         fmt.Errorf("select card 42: %w", errNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantCode:    "card_not_found",
+			wantMessage: "card not found",
+		},
+		{
+			name:        "rule message overrides target text",
+			err:         errTaken,
+			wantStatus:  http.StatusConflict,
+			wantCode:    "email_taken",
+			wantMessage: "Email занят",
+		},
+		{
+			name:        "api error passes through",
+			err:         fmt.Errorf("guard: %w", errCustom),
+			wantStatus:  http.StatusLocked,
+			wantCode:    "locked",
+			wantMessage: "Заблокировано",
+		},
+		{
+			name:        "unknown error becomes internal",
+			err:         errors.New("connection reset"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    ErrInternal.Code,
+			wantMessage: ErrInternal.Message,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapping.Map(tt.err)
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode || got.Message != tt.wantMessage {
+				t.Fatalf("Map() = %d %q %q, want %d %q %q",
+					got.Status, got.Code, got.Message, tt.wantStatus, tt.wantCode, tt.wantMessage)
+			}
+			if !errors.Is(got, tt.err) && !errors.Is(tt.err, got) {
+				t.Fatalf("Map() lost the cause %v", tt.err)
+			}
+		})
+	}
+}
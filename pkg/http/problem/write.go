@@ -0,0 +1,22 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Write отправляет ошибку как application/problem+json на языке из
+// Accept-Language запроса. Для net/http обработчиков, echo использует
+// обработчик ошибок из pkg/http.
+func Write(w http.ResponseWriter, r *http.Request, e *Error) {
+	acceptLanguage := r.Header.Get("Accept-Language")
+	body := e.Problem(acceptLanguage)
+	body.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Content-Language", Language(acceptLanguage).String())
+	w.WriteHeader(e.Status)
+	if r.Method != http.MethodHead {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
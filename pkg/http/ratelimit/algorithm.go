@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+func (r *Rule) apply(s *State, now time.Time) Result {
+	if r.Algorithm == SlidingWindow {
+		return r.slidingWindow(s, now)
+	}
+	return r.tokenBucket(s, now)
+}
+
+func (r *Rule) tokenBucket(s *State, now time.Time) Result {
+	capacity := float64(r.Limit)
+	if r.Burst > 0 {
+		capacity = float64(r.Burst)
+	}
+	// токенов в секунду
+	rate := float64(r.Limit) / r.Window.Seconds()
+
+	switch elapsed := now.Sub(s.Stamp).Seconds(); {
+	case s.Stamp.IsZero():
+		s.Count = capacity
+	case elapsed > 0:
+		s.Count = math.Min(capacity, s.Count+elapsed*rate)
+	}
+	s.Stamp = now
+
+	res := Result{Limit: int(capacity), Window: r.Window}
+	if s.Count >= 1 {
+		s.Count--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - s.Count) / rate)
+	}
+
+	res.Remaining = int(s.Count)
+	res.Reset = seconds((capacity - s.Count) / rate)
+	s.Expires = now.Add(res.Reset)
+	return res
+}
+
+func (r *Rule) slidingWindow(s *State, now time.Time) Result {
+	start := now.Truncate(r.Window)
+	switch {
+	case s.Stamp.Equal(start):
+	case s.Stamp.Equal(start.Add(-r.Window)):
+		s.Prev, s.Count = s.Count, 0
+	default:
+		s.Prev, s.Count = 0, 0
+	}
+	s.Stamp = start
+
+	elapsed := now.Sub(start)
+	limit := float64(r.Limit)
+	// запросы предыдущего окна учитываются с весом оставшейся его доли
+	used := s.Prev*(1-elapsed.Seconds()/r.Window.Seconds()) + s.Count
+
+	res := Result{Limit: r.Limit, Reset: r.Window - elapsed, Window: r.Window}
+	switch {
+	case used+1 <= limit:
+		s.Count++
+		used++
+		res.Allowed = true
+	case s.Count+1 > limit || s.Prev == 0:
+		res.RetryAfter = res.Reset
+	default:
+		// через сколько вес предыдущего окна упадет достаточно для запроса
+		share := 1 - (limit-1-s.Count)/s.Prev
+		res.RetryAfter = seconds(share*r.Window.Seconds()) - elapsed
+	}
+
+	res.Remaining = max(0, int(limit-used))
+	s.Expires = start.Add(2 * r.Window)
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuleApply(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type step struct {
+		at            time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+	}
+
+	tests := []struct {
+		name  string
+		rule  Rule
+		steps []step
+	}{
+		{
+			name: "token bucket spends burst and refills at rate",
+			rule: Rule{Algorithm: TokenBucket, Limit: 60, Window: time.Minute, Burst: 2},
+			steps: []step{
+				{at: 0, wantAllowed: true, wantRemaining: 1},
+				{at: 0, wantAllowed: true, wantRemaining: 0},
+				{at: 0, wantAllowed: false, wantRemaining: 0, wantRetry: time.Second},
+				{at: time.Second, wantAllowed: true, wantRemaining: 0},
+				{at: 10 * time.Second, wantAllowed: true, wantRemaining: 1},
+			},
+		},
+		{
+			name: "token bucket capacity defaults to limit",
+			rule: Rule{Algorithm: TokenBucket, Limit: 3, Window: time.Minute},
+			steps: []step{
+				{at: 0, wantAllowed: true, wantRemaining: 2},
+				{at: 0, wantAllowed: true, wantRemaining: 1},
+				{at: 0, wantAllowed: true, wantRemaining: 0},
+				{at: 0, wantAllowed: false, wantRemaining: 0, wantRetry: 20 * time.Second},
+			},
+		},
+		{
+			name: "sliding window limits requests within window",
+			rule: Rule{Algorithm: SlidingWindow, Limit: 2, Window: time.Minute},
+			steps: []step{
+				{at: 0, wantAllowed: true, wantRemaining: 1},
+				{at: 10 * time.Second, wantAllowed: true, wantRemaining: 0},
+				{at: 20 * time.Second, wantAllowed: false, wantRemaining: 0, wantRetry: 40 * time.Second},
+			},
+		},
+		{
+			name: "sliding window weighs previous window",
+			rule: Rule{Algorithm: SlidingWindow, Limit: 2, Window: time.Minute},
+			steps: []step{
+				{at: 0, wantAllowed: true, wantRemaining: 1},
+				{at: 0, wantAllowed: true, wantRemaining: 0},
+				// половина предыдущего окна еще учитывается: 2*0.5 + 1 = 2
+				{at: 90 * time.Second, wantAllowed: true, wantRemaining: 0},
+				{at: 90 * time.Second, wantAllowed: false, wantRemaining: 0, wantRetry: 30 * time.Second},
+			},
+		},
+		{
+			name: "sliding window resets after idle window",
+			rule: Rule{Algorithm: SlidingWindow, Limit: 1, Window: time.Minute},
+			steps: []step{
+				{at: 0, wantAllowed: true, wantRemaining: 0},
+				{at: 30 * time.Second, wantAllowed: false, wantRemaining: 0, wantRetry: 30 * time.Second},
+				{at: 150 * time.Second, wantAllowed: true, wantRemaining: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state State
+			for i, s := range tt.steps {
+				got := tt.rule.apply(&state, start.Add(s.at))
+				if got.Allowed != s.wantAllowed || got.Remaining != s.wantRemaining {
+					t.Fatalf("step %d: allowed=%v remaining=%d, want allowed=%v remaining=%d",
+						i, got.Allowed, got.Remaining, s.wantAllowed, s.wantRemaining)
+				}
+				if !s.wantAllowed && got.RetryAfter.Round(time.Millisecond) != s.wantRetry {
+					t.Fatalf("step %d: retry after %v, want %v", i, got.RetryAfter, s.wantRetry)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"marketai/pkg/http/problem"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Заголовки ответа по draft-ietf-httpapi-ratelimit-headers
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderPolicy     = "RateLimit-Policy"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Headers - заголовки ответа с состоянием лимита. Retry-After есть только у
+// отклоненного запроса.
+func (r Result) Headers() map[string]string {
+	h := map[string]string{
+		HeaderLimit:     strconv.Itoa(r.Limit),
+		HeaderRemaining: strconv.Itoa(r.Remaining),
+		HeaderReset:     ceilSeconds(r.Reset),
+		HeaderPolicy:    strconv.Itoa(r.Limit) + ";w=" + ceilSeconds(r.Window),
+	}
+	if !r.Allowed {
+		h[HeaderRetryAfter] = ceilSeconds(r.RetryAfter)
+	}
+	return h
+}
+
+// Middleware ограничивает частоту запросов к маршрутам echo по правилам с
+// ключом ip и ставится до аутентификации. Правило сопоставляется с шаблоном
+// маршрута, а для неизвестных маршрутов - с путем запроса. При превышении
+// лимита отвечает 429 problem+json.
+func (l *Limiter) Middleware() echo.MiddlewareFunc {
+	if l == nil || !l.hasKey(KeyIP) {
+		return passThrough
+	}
+	return l.middleware(nil, KeyIP)
+}
+
+// Authenticated применяет правила с ключами user и api_key и ставится сразу
+// после middleware аутентификации сервиса: subject возвращает проверенные ей
+// ID пользователя и API ключа. Без них запрос считается по адресу клиента.
+func (l *Limiter) Authenticated(subject func(c echo.Context) Subject) echo.MiddlewareFunc {
+	if l == nil || !l.hasKey(KeyUser, KeyAPIKey) {
+		return passThrough
+	}
+	return l.middleware(subject, KeyUser, KeyAPIKey)
+}
+
+func (l *Limiter) middleware(subject func(c echo.Context) Subject, keys ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			path := c.Path()
+			if path == "" {
+				path = req.URL.Path
+			}
+
+			id := Identity{IP: c.RealIP()}
+			if subject != nil {
+				id.Subject = subject(c)
+			}
+
+			res, ok := l.Take(req.Context(), req.Method, path, id, keys...)
+			if !ok {
+				return next(c)
+			}
+
+			for k, v := range res.Headers() {
+				c.Response().Header().Set(k, v)
+			}
+			if !res.Allowed {
+				return problem.ErrTooManyRequests
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func passThrough(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(max(0, int(math.Ceil(d.Seconds()))))
+}
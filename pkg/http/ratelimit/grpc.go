@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"marketai/pkg/http/problem"
+	"net"
+	"net/netip"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// UnaryServerInterceptor ограничивает частоту вызовов gRPC методов. Path
+// правила сопоставляется с полным именем метода (/auth.AuthService/Login),
+// Method правила должен быть пустым. Вызовы считаются по адресу клиента, а
+// вызовы из сетей trusted (другие сервисы marketai) не ограничиваются, чтобы
+// запросы всех пользователей сервиса не попадали в один счетчик. Состояние
+// лимита уходит в заголовках ответа с именами в нижнем регистре, при
+// превышении лимита возвращается problem.ErrTooManyRequests.
+func (l *Limiter) UnaryServerInterceptor(trusted ...netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ip := peerIP(ctx)
+		if isTrusted(ip, trusted) {
+			return handler(ctx, req)
+		}
+
+		res, ok := l.Take(ctx, "", info.FullMethod, Identity{IP: ip})
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		header := metadata.MD{}
+		for k, v := range res.Headers() {
+			header.Set(k, v)
+		}
+		_ = grpc.SetHeader(ctx, header)
+
+		if !res.Allowed {
+			return nil, problem.ErrTooManyRequests
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func isTrusted(ip string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return slices.ContainsFunc(trusted, func(p netip.Prefix) bool {
+		return p.Contains(addr)
+	})
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore хранит счетчики в памяти процесса. Подходит для одного
+// экземпляра сервиса: у каждого экземпляра свои счетчики.
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string]*State
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: map[string]*State{}}
+}
+
+func (m *MemoryStore) Update(_ context.Context, key string, fn func(*State)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.states[key]
+	if !ok {
+		s = &State{}
+		m.states[key] = s
+	}
+	fn(s)
+	return nil
+}
+
+func (m *MemoryStore) Sweep(_ context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, s := range m.states {
+		if s.Expires.Before(now) {
+			delete(m.states, key)
+		}
+	}
+	return nil
+}
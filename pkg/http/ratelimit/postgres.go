@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// пустая запись нужна, чтобы параллельные запросы с новым ключом ждали
+	// друг друга на блокировке строки, а не перезаписывали состояние
+	insertRateLimit = `
+INSERT INTO rate_limits (key, expires_at) VALUES ($1, $2)
+ON CONFLICT (key) DO NOTHING`
+
+	selectRateLimit = `
+SELECT count, prev, stamp, expires_at FROM rate_limits WHERE key = $1 FOR UPDATE`
+
+	updateRateLimit = `
+UPDATE rate_limits SET count = $2, prev = $3, stamp = $4, expires_at = $5 WHERE key = $1`
+
+	deleteExpiredRateLimits = `
+DELETE FROM rate_limits WHERE expires_at < $1`
+)
+
+// PostgresStore хранит счетчики в таблице rate_limits, общей для всех
+// экземпляров сервиса. Таблицу создает миграция сервиса.
+type PostgresStore struct {
+	conn *pgxpool.Pool
+}
+
+func NewPostgresStore(conn *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{conn: conn}
+}
+
+func (p *PostgresStore) Update(ctx context.Context, key string, fn func(*State)) error {
+	tx, err := p.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, insertRateLimit, key, time.Now()); err != nil {
+		return err
+	}
+
+	var (
+		s     State
+		stamp *time.Time
+	)
+	err = tx.QueryRow(ctx, selectRateLimit, key).Scan(&s.Count, &s.Prev, &stamp, &s.Expires)
+	if err != nil {
+		return err
+	}
+	if stamp != nil {
+		s.Stamp = *stamp
+	}
+
+	fn(&s)
+
+	if _, err := tx.Exec(ctx, updateRateLimit, key, s.Count, s.Prev, s.Stamp, s.Expires); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (p *PostgresStore) Sweep(ctx context.Context, now time.Time) error {
+	_, err := p.conn.Exec(ctx, deleteExpiredRateLimits, now)
+	return err
+}
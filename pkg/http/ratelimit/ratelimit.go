@@ -0,0 +1,220 @@
+// Package ratelimit ограничивает частоту запросов к HTTP маршрутам и gRPC
+// методам. Лимиты задаются правилами в конфиге, состояние счетчиков хранится
+// в Store: в памяти процесса или в Postgres, если экземпляров сервиса несколько.
+// Правила по IP применяются до аутентификации, правила по пользователю и API
+// ключу - после нее, к проверенным ID.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Алгоритмы ограничения
+const (
+	// TokenBucket допускает всплеск до Burst запросов и пополняется со
+	// скоростью Limit запросов за Window
+	TokenBucket = "token_bucket"
+	// SlidingWindow допускает не больше Limit запросов за любое окно Window
+	// (приближение по двум соседним фиксированным окнам)
+	SlidingWindow = "sliding_window"
+)
+
+// Ключи, по которым считаются запросы
+const (
+	// KeyIP - адрес клиента
+	KeyIP = "ip"
+	// KeyUser - ID пользователя после аутентификации, общий для всех его
+	// токенов и API ключей; без аутентификации - адрес клиента
+	KeyUser = "user"
+	// KeyAPIKey - ID API ключа после аутентификации; запросы по токену и без
+	// аутентификации считаются по адресу клиента
+	KeyAPIKey = "api_key"
+)
+
+type (
+	// Config - настройки ограничения частоты запросов
+	Config struct {
+		// Store - memory или postgres
+		Store string `mapstructure:"store" validate:"omitempty,oneof=memory postgres"`
+		Rules []Rule `mapstructure:"rules" validate:"dive"`
+	}
+
+	// Rule - лимит для маршрута. Path - шаблон маршрута echo вместе с
+	// префиксом группы (/auth/api/v1/sessions/:id) или полное имя gRPC метода,
+	// * в конце задает префикс. Пустой Method подходит для любого метода.
+	// Если подходит несколько правил, запрос учитывается в каждом.
+	Rule struct {
+		Method    string        `mapstructure:"method"`
+		Path      string        `mapstructure:"path" validate:"required"`
+		Key       string        `mapstructure:"key" validate:"omitempty,oneof=ip user api_key"`
+		Algorithm string        `mapstructure:"algorithm" validate:"omitempty,oneof=token_bucket sliding_window"`
+		Limit     int           `mapstructure:"limit" validate:"gt=0"`
+		Window    time.Duration `mapstructure:"window" validate:"gt=0"`
+		// Burst - емкость token bucket, по умолчанию равна Limit
+		Burst int `mapstructure:"burst" validate:"gte=0"`
+	}
+
+	// State - состояние счетчика одного ключа. Для token bucket Count - число
+	// оставшихся токенов, а Stamp - время последнего пополнения, для sliding
+	// window Count и Prev - запросы в текущем и предыдущем окне, а Stamp -
+	// начало текущего окна. После Expires состояние можно удалить.
+	State struct {
+		Count   float64
+		Prev    float64
+		Stamp   time.Time
+		Expires time.Time
+	}
+
+	// Store хранит состояния счетчиков
+	Store interface {
+		// Update атомарно читает состояние ключа, передает его fn и сохраняет
+		// измененное. Для нового ключа fn получает нулевое состояние.
+		Update(ctx context.Context, key string, fn func(*State)) error
+		// Sweep удаляет состояния, истекшие к моменту now
+		Sweep(ctx context.Context, now time.Time) error
+	}
+
+	// Result - решение по одному правилу
+	Result struct {
+		Allowed    bool
+		Limit      int
+		Remaining  int
+		Reset      time.Duration
+		RetryAfter time.Duration
+		Window     time.Duration
+	}
+
+	// Identity - данные клиента, из которых строятся ключи счетчиков
+	Identity struct {
+		IP string
+		Subject
+	}
+
+	// Subject - проверенные аутентификацией ID пользователя и API ключа
+	Subject struct {
+		UserID   string
+		APIKeyID string
+	}
+
+	// Limiter применяет правила к запросам
+	Limiter struct {
+		rules  []Rule
+		store  Store
+		logger *zap.Logger
+		now    func() time.Time
+	}
+)
+
+// NewLimiter проверяет правила и подставляет значения по умолчанию
+func NewLimiter(rules []Rule, store Store, logger *zap.Logger) (*Limiter, error) {
+	normalized := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		if r.Path == "" || r.Limit <= 0 || r.Window <= 0 {
+			return nil, fmt.Errorf("rate limit rule %q: path, limit and window are required", r.Path)
+		}
+		if r.Algorithm == "" {
+			r.Algorithm = TokenBucket
+		}
+		if r.Algorithm != TokenBucket && r.Algorithm != SlidingWindow {
+			return nil, fmt.Errorf("rate limit rule %q: unknown algorithm %q", r.Path, r.Algorithm)
+		}
+		if r.Key == "" {
+			r.Key = KeyIP
+		}
+		if r.Key != KeyIP && r.Key != KeyUser && r.Key != KeyAPIKey {
+			return nil, fmt.Errorf("rate limit rule %q: unknown key %q", r.Path, r.Key)
+		}
+		r.Method = strings.ToUpper(r.Method)
+		normalized = append(normalized, r)
+	}
+
+	return &Limiter{
+		rules:  normalized,
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+	}, nil
+}
+
+// Take учитывает запрос во всех подходящих правилах с ключами из keys (все
+// правила, если keys пуст) и возвращает самое строгое решение. ok == false,
+// если ни одно правило не подошло. Ошибки хранилища пишутся в лог, и запрос
+// пропускается.
+func (l *Limiter) Take(
+	ctx context.Context,
+	method, path string,
+	id Identity,
+	keys ...string,
+) (decision Result, ok bool) {
+	now := l.now()
+
+	for i := range l.rules {
+		r := &l.rules[i]
+		if len(keys) > 0 && !slices.Contains(keys, r.Key) {
+			continue
+		}
+		if !r.matches(method, path) {
+			continue
+		}
+
+		var res Result
+		err := l.store.Update(ctx, r.storeKey(id), func(s *State) {
+			res = r.apply(s, now)
+		})
+		if err != nil {
+			l.logger.Error("rate limit store failed", zap.String("path", r.Path), zap.Error(err))
+			continue
+		}
+
+		if !ok || stricter(res, decision) {
+			decision = res
+		}
+		ok = true
+	}
+
+	return decision, ok
+}
+
+func stricter(a, b Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	return a.Remaining < b.Remaining
+}
+
+func (r *Rule) matches(method, path string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	return r.Path == path
+}
+
+// hasKey - есть ли правила с одним из ключей keys
+func (l *Limiter) hasKey(keys ...string) bool {
+	return slices.ContainsFunc(l.rules, func(r Rule) bool {
+		return slices.Contains(keys, r.Key)
+	})
+}
+
+// storeKey - ключ счетчика: правило и клиент
+func (r *Rule) storeKey(id Identity) string {
+	var client string
+	switch {
+	case r.Key == KeyUser && id.UserID != "":
+		client = "user:" + id.UserID
+	case r.Key == KeyAPIKey && id.APIKeyID != "":
+		client = "api_key:" + id.APIKeyID
+	default:
+		client = "ip:" + id.IP
+	}
+	return fmt.Sprintf("%s %s|%s|%s", r.Method, r.Path, r.Algorithm, client)
+}
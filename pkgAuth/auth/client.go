@@ -19,9 +19,13 @@ type ValidateTokenRequest struct {
 }
 
 type ValidateTokenResponse struct {
-	Valid  bool   `json:"valid"`
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
+	Valid         bool   `json:"valid"`
+	UserID        string `json:"user_id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role"`
+	WorkspaceID   string `json:"workspace_id"`
+	WorkspaceRole string `json:"workspace_role"`
 }
 
 func NewClient(baseURL string, timeout time.Duration) *Client {
@@ -0,0 +1,46 @@
+package jwt
+
+import (
+	"marketai/pkg/http/problem"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EchoAuth проверяет Bearer токен через verifier и сохраняет claims в контексте
+// запроса, откуда их читают GetUserFromContext и EchoRequirePermission
+func EchoAuth(verifier Verifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			parts := strings.Split(c.Request().Header.Get("Authorization"), " ")
+			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+				return ErrAuthRequired
+			}
+
+			claims, err := verifier.Verify(c.Request().Context(), parts[1])
+			if err != nil {
+				return AuthError(err)
+			}
+
+			c.SetRequest(c.Request().WithContext(ContextWithClaims(c.Request().Context(), claims)))
+			return next(c)
+		}
+	}
+}
+
+// EchoRequirePermission требует все указанные права в scope токена. Ставится
+// после EchoAuth или другой аутентификации, сохранившей claims через ContextWithClaims.
+func EchoRequirePermission(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := GetUserFromContext(c.Request().Context())
+			if !ok {
+				return ErrAuthRequired
+			}
+			if !hasPermissions(claims, permissions) {
+				return problem.ErrForbidden
+			}
+			return next(c)
+		}
+	}
+}
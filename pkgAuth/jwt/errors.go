@@ -0,0 +1,46 @@
+package jwt
+
+import (
+	"errors"
+	"marketai/pkg/http/problem"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// Ошибки проверки токена. Проверяйте через errors.Is: к ним добавляются подробности.
+var (
+	ErrTokenMalformed           = errors.New("неверный формат токена")
+	ErrTokenSignatureInvalid    = errors.New("неверная подпись токена")
+	ErrTokenUnexpectedAlgorithm = errors.New("неверный алгоритм подписи токена")
+	ErrTokenUnknownKey          = errors.New("неизвестный ключ подписи токена")
+	ErrTokenExpired             = errors.New("токен просрочен")
+	ErrTokenNotYetValid         = errors.New("токен еще не действителен")
+	ErrTokenInvalidIssuer       = errors.New("неверный издатель токена")
+	ErrTokenInvalidAudience     = errors.New("токен выпущен для другого получателя")
+)
+
+// Ошибки API, которыми middleware отвечают на запросы без действительного токена
+var (
+	ErrAuthRequired  = problem.New(http.StatusUnauthorized, "token_required", "Требуется токен авторизации")
+	ErrAuthMalformed = problem.New(http.StatusUnauthorized, "token_malformed", "Неверный формат токена авторизации")
+	ErrAuthExpired   = problem.New(http.StatusUnauthorized, "token_expired", "Токен просрочен")
+	ErrAuthInvalid   = problem.New(http.StatusUnauthorized, "token_invalid", "Недействительный токен")
+)
+
+func init() {
+	problem.RegisterMessages(language.English, map[string]string{
+		ErrAuthRequired.Code:  "Authorization token is required",
+		ErrAuthMalformed.Code: "Malformed authorization token",
+		ErrAuthExpired.Code:   "Token has expired",
+		ErrAuthInvalid.Code:   "Invalid token",
+	})
+}
+
+// AuthError выбирает ошибку API для ошибки проверки токена
+func AuthError(err error) *problem.Error {
+	if errors.Is(err, ErrTokenExpired) {
+		return ErrAuthExpired.Wrap(err)
+	}
+	return ErrAuthInvalid.Wrap(err)
+}
@@ -0,0 +1,225 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultJWKSCacheTTL = 10 * time.Minute
+	// jwksMinRefresh ограничивает внеочередные запросы JWKS при неизвестном kid
+	jwksMinRefresh = 30 * time.Second
+)
+
+// JWK - открытый ключ в формате RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func newJWK(key *SigningKey) (JWK, bool) {
+	jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Algorithm}
+	switch public := key.public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return JWK{}, false
+	}
+	return jwk, true
+}
+
+// SigningKey восстанавливает ключ проверки подписи из JWK
+func (k JWK) SigningKey() (*SigningKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("ключ %s: неверный модуль: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("ключ %s: неверная экспонента: %w", k.Kid, err)
+		}
+		return newPublicKey(k.Kid, &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		})
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("ключ %s: неподдерживаемая кривая %s", k.Kid, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("ключ %s: неверный открытый ключ", k.Kid)
+		}
+		return newPublicKey(k.Kid, ed25519.PublicKey(x))
+	}
+	return nil, fmt.Errorf("ключ %s: неподдерживаемый тип %s", k.Kid, k.Kty)
+}
+
+// JWKSVerifier проверяет токены локально по открытым ключам, опубликованным
+// auth сервисом. Набор ключей кешируется на cacheTTL; токен с неизвестным kid
+// вызывает внеочередную загрузку, чтобы новый активный ключ подхватывался сразу.
+// Загрузка идет без блокировки кеша, одновременные запросы ждут одну загрузку.
+type JWKSVerifier struct {
+	url        string
+	client     *http.Client
+	cacheTTL   time.Duration
+	validation ValidationOptions
+	fetches    singleflight.Group
+
+	mu        sync.RWMutex
+	keys      *KeySet
+	fetchedAt time.Time
+}
+
+func NewJWKSVerifier(url string, cacheTTL time.Duration, opts ValidationOptions) *JWKSVerifier {
+	if cacheTTL <= 0 {
+		cacheTTL = defaultJWKSCacheTTL
+	}
+	return &JWKSVerifier{
+		url:        url,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		cacheTTL:   cacheTTL,
+		validation: opts,
+		keys:       NewKeySet(nil),
+	}
+}
+
+func (v *JWKSVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parsed, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := v.key(ctx, parsed.header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.verifyWith(key, v.validation)
+}
+
+// VerifyInto проверяет токен как Verify и дополнительно разбирает его payload
+// в dst. Нужен для токенов сторонних издателей, например OIDC id_token, claims
+// которых (nonce, email) не входят в Claims.
+func (v *JWKSVerifier) VerifyInto(ctx context.Context, token string, dst any) (*Claims, error) {
+	parsed, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := v.key(ctx, parsed.header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := parsed.verifyWith(key, v.validation)
+	if err != nil {
+		return nil, err
+	}
+	if err := parsed.decodePayload(dst); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *JWKSVerifier) key(ctx context.Context, kid string) (*SigningKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys.keys[kid]
+	sinceFetch := time.Since(v.fetchedAt)
+	v.mu.RUnlock()
+
+	if (ok && sinceFetch < v.cacheTTL) || (!ok && sinceFetch < jwksMinRefresh) {
+		if !ok {
+			return nil, ErrTokenUnknownKey
+		}
+		return key, nil
+	}
+
+	// Загрузка общая для всех ожидающих, поэтому не прерывается отменой запроса
+	// одного из них; время загрузки ограничено таймаутом клиента
+	res, err, _ := v.fetches.Do(v.url, func() (any, error) {
+		keys, err := v.fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		v.mu.Lock()
+		v.keys = keys
+		v.fetchedAt = time.Now()
+		v.mu.Unlock()
+		return keys, nil
+	})
+	if err != nil {
+		// Пока auth недоступен, проверяем токены ранее загруженными ключами
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	if key, ok = res.(*KeySet).keys[kid]; !ok {
+		return nil, ErrTokenUnknownKey
+	}
+	return key, nil
+}
+
+func (v *JWKSVerifier) fetch(ctx context.Context) (*KeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса JWKS: %w", err)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка загрузки JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ошибка загрузки JWKS: статус %d", resp.StatusCode)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("ошибка разбора JWKS: %w", err)
+	}
+
+	keys := make([]*SigningKey, 0, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.SigningKey()
+		if err != nil {
+			// Ключи неизвестных типов пропускаем, остальные остаются рабочими
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return NewKeySet(nil, keys...), nil
+}
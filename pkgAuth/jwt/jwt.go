@@ -1,87 +1,209 @@
 package jwt
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
+	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/labstack/echo/v4"
+	"github.com/google/uuid"
 )
 
 const (
-	header = `{"alg":"HS256","typ":"JWT"}`
+	typJWT = "JWT"
 )
 
 type Claims struct {
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
-	Exp    int64  `json:"exp"` // Срок действия токена (Unix timestamp)
-	Iat    int64  `json:"iat"` // Время выдачи токена (Unix timestamp)
+	ID            string   `json:"jti,omitempty"` // Идентификатор токена, по нему токен отзывается
+	Issuer        string   `json:"iss,omitempty"` // Сервис, выпустивший токен
+	Subject       string   `json:"sub,omitempty"` // Пользователь, совпадает с user_id
+	Audience      Audience `json:"aud,omitempty"` // Сервисы, для которых выпущен токен
+	UserID        string   `json:"user_id"`
+	Role          string   `json:"role"`
+	Roles         []string `json:"roles,omitempty"`          // Все роли пользователя
+	Scope         string   `json:"scope,omitempty"`          // Права ролей через пробел, как scope в OAuth 2.0
+	WorkspaceID   string   `json:"workspace_id,omitempty"`   // Текущее рабочее пространство
+	WorkspaceRole string   `json:"workspace_role,omitempty"` // Роль в рабочем пространстве: owner, editor, viewer
+	EmailVerified bool     `json:"email_verified,omitempty"` // Пользователь подтвердил email
+	Actor         *Actor   `json:"act,omitempty"`            // Администратор, вошедший от имени пользователя (RFC 8693)
+	SessionID     string   `json:"sid,omitempty"`            // Сессия, в которой выпущен токен; с ее завершением токен отзывается
+	Exp           int64    `json:"exp"`                      // Срок действия токена (Unix timestamp)
+	Nbf           int64    `json:"nbf,omitempty"`            // Токен недействителен до этого момента (Unix timestamp)
+	Iat           int64    `json:"iat"`                      // Время выдачи токена (Unix timestamp)
+	IatMs         int64    `json:"iat_ms,omitempty"`         // Время выдачи токена в миллисекундах, для сравнения с отзывом
+}
+
+// Actor - кто действует от имени пользователя токена
+type Actor struct {
+	Subject string `json:"sub"`
+}
+
+// Impersonated - токен выпущен администратору для входа от имени пользователя
+func (c *Claims) Impersonated() bool {
+	return c.Actor != nil
+}
+
+// IssuedAt - время выдачи токена. У токенов без iat_ms точность - секунда.
+func (c *Claims) IssuedAt() time.Time {
+	if c.IatMs != 0 {
+		return time.UnixMilli(c.IatMs)
+	}
+	return time.Unix(c.Iat, 0)
+}
+
+// Permissions возвращает права из claim scope
+func (c *Claims) Permissions() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasPermission проверяет, что в scope есть право permission
+func (c *Claims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Verifier проверяет подпись и срок действия токена
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// parsedToken - токен, разобранный на части до проверки подписи
+type parsedToken struct {
+	header        tokenHeader
+	claimsEncoded string
+	signingInput  string
+	signature     []byte
 }
 
 func GenerateToken(userID, role, secret string, expiration time.Duration) (string, error) {
-	now := time.Now()
-	claims := Claims{
+	return GenerateTokenWithClaims(Claims{
 		UserID: userID,
 		Role:   role,
-		Exp:    now.Add(expiration).Unix(),
-		Iat:    now.Unix(),
+	}, secret, expiration)
+}
+
+// GenerateTokenWithClaims подписывает переданные claims по HS256, время выдачи,
+// срок действия, идентификатор и sub (если не заданы) проставляются автоматически
+func GenerateTokenWithClaims(claims Claims, secret string, expiration time.Duration) (string, error) {
+	return NewKeySet(NewHMACKey("", secret)).Sign(claims, expiration)
+}
+
+// ValidateToken проверяет токен, подписанный по HS256 общим секретом. Ошибки
+// типизированы: ErrTokenMalformed, ErrTokenSignatureInvalid, ErrTokenExpired и др.
+func ValidateToken(token, secret string) (*Claims, error) {
+	return ValidateTokenWithOptions(token, secret, ValidationOptions{})
+}
+
+// ValidateTokenWithOptions проверяет HS256 токен, а также издателя, получателя
+// и сроки действия с учетом допустимого расхождения часов
+func ValidateTokenWithOptions(token, secret string, opts ValidationOptions) (*Claims, error) {
+	return NewKeySet(NewHMACKey("", secret)).WithValidation(opts).Verify(context.Background(), token)
+}
+
+func signToken(key *SigningKey, claims Claims, expiration time.Duration) (string, error) {
+	if claims.ID == "" {
+		claims.ID = uuid.NewString()
+	}
+	if claims.Subject == "" {
+		claims.Subject = claims.UserID
 	}
 
-	headerEncoded := base64.RawURLEncoding.EncodeToString([]byte(header))
+	now := time.Now()
+	claims.Exp = now.Add(expiration).Unix()
+	claims.Nbf = now.Unix()
+	claims.Iat = now.Unix()
+	claims.IatMs = now.UnixMilli()
+
+	headerJSON, err := json.Marshal(tokenHeader{Alg: key.Algorithm, Typ: typJWT, Kid: key.ID})
+	if err != nil {
+		return "", fmt.Errorf("ошибка при маршалинге заголовка: %w", err)
+	}
+	headerEncoded := base64.RawURLEncoding.EncodeToString(headerJSON)
 	claimsJSON, err := json.Marshal(claims)
 	if err != nil {
-		return "", echo.ErrBadRequest.WithInternal(fmt.Errorf("ошибка при маршалинге claims: %w", err))
+		return "", fmt.Errorf("ошибка при маршалинге claims: %w", err)
 	}
 	claimsEncoded := base64.RawURLEncoding.EncodeToString(claimsJSON)
 
 	message := headerEncoded + "." + claimsEncoded
-	signature := signMessage(message, secret)
+	signature, err := key.sign([]byte(message))
+	if err != nil {
+		return "", fmt.Errorf("ошибка при подписи токена: %w", err)
+	}
 
-	return message + "." + signature, nil
+	return message + "." + base64.RawURLEncoding.EncodeToString(signature), nil
 }
 
-func ValidateToken(token, secret string) (*Claims, error) {
+func parseToken(token string) (*parsedToken, error) {
 	parts := strings.Split(token, ".")
 	if len(parts) != 3 {
-		return nil, errors.New("неверный формат токена")
+		return nil, ErrTokenMalformed
 	}
 
-	headerEncoded := parts[0]
-	claimsEncoded := parts[1]
-	signature := parts[2]
-
-	message := headerEncoded + "." + claimsEncoded
-	expectedSignature := signMessage(message, secret)
-
-	if signature != expectedSignature {
-		return nil, errors.New("неверная подпись токена")
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: заголовок: %v", ErrTokenMalformed, err)
+	}
+	var header tokenHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("%w: заголовок: %v", ErrTokenMalformed, err)
 	}
 
-	claimsJSON, err := base64.RawURLEncoding.DecodeString(claimsEncoded)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
 	if err != nil {
-		return nil, echo.ErrBadRequest.WithInternal(fmt.Errorf("ошибка при декодировании claims: %w", err))
+		return nil, fmt.Errorf("%w: подпись: %v", ErrTokenMalformed, err)
+	}
+
+	return &parsedToken{
+		header:        header,
+		claimsEncoded: parts[1],
+		signingInput:  parts[0] + "." + parts[1],
+		signature:     signature,
+	}, nil
+}
+
+// verifyWith проверяет подпись ключом key и claims по opts
+func (t *parsedToken) verifyWith(key *SigningKey, opts ValidationOptions) (*Claims, error) {
+	// Алгоритм определяется ключом, а не заголовком токена: так нельзя
+	// подменить RS256 на HS256 с открытым ключом в роли секрета или на none
+	if t.header.Alg != key.Algorithm {
+		return nil, fmt.Errorf("%w: %q", ErrTokenUnexpectedAlgorithm, t.header.Alg)
 	}
+	if err := key.verify([]byte(t.signingInput), t.signature); err != nil {
+		return nil, ErrTokenSignatureInvalid
+	}
+
 	var claims Claims
-	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
-		return nil, echo.ErrBadRequest.WithInternal(fmt.Errorf("ошибка при демаршалинге claims: %w", err))
+	if err := t.decodePayload(&claims); err != nil {
+		return nil, err
 	}
 
-	if claims.Exp < time.Now().Unix() {
-		return nil, errors.New("токен просрочен")
+	if err := opts.validate(&claims, time.Now()); err != nil {
+		return nil, err
 	}
 
 	return &claims, nil
-
 }
 
-func signMessage(message, secret string) string {
-	h := hmac.New(sha256.New, []byte(secret))
-	h.Write([]byte(message))
-	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+func (t *parsedToken) decodePayload(dst any) error {
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(t.claimsEncoded)
+	if err != nil {
+		return fmt.Errorf("%w: claims: %v", ErrTokenMalformed, err)
+	}
+	if err := json.Unmarshal(claimsJSON, dst); err != nil {
+		return fmt.Errorf("%w: claims: %v", ErrTokenMalformed, err)
+	}
+	return nil
 }
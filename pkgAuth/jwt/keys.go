@@ -0,0 +1,194 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// SigningKey - ключ подписи или проверки токенов. Для асимметричных
+// алгоритмов ключ без закрытой части может только проверять подпись.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+
+	secret  []byte
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// NewHMACKey создает ключ HS256 на общем секрете. Такой ключ не публикуется в JWKS.
+func NewHMACKey(id, secret string) *SigningKey {
+	return &SigningKey{
+		ID:        id,
+		Algorithm: AlgHS256,
+		secret:    []byte(secret),
+	}
+}
+
+// NewSigningKey создает ключ RS256 или EdDSA по закрытому ключу RSA или Ed25519
+func NewSigningKey(id string, private crypto.Signer) (*SigningKey, error) {
+	key, err := newPublicKey(id, private.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.private = private
+	return key, nil
+}
+
+// ParsePrivateKeyPEM разбирает закрытый ключ RSA (PKCS#1 или PKCS#8) или Ed25519 (PKCS#8)
+func ParsePrivateKeyPEM(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("ключ не в формате PEM")
+	}
+
+	if rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewSigningKey(id, rsaKey)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора закрытого ключа: %w", err)
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("неподдерживаемый тип ключа %T", parsed)
+	}
+	return NewSigningKey(id, signer)
+}
+
+func newPublicKey(id string, public crypto.PublicKey) (*SigningKey, error) {
+	key := &SigningKey{ID: id, public: public}
+	switch public.(type) {
+	case *rsa.PublicKey:
+		key.Algorithm = AlgRS256
+	case ed25519.PublicKey:
+		key.Algorithm = AlgEdDSA
+	default:
+		return nil, fmt.Errorf("неподдерживаемый тип ключа %T", public)
+	}
+	return key, nil
+}
+
+func (k *SigningKey) canSign() bool {
+	return k.secret != nil || k.private != nil
+}
+
+func (k *SigningKey) sign(message []byte) ([]byte, error) {
+	switch k.Algorithm {
+	case AlgHS256:
+		h := hmac.New(sha256.New, k.secret)
+		h.Write(message)
+		return h.Sum(nil), nil
+	case AlgRS256:
+		if k.private == nil {
+			return nil, errors.New("ключ не содержит закрытой части")
+		}
+		digest := sha256.Sum256(message)
+		return k.private.Sign(rand.Reader, digest[:], crypto.SHA256)
+	case AlgEdDSA:
+		if k.private == nil {
+			return nil, errors.New("ключ не содержит закрытой части")
+		}
+		return k.private.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+	return nil, fmt.Errorf("неподдерживаемый алгоритм %s", k.Algorithm)
+}
+
+func (k *SigningKey) verify(message, signature []byte) error {
+	switch k.Algorithm {
+	case AlgHS256:
+		h := hmac.New(sha256.New, k.secret)
+		h.Write(message)
+		if !hmac.Equal(signature, h.Sum(nil)) {
+			return errors.New("подпись не совпадает")
+		}
+		return nil
+	case AlgRS256:
+		digest := sha256.Sum256(message)
+		return rsa.VerifyPKCS1v15(k.public.(*rsa.PublicKey), crypto.SHA256, digest[:], signature)
+	case AlgEdDSA:
+		if !ed25519.Verify(k.public.(ed25519.PublicKey), message, signature) {
+			return errors.New("подпись не совпадает")
+		}
+		return nil
+	}
+	return fmt.Errorf("неподдерживаемый алгоритм %s", k.Algorithm)
+}
+
+// KeySet - набор ключей сервиса: активный ключ подписывает новые токены,
+// выводимые из оборота ключи только проверяют ранее выпущенные.
+// Ключ для проверки выбирается по kid из заголовка токена.
+type KeySet struct {
+	active     *SigningKey
+	keys       map[string]*SigningKey
+	validation ValidationOptions
+}
+
+func NewKeySet(active *SigningKey, retiring ...*SigningKey) *KeySet {
+	set := &KeySet{
+		active: active,
+		keys:   make(map[string]*SigningKey, len(retiring)+1),
+	}
+	for _, key := range retiring {
+		set.keys[key.ID] = key
+	}
+	if active != nil {
+		set.keys[active.ID] = active
+	}
+	return set
+}
+
+// WithValidation задает проверки claims для Verify
+func (s *KeySet) WithValidation(opts ValidationOptions) *KeySet {
+	s.validation = opts
+	return s
+}
+
+// Sign подписывает claims активным ключом
+func (s *KeySet) Sign(claims Claims, expiration time.Duration) (string, error) {
+	if s.active == nil || !s.active.canSign() {
+		return "", errors.New("нет активного ключа подписи")
+	}
+	return signToken(s.active, claims, expiration)
+}
+
+func (s *KeySet) Verify(_ context.Context, token string) (*Claims, error) {
+	parsed, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := s.keys[parsed.header.Kid]
+	if !ok {
+		return nil, ErrTokenUnknownKey
+	}
+	return parsed.verifyWith(key, s.validation)
+}
+
+// JWKS возвращает открытые части асимметричных ключей набора
+func (s *KeySet) JWKS() *JWKS {
+	jwks := &JWKS{Keys: []JWK{}}
+	for _, key := range s.keys {
+		if jwk, ok := newJWK(key); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+	return jwks
+}
@@ -2,6 +2,7 @@ package jwt
 
 import (
 	"context"
+	"marketai/pkg/http/problem"
 	"net/http"
 	"strings"
 )
@@ -16,29 +17,33 @@ func JWTAuthMiddleware(jwtSecret string) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				http.Error(w, "Требуется токен авторизации", http.StatusUnauthorized)
+				problem.Write(w, r, ErrAuthRequired)
 				return
 			}
 
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-				http.Error(w, "Неверный формат токена авторизации", http.StatusUnauthorized)
+				problem.Write(w, r, ErrAuthMalformed)
 				return
 			}
 
 			tokenString := parts[1]
 			claims, err := ValidateToken(tokenString, jwtSecret)
 			if err != nil {
-				http.Error(w, "Недействительный токен", http.StatusUnauthorized)
+				problem.Write(w, r, AuthError(err))
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), userContextKey, claims)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
 		})
 	}
 }
 
+// ContextWithClaims сохраняет claims в контексте, откуда их читают GetUserFromContext и проверки прав
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, userContextKey, claims)
+}
+
 // GetUserFromContext извлекает информацию о пользователе из контекста
 func GetUserFromContext(ctx context.Context) (*Claims, bool) {
 	claims, ok := ctx.Value(userContextKey).(*Claims)
@@ -52,21 +57,46 @@ func RequireAuth(jwtSecret string) func(http.Handler) http.Handler {
 
 // RequireRole создает middleware, который требует определенной роли
 func RequireRole(jwtSecret, requiredRole string) func(http.Handler) http.Handler {
+	return RequireAnyRole(jwtSecret, requiredRole)
+}
+
+// RequireAnyRole создает middleware, который требует одну из указанных ролей.
+// Токен проверяется один раз, обработчик вызывается только после проверки роли.
+func RequireAnyRole(jwtSecret string, roles ...string) func(http.Handler) http.Handler {
+	return requireClaims(jwtSecret, func(claims *Claims) bool {
+		for _, role := range roles {
+			if claims.Role == role {
+				return true
+			}
+			for _, userRole := range claims.Roles {
+				if userRole == role {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+// RequirePermission создает middleware, который требует все указанные права в scope токена
+func RequirePermission(jwtSecret string, permissions ...string) func(http.Handler) http.Handler {
+	return requireClaims(jwtSecret, func(claims *Claims) bool {
+		return hasPermissions(claims, permissions)
+	})
+}
+
+// PermissionMiddleware проверяет права для запросов, уже прошедших аутентификацию
+// (JWTAuthMiddleware или аналог, сохранивший claims через ContextWithClaims)
+func PermissionMiddleware(permissions ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Сначала валидируем токен
-			authMiddleware := JWTAuthMiddleware(jwtSecret)
-			authMiddleware(next).ServeHTTP(w, r)
-
-			// Проверяем роль
 			claims, ok := GetUserFromContext(r.Context())
 			if !ok {
-				http.Error(w, "Ошибка контекста пользователя", http.StatusInternalServerError)
+				problem.Write(w, r, ErrAuthRequired)
 				return
 			}
-
-			if claims.Role != requiredRole {
-				http.Error(w, "Недостаточно прав", http.StatusForbidden)
+			if !hasPermissions(claims, permissions) {
+				problem.Write(w, r, problem.ErrForbidden)
 				return
 			}
 
@@ -75,37 +105,32 @@ func RequireRole(jwtSecret, requiredRole string) func(http.Handler) http.Handler
 	}
 }
 
-// RequireAnyRole создает middleware, который требует одну из указанных ролей
-func RequireAnyRole(jwtSecret string, roles ...string) func(http.Handler) http.Handler {
+func requireClaims(jwtSecret string, allowed func(*Claims) bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Сначала валидируем токен
-			authMiddleware := JWTAuthMiddleware(jwtSecret)
-			authMiddleware(next).ServeHTTP(w, r)
-
-			// Проверяем роль
+		check := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			claims, ok := GetUserFromContext(r.Context())
 			if !ok {
-				http.Error(w, "Ошибка контекста пользователя", http.StatusInternalServerError)
+				problem.Write(w, r, problem.ErrInternal)
 				return
 			}
-
-			hasRole := false
-			for _, role := range roles {
-				if claims.Role == role {
-					hasRole = true
-					break
-				}
-			}
-
-			if !hasRole {
-				http.Error(w, "Недостаточно прав", http.StatusForbidden)
+			if !allowed(claims) {
+				problem.Write(w, r, problem.ErrForbidden)
 				return
 			}
 
 			next.ServeHTTP(w, r)
 		})
+		return JWTAuthMiddleware(jwtSecret)(check)
+	}
+}
+
+func hasPermissions(claims *Claims, permissions []string) bool {
+	for _, permission := range permissions {
+		if !claims.HasPermission(permission) {
+			return false
+		}
 	}
+	return true
 }
 
 // OptionalAuth создает middleware, который добавляет информацию о пользователе, если токен есть
@@ -118,8 +143,7 @@ func OptionalAuth(jwtSecret string) func(http.Handler) http.Handler {
 				if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
 					tokenString := parts[1]
 					if claims, err := ValidateToken(tokenString, jwtSecret); err == nil {
-						ctx := context.WithValue(r.Context(), userContextKey, claims)
-						next.ServeHTTP(w, r.WithContext(ctx))
+						next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
 						return
 					}
 				}
@@ -137,6 +161,7 @@ func OptionalAuth(jwtSecret string) func(http.Handler) http.Handler {
 mux := http.NewServeMux()
 mux.Handle("/api/users", jwt.RequireRole(jwtSecret, "admin")(usersHandler))
 mux.Handle("/api/profile", jwt.RequireAuth(jwtSecret)(profileHandler))
+mux.Handle("/api/users", jwt.RequirePermission(jwtSecret, "users:read")(usersHandler))
 */
 
 /*
@@ -149,6 +174,8 @@ GetUserFromContext - извлечение информации о пользов
 RequireAuth - middleware, требующий аутентификации
 RequireRole - middleware, требующий определенной роли
 RequireAnyRole - middleware, требующий одну из указанных ролей
+RequirePermission, PermissionMiddleware - middleware, требующие права из scope токена
+EchoAuth, EchoRequirePermission - то же для Echo (echo.go)
 OptionalAuth - опциональная аутентификация
 
 
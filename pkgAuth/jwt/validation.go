@@ -0,0 +1,72 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Audience - claim aud. По RFC 7519 это строка или массив строк, принимаются оба варианта.
+type Audience []string
+
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("aud должен быть строкой или массивом строк: %w", err)
+	}
+	*a = many
+	return nil
+}
+
+func (a Audience) Contains(audience string) bool {
+	return slices.Contains(a, audience)
+}
+
+// ValidationOptions - проверки claims помимо подписи. Пустые Issuer и
+// Audience не проверяются, ClockSkew допускает расхождение часов сервисов
+// при проверке exp, nbf и iat.
+type ValidationOptions struct {
+	Issuer    string
+	Audience  string
+	ClockSkew time.Duration
+}
+
+func (o ValidationOptions) validate(claims *Claims, now time.Time) error {
+	if claims.Exp == 0 {
+		return fmt.Errorf("%w: нет срока действия", ErrTokenMalformed)
+	}
+	if now.After(time.Unix(claims.Exp, 0).Add(o.ClockSkew)) {
+		return ErrTokenExpired
+	}
+
+	notBefore := now.Add(o.ClockSkew)
+	if claims.Nbf != 0 && notBefore.Before(time.Unix(claims.Nbf, 0)) {
+		return ErrTokenNotYetValid
+	}
+	if claims.Iat != 0 && notBefore.Before(time.Unix(claims.Iat, 0)) {
+		return fmt.Errorf("%w: время выдачи в будущем", ErrTokenNotYetValid)
+	}
+
+	if o.Issuer != "" && claims.Issuer != o.Issuer {
+		return ErrTokenInvalidIssuer
+	}
+	if o.Audience != "" && !claims.Audience.Contains(o.Audience) {
+		return ErrTokenInvalidAudience
+	}
+
+	return nil
+}